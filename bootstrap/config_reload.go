@@ -0,0 +1,58 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package bootstrap
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/services/blockstorage"
+	"github.com/orbs-network/orbs-network-go/services/transactionpool"
+	"github.com/orbs-network/scribe/log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+)
+
+// RegisterConfigReloader declares the keys the node services can apply without a restart and exposes the reload
+// over http, the reloader must own the config the node was created with
+func (n *Node) RegisterConfigReloader(reloader *config.Reloader) {
+	reloader.DeclareHotReloadable(nil, transactionpool.HotReloadableConfigKeys...)
+	reloader.DeclareHotReloadable(nil, blockstorage.HotReloadableConfigKeys...)
+	reloader.DeclareHotReloadable(func(cfg config.NodeConfig) {
+		instrumentation.UpdateLoggerFilter(n.logger, cfg)
+	}, config.LOGGER_FULL_LOG)
+
+	n.httpServer.RegisterConfigReloader(reloader)
+}
+
+// ListenToOSConfigReloadSignal reloads the config whenever the process receives SIGHUP
+func ListenToOSConfigReloadSignal(ctx context.Context, logger log.Logger, reloader *config.Reloader) *govnr.ForeverHandle {
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, syscall.SIGHUP)
+	return govnr.Forever(ctx, "config reload signal listener", logfields.GovnrErrorer(logger), func() {
+		select {
+		case <-ctx.Done():
+			signal.Stop(signalChan)
+			return
+		case <-signalChan:
+		}
+
+		logger.Info("reloading config due to os signal received")
+		result, err := reloader.Reload()
+		if err != nil {
+			logger.Error("config reload failed, keeping the current config", log.Error(err))
+			return
+		}
+		logger.Info("config reloaded",
+			log.String("applied", strings.Join(result.Applied, ",")),
+			log.String("requires-restart", strings.Join(result.RequiresRestart, ",")))
+	})
+}
@@ -65,6 +65,8 @@ func StartGammaServer(config ServerConfig) *Server {
 
 	httpServer := httpserver.NewHttpServer(cfg,	rootLogger, network.MetricRegistry(0))
 	httpServer.RegisterPublicApi(network.PublicApi(0))
+	httpServer.RegisterContractAbi(network.ContractAbi(0))
+	httpServer.RegisterTransactionSimulator(network.TransactionSimulator(0))
 
 	s := &Server{
 		network:    network,
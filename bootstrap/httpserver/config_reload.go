@@ -0,0 +1,50 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/scribe/log"
+	"net/http"
+	"strings"
+)
+
+type ConfigReloader interface {
+	Reload() (*config.ReloadResult, error)
+}
+
+// re-reads the config files and returns which changed keys were applied and which require a restart
+func (s *HttpServer) configReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if s.configReloader == nil || !s.config.HttpConfigReloadEnabled() {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusNotFound, nil, "config reload is not supported by this node"})
+		return
+	}
+
+	result, err := s.configReloader.Reload()
+	if err != nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusBadRequest, log.Error(err), "config reload failed: " + err.Error()})
+		return
+	}
+
+	s.logger.Info("config reloaded",
+		log.String("applied", strings.Join(result.Applied, ",")),
+		log.String("requires-restart", strings.Join(result.RequiresRestart, ",")))
+
+	data, _ := json.MarshalIndent(result, "", "  ")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		s.logger.Info("error writing config reload response", log.Error(err))
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type stubConfigReloader struct {
+	result *config.ReloadResult
+	err    error
+}
+
+func (r *stubConfigReloader) Reload() (*config.ReloadResult, error) {
+	return r.result, r.err
+}
+
+func postConfigReload(h *harness) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("POST", "/debug/config/reload", nil)
+	rec := httptest.NewRecorder()
+	h.server.configReloadHandler(rec, req)
+	return rec
+}
+
+func enableConfigReload(h *harness) {
+	h.server.config = config.TemplateForGamma(nil, nil, ":0", false).Set(config.HTTP_CONFIG_RELOAD_ENABLED, config.NodeConfigValue{BoolValue: true})
+}
+
+func TestHttpServer_ConfigReload(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			enableConfigReload(h)
+			require.Equal(t, http.StatusNotFound, postConfigReload(h).Code, "should fail when no reloader is registered")
+
+			expected := &config.ReloadResult{Applied: []string{config.LOGGER_FULL_LOG}, RequiresRestart: []string{config.GOSSIP_LISTEN_PORT}}
+			h.server.RegisterConfigReloader(&stubConfigReloader{result: expected})
+
+			rec := postConfigReload(h)
+			require.Equal(t, http.StatusOK, rec.Code)
+
+			result := &config.ReloadResult{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), result))
+			require.Equal(t, expected, result)
+		})
+	})
+}
+
+func TestHttpServer_ConfigReloadError(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			enableConfigReload(h)
+			h.server.RegisterConfigReloader(&stubConfigReloader{err: errors.New("reloaded config is not valid")})
+
+			rec := postConfigReload(h)
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			require.Contains(t, rec.Body.String(), "reloaded config is not valid")
+		})
+	})
+}
+
+func TestHttpServer_ConfigReloadRespondsNotFoundWhenDisabled(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.RegisterConfigReloader(&stubConfigReloader{result: &config.ReloadResult{}})
+
+			require.Equal(t, http.StatusNotFound, postConfigReload(h).Code, "reload over http should be disabled by default")
+		})
+	})
+}
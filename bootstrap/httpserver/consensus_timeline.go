@@ -0,0 +1,46 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"github.com/orbs-network/scribe/log"
+	"net/http"
+	"strconv"
+)
+
+const DEFAULT_CONSENSUS_TIMELINE_HEIGHTS = 10
+
+type ConsensusTimelineProvider interface {
+	ExportConsensusTimeline(lastHeights int) interface{}
+}
+
+// returns the recorded consensus events of the last N heights, N is taken from the "heights" query parameter
+func (s *HttpServer) consensusTimelineHandler(w http.ResponseWriter, r *http.Request) {
+	if s.consensusTimeline == nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusNotFound, nil, "active consensus algo does not record a timeline"})
+		return
+	}
+
+	heights := DEFAULT_CONSENSUS_TIMELINE_HEIGHTS
+	if param := r.URL.Query().Get("heights"); param != "" {
+		parsed, err := strconv.Atoi(param)
+		if err != nil || parsed <= 0 {
+			s.writeErrorResponseAndLog(w, &httpErr{http.StatusBadRequest, log.String("heights", param), "heights must be a positive number"})
+			return
+		}
+		heights = parsed
+	}
+
+	data, _ := json.MarshalIndent(s.consensusTimeline.ExportConsensusTimeline(heights), "", "  ")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err := w.Write(data)
+	if err != nil {
+		s.logger.Info("error writing consensus timeline response", log.Error(err))
+	}
+}
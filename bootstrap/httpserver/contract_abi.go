@@ -0,0 +1,48 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"net/http"
+)
+
+type ContractAbiProvider interface {
+	GetContractAbi(ctx context.Context, contractName primitives.ContractName) (*processor.ContractAbi, error)
+}
+
+// returns the methods and events of the contract named by the "contract" query parameter as json
+func (s *HttpServer) getContractAbiHandler(w http.ResponseWriter, r *http.Request) {
+	if s.contractAbi == nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusNotFound, nil, "node does not describe contracts"})
+		return
+	}
+
+	contractName := r.URL.Query().Get("contract")
+	if contractName == "" {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusBadRequest, nil, "contract query parameter is missing"})
+		return
+	}
+
+	contractAbi, err := s.contractAbi.GetContractAbi(r.Context(), primitives.ContractName(contractName))
+	if err != nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusNotFound, log.Error(err), err.Error()})
+		return
+	}
+
+	data, _ := json.MarshalIndent(contractAbi, "", "  ")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		s.logger.Info("error writing contract abi response", log.Error(err))
+	}
+}
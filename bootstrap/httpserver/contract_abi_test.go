@@ -0,0 +1,71 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type stubContractAbi map[primitives.ContractName]*processor.ContractAbi
+
+func (s stubContractAbi) GetContractAbi(ctx context.Context, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	if contractAbi, found := s[contractName]; found {
+		return contractAbi, nil
+	}
+	return nil, errors.Errorf("contract %s is not deployed", contractName)
+}
+
+func (h *harness) getContractAbi(query string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", "/api/v1/get-contract-abi"+query, nil)
+	rec := httptest.NewRecorder()
+	h.server.getContractAbiHandler(rec, req)
+	return rec
+}
+
+func TestHttpServer_GetContractAbi(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			counterAbi := &processor.ContractAbi{
+				Name:       "Counter",
+				Permission: "PERMISSION_SCOPE_SERVICE",
+				Methods: []*processor.MethodAbi{
+					{Name: "add", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"uint64"}, Returns: []string{}},
+				},
+				Events: []*processor.EventAbi{},
+			}
+			h.server.RegisterContractAbi(stubContractAbi{"Counter": counterAbi})
+
+			rec := h.getContractAbi("?contract=Counter")
+			require.Equal(t, http.StatusOK, rec.Code, "should succeed")
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			res := &processor.ContractAbi{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), res))
+			require.Equal(t, counterAbi, res)
+
+			require.Equal(t, http.StatusNotFound, h.getContractAbi("?contract=Missing").Code, "unknown contracts should not be found")
+			require.Equal(t, http.StatusBadRequest, h.getContractAbi("").Code, "contract name is required")
+		})
+	})
+}
+
+func TestHttpServer_GetContractAbiRespondsNotFoundUntilRegistered(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			require.Equal(t, http.StatusNotFound, h.getContractAbi("?contract=Counter").Code)
+		})
+	})
+}
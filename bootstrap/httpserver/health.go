@@ -0,0 +1,48 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/scribe/log"
+	"net/http"
+)
+
+// a node that is not live should be restarted, a node that is live but not ready (syncing for example) should only be taken out of rotation
+func (s *HttpServer) livenessHandler(w http.ResponseWriter, r *http.Request) {
+	s.writeHealthReport(w, r, func(report *health.Report) bool {
+		return report.Live
+	})
+}
+
+func (s *HttpServer) readinessHandler(w http.ResponseWriter, r *http.Request) {
+	s.writeHealthReport(w, r, func(report *health.Report) bool {
+		return report.Ready
+	})
+}
+
+func (s *HttpServer) writeHealthReport(w http.ResponseWriter, r *http.Request, passes func(report *health.Report) bool) {
+	if s.health == nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusServiceUnavailable, nil, "health checks are not registered yet"})
+		return
+	}
+
+	report := s.health.Report(r.Context())
+	data, _ := json.MarshalIndent(report, "", "  ")
+
+	w.Header().Set("Content-Type", "application/json")
+	if passes(report) {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_, err := w.Write(data)
+	if err != nil {
+		s.logger.Info("error writing health response", log.Error(err))
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"fmt"
 	membuffers "github.com/orbs-network/membuffers/go"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/synchronization/supervised"
 	"io/ioutil"
@@ -25,6 +26,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/protocol/client"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/scribe/log"
+	"golang.org/x/time/rate"
 )
 
 var LogTag = log.String("adapter", "http-HttpServer")
@@ -40,10 +42,16 @@ type HttpServer struct {
 	httpServer *http.Server
 	router     *http.ServeMux
 
-	logger         log.Logger
-	publicApi      services.PublicApi
-	metricRegistry metric.Registry
-	config         config.HttpServerConfig
+	logger            log.Logger
+	publicApi         services.PublicApi
+	consensusTimeline ConsensusTimelineProvider
+	contractAbi       ContractAbiProvider
+	simulator         TransactionSimulator
+	simulateLimiter   *rate.Limiter
+	configReloader    ConfigReloader
+	health            health.Registry
+	metricRegistry    metric.Registry
+	config            config.HttpServerConfig
 
 	port int
 }
@@ -74,6 +82,7 @@ func NewHttpServer(cfg config.HttpServerConfig, logger log.Logger, metricRegistr
 		publicApi:          nil,
 		metricRegistry:     metricRegistry,
 		config:             cfg,
+		simulateLimiter:    newRateLimiter(cfg.HttpSimulateTransactionRateLimit()),
 		ChanShutdownWaiter: supervised.NewChanWaiter("NodeHttpServer"),
 	}
 
@@ -124,6 +133,26 @@ func (s *HttpServer) RegisterPublicApi(publicApi services.PublicApi) {
 	s.publicApi = publicApi
 }
 
+func (s *HttpServer) RegisterConsensusTimeline(consensusTimeline ConsensusTimelineProvider) {
+	s.consensusTimeline = consensusTimeline
+}
+
+func (s *HttpServer) RegisterContractAbi(contractAbi ContractAbiProvider) {
+	s.contractAbi = contractAbi
+}
+
+func (s *HttpServer) RegisterTransactionSimulator(simulator TransactionSimulator) {
+	s.simulator = simulator
+}
+
+func (s *HttpServer) RegisterConfigReloader(configReloader ConfigReloader) {
+	s.configReloader = configReloader
+}
+
+func (s *HttpServer) RegisterHealthRegistry(registry health.Registry) {
+	s.health = registry
+}
+
 // Allows handler to be called via XHR requests from any host
 func wrapHandlerWithCORS(f func(w http.ResponseWriter, r *http.Request)) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -170,13 +199,19 @@ func (s *HttpServer) createRouter() *http.ServeMux {
 	s.registerHttpHandler(router, "/api/v1/get-transaction-status", true, s.getTransactionStatusHandler)
 	s.registerHttpHandler(router, "/api/v1/get-transaction-receipt-proof", true, s.getTransactionReceiptProofHandler)
 	s.registerHttpHandler(router, "/api/v1/get-block", true, s.getBlockHandler)
+	s.registerHttpHandler(router, "/api/v1/get-contract-abi", true, s.getContractAbiHandler)
+	s.registerHttpHandler(router, "/api/v1/simulate-transaction", true, s.simulateTransactionHandler)
 	s.registerHttpHandler(router, "/status", true, s.getStatus)
+	s.registerHttpHandler(router, "/health/live", false, s.livenessHandler)
+	s.registerHttpHandler(router, "/health/ready", false, s.readinessHandler)
 	s.registerHttpHandler(router, "/metrics", true, s.dumpMetricsAsJSON)
 	s.registerHttpHandler(router, "/metrics.json", true, s.dumpMetricsAsJSON)
 	s.registerHttpHandler(router, "/metrics.prometheus", true, s.dumpMetricsAsPrometheus)
 	s.registerHttpHandler(router, "/robots.txt", false, s.robots)
 	s.registerHttpHandler(router, "/debug/logs/filter-on", false, s.filterOn)
 	s.registerHttpHandler(router, "/debug/logs/filter-off", false, s.filterOff)
+	s.registerHttpHandler(router, "/debug/consensus/timeline", true, s.consensusTimelineHandler)
+	s.registerHttpHandler(router, "/debug/config/reload", false, s.configReloadHandler)
 
 	router.Handle("/", http.HandlerFunc(wrapHandlerWithCORS(s.Index)))
 
@@ -8,10 +8,12 @@ package httpserver
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-network-go/test/with"
@@ -282,6 +284,7 @@ func TestHttpServer_PublicApiGetStatus(t *testing.T) {
 			h.server.metricRegistry.NewGauge("Management.LastUpdateTime").Update(700)
 			h.server.metricRegistry.NewText("Management.Subscription.Current").Update("Active")
 			h.server.metricRegistry.NewGauge("ConsensusAlgo.LeanHelix.LastCommitted.TimeNano").Update(1000)
+			h.registerHealthCheck("last-committed-block", health.SEVERITY_CRITICAL, health.Failing("block 1 was committed 1h0m0s ago"))
 
 			req, _ := http.NewRequest("Get", "/status", nil)
 			rec := httptest.NewRecorder()
@@ -295,12 +298,69 @@ func TestHttpServer_PublicApiGetStatus(t *testing.T) {
 
 			require.Contains(t, res, "Timestamp")
 			require.Contains(t, res, "Error")
-			require.Equal(t, "Last Successful Committed Block was too long ago", res["Status"])
+			require.Equal(t, "last-committed-block: block 1 was committed 1h0m0s ago", res["Status"])
+			require.NotEmpty(t, res["Health"])
 			require.NotEmpty(t, res["Payload"])
 		})
 	})
 }
 
+func TestHttpServer_HealthEndpointsAreUnavailableBeforeRegistration(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			require.Equal(t, http.StatusServiceUnavailable, h.getHealth("/health/live").Code)
+			require.Equal(t, http.StatusServiceUnavailable, h.getHealth("/health/ready").Code)
+		})
+	})
+}
+
+func TestHttpServer_HealthyNodeIsLiveAndReady(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.registerHealthCheck("signer", health.SEVERITY_CRITICAL, health.Ok("signing with local key"))
+
+			rec := h.getHealth("/health/live")
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"), "should have our content type")
+			require.Equal(t, http.StatusOK, h.getHealth("/health/ready").Code)
+
+			report := &health.Report{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), report))
+			require.Equal(t, health.STATUS_OK, report.Status)
+			require.Len(t, report.Checks, 1)
+			require.Equal(t, "signer", report.Checks[0].Name)
+		})
+	})
+}
+
+func TestHttpServer_SyncingNodeIsLiveButNotReady(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.registerHealthCheck("block-sync", health.SEVERITY_WARNING, health.Syncing("100 blocks behind the network"))
+
+			require.Equal(t, http.StatusOK, h.getHealth("/health/live").Code)
+			rec := h.getHealth("/health/ready")
+			require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+			report := &health.Report{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), report))
+			require.Equal(t, health.STATUS_SYNCING, report.Status, "per check details should be returned when not ready")
+			require.Equal(t, "100 blocks behind the network", report.Checks[0].Message)
+		})
+	})
+}
+
+func TestHttpServer_BrokenNodeIsNotLive(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.registerHealthCheck("foo", health.SEVERITY_CRITICAL, health.Failing("foo is broken"))
+
+			require.Equal(t, http.StatusServiceUnavailable, h.getHealth("/health/live").Code)
+			require.Equal(t, http.StatusServiceUnavailable, h.getHealth("/health/ready").Code)
+		})
+	})
+}
+
 func aCompletedResult() *client.RequestResultBuilder {
 	return &client.RequestResultBuilder{
 		RequestStatus:  protocol.REQUEST_STATUS_COMPLETED,
@@ -319,6 +379,21 @@ func (h *harness) shutdown() {
 	h.server.Shutdown()
 }
 
+func (h *harness) registerHealthCheck(name string, severity health.Severity, result health.Result) {
+	registry := health.NewRegistry()
+	registry.Register(name, severity, func(ctx context.Context) health.Result {
+		return result
+	})
+	h.server.RegisterHealthRegistry(registry)
+}
+
+func (h *harness) getHealth(urlPath string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest("GET", urlPath, nil)
+	rec := httptest.NewRecorder()
+	h.server.Router().ServeHTTP(rec, req)
+	return rec
+}
+
 func (h *harness) buildUrl(urlPath string) string {
 	return fmt.Sprintf("http://127.0.0.1:%d%s", h.server.port, urlPath)
 }
@@ -0,0 +1,186 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/client"
+	"github.com/orbs-network/scribe/log"
+	"golang.org/x/time/rate"
+	"math/big"
+	"net/http"
+)
+
+type TransactionSimulator interface {
+	SimulateTransaction(ctx context.Context, signedTransaction *protocol.SignedTransaction) (*virtualmachine.TransactionSimulation, error)
+}
+
+type SimulateTransactionResponse struct {
+	BlockHeight     uint64
+	BlockTimestamp  string
+	TxHash          string
+	ExecutionResult string
+	OutputArguments []interface{}
+	OutputEvents    []*SimulatedEvent
+	StateDiffs      []*SimulatedStateDiff
+}
+
+type SimulatedEvent struct {
+	ContractName string
+	EventName    string
+	Arguments    []interface{}
+}
+
+type SimulatedStateDiff struct {
+	ContractName string
+	Key          string
+	Value        string
+}
+
+// allows requestsPerSecond requests on average and as many in a burst, zero means no limit
+func newRateLimiter(requestsPerSecond uint32) *rate.Limiter {
+	if requestsPerSecond == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), int(requestsPerSecond))
+}
+
+// runs the transaction of a send-transaction request against the latest state and returns its effect as json, the
+// transaction is never committed or broadcast so it does not have to be signed
+func (s *HttpServer) simulateTransactionHandler(w http.ResponseWriter, r *http.Request) {
+	if s.simulator == nil || !s.config.HttpSimulateTransactionEnabled() {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusNotFound, nil, "node does not simulate transactions"})
+		return
+	}
+
+	if !s.simulateLimiter.Allow() {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusTooManyRequests, nil, "too many simulate-transaction requests"})
+		return
+	}
+
+	bytes, e := readInput(r)
+	if e != nil {
+		s.writeErrorResponseAndLog(w, e)
+		return
+	}
+
+	clientRequest := client.SendTransactionRequestReader(bytes)
+	if e := validate(clientRequest); e != nil {
+		s.writeErrorResponseAndLog(w, e)
+		return
+	}
+
+	s.logger.Info("http HttpServer received simulate-transaction", log.Stringable("request", clientRequest))
+	simulation, err := s.simulator.SimulateTransaction(r.Context(), clientRequest.SignedTransaction())
+	if err == virtualmachine.ErrDeploymentNotSimulated {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusBadRequest, log.Error(err), err.Error()})
+		return
+	}
+	if err != nil {
+		s.writeErrorResponseAndLog(w, &httpErr{http.StatusInternalServerError, log.Error(err), err.Error()})
+		return
+	}
+
+	data, _ := json.MarshalIndent(toSimulateTransactionResponse(simulation), "", "  ")
+
+	w.Header().Set("Content-Type", "application/json")
+	_, err = w.Write(data)
+	if err != nil {
+		s.logger.Info("error writing simulate transaction response", log.Error(err))
+	}
+}
+
+func toSimulateTransactionResponse(simulation *virtualmachine.TransactionSimulation) *SimulateTransactionResponse {
+	receipt := simulation.TransactionReceipt
+	response := &SimulateTransactionResponse{
+		BlockHeight:     uint64(simulation.BlockHeight),
+		BlockTimestamp:  sprintfTimestamp(simulation.BlockTimestamp),
+		TxHash:          "0x" + hex.EncodeToString(receipt.Txhash()),
+		ExecutionResult: receipt.ExecutionResult().String(),
+		OutputArguments: argumentsToJson(protocol.ArgumentArrayReader(receipt.RawOutputArgumentArrayWithHeader())),
+		OutputEvents:    []*SimulatedEvent{},
+		StateDiffs:      []*SimulatedStateDiff{},
+	}
+
+	for i := protocol.EventsArrayReader(receipt.RawOutputEventsArrayWithHeader()).EventsIterator(); i.HasNext(); {
+		event := i.NextEvents()
+		response.OutputEvents = append(response.OutputEvents, &SimulatedEvent{
+			ContractName: string(event.ContractName()),
+			EventName:    string(event.EventName()),
+			Arguments:    argumentsToJson(protocol.ArgumentArrayReader(event.RawOutputArgumentArrayWithHeader())),
+		})
+	}
+
+	for _, stateDiff := range simulation.ContractStateDiffs {
+		for i := stateDiff.StateDiffsIterator(); i.HasNext(); {
+			record := i.NextStateDiffs()
+			response.StateDiffs = append(response.StateDiffs, &SimulatedStateDiff{
+				ContractName: string(stateDiff.ContractName()),
+				Key:          "0x" + hex.EncodeToString(record.Key()),
+				Value:        "0x" + hex.EncodeToString(record.Value()),
+			})
+		}
+	}
+
+	return response
+}
+
+// binary values are written as 0x prefixed hex and big numbers as decimal strings
+func argumentsToJson(argumentArray *protocol.ArgumentArray) []interface{} {
+	natives, err := argumentArray.ToNatives()
+	if err != nil {
+		return []interface{}{}
+	}
+
+	res := make([]interface{}, len(natives))
+	for i, native := range natives {
+		res[i] = nativeToJson(native)
+	}
+	return res
+}
+
+func nativeToJson(native interface{}) interface{} {
+	switch v := native.(type) {
+	case []byte:
+		return "0x" + hex.EncodeToString(v)
+	case [20]byte:
+		return "0x" + hex.EncodeToString(v[:])
+	case [32]byte:
+		return "0x" + hex.EncodeToString(v[:])
+	case *big.Int:
+		return v.String()
+	case [][]byte:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = nativeToJson(v[i])
+		}
+		return res
+	case [][20]byte:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = nativeToJson(v[i])
+		}
+		return res
+	case [][32]byte:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = nativeToJson(v[i])
+		}
+		return res
+	case []*big.Int:
+		res := make([]interface{}, len(v))
+		for i := range v {
+			res[i] = nativeToJson(v[i])
+		}
+		return res
+	}
+	return native
+}
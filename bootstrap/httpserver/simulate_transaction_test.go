@@ -0,0 +1,148 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package httpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/client"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type stubTransactionSimulator struct {
+	simulation *virtualmachine.TransactionSimulation
+	err        error
+}
+
+func (s *stubTransactionSimulator) SimulateTransaction(ctx context.Context, signedTransaction *protocol.SignedTransaction) (*virtualmachine.TransactionSimulation, error) {
+	return s.simulation, s.err
+}
+
+func (h *harness) simulateTransaction() *httptest.ResponseRecorder {
+	request := (&client.SendTransactionRequestBuilder{
+		SignedTransaction: builders.TransferTransaction().Builder(),
+	}).Build()
+
+	req, _ := http.NewRequest("POST", "/api/v1/simulate-transaction", bytes.NewReader(request.Raw()))
+	rec := httptest.NewRecorder()
+	h.server.simulateTransactionHandler(rec, req)
+	return rec
+}
+
+func aTransferSimulation() *virtualmachine.TransactionSimulation {
+	events := (&protocol.EventsArrayBuilder{
+		Events: []*protocol.EventBuilder{
+			{
+				ContractName:        "BenchmarkToken",
+				EventName:           "Transferred",
+				OutputArgumentArray: builders.ArgumentsArray(uint64(10), []byte{0xab}).RawArgumentsArray(),
+			},
+		},
+	}).Build()
+
+	return &virtualmachine.TransactionSimulation{
+		BlockHeight:    13,
+		BlockTimestamp: primitives.TimestampNano(1000),
+		TransactionReceipt: (&protocol.TransactionReceiptBuilder{
+			Txhash:              []byte{0x01, 0x02},
+			ExecutionResult:     protocol.EXECUTION_RESULT_SUCCESS,
+			OutputEventsArray:   events.RawEventsArray(),
+			OutputArgumentArray: builders.ArgumentsArray("done").RawArgumentsArray(),
+		}).Build(),
+		ContractStateDiffs: []*protocol.ContractStateDiff{
+			(&protocol.ContractStateDiffBuilder{
+				ContractName: "BenchmarkToken",
+				StateDiffs: []*protocol.StateRecordBuilder{
+					{Key: []byte{0x0a}, Value: []byte{0x0b, 0x0c}},
+				},
+			}).Build(),
+		},
+	}
+}
+
+func TestHttpServer_SimulateTransaction(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.RegisterTransactionSimulator(&stubTransactionSimulator{simulation: aTransferSimulation()})
+
+			rec := h.simulateTransaction()
+			require.Equal(t, http.StatusOK, rec.Code, "should succeed")
+			require.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+			res := &SimulateTransactionResponse{}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), res))
+			require.EqualValues(t, 13, res.BlockHeight)
+			require.Equal(t, "0x0102", res.TxHash)
+			require.Equal(t, "EXECUTION_RESULT_SUCCESS", res.ExecutionResult)
+			require.Equal(t, []interface{}{"done"}, res.OutputArguments)
+			require.Equal(t, []*SimulatedEvent{{ContractName: "BenchmarkToken", EventName: "Transferred", Arguments: []interface{}{float64(10), "0xab"}}}, res.OutputEvents)
+			require.Equal(t, []*SimulatedStateDiff{{ContractName: "BenchmarkToken", Key: "0x0a", Value: "0x0b0c"}}, res.StateDiffs)
+		})
+	})
+}
+
+func TestHttpServer_SimulateTransaction_Error(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.RegisterTransactionSimulator(&stubTransactionSimulator{err: errors.New("state unavailable")})
+
+			require.Equal(t, http.StatusInternalServerError, h.simulateTransaction().Code, "should fail with 500")
+		})
+	})
+}
+
+func TestHttpServer_SimulateTransactionRespondsNotFoundUntilRegistered(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			require.Equal(t, http.StatusNotFound, h.simulateTransaction().Code)
+		})
+	})
+}
+
+func TestHttpServer_SimulateTransactionRespondsNotFoundWhenDisabled(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.config = config.TemplateForGamma(nil, nil, ":0", false).Set(config.HTTP_SIMULATE_TRANSACTION_ENABLED, config.NodeConfigValue{BoolValue: false})
+			h.server.RegisterTransactionSimulator(&stubTransactionSimulator{simulation: aTransferSimulation()})
+
+			require.Equal(t, http.StatusNotFound, h.simulateTransaction().Code)
+		})
+	})
+}
+
+func TestHttpServer_SimulateTransactionIsRateLimited(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.simulateLimiter = newRateLimiter(1)
+			h.server.RegisterTransactionSimulator(&stubTransactionSimulator{simulation: aTransferSimulation()})
+
+			require.Equal(t, http.StatusOK, h.simulateTransaction().Code)
+			require.Equal(t, http.StatusTooManyRequests, h.simulateTransaction().Code, "second request within the same second should be rejected")
+		})
+	})
+}
+
+func TestHttpServer_SimulateTransactionRejectsDeployments(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withServerHarness(parent, func(h *harness) {
+			h.server.RegisterTransactionSimulator(&stubTransactionSimulator{err: virtualmachine.ErrDeploymentNotSimulated})
+
+			require.Equal(t, http.StatusBadRequest, h.simulateTransaction().Code, "should fail with 400")
+		})
+	})
+}
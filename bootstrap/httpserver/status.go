@@ -2,6 +2,7 @@ package httpserver
 
 import (
 	"encoding/json"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/scribe/log"
 	"net/http"
 	"time"
@@ -11,15 +12,18 @@ type StatusResponse struct {
 	Timestamp time.Time
 	Status    string
 	Error     string
+	Health    *health.Report
 	Payload   interface{}
 }
 
 func (s *HttpServer) getStatus(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
+	summary, report := s.getStatusReport(r)
 	status := StatusResponse{
 		Timestamp: time.Now(),
-		Status:    s.getStatusWarningMessage(),
+		Status:    summary,
+		Health:    report,
 		Payload:   s.metricRegistry.ExportAll(),
 	}
 
@@ -33,39 +37,11 @@ func (s *HttpServer) getStatus(w http.ResponseWriter, r *http.Request) {
 
 }
 
-func (s *HttpServer) getStatusWarningMessage() string {
-	maxTimeSinceLastBlock := s.config.TransactionPoolTimeBetweenEmptyBlocks().Nanoseconds() * 10
-	if maxTimeSinceLastBlock < 600000000 { // ten minutes
-		maxTimeSinceLastBlock = 600000000
+// the status string names the check responsible for the node not being ok, the full report is under Health
+func (s *HttpServer) getStatusReport(r *http.Request) (string, *health.Report) {
+	if s.health == nil {
+		return "Health checks are not registered", nil
 	}
-	leanHelixLastCommitteed := s.getGaugeValueFromMetrics("ConsensusAlgo.LeanHelix.LastCommitted.TimeNano")
-	if leanHelixLastCommitteed == 0 {
-		return "LeanHelix Service has not committed any blocks yet"
-	}
-	if leanHelixLastCommitteed + maxTimeSinceLastBlock < time.Now().UnixNano() {
-		return "Last Successful Committed Block was too long ago"
-	}
-
-	if len(s.config.ManagementFilePath()) != 0 && s.config.ManagementPollingInterval() > 0 {
-		maxIntervalSinceLastSuccessfulManagementUpdate := int64(s.config.ManagementPollingInterval().Seconds()) * 20
-		managementLastSuccessfullUpdate := s.getGaugeValueFromMetrics("Management.Data.LastSuccessfulUpdateTime")
-		if managementLastSuccessfullUpdate == 0 {
-			return "Management Service has never successfully updated"
-		}
-		if managementLastSuccessfullUpdate + maxIntervalSinceLastSuccessfulManagementUpdate < time.Now().Unix() {
-			return "Last successful Management Service update was too long ago"
-		}
-	}
-
-	return "OK"
-}
-
-func (s *HttpServer) getGaugeValueFromMetrics(name string) (value int64) {
-	metric := s.metricRegistry.Get(name)
-	if metric == nil {
-		return 0
-	}
-	rows := metric.Export().LogRow()
-	value = rows[len(rows)-1].Int
-	return value
+	report := s.health.Report(r.Context())
+	return report.Summary(), report
 }
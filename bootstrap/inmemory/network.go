@@ -12,6 +12,7 @@ import (
 	"github.com/orbs-network/crypto-lib-go/crypto/digest"
 	"github.com/orbs-network/govnr"
 	"github.com/orbs-network/orbs-network-go/bootstrap"
+	"github.com/orbs-network/orbs-network-go/bootstrap/httpserver"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	blockStorageAdapter "github.com/orbs-network/orbs-network-go/services/blockstorage/adapter"
@@ -181,6 +182,14 @@ func (n *Network) PublicApi(nodeIndex int) services.PublicApi {
 	return n.Nodes[nodeIndex].nodeLogic.PublicApi()
 }
 
+func (n *Network) ContractAbi(nodeIndex int) httpserver.ContractAbiProvider {
+	return n.Nodes[nodeIndex].nodeLogic.ContractAbi()
+}
+
+func (n *Network) TransactionSimulator(nodeIndex int) httpserver.TransactionSimulator {
+	return n.Nodes[nodeIndex].nodeLogic.TransactionSimulator()
+}
+
 type sendTxResp struct {
 	res *services.SendTransactionOutput
 	err error
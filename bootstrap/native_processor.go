@@ -0,0 +1,40 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package bootstrap
+
+import (
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/processor/native"
+	nativeProcessorAdapter "github.com/orbs-network/orbs-network-go/services/processor/native/adapter"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sandbox"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+)
+
+func newNativeProcessor(nativeCompiler nativeProcessorAdapter.Compiler, nodeConfig config.NodeConfig, logger log.Logger, metricFactory metric.Factory) services.Processor {
+	if !nodeConfig.ProcessorNativeSandboxEnabled() {
+		return native.NewNativeProcessor(nativeCompiler, nodeConfig, logger, metricFactory)
+	}
+
+	sharedObjectCompiler, ok := nativeCompiler.(nativeProcessorAdapter.SharedObjectCompiler)
+	if !ok {
+		logger.Error("native sandbox requires a compiler which builds shared objects, running deployed contracts in the node process")
+		return native.NewNativeProcessor(nativeCompiler, nodeConfig, logger, metricFactory)
+	}
+
+	command, err := sandbox.ChildCommand()
+	if err != nil {
+		logger.Error("Node logic native sandbox error cannot start", log.Error(err))
+		panic(fmt.Sprintf("Node logic native sandbox error cannot start: %s", err))
+	}
+
+	logger.Info("running deployed native contracts in sandbox processes", log.Uint32("workers", nodeConfig.ProcessorNativeSandboxWorkers()))
+	supervisor := sandbox.NewSupervisor(nodeConfig, command, logger, metricFactory)
+	return native.NewSandboxedNativeProcessor(sharedObjectCompiler, supervisor, nodeConfig, logger, metricFactory)
+}
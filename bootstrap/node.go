@@ -12,6 +12,7 @@ import (
 	"github.com/orbs-network/govnr"
 	"github.com/orbs-network/orbs-network-go/bootstrap/httpserver"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/adapter/filesystem"
@@ -61,16 +62,35 @@ func NewNode(nodeConfig config.NodeConfig, logger log.Logger) *Node {
 
 	transport := tcp.NewDirectTransport(ctx, nodeConfig, nodeLogger, metricRegistry)
 
+	ethereumConnection := ethereumAdapter.NewEthereumRpcConnection(nodeConfig, logger, metricRegistry)
+
 	var managementProvider management.Provider
-	if nodeConfig.ManagementFilePath() == "" {
+	if nodeConfig.IsManagementFromEthereum() {
+		managementProvider = managementAdapter.NewEthereumProvider(ethereumConnection, nodeConfig, nodeLogger, metricRegistry)
+	} else if len(nodeConfig.ManagementSources()) == 0 {
 		err := config.ValidateInMemoryManagement(nodeConfig)
 		if err != nil {
 			nodeLogger.Error("InMemory parmerters error cannot start", log.Error(err))
 			panic(err)
 		}
 		managementProvider = managementAdapter.NewMemoryProvider(nodeConfig, nodeLogger)
+	} else if sources := nodeConfig.ManagementSources(); len(sources) == 1 {
+		managementProvider = managementAdapter.NewFileProviderForPath(nodeConfig, sources[0], nodeLogger)
 	} else {
-		managementProvider = managementAdapter.NewFileProvider(nodeConfig, nodeLogger)
+		var managementSources []managementAdapter.ManagementSource
+		for _, path := range sources {
+			managementSources = append(managementSources, managementAdapter.ManagementSource{Name: path, Provider: managementAdapter.NewFileProviderForPath(nodeConfig, path, nodeLogger)})
+		}
+		managementProvider = managementAdapter.NewMultiProvider(nodeConfig, managementSources, nodeLogger, metricRegistry)
+	}
+
+	// only documents read from management sources can be verified again when served from the cache
+	if documentProvider, isDocumentProvider := managementProvider.(managementAdapter.DocumentProvider); isDocumentProvider {
+		historicCacheProvider, err := managementAdapter.NewHistoricCacheProvider(nodeConfig, documentProvider, nodeLogger, metricRegistry)
+		if err != nil {
+			panic(fmt.Sprintf("failed initializing management historic cache, err=%s", err.Error()))
+		}
+		managementProvider = historicCacheProvider
 	}
 
 	blockPersistence, err := filesystem.NewBlockPersistence(nodeConfig, nodeLogger, metricRegistry)
@@ -79,13 +99,17 @@ func NewNode(nodeConfig config.NodeConfig, logger log.Logger) *Node {
 	}
 
 	statePersistence := stateStorageAdapter.NewStatePersistence(metricRegistry)
-	ethereumConnection := ethereumAdapter.NewEthereumRpcConnection(nodeConfig, logger, metricRegistry)
 	nativeCompiler := nativeProcessorAdapter.NewNativeCompiler(nodeConfig, nodeLogger, metricRegistry)
 	nodeLogic := NewNodeLogic(ctx,
 		transport, blockPersistence, statePersistence, nil, nil, txPoolAdapter.NewSystemClock(), nativeCompiler, managementProvider,
 		nodeLogger, metricRegistry, nodeConfig, ethereumConnection)
 
 	httpServer.RegisterPublicApi(nodeLogic.PublicApi())
+	httpServer.RegisterContractAbi(nodeLogic.ContractAbi())
+	httpServer.RegisterTransactionSimulator(nodeLogic.TransactionSimulator())
+	if consensusTimeline := nodeLogic.ConsensusTimeline(); consensusTimeline != nil {
+		httpServer.RegisterConsensusTimeline(consensusTimeline)
+	}
 
 	n := &Node{
 		logger:           nodeLogger,
@@ -98,6 +122,12 @@ func NewNode(nodeConfig config.NodeConfig, logger log.Logger) *Node {
 
 	ethereumConnection.ReportConnectionStatus(ctx)
 
+	healthRegistry := health.NewRegistry()
+	transport.RegisterHealthChecks(healthRegistry)
+	ethereumConnection.RegisterHealthChecks(healthRegistry)
+	nodeLogic.RegisterHealthChecks(healthRegistry)
+	httpServer.RegisterHealthRegistry(healthRegistry)
+
 	n.Supervise(ethereumConnection)
 	n.Supervise(nodeLogic)
 	n.Supervise(transport)
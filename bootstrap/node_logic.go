@@ -9,46 +9,56 @@ package bootstrap
 import (
 	"context"
 	"fmt"
-	"github.com/orbs-network/crypto-lib-go/crypto/signer"
 	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/bootstrap/httpserver"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage"
 	blockStorageAdapter "github.com/orbs-network/orbs-network-go/services/blockstorage/adapter"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/servicesync"
-	"github.com/orbs-network/orbs-network-go/services/consensusalgo/benchmarkconsensus"
-	"github.com/orbs-network/orbs-network-go/services/consensusalgo/leanhelixconsensus"
+	"github.com/orbs-network/orbs-network-go/services/consensusalgo"
 	"github.com/orbs-network/orbs-network-go/services/consensuscontext"
 	"github.com/orbs-network/orbs-network-go/services/crosschainconnector/ethereum"
 	ethereumAdapter "github.com/orbs-network/orbs-network-go/services/crosschainconnector/ethereum/adapter"
 	"github.com/orbs-network/orbs-network-go/services/gossip"
 	gossipAdapter "github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-network-go/services/management"
-	"github.com/orbs-network/orbs-network-go/services/processor/native"
 	nativeProcessorAdapter "github.com/orbs-network/orbs-network-go/services/processor/native/adapter"
 	"github.com/orbs-network/orbs-network-go/services/publicapi"
+	resilientSigner "github.com/orbs-network/orbs-network-go/services/signer"
 	"github.com/orbs-network/orbs-network-go/services/statestorage"
 	stateStorageAdapter "github.com/orbs-network/orbs-network-go/services/statestorage/adapter"
 	"github.com/orbs-network/orbs-network-go/services/transactionpool"
 	txPoolAdapter "github.com/orbs-network/orbs-network-go/services/transactionpool/adapter"
 	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
-	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/scribe/log"
-	"github.com/pkg/errors"
 )
 
 type NodeLogic interface {
 	govnr.ShutdownWaiter
 	PublicApi() services.PublicApi
+	ConsensusTimeline() httpserver.ConsensusTimelineProvider
+	ContractAbi() httpserver.ContractAbiProvider
+	TransactionSimulator() httpserver.TransactionSimulator
+	RegisterHealthChecks(registry health.Registry)
+}
+
+type healthReporter interface {
+	RegisterHealthChecks(registry health.Registry)
 }
 
 type nodeLogic struct {
 	govnr.TreeSupervisor
-	publicApi      services.PublicApi
-	consensusAlgos []services.ConsensusAlgo
+	publicApi       services.PublicApi
+	consensusAlgos  []services.ConsensusAlgo
+	contractAbi     httpserver.ContractAbiProvider
+	simulator       httpserver.TransactionSimulator
+	healthReporters []healthReporter
 }
 
 func NewNodeLogic(parentCtx context.Context,
@@ -71,37 +81,78 @@ func NewNodeLogic(parentCtx context.Context,
 	}
 
 	processors := make(map[protocol.ProcessorType]services.Processor)
-	processors[protocol.PROCESSOR_TYPE_NATIVE] = native.NewNativeProcessor(nativeCompiler, nodeConfig, logger, metricRegistry)
-	addExtraProcessors(processors, nodeConfig, logger)
+	processors[protocol.PROCESSOR_TYPE_NATIVE] = newNativeProcessor(nativeCompiler, nodeConfig, logger, metricRegistry)
+	addExtraProcessors(processors, nodeConfig, logger, metricRegistry)
 
 	crosschainConnectors := make(map[protocol.CrosschainConnectorType]services.CrosschainConnector)
 	crosschainConnectors[protocol.CROSSCHAIN_CONNECTOR_TYPE_ETHEREUM] = ethereum.NewEthereumCrosschainConnector(ethereumConnection, nodeConfig, logger, metricRegistry)
 
-	signer, err := signer.New(nodeConfig)
+	signer, err := resilientSigner.NewResilientSigner(ctx, nodeConfig, logger, metricRegistry)
 	if err != nil {
 		logger.Error("Node logic signer error cannot start", log.Error(err))
 		panic(fmt.Sprintf("Node logic signer error cannot start: %s", err))
 	}
 
+	// without a collector spans are not exported, trace ids are still propagated to logs and to other nodes
+	tracer := trace.NewNoopTracer()
+	var spanExporter *trace.OtlpExporter
+	if nodeConfig.TracingOtlpEndpoint() != "" {
+		spanExporter = trace.NewOtlpExporter(ctx, nodeConfig.TracingOtlpEndpoint(), nodeConfig.TracingExportInterval(), map[string]string{
+			"service.name":          "orbs-node",
+			"orbs.node-address":     nodeConfig.NodeAddress().String(),
+			"orbs.virtual-chain-id": nodeConfig.VirtualChainId().String(),
+		}, logger)
+		tracer = trace.NewTracer(spanExporter)
+	}
+
 	gossipService := gossip.NewGossip(ctx, gossipTransport, nodeConfig, logger, metricRegistry)
-	management := management.NewManagement(ctx, nodeConfig, managementProvider, gossipService, logger, metricRegistry)
+	management, err := management.NewManagement(ctx, nodeConfig, managementProvider, gossipService, logger, metricRegistry)
+	if err != nil {
+		logger.Error("Node logic management error cannot start", log.Error(err))
+		panic(fmt.Sprintf("Node logic management error cannot start: %s", err))
+	}
 	stateStorageService := statestorage.NewStateStorage(nodeConfig, statePersistence, stateBlockHeightReporter, logger, metricRegistry)
-	virtualMachineService := virtualmachine.NewVirtualMachine(stateStorageService, processors, crosschainConnectors, management, nodeConfig, logger)
-	transactionPoolService := transactionpool.NewTransactionPool(ctx, maybeClock, gossipService, virtualMachineService, signer, transactionPoolBlockHeightReporter, nodeConfig, logger, metricRegistry)
-	serviceSyncCommitters := []servicesync.BlockPairCommitter{servicesync.NewStateStorageCommitter(stateStorageService), servicesync.NewTxPoolCommitter(transactionPoolService)}
-	blockStorageService := blockstorage.NewBlockStorage(ctx, nodeConfig, blockPersistence, gossipService, logger, metricRegistry, serviceSyncCommitters)
-	publicApiService := publicapi.NewPublicApi(nodeConfig, transactionPoolService, virtualMachineService, blockStorageService, logger, metricRegistry)
-	consensusContextService := consensuscontext.NewConsensusContext(transactionPoolService, virtualMachineService, stateStorageService, management, nodeConfig, logger, metricRegistry)
-
-	consensusAlgo := createConsensusAlgo(nodeConfig)(ctx, gossipService, blockStorageService, consensusContextService, signer, logger, metricRegistry)
+	virtualMachineService := virtualmachine.NewVirtualMachine(stateStorageService, processors, crosschainConnectors, management, nodeConfig, logger, tracer)
+	transactionPoolService := transactionpool.NewTransactionPool(ctx, maybeClock, gossipService, virtualMachineService, signer, transactionPoolBlockHeightReporter, nodeConfig, logger, metricRegistry, tracer)
+	serviceSyncCommitters := []servicesync.BlockPairCommitter{servicesync.NewStateStorageCommitter(stateStorageService), servicesync.NewTxPoolCommitter(transactionPoolService), servicesync.NewSignerCommitter(signer)}
+	blockStorageService := blockstorage.NewBlockStorage(ctx, nodeConfig, blockPersistence, gossipService, logger, metricRegistry, tracer, serviceSyncCommitters)
+	publicApiService := publicapi.NewPublicApi(nodeConfig, transactionPoolService, virtualMachineService, blockStorageService, logger, metricRegistry, tracer)
+	consensusContextService := consensuscontext.NewConsensusContext(transactionPoolService, virtualMachineService, stateStorageService, management, nodeConfig, logger, metricRegistry, tracer)
+
+	govnr.Once(logfields.GovnrErrorer(logger), func() {
+		preWarmDeployedContracts(ctx, nodeConfig, blockPersistence, stateStorageService, virtualMachineService.(deployedContractsPreWarmer), logger)
+	})
+
+	consensusAlgo, err := consensusalgo.DefaultRegistry.Create(ctx, nodeConfig.ActiveConsensusAlgo(), &consensusalgo.Dependencies{
+		Gossip:           gossipService,
+		BlockStorage:     blockStorageService,
+		ConsensusContext: consensusContextService,
+		Signer:           signer,
+		Logger:           logger,
+		Config:           nodeConfig,
+		MetricFactory:    metricRegistry,
+	})
+	if err != nil {
+		logger.Error("Node logic consensus algo error cannot start", log.Error(err))
+		panic(err)
+	}
 
 	logger.Info("Node started")
 
 	node := &nodeLogic{
 		publicApi:      publicApiService,
 		consensusAlgos: []services.ConsensusAlgo{consensusAlgo},
+		contractAbi:    virtualMachineService.(httpserver.ContractAbiProvider),
+		simulator:      virtualMachineService.(httpserver.TransactionSimulator),
+		healthReporters: []healthReporter{
+			signer,
+			management,
+			blockStorageService,
+			&stateStorageHealthReporter{stateStorage: stateStorageService, blockStorage: blockStorageService},
+		},
 	}
 
+	node.Supervise(signer)
 	node.Supervise(management)
 	node.Supervise(gossipService)
 	node.Supervise(blockStorageService)
@@ -109,6 +160,9 @@ func NewNodeLogic(parentCtx context.Context,
 	node.Supervise(metric.NewSystemReporter(ctx, metricRegistry, logger))
 	node.Supervise(metric.NewRuntimeReporter(ctx, metricRegistry, logger))
 	node.Supervise(metricRegistry.PeriodicallyRotate(ctx, logger))
+	if spanExporter != nil {
+		node.Supervise(spanExporter)
+	}
 	if nodeConfig.NTPEndpoint() != "" {
 		node.Supervise(metric.NewNtpReporter(ctx, metricRegistry, logger, nodeConfig.NTPEndpoint()))
 	}
@@ -116,31 +170,44 @@ func NewNodeLogic(parentCtx context.Context,
 	return node
 }
 
-type consensusAlgo interface {
-	services.ConsensusAlgo
-	govnr.ShutdownWaiter
+func (n *nodeLogic) PublicApi() services.PublicApi {
+	return n.publicApi
 }
 
-func createConsensusAlgo(nodeConfig config.NodeConfig) func(ctx context.Context,
-	gossip services.Gossip,
-	blockStorage services.BlockStorage,
-	consensusContext services.ConsensusContext,
-	signer signer.Signer,
-	parentLogger log.Logger,
-	metricFactory metric.Factory) consensusAlgo {
-
-	return func(ctx context.Context, gossip services.Gossip, blockStorage services.BlockStorage, consensusContext services.ConsensusContext, signer signer.Signer, parentLogger log.Logger, metricFactory metric.Factory) consensusAlgo {
-		switch nodeConfig.ActiveConsensusAlgo() {
-		case consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX:
-			return leanhelixconsensus.NewLeanHelixConsensusAlgo(ctx, gossip, blockStorage, consensusContext, signer, parentLogger, nodeConfig, metricFactory)
-		case consensus.CONSENSUS_ALGO_TYPE_BENCHMARK_CONSENSUS:
-			return benchmarkconsensus.NewBenchmarkConsensusAlgo(ctx, gossip, blockStorage, consensusContext, signer, parentLogger, nodeConfig, metricFactory)
-		default:
-			panic(errors.Errorf("unknown consensus algo type %s", nodeConfig.ActiveConsensusAlgo()))
-		}
+func (n *nodeLogic) ContractAbi() httpserver.ContractAbiProvider {
+	return n.contractAbi
+}
+
+func (n *nodeLogic) TransactionSimulator() httpserver.TransactionSimulator {
+	return n.simulator
+}
+
+func (n *nodeLogic) RegisterHealthChecks(registry health.Registry) {
+	for _, reporter := range n.healthReporters {
+		reporter.RegisterHealthChecks(registry)
 	}
 }
 
-func (n *nodeLogic) PublicApi() services.PublicApi {
-	return n.publicApi
+// state storage is checked against block storage, which it is synced from
+type stateStorageHealthReporter struct {
+	stateStorage services.StateStorage
+	blockStorage services.BlockStorage
+}
+
+func (r *stateStorageHealthReporter) RegisterHealthChecks(registry health.Registry) {
+	if reporter, ok := r.stateStorage.(interface {
+		RegisterHealthChecks(registry health.Registry, blockStorage services.BlockStorage)
+	}); ok {
+		reporter.RegisterHealthChecks(registry, r.blockStorage)
+	}
+}
+
+// returns nil when none of the consensus algos records a timeline
+func (n *nodeLogic) ConsensusTimeline() httpserver.ConsensusTimelineProvider {
+	for _, algo := range n.consensusAlgos {
+		if timeline, ok := algo.(httpserver.ConsensusTimelineProvider); ok {
+			return timeline
+		}
+	}
+	return nil
 }
@@ -0,0 +1,76 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package bootstrap
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"time"
+)
+
+const preWarmPollingInterval = 100 * time.Millisecond
+
+type preWarmConfig interface {
+	ProcessorPreWarmDeployedContracts() bool
+	ProcessorPreWarmTimeout() time.Duration
+}
+
+type deployedContractsPreWarmer interface {
+	PreWarmDeployedContracts(ctx context.Context) error
+}
+
+type lastBlockHeightReader interface {
+	GetLastBlockHeight() (primitives.BlockHeight, error)
+}
+
+// preWarmDeployedContracts compiles the deployed contracts, it first waits for the state to catch up with the blocks already
+// persisted so contracts deployed in them are included. The node starts in parallel and compiles contracts called before
+// they were pre-warmed on first use like before
+func preWarmDeployedContracts(ctx context.Context, cfg preWarmConfig, blockPersistence lastBlockHeightReader, stateStorage services.StateStorage, preWarmer deployedContractsPreWarmer, logger log.Logger) {
+	if !cfg.ProcessorPreWarmDeployedContracts() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.ProcessorPreWarmTimeout())
+	defer cancel()
+
+	start := time.Now()
+	if err := waitForStateToReachPersistedBlocks(ctx, blockPersistence, stateStorage); err != nil {
+		logger.Info("skipped pre-warming deployed contracts", log.Error(err))
+		return
+	}
+	if err := preWarmer.PreWarmDeployedContracts(ctx); err != nil {
+		logger.Info("failed to pre-warm deployed contracts", log.Error(err))
+		return
+	}
+	logger.Info("pre-warmed deployed contracts", log.Stringable("duration", time.Since(start)))
+}
+
+func waitForStateToReachPersistedBlocks(ctx context.Context, blockPersistence lastBlockHeightReader, stateStorage services.StateStorage) error {
+	persistedHeight, err := blockPersistence.GetLastBlockHeight()
+	if err != nil {
+		return errors.Wrap(err, "could not read the last persisted block height")
+	}
+
+	ticker := time.NewTicker(preWarmPollingInterval)
+	defer ticker.Stop()
+	for {
+		output, err := stateStorage.GetLastCommittedBlockInfo(ctx, &services.GetLastCommittedBlockInfoInput{})
+		if err == nil && output.BlockHeight >= persistedHeight {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "state did not reach persisted block height %d", persistedHeight)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package bootstrap
+
+import (
+	"context"
+	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type preWarmTestConfig struct {
+	enabled bool
+	timeout time.Duration
+}
+
+func (c *preWarmTestConfig) ProcessorPreWarmDeployedContracts() bool {
+	return c.enabled
+}
+
+func (c *preWarmTestConfig) ProcessorPreWarmTimeout() time.Duration {
+	return c.timeout
+}
+
+type stubBlockPersistence struct {
+	lastBlockHeight primitives.BlockHeight
+}
+
+func (p *stubBlockPersistence) GetLastBlockHeight() (primitives.BlockHeight, error) {
+	return p.lastBlockHeight, nil
+}
+
+type countingPreWarmer struct {
+	calls int
+}
+
+func (p *countingPreWarmer) PreWarmDeployedContracts(ctx context.Context) error {
+	p.calls++
+	return nil
+}
+
+func stateStorageAtHeight(height primitives.BlockHeight) *services.MockStateStorage {
+	stateStorage := &services.MockStateStorage{}
+	stateStorage.When("GetLastCommittedBlockInfo", mock.Any, mock.Any).Return(&services.GetLastCommittedBlockInfoOutput{BlockHeight: height}, nil)
+	return stateStorage
+}
+
+func TestPreWarmDeployedContracts_WhenStateReachedPersistedBlocks(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			preWarmer := &countingPreWarmer{}
+			cfg := &preWarmTestConfig{enabled: true, timeout: time.Second}
+
+			preWarmDeployedContracts(ctx, cfg, &stubBlockPersistence{lastBlockHeight: 5}, stateStorageAtHeight(5), preWarmer, parent.Logger)
+
+			require.Equal(t, 1, preWarmer.calls)
+		})
+	})
+}
+
+func TestPreWarmDeployedContracts_SkippedWhenStateDoesNotCatchUp(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			preWarmer := &countingPreWarmer{}
+			cfg := &preWarmTestConfig{enabled: true, timeout: 3 * preWarmPollingInterval}
+
+			preWarmDeployedContracts(ctx, cfg, &stubBlockPersistence{lastBlockHeight: 5}, stateStorageAtHeight(2), preWarmer, parent.Logger)
+
+			require.Zero(t, preWarmer.calls, "should not pre-warm before the state includes all persisted blocks")
+		})
+	})
+}
+
+func TestPreWarmDeployedContracts_Disabled(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			preWarmer := &countingPreWarmer{}
+			cfg := &preWarmTestConfig{enabled: false, timeout: time.Second}
+
+			preWarmDeployedContracts(ctx, cfg, &stubBlockPersistence{}, stateStorageAtHeight(0), preWarmer, parent.Logger)
+
+			require.Zero(t, preWarmer.calls)
+		})
+	})
+}
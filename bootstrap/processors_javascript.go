@@ -10,12 +10,15 @@ package bootstrap
 
 import (
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/processor/javascript"
+	"github.com/orbs-network/orbs-network-go/services/processor/wasm"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/scribe/log"
 )
 
-func addExtraProcessors(processors map[protocol.ProcessorType]services.Processor, nodeConfig config.NodeConfig, logger log.Logger) {
+func addExtraProcessors(processors map[protocol.ProcessorType]services.Processor, nodeConfig config.NodeConfig, logger log.Logger, metricFactory metric.Factory) {
 	processors[protocol.PROCESSOR_TYPE_JAVASCRIPT] = javascript.NewJavaScriptProcessor(logger, nodeConfig)
+	processors[wasm.PROCESSOR_TYPE_WASM] = wasm.NewWasmProcessor(nodeConfig, logger, metricFactory)
 }
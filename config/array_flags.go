@@ -24,6 +24,17 @@ func (i *ArrayFlags) Set(value string) error {
 }
 
 func GetNodeConfigFromFiles(configFiles ArrayFlags, httpAddress string) (NodeConfig, error) {
+	cfg, err := readNodeConfigFromFiles(configFiles, httpAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := unlockNodeKeystore(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func readNodeConfigFromFiles(configFiles ArrayFlags, httpAddress string) (*config, error) {
 	cfg := ForProduction("")
 
 	if len(configFiles) != 0 {
@@ -47,5 +58,9 @@ func GetNodeConfigFromFiles(configFiles ArrayFlags, httpAddress string) (NodeCon
 
 	cfg.SetString(HTTP_ADDRESS, httpAddress)
 
-	return cfg, nil
+	if err := applyEnvOverrides(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return cfg.(*config), nil
 }
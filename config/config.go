@@ -20,6 +20,8 @@ type NodeConfig interface {
 	NetworkType() protocol.SignerNetworkType
 	NodeAddress() primitives.NodeAddress
 	NodePrivateKey() primitives.EcdsaSecp256K1PrivateKey
+	NodeKeystorePath() string
+	NodeKeystorePassphraseFile() string
 	GenesisValidatorNodes() map[string]ValidatorNode // TODO POSV2 remove this ?
 	TransactionExpirationWindow() time.Duration
 
@@ -29,6 +31,19 @@ type NodeConfig interface {
 	ManagementPollingInterval() time.Duration
 	ManagementConsensusGraceTimeout() time.Duration
 	ManagementNetworkLivenessTimeout() time.Duration
+	ManagementAuthorityAddresses() []primitives.NodeAddress
+	ManagementAuthorityThreshold() uint32
+	ManagementMaxDataAge() time.Duration
+	ManagementSources() []string
+	ManagementSourcesQuorum() uint32
+	ManagementHistoricCacheDir() string
+	ManagementHistoricCacheBundle() string
+	ManagementEthereumGuardiansContract() []byte
+	ManagementEthereumCommitteeContract() []byte
+	ManagementEthereumSubscriptionsContract() []byte
+	ManagementEthereumProtocolContract() []byte
+	ManagementEthereumFromBlock() uint32
+	IsManagementFromEthereum() bool
 
 	// consensus
 	ActiveConsensusAlgo() consensus.ConsensusAlgoType
@@ -38,6 +53,7 @@ type NodeConfig interface {
 	LeanHelixConsensusMinimumCommitteeSize() uint32
 	LeanHelixConsensusMaximumCommitteeSize() uint32
 	LeanHelixShowDebug() bool
+	LeanHelixTimelineMaxHeights() uint32
 	InterNodeSyncAuditBlocksYoungerThan() time.Duration
 
 	// benchmark consensus
@@ -52,6 +68,11 @@ type NodeConfig interface {
 	BlockSyncCollectChunksTimeout() time.Duration
 	BlockSyncDescendingEnabled() bool
 	BlockSyncReferenceMaxAllowedDistance() time.Duration
+	BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight
+	BlockSyncFastSyncCheckpointHash() primitives.Sha256
+	BlockSyncServerMaxConcurrentRequestors() uint32
+	BlockSyncServerMaxBytesPerSecond() uint32
+	BlockSyncServerQueueTimeout() time.Duration
 	BlockStorageTransactionReceiptQueryTimestampGrace() time.Duration
 	BlockStorageFileSystemDataDir() string
 	BlockStorageFileSystemMaxBlockSizeInBytes() uint32
@@ -91,8 +112,19 @@ type NodeConfig interface {
 
 	// processor
 	ProcessorArtifactPath() string
+	ProcessorArtifactStorePath() string
+	ProcessorArtifactStoreSecret() string
+	ProcessorPreWarmDeployedContracts() bool
+	ProcessorPreWarmTimeout() time.Duration
 	ProcessorSanitizeDeployedContracts() bool
 	ProcessorPerformWarmUpCompilation() bool
+	ProcessorWasmMaxInstructionsPerCall() uint32
+	ProcessorWasmMaxMemoryPages() uint32
+	ProcessorNativeSandboxEnabled() bool
+	ProcessorNativeSandboxWorkers() uint32
+	ProcessorNativeSandboxMaxMemoryMegabytes() uint32
+	ProcessorNativeSandboxMaxCpuTimePerCall() time.Duration
+	ProcessorNativeSandboxCallTimeout() time.Duration
 
 	// ethereum connector (crosschain)
 	EthereumEndpoint() string
@@ -107,6 +139,9 @@ type NodeConfig interface {
 
 	// http server
 	HttpAddress() string
+	HttpSimulateTransactionEnabled() bool
+	HttpSimulateTransactionRateLimit() uint32
+	HttpConfigReloadEnabled() bool
 
 	// profiling
 	Profiling() bool
@@ -114,8 +149,20 @@ type NodeConfig interface {
 	// NTP Network Time Protocol
 	NTPEndpoint() string
 
+	// tracing
+	TracingOtlpEndpoint() string
+	TracingExportInterval() time.Duration
+
 	// Remote signer
 	SignerEndpoint() string
+	SignerRequestTimeout() time.Duration
+	SignerRetryAttempts() uint32
+	SignerRetryBackoff() time.Duration
+	SignerHealthCheckInterval() time.Duration
+	SignerCircuitBreakerThreshold() uint32
+	SignerCircuitBreakerCooldown() time.Duration
+	SignerSwitchEndpoint() string
+	SignerSwitchReferenceTime() uint32
 
 	// Build-dependent configuration
 	ExtraConfig
@@ -151,9 +198,15 @@ type BlockStorageConfig interface {
 	BlockSyncCollectChunksTimeout() time.Duration
 	BlockSyncDescendingEnabled() bool
 	BlockSyncReferenceMaxAllowedDistance() time.Duration
+	BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight
+	BlockSyncFastSyncCheckpointHash() primitives.Sha256
+	BlockSyncServerMaxConcurrentRequestors() uint32
+	BlockSyncServerMaxBytesPerSecond() uint32
+	BlockSyncServerQueueTimeout() time.Duration
 	BlockStorageTransactionReceiptQueryTimestampGrace() time.Duration
 	TransactionExpirationWindow() time.Duration
 	BlockTrackerGraceTimeout() time.Duration
+	TransactionPoolTimeBetweenEmptyBlocks() time.Duration
 }
 
 type FilesystemBlockPersistenceConfig interface {
@@ -229,11 +282,25 @@ type NativeProcessorConfig interface {
 	VirtualChainId() primitives.VirtualChainId
 }
 
+type NativeSandboxConfig interface {
+	ProcessorNativeSandboxWorkers() uint32
+	ProcessorNativeSandboxMaxMemoryMegabytes() uint32
+	ProcessorNativeSandboxMaxCpuTimePerCall() time.Duration
+	ProcessorNativeSandboxCallTimeout() time.Duration
+}
+
+type WasmProcessorConfig interface {
+	ProcessorWasmMaxInstructionsPerCall() uint32
+	ProcessorWasmMaxMemoryPages() uint32
+	VirtualChainId() primitives.VirtualChainId
+}
+
 type LeanHelixConsensusConfig interface {
 	NodeAddress() primitives.NodeAddress
 	LeanHelixConsensusRoundTimeoutInterval() time.Duration
 	LeanHelixConsensusMaximumCommitteeSize() uint32
 	LeanHelixShowDebug() bool
+	LeanHelixTimelineMaxHeights() uint32
 	ActiveConsensusAlgo() consensus.ConsensusAlgoType
 	VirtualChainId() primitives.VirtualChainId
 	NetworkType() protocol.SignerNetworkType
@@ -252,13 +319,26 @@ type ValidatorNode interface {
 
 type HttpServerConfig interface {
 	HttpAddress() string
+	HttpSimulateTransactionEnabled() bool
+	HttpSimulateTransactionRateLimit() uint32
+	HttpConfigReloadEnabled() bool
 	Profiling() bool
-	ManagementFilePath() string
-	ManagementPollingInterval() time.Duration
-	TransactionPoolTimeBetweenEmptyBlocks() time.Duration
 }
 
 type SignerConfig interface {
 	NodePrivateKey() primitives.EcdsaSecp256K1PrivateKey
 	SignerEndpoint() string
 }
+
+type ResilientSignerConfig interface {
+	SignerConfig
+	NodeAddress() primitives.NodeAddress
+	SignerRequestTimeout() time.Duration
+	SignerRetryAttempts() uint32
+	SignerRetryBackoff() time.Duration
+	SignerHealthCheckInterval() time.Duration
+	SignerCircuitBreakerThreshold() uint32
+	SignerCircuitBreakerCooldown() time.Duration
+	SignerSwitchEndpoint() string
+	SignerSwitchReferenceTime() uint32
+}
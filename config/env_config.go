@@ -0,0 +1,53 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"io"
+)
+
+const ENV_VAR_PREFIX = "ORBS_"
+
+// overrides keys of the config schema with ORBS_<KEY> environment variables, e.g. ORBS_GOSSIP_LISTEN_PORT=4400.
+// List and json keys take a comma separated string or a json value. Other ORBS_ variables are ignored since an
+// orchestrator may define some of its own (kubernetes adds ORBS_SERVICE_HOST for a service named orbs)
+func applyEnvOverrides(cfg mutableNodeConfig, lookupEnv func(key string) (string, bool)) error {
+	for _, k := range configSchema {
+		envValue, ok := lookupEnv(k.envVar())
+		if !ok {
+			continue
+		}
+
+		value, err := k.envValue(envValue)
+		if err != nil {
+			return errors.Errorf("invalid value for environment variable %s (%s, %s): %s", k.envVar(), k.valueType, k.doc, err)
+		}
+		if err := k.populate(cfg, value); err != nil {
+			return errors.Wrapf(err, "environment variable %s", k.envVar())
+		}
+	}
+	return nil
+}
+
+// PrintConfig writes the effective value of every key in the config schema as a json config file, with secrets redacted
+func PrintConfig(cfg NodeConfig, w io.Writer) error {
+	c, ok := cfg.(*config)
+	if !ok {
+		return errors.Errorf("cannot print config of type %T", cfg)
+	}
+
+	effective := make(map[string]interface{})
+	for _, k := range configSchema {
+		effective[k.name] = k.effectiveValue(c)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(effective)
+}
@@ -36,7 +36,7 @@ func (c *config) Clone() mutableNodeConfig {
 		gossipPeers:             c.gossipPeers,
 		nodePrivateKey:          c.nodePrivateKey,
 		nodeAddress:             c.nodeAddress,
-		kv:                      cloneMap(c.kv),
+		kv:                      c.values(),
 	}
 }
 
@@ -12,10 +12,9 @@ import (
 	"fmt"
 	topologyProviderAdapter "github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
-	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
-	"strconv"
+	"github.com/pkg/errors"
+	"sort"
 	"strings"
-	"time"
 )
 
 func newEmptyFileConfig(source string) (mutableNodeConfig, error) {
@@ -39,130 +38,113 @@ func convertKeyName(key string) string {
 	return strings.ToUpper(strings.Replace(key, "-", "_", -1))
 }
 
-func parseUint32(f64 float64) (uint32, error) { // TODO posv2 this may be over kill can do direct casting from float
-	s := fmt.Sprintf("%.0f", f64)
-	if i, err := strconv.Atoi(s); err == nil {
-		return uint32(i), nil
-	} else {
-		return 0, err
-	}
-}
-
 func parseNodes(value interface{}) (nodes map[string]ValidatorNode, err error) {
 	nodes = make(map[string]ValidatorNode)
 
-	if nodeList, ok := value.([]interface{}); ok {
-		for _, item := range nodeList {
-			address := item.(string)
+	nodeList, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected a list of addresses but got %s", describeValue(value))
+	}
+	for _, item := range nodeList {
+		nodeAddress, err := parseHex(item)
+		if err != nil {
+			return nil, err
+		}
+		nodes[primitives.NodeAddress(nodeAddress).KeyForMap()] = &hardCodedValidatorNode{
+			nodeAddress: nodeAddress,
+		}
+	}
 
-			if nodeAddress, err := hex.DecodeString(address); err != nil {
-				return nodes, err
-			} else {
-				nodeAddress := primitives.NodeAddress(nodeAddress)
+	return nodes, nil
+}
 
-				nodes[nodeAddress.KeyForMap()] = &hardCodedValidatorNode{
-					nodeAddress: nodeAddress,
-				}
+// accepts either a json list of hex addresses or an already comma separated string
+func parseAddressList(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []interface{}:
+		var addresses []string
+		for _, item := range v {
+			address, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("address %v is not a string", item)
 			}
+			if _, err := hex.DecodeString(address); err != nil {
+				return "", err
+			}
+			addresses = append(addresses, address)
 		}
+		return strings.Join(addresses, ","), nil
 	}
+	return "", fmt.Errorf("expected a list of addresses but got %v", value)
+}
 
-	return nodes, nil
+func parseStringList(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return "", fmt.Errorf("%v is not a string", item)
+			}
+			items = append(items, s)
+		}
+		return strings.Join(items, ","), nil
+	}
+	return "", fmt.Errorf("expected a list of strings but got %v", value)
 }
 
 func parsePeers(value interface{}) (peers topologyProviderAdapter.TransportPeers, err error) {
 	peers = make(topologyProviderAdapter.TransportPeers)
 
-	if nodeList, ok := value.([]interface{}); ok {
-		for _, item := range nodeList {
-			kv := item.(map[string]interface{})
-
-			hexAddress := kv["address"].(string)
-			if nodeAddress, err := hex.DecodeString(hexAddress); err != nil {
-				return peers, err
-			} else {
-				nodeAddress := primitives.NodeAddress(nodeAddress)
-
-				if i, err := parseUint32(kv["port"].(float64)); err != nil {
-					return peers, err
-				} else {
-					peers[nodeAddress.KeyForMap()] = topologyProviderAdapter.NewGossipPeer(int(i), kv["ip"].(string), hexAddress)
-				}
-			}
+	nodeList, ok := value.([]interface{})
+	if !ok {
+		return nil, errors.Errorf("expected a list of peers but got %s", describeValue(value))
+	}
+	for _, item := range nodeList {
+		kv, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected a peer object with address, ip and port but got %s", describeValue(item))
+		}
+
+		nodeAddress, err := parseHex(kv["address"])
+		if err != nil {
+			return nil, errors.Wrap(err, "peer address")
+		}
+		port, err := parseUint32Value(kv["port"])
+		if err != nil {
+			return nil, errors.Wrap(err, "peer port")
+		}
+		ip, ok := kv["ip"].(string)
+		if !ok {
+			return nil, errors.Errorf("peer ip: expected a string but got %s", describeValue(kv["ip"]))
 		}
+
+		peers[primitives.NodeAddress(nodeAddress).KeyForMap()] = topologyProviderAdapter.NewGossipPeer(int(port), ip, hex.EncodeToString(nodeAddress))
 	}
 
 	return peers, nil
 }
 
+// every key must be declared in the config schema, an unknown key or a value of the wrong type fails the whole config
 func populateConfig(cfg mutableNodeConfig, data map[string]interface{}) error {
-	for key, value := range data {
-		var nodeAddress primitives.NodeAddress
-		var err error
-		var processed bool
-
-		if key == "benchmark-consensus-constant-leader" {
-			nodeAddress, err = hex.DecodeString(value.(string))
-			cfg.SetBenchmarkConsensusConstantLeader(primitives.NodeAddress(nodeAddress))
-			processed = true
-		} else if key == "active-consensus-algo" {
-			var i uint32
-			i, err = parseUint32(value.(float64))
-			cfg.SetActiveConsensusAlgo(consensus.ConsensusAlgoType(i))
-			processed = true
-		} else if key == "node-address" {
-			nodeAddress, err = hex.DecodeString(value.(string))
-			cfg.SetNodeAddress(nodeAddress)
-			processed = true
-		} else if key == "node-private-key" {
-			var privateKey primitives.EcdsaSecp256K1PrivateKey
-			privateKey, err = hex.DecodeString(value.(string))
-			cfg.SetNodePrivateKey(privateKey)
-			processed = true
-		} else if key == "ethereum-finality-blocks-component" {
-			var finalityBlocksComponent uint32
-			finalityBlocksComponent, err = parseUint32(value.(float64))
-			cfg.SetUint32(ETHEREUM_FINALITY_BLOCKS_COMPONENT, finalityBlocksComponent)
-			processed = true
-		} else if key == "gossip-port" {
-			var gossipPort uint32
-			gossipPort, err = parseUint32(value.(float64))
-			cfg.SetUint32(GOSSIP_LISTEN_PORT, gossipPort)
-			processed = true
-		} else if key == "genesis-validator-addresses" {
-			var nodes map[string]ValidatorNode
-			nodes, err = parseNodes(value)
-			cfg.SetGenesisValidatorNodes(nodes)
-			processed = true
-		} else if key == "federation-nodes" || key == "topology-nodes" { // note: "federation-nodes" is deprecated but kept for backwards-compatibility
-			var peers topologyProviderAdapter.TransportPeers
-			peers, err = parsePeers(value)
-			cfg.SetGossipPeers(peers)
-			processed = true
-		}
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names) // reports the same error for the same file on every run
 
-		if err != nil {
-			return fmt.Errorf("could not decode value for config key %s: %s", key, err)
-		} else if processed {
-			continue
+	for _, name := range names {
+		k, ok := configSchemaByName[name]
+		if !ok {
+			return errors.Errorf("unknown config key %s", name)
 		}
-
-		switch value.(type) {
-		case bool:
-			cfg.SetBool(convertKeyName(key), value.(bool))
-		case float64:
-			numericValue, err := parseUint32(value.(float64))
-			if err != nil {
-				return fmt.Errorf("could not decode value for config key %s: %s", key, err)
-			}
-			cfg.SetUint32(convertKeyName(key), numericValue)
-		case string:
-			// Sometimes we try to parse duration, but sometimes it's not worth it, like with Ethereum endpoint
-			if duration, decodeError := time.ParseDuration(value.(string)); decodeError != nil {
-				cfg.SetString(convertKeyName(key), value.(string))
-			} else {
-				cfg.SetDuration(convertKeyName(key), duration)
-			}
+		if err := k.populate(cfg, data[name]); err != nil {
+			return err
 		}
 	}
 
@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 	topologyProviderAdapter "github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
 	"testing"
@@ -135,6 +136,15 @@ func TestErrorWhenInvalidAddress(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestErrorWhenInvalidFastSyncCheckpointHash(t *testing.T) {
+	cfg, err := newEmptyFileConfig(`{
+		"block-sync-fast-sync-checkpoint-hash": "not-a-hash"
+	}`)
+
+	require.Nil(t, cfg)
+	require.Error(t, err)
+}
+
 func TestSetGenesisValidatorNodes(t *testing.T) {
 	cfg, err := newEmptyFileConfig(`{
 		"genesis-validator-addresses": [
@@ -161,6 +171,45 @@ func TestSetGenesisValidatorNodes(t *testing.T) {
 	require.EqualValues(t, node1, cfg.GenesisValidatorNodes()[keyPair.NodeAddress().KeyForMap()])
 }
 
+func TestSetManagementAuthorityAddresses(t *testing.T) {
+	cfg, err := newEmptyFileConfig(`{
+		"management-authority-addresses": [
+		"a328846cd5b4979d68a8c58a9bdfeee657b34de7",
+		"d27e2e7398e2582f63d0800330010b3e58952ff6"
+		],
+		"management-authority-threshold": 2
+	}`)
+
+	require.NoError(t, err)
+	require.EqualValues(t, []primitives.NodeAddress{keys.EcdsaSecp256K1KeyPairForTests(0).NodeAddress(), keys.EcdsaSecp256K1KeyPairForTests(1).NodeAddress()}, cfg.ManagementAuthorityAddresses())
+	require.EqualValues(t, 2, cfg.ManagementAuthorityThreshold())
+
+	_, err = newEmptyFileConfig(`{"management-authority-addresses": ["gggg"]}`)
+	require.Error(t, err)
+}
+
+func TestSetManagementSources(t *testing.T) {
+	cfg, err := newEmptyFileConfig(`{
+		"management-file-path": "http://management-1/vc",
+		"management-sources": ["http://management-1/vc", "http://management-2/vc", "/opt/orbs/management.json"],
+		"management-sources-quorum": 2
+	}`)
+
+	require.NoError(t, err)
+	require.EqualValues(t, []string{"http://management-1/vc", "http://management-2/vc", "/opt/orbs/management.json"}, cfg.ManagementSources(), "the management file path should be listed once, first")
+	require.EqualValues(t, 2, cfg.ManagementSourcesQuorum())
+}
+
+func TestManagementHistoricCacheDirDefaultsToBlockStorageDir(t *testing.T) {
+	cfg, err := newEmptyFileConfig(`{"block-storage-file-system-data-dir": "/var/orbs"}`)
+	require.NoError(t, err)
+	require.Equal(t, "/var/orbs/management-history", cfg.ManagementHistoricCacheDir())
+
+	cfg, err = newEmptyFileConfig(`{"management-historic-cache-dir": "/var/cache/orbs"}`)
+	require.NoError(t, err)
+	require.Equal(t, "/var/cache/orbs", cfg.ManagementHistoricCacheDir())
+}
+
 func TestSetGossipPeers(t *testing.T) {
 	cfg, err := newEmptyFileConfig(`{
 	"federation-nodes": [
@@ -7,10 +7,14 @@
 package config
 
 import (
+	"encoding/hex"
 	topologyProviderAdapter "github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -26,6 +30,7 @@ type NodeConfigValue struct {
 }
 
 type config struct {
+	kvMutex                 sync.RWMutex // kv values may be replaced by a config reload while services read them
 	kv                      map[string]NodeConfigValue
 	genesisValidatorNodes   map[string]ValidatorNode
 	gossipPeers             topologyProviderAdapter.TransportPeers
@@ -40,12 +45,27 @@ const (
 	MINIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE = primitives.ProtocolVersion(1) // do not re-define in other places (not even in tests)
 	VIRTUAL_CHAIN_ID                         = "VIRTUAL_CHAIN_ID"
 	NETWORK_TYPE                             = "NETWORK_TYPE"
+	NODE_KEYSTORE_PATH                       = "NODE_KEYSTORE_PATH"
+	NODE_KEYSTORE_PASSPHRASE_FILE            = "NODE_KEYSTORE_PASSPHRASE_FILE"
 
 	MANAGEMENT_FILE_PATH                = "MANAGEMENT_FILE_PATH"
 	MANAGEMENT_MAX_FILE_SIZE            = "MANAGEMENT_MAX_FILE_SIZE"
 	MANAGEMENT_POLLING_INTERVAL         = "MANAGEMENT_POLLING_INTERVAL"
 	MANAGEMENT_CONSENSUS_GRACE_TIMEOUT  = "MANAGEMENT_CONSENSUS_GRACE_TIMEOUT"
 	MANAGEMENT_NETWORK_LIVENESS_TIMEOUT = "MANAGEMENT_NETWORK_LIVENESS_TIMEOUT"
+	MANAGEMENT_AUTHORITY_ADDRESSES      = "MANAGEMENT_AUTHORITY_ADDRESSES"
+	MANAGEMENT_AUTHORITY_THRESHOLD      = "MANAGEMENT_AUTHORITY_THRESHOLD"
+	MANAGEMENT_MAX_DATA_AGE             = "MANAGEMENT_MAX_DATA_AGE"
+	MANAGEMENT_SOURCES                  = "MANAGEMENT_SOURCES"
+	MANAGEMENT_SOURCES_QUORUM           = "MANAGEMENT_SOURCES_QUORUM"
+	MANAGEMENT_HISTORIC_CACHE_DIR       = "MANAGEMENT_HISTORIC_CACHE_DIR"
+	MANAGEMENT_HISTORIC_CACHE_BUNDLE    = "MANAGEMENT_HISTORIC_CACHE_BUNDLE"
+
+	MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT     = "MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT"
+	MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT     = "MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT"
+	MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT = "MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT"
+	MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT      = "MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT"
+	MANAGEMENT_ETHEREUM_FROM_BLOCK             = "MANAGEMENT_ETHEREUM_FROM_BLOCK"
 
 	BENCHMARK_CONSENSUS_RETRY_INTERVAL             = "BENCHMARK_CONSENSUS_RETRY_INTERVAL"
 	BENCHMARK_CONSENSUS_REQUIRED_QUORUM_PERCENTAGE = "BENCHMARK_CONSENSUS_REQUIRED_QUORUM_PERCENTAGE"
@@ -55,6 +75,7 @@ const (
 	LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE = "LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE"
 	INTER_NODE_SYNC_AUDIT_BLOCKS_YOUNGER_THAN   = "INTER_NODE_SYNC_AUDIT_BLOCKS_YOUNGER_THAN"
 	LEAN_HELIX_SHOW_DEBUG                       = "LEAN_HELIX_SHOW_DEBUG"
+	LEAN_HELIX_TIMELINE_MAX_HEIGHTS             = "LEAN_HELIX_TIMELINE_MAX_HEIGHTS"
 
 	BLOCK_SYNC_NUM_BLOCKS_IN_BATCH            = "BLOCK_SYNC_NUM_BLOCKS_IN_BATCH"
 	BLOCK_SYNC_NO_COMMIT_INTERVAL             = "BLOCK_SYNC_NO_COMMIT_INTERVAL"
@@ -62,6 +83,12 @@ const (
 	BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT         = "BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT"
 	BLOCK_SYNC_DESCENDING_ENABLED             = "BLOCK_SYNC_DESCENDING_ENABLED"
 	BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE = "BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE"
+	BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT    = "BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT"
+	BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH      = "BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH"
+
+	BLOCK_SYNC_SERVER_MAX_CONCURRENT_REQUESTORS = "BLOCK_SYNC_SERVER_MAX_CONCURRENT_REQUESTORS"
+	BLOCK_SYNC_SERVER_MAX_BYTES_PER_SECOND      = "BLOCK_SYNC_SERVER_MAX_BYTES_PER_SECOND"
+	BLOCK_SYNC_SERVER_QUEUE_TIMEOUT             = "BLOCK_SYNC_SERVER_QUEUE_TIMEOUT"
 
 	BLOCK_STORAGE_TRANSACTION_RECEIPT_QUERY_TIMESTAMP_GRACE = "BLOCK_STORAGE_TRANSACTION_RECEIPT_QUERY_TIMESTAMP_GRACE"
 
@@ -92,9 +119,21 @@ const (
 	PUBLIC_API_SEND_TRANSACTION_TIMEOUT = "PUBLIC_API_SEND_TRANSACTION_TIMEOUT"
 	PUBLIC_API_NODE_SYNC_WARNING_TIME   = "PUBLIC_API_NODE_SYNC_WARNING_TIME"
 
-	PROCESSOR_ARTIFACT_PATH               = "PROCESSOR_ARTIFACT_PATH"
-	PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS = "PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS"
-	PROCESSOR_PERFORM_WARM_UP_COMPILATION = "PROCESSOR_PERFORM_WARM_UP_COMPILATION"
+	PROCESSOR_ARTIFACT_PATH                  = "PROCESSOR_ARTIFACT_PATH"
+	PROCESSOR_ARTIFACT_STORE_PATH            = "PROCESSOR_ARTIFACT_STORE_PATH"
+	PROCESSOR_ARTIFACT_STORE_SECRET          = "PROCESSOR_ARTIFACT_STORE_SECRET"
+	PROCESSOR_PRE_WARM_DEPLOYED_CONTRACTS    = "PROCESSOR_PRE_WARM_DEPLOYED_CONTRACTS"
+	PROCESSOR_PRE_WARM_TIMEOUT               = "PROCESSOR_PRE_WARM_TIMEOUT"
+	PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS    = "PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS"
+	PROCESSOR_PERFORM_WARM_UP_COMPILATION    = "PROCESSOR_PERFORM_WARM_UP_COMPILATION"
+	PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL = "PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL"
+	PROCESSOR_WASM_MAX_MEMORY_PAGES          = "PROCESSOR_WASM_MAX_MEMORY_PAGES"
+
+	PROCESSOR_NATIVE_SANDBOX_ENABLED               = "PROCESSOR_NATIVE_SANDBOX_ENABLED"
+	PROCESSOR_NATIVE_SANDBOX_WORKERS               = "PROCESSOR_NATIVE_SANDBOX_WORKERS"
+	PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES  = "PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES"
+	PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL = "PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL"
+	PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT          = "PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT"
 
 	ETHEREUM_ENDPOINT                  = "ETHEREUM_ENDPOINT"
 	ETHEREUM_FINALITY_TIME_COMPONENT   = "ETHEREUM_FINALITY_TIME_COMPONENT"
@@ -110,11 +149,25 @@ const (
 
 	PROFILING = "PROFILING"
 
-	HTTP_ADDRESS = "HTTP_ADDRESS"
+	HTTP_ADDRESS                         = "HTTP_ADDRESS"
+	HTTP_SIMULATE_TRANSACTION_ENABLED    = "HTTP_SIMULATE_TRANSACTION_ENABLED"
+	HTTP_SIMULATE_TRANSACTION_RATE_LIMIT = "HTTP_SIMULATE_TRANSACTION_RATE_LIMIT"
+	HTTP_CONFIG_RELOAD_ENABLED           = "HTTP_CONFIG_RELOAD_ENABLED"
 
 	NTP_ENDPOINT = "NTP_ENDPOINT"
 
-	SIGNER_ENDPOINT = "SIGNER_ENDPOINT"
+	TRACING_OTLP_ENDPOINT   = "TRACING_OTLP_ENDPOINT"
+	TRACING_EXPORT_INTERVAL = "TRACING_EXPORT_INTERVAL"
+
+	SIGNER_ENDPOINT                  = "SIGNER_ENDPOINT"
+	SIGNER_REQUEST_TIMEOUT           = "SIGNER_REQUEST_TIMEOUT"
+	SIGNER_RETRY_ATTEMPTS            = "SIGNER_RETRY_ATTEMPTS"
+	SIGNER_RETRY_BACKOFF             = "SIGNER_RETRY_BACKOFF"
+	SIGNER_HEALTH_CHECK_INTERVAL     = "SIGNER_HEALTH_CHECK_INTERVAL"
+	SIGNER_CIRCUIT_BREAKER_THRESHOLD = "SIGNER_CIRCUIT_BREAKER_THRESHOLD"
+	SIGNER_CIRCUIT_BREAKER_COOLDOWN  = "SIGNER_CIRCUIT_BREAKER_COOLDOWN"
+	SIGNER_SWITCH_ENDPOINT           = "SIGNER_SWITCH_ENDPOINT"
+	SIGNER_SWITCH_REFERENCE_TIME     = "SIGNER_SWITCH_REFERENCE_TIME"
 
 	EXPERIMENTAL_EXTERNAL_PROCESSOR_PLUGIN_PATH = "EXPERIMENTAL_EXTERNAL_PROCESSOR_PLUGIN_PATH"
 )
@@ -125,29 +178,39 @@ func NewHardCodedValidatorNode(nodeAddress primitives.NodeAddress) ValidatorNode
 	}
 }
 
+func (c *config) value(key string) NodeConfigValue {
+	c.kvMutex.RLock()
+	defer c.kvMutex.RUnlock()
+	return c.kv[key]
+}
+
+func (c *config) values() map[string]NodeConfigValue {
+	c.kvMutex.RLock()
+	defer c.kvMutex.RUnlock()
+	return cloneMap(c.kv)
+}
+
 func (c *config) Set(key string, value NodeConfigValue) mutableNodeConfig {
+	c.kvMutex.Lock()
+	defer c.kvMutex.Unlock()
 	c.kv[key] = value
 	return c
 }
 
 func (c *config) SetDuration(key string, value time.Duration) mutableNodeConfig {
-	c.kv[key] = NodeConfigValue{DurationValue: value}
-	return c
+	return c.Set(key, NodeConfigValue{DurationValue: value})
 }
 
 func (c *config) SetUint32(key string, value uint32) mutableNodeConfig {
-	c.kv[key] = NodeConfigValue{Uint32Value: value}
-	return c
+	return c.Set(key, NodeConfigValue{Uint32Value: value})
 }
 
 func (c *config) SetString(key string, value string) mutableNodeConfig {
-	c.kv[key] = NodeConfigValue{StringValue: value}
-	return c
+	return c.Set(key, NodeConfigValue{StringValue: value})
 }
 
 func (c *config) SetBool(key string, value bool) mutableNodeConfig {
-	c.kv[key] = NodeConfigValue{BoolValue: value}
-	return c
+	return c.Set(key, NodeConfigValue{BoolValue: value})
 }
 
 func (c *config) SetNodeAddress(key primitives.NodeAddress) mutableNodeConfig {
@@ -192,32 +255,123 @@ func (c *config) NodePrivateKey() primitives.EcdsaSecp256K1PrivateKey {
 	return c.nodePrivateKey
 }
 
+func (c *config) NodeKeystorePath() string {
+	return c.value(NODE_KEYSTORE_PATH).StringValue
+}
+
+func (c *config) NodeKeystorePassphraseFile() string {
+	return c.value(NODE_KEYSTORE_PASSPHRASE_FILE).StringValue
+}
+
 func (c *config) VirtualChainId() primitives.VirtualChainId {
-	return primitives.VirtualChainId(c.kv[VIRTUAL_CHAIN_ID].Uint32Value)
+	return primitives.VirtualChainId(c.value(VIRTUAL_CHAIN_ID).Uint32Value)
 }
 
 func (c *config) NetworkType() protocol.SignerNetworkType {
-	return protocol.SignerNetworkType(c.kv[NETWORK_TYPE].Uint32Value)
+	return protocol.SignerNetworkType(c.value(NETWORK_TYPE).Uint32Value)
 }
 
 func (c *config) ManagementFilePath() string {
-	return c.kv[MANAGEMENT_FILE_PATH].StringValue
+	return c.value(MANAGEMENT_FILE_PATH).StringValue
 }
 
 func (c *config) ManagementMaxFileSize() uint32 {
-	return c.kv[MANAGEMENT_MAX_FILE_SIZE].Uint32Value
+	return c.value(MANAGEMENT_MAX_FILE_SIZE).Uint32Value
 }
 
 func (c *config) ManagementPollingInterval() time.Duration {
-	return c.kv[MANAGEMENT_POLLING_INTERVAL].DurationValue
+	return c.value(MANAGEMENT_POLLING_INTERVAL).DurationValue
 }
 
 func (c *config) ManagementConsensusGraceTimeout() time.Duration {
-	return c.kv[MANAGEMENT_CONSENSUS_GRACE_TIMEOUT].DurationValue
+	return c.value(MANAGEMENT_CONSENSUS_GRACE_TIMEOUT).DurationValue
 }
 
 func (c *config) ManagementNetworkLivenessTimeout() time.Duration {
-	return c.kv[MANAGEMENT_NETWORK_LIVENESS_TIMEOUT].DurationValue
+	return c.value(MANAGEMENT_NETWORK_LIVENESS_TIMEOUT).DurationValue
+}
+
+// addresses are kept as a comma separated hex list, an entry which is not valid hex is returned empty so validation can reject it
+func (c *config) ManagementAuthorityAddresses() []primitives.NodeAddress {
+	var addresses []primitives.NodeAddress
+	for _, hexAddress := range strings.Split(c.value(MANAGEMENT_AUTHORITY_ADDRESSES).StringValue, ",") {
+		hexAddress = strings.TrimSpace(hexAddress)
+		if hexAddress == "" {
+			continue
+		}
+		address, _ := hex.DecodeString(hexAddress)
+		addresses = append(addresses, address)
+	}
+	return addresses
+}
+
+func (c *config) ManagementAuthorityThreshold() uint32 {
+	return c.value(MANAGEMENT_AUTHORITY_THRESHOLD).Uint32Value
+}
+
+func (c *config) ManagementMaxDataAge() time.Duration {
+	return c.value(MANAGEMENT_MAX_DATA_AGE).DurationValue
+}
+
+// the management file path followed by the additional management sources, each is a file path or a url
+func (c *config) ManagementSources() []string {
+	var sources []string
+	if path := c.ManagementFilePath(); path != "" {
+		sources = append(sources, path)
+	}
+	for _, source := range strings.Split(c.value(MANAGEMENT_SOURCES).StringValue, ",") {
+		source = strings.TrimSpace(source)
+		if source == "" || (len(sources) > 0 && source == sources[0]) {
+			continue
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+func (c *config) ManagementSourcesQuorum() uint32 {
+	return c.value(MANAGEMENT_SOURCES_QUORUM).Uint32Value
+}
+
+// historic management pages are kept next to the blocks unless a directory is configured
+func (c *config) ManagementHistoricCacheDir() string {
+	if dir := c.value(MANAGEMENT_HISTORIC_CACHE_DIR).StringValue; dir != "" {
+		return dir
+	}
+	return filepath.Join(c.BlockStorageFileSystemDataDir(), "management-history")
+}
+
+func (c *config) ManagementHistoricCacheBundle() string {
+	return c.value(MANAGEMENT_HISTORIC_CACHE_BUNDLE).StringValue
+}
+
+func (c *config) ManagementEthereumGuardiansContract() []byte {
+	address, _ := hex.DecodeString(strings.TrimPrefix(c.value(MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT).StringValue, "0x"))
+	return address
+}
+
+func (c *config) ManagementEthereumCommitteeContract() []byte {
+	address, _ := hex.DecodeString(strings.TrimPrefix(c.value(MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT).StringValue, "0x"))
+	return address
+}
+
+func (c *config) ManagementEthereumSubscriptionsContract() []byte {
+	address, _ := hex.DecodeString(strings.TrimPrefix(c.value(MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT).StringValue, "0x"))
+	return address
+}
+
+func (c *config) ManagementEthereumProtocolContract() []byte {
+	address, _ := hex.DecodeString(strings.TrimPrefix(c.value(MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT).StringValue, "0x"))
+	return address
+}
+
+func (c *config) ManagementEthereumFromBlock() uint32 {
+	return c.value(MANAGEMENT_ETHEREUM_FROM_BLOCK).Uint32Value
+}
+
+// management is read from ethereum once the management contracts are configured, this takes precedence over a management file
+func (c *config) IsManagementFromEthereum() bool {
+	return c.value(MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT).StringValue != ""
 }
 
 func (c *config) GenesisValidatorNodes() map[string]ValidatorNode {
@@ -233,127 +387,200 @@ func (c *config) ActiveConsensusAlgo() consensus.ConsensusAlgoType {
 }
 
 func (c *config) BenchmarkConsensusRetryInterval() time.Duration {
-	return c.kv[BENCHMARK_CONSENSUS_RETRY_INTERVAL].DurationValue
+	return c.value(BENCHMARK_CONSENSUS_RETRY_INTERVAL).DurationValue
 }
 
 func (c *config) LeanHelixConsensusRoundTimeoutInterval() time.Duration {
-	return c.kv[LEAN_HELIX_CONSENSUS_ROUND_TIMEOUT_INTERVAL].DurationValue
+	return c.value(LEAN_HELIX_CONSENSUS_ROUND_TIMEOUT_INTERVAL).DurationValue
 }
 
 func (c *config) LeanHelixShowDebug() bool {
-	return c.kv[LEAN_HELIX_SHOW_DEBUG].BoolValue
+	return c.value(LEAN_HELIX_SHOW_DEBUG).BoolValue
+}
+
+func (c *config) LeanHelixTimelineMaxHeights() uint32 {
+	return c.value(LEAN_HELIX_TIMELINE_MAX_HEIGHTS).Uint32Value
 }
 
 func (c *config) BlockSyncNumBlocksInBatch() uint32 {
-	return c.kv[BLOCK_SYNC_NUM_BLOCKS_IN_BATCH].Uint32Value
+	return c.value(BLOCK_SYNC_NUM_BLOCKS_IN_BATCH).Uint32Value
 }
 
 func (c *config) BlockSyncNoCommitInterval() time.Duration {
-	return c.kv[BLOCK_SYNC_NO_COMMIT_INTERVAL].DurationValue
+	return c.value(BLOCK_SYNC_NO_COMMIT_INTERVAL).DurationValue
 }
 
 func (c *config) BlockSyncCollectResponseTimeout() time.Duration {
-	return c.kv[BLOCK_SYNC_COLLECT_RESPONSE_TIMEOUT].DurationValue
+	return c.value(BLOCK_SYNC_COLLECT_RESPONSE_TIMEOUT).DurationValue
 }
 
 func (c *config) BlockStorageTransactionReceiptQueryTimestampGrace() time.Duration {
-	return c.kv[BLOCK_STORAGE_TRANSACTION_RECEIPT_QUERY_TIMESTAMP_GRACE].DurationValue
+	return c.value(BLOCK_STORAGE_TRANSACTION_RECEIPT_QUERY_TIMESTAMP_GRACE).DurationValue
 }
 
 func (c *config) ConsensusContextMaximumTransactionsInBlock() uint32 {
-	return c.kv[CONSENSUS_CONTEXT_MAXIMUM_TRANSACTIONS_IN_BLOCK].Uint32Value
+	return c.value(CONSENSUS_CONTEXT_MAXIMUM_TRANSACTIONS_IN_BLOCK).Uint32Value
 }
 
 func (c *config) ConsensusContextSystemTimestampAllowedJitter() time.Duration {
-	return c.kv[CONSENSUS_CONTEXT_SYSTEM_TIMESTAMP_ALLOWED_JITTER].DurationValue
+	return c.value(CONSENSUS_CONTEXT_SYSTEM_TIMESTAMP_ALLOWED_JITTER).DurationValue
 }
 
 func (c *config) ConsensusContextTriggersEnabled() bool {
-	return c.kv[CONSENSUS_CONTEXT_TRIGGERS_ENABLED].BoolValue
+	return c.value(CONSENSUS_CONTEXT_TRIGGERS_ENABLED).BoolValue
 }
 
 func (c *config) StateStorageHistorySnapshotNum() uint32 {
-	return c.kv[STATE_STORAGE_HISTORY_SNAPSHOT_NUM].Uint32Value
+	return c.value(STATE_STORAGE_HISTORY_SNAPSHOT_NUM).Uint32Value
 }
 
 func (c *config) BlockTrackerGraceDistance() uint32 {
-	return c.kv[BLOCK_TRACKER_GRACE_DISTANCE].Uint32Value
+	return c.value(BLOCK_TRACKER_GRACE_DISTANCE).Uint32Value
 }
 
 func (c *config) BlockTrackerGraceTimeout() time.Duration {
-	return c.kv[BLOCK_TRACKER_GRACE_TIMEOUT].DurationValue
+	return c.value(BLOCK_TRACKER_GRACE_TIMEOUT).DurationValue
 }
 
 func (c *config) TransactionPoolPendingPoolSizeInBytes() uint32 {
-	return c.kv[TRANSACTION_POOL_PENDING_POOL_SIZE_IN_BYTES].Uint32Value
+	return c.value(TRANSACTION_POOL_PENDING_POOL_SIZE_IN_BYTES).Uint32Value
 }
 
 func (c *config) TransactionExpirationWindow() time.Duration {
-	return c.kv[TRANSACTION_EXPIRATION_WINDOW].DurationValue
+	return c.value(TRANSACTION_EXPIRATION_WINDOW).DurationValue
 }
 
 func (c *config) TransactionPoolFutureTimestampGraceTimeout() time.Duration {
-	return c.kv[TRANSACTION_POOL_FUTURE_TIMESTAMP_GRACE_TIMEOUT].DurationValue
+	return c.value(TRANSACTION_POOL_FUTURE_TIMESTAMP_GRACE_TIMEOUT).DurationValue
 }
 
 func (c *config) TransactionPoolPendingPoolClearExpiredInterval() time.Duration {
-	return c.kv[TRANSACTION_POOL_PENDING_POOL_CLEAR_EXPIRED_INTERVAL].DurationValue
+	return c.value(TRANSACTION_POOL_PENDING_POOL_CLEAR_EXPIRED_INTERVAL).DurationValue
 }
 
 func (c *config) TransactionPoolCommittedPoolClearExpiredInterval() time.Duration {
-	return c.kv[TRANSACTION_POOL_COMMITTED_POOL_CLEAR_EXPIRED_INTERVAL].DurationValue
+	return c.value(TRANSACTION_POOL_COMMITTED_POOL_CLEAR_EXPIRED_INTERVAL).DurationValue
 }
 
 func (c *config) TransactionPoolPropagationBatchSize() uint16 {
-	return uint16(c.kv[TRANSACTION_POOL_PROPAGATION_BATCH_SIZE].Uint32Value)
+	return uint16(c.value(TRANSACTION_POOL_PROPAGATION_BATCH_SIZE).Uint32Value)
 }
 
 func (c *config) TransactionPoolPropagationBatchingTimeout() time.Duration {
-	return c.kv[TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT].DurationValue
+	return c.value(TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT).DurationValue
 }
 
 func (c *config) TransactionPoolTimeBetweenEmptyBlocks() time.Duration {
-	return c.kv[TRANSACTION_POOL_TIME_BETWEEN_EMPTY_BLOCKS].DurationValue
+	return c.value(TRANSACTION_POOL_TIME_BETWEEN_EMPTY_BLOCKS).DurationValue
 }
 
 func (c *config) TransactionPoolNodeSyncRejectTime() time.Duration {
-	return c.kv[TRANSACTION_POOL_NODE_SYNC_REJECT_TIME].DurationValue
+	return c.value(TRANSACTION_POOL_NODE_SYNC_REJECT_TIME).DurationValue
 }
 
 func (c *config) PublicApiSendTransactionTimeout() time.Duration {
-	return c.kv[PUBLIC_API_SEND_TRANSACTION_TIMEOUT].DurationValue
+	return c.value(PUBLIC_API_SEND_TRANSACTION_TIMEOUT).DurationValue
 }
 
 func (c *config) PublicApiNodeSyncWarningTime() time.Duration {
-	return c.kv[PUBLIC_API_NODE_SYNC_WARNING_TIME].DurationValue
+	return c.value(PUBLIC_API_NODE_SYNC_WARNING_TIME).DurationValue
 }
 
 func (c *config) BlockSyncCollectChunksTimeout() time.Duration {
-	return c.kv[BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT].DurationValue
+	return c.value(BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT).DurationValue
 }
 
 func (c *config) BlockSyncDescendingEnabled() bool {
-	return c.kv[BLOCK_SYNC_DESCENDING_ENABLED].BoolValue
+	return c.value(BLOCK_SYNC_DESCENDING_ENABLED).BoolValue
 }
 
 func (c *config) BlockSyncReferenceMaxAllowedDistance() time.Duration {
-	return c.kv[BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE].DurationValue
+	return c.value(BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE).DurationValue
+}
+
+func (c *config) BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight {
+	return primitives.BlockHeight(c.value(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT).Uint32Value)
+}
+
+// a value which is not hex is rejected when the config is loaded, if set otherwise no hash is returned
+func (c *config) BlockSyncFastSyncCheckpointHash() primitives.Sha256 {
+	hash, err := hex.DecodeString(c.value(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH).StringValue)
+	if err != nil {
+		return nil
+	}
+	return hash
+}
+
+func (c *config) BlockSyncServerMaxConcurrentRequestors() uint32 {
+	return c.value(BLOCK_SYNC_SERVER_MAX_CONCURRENT_REQUESTORS).Uint32Value
+}
+
+func (c *config) BlockSyncServerMaxBytesPerSecond() uint32 {
+	return c.value(BLOCK_SYNC_SERVER_MAX_BYTES_PER_SECOND).Uint32Value
+}
+
+func (c *config) BlockSyncServerQueueTimeout() time.Duration {
+	return c.value(BLOCK_SYNC_SERVER_QUEUE_TIMEOUT).DurationValue
 }
 
 func (c *config) ProcessorArtifactPath() string {
-	return c.kv[PROCESSOR_ARTIFACT_PATH].StringValue
+	return c.value(PROCESSOR_ARTIFACT_PATH).StringValue
+}
+
+func (c *config) ProcessorArtifactStorePath() string {
+	return c.value(PROCESSOR_ARTIFACT_STORE_PATH).StringValue
+}
+
+func (c *config) ProcessorArtifactStoreSecret() string {
+	return c.value(PROCESSOR_ARTIFACT_STORE_SECRET).StringValue
+}
+
+func (c *config) ProcessorPreWarmDeployedContracts() bool {
+	return c.value(PROCESSOR_PRE_WARM_DEPLOYED_CONTRACTS).BoolValue
+}
+
+func (c *config) ProcessorPreWarmTimeout() time.Duration {
+	return c.value(PROCESSOR_PRE_WARM_TIMEOUT).DurationValue
 }
 
 func (c *config) ProcessorSanitizeDeployedContracts() bool {
-	return c.kv[PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS].BoolValue
+	return c.value(PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS).BoolValue
 }
 
 func (c *config) ProcessorPerformWarmUpCompilation() bool {
-	return c.kv[PROCESSOR_PERFORM_WARM_UP_COMPILATION].BoolValue
+	return c.value(PROCESSOR_PERFORM_WARM_UP_COMPILATION).BoolValue
+}
+
+func (c *config) ProcessorWasmMaxInstructionsPerCall() uint32 {
+	return c.value(PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL).Uint32Value
+}
+
+func (c *config) ProcessorWasmMaxMemoryPages() uint32 {
+	return c.value(PROCESSOR_WASM_MAX_MEMORY_PAGES).Uint32Value
+}
+
+func (c *config) ProcessorNativeSandboxEnabled() bool {
+	return c.value(PROCESSOR_NATIVE_SANDBOX_ENABLED).BoolValue
+}
+
+func (c *config) ProcessorNativeSandboxWorkers() uint32 {
+	return c.value(PROCESSOR_NATIVE_SANDBOX_WORKERS).Uint32Value
+}
+
+func (c *config) ProcessorNativeSandboxMaxMemoryMegabytes() uint32 {
+	return c.value(PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES).Uint32Value
+}
+
+func (c *config) ProcessorNativeSandboxMaxCpuTimePerCall() time.Duration {
+	return c.value(PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL).DurationValue
+}
+
+func (c *config) ProcessorNativeSandboxCallTimeout() time.Duration {
+	return c.value(PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT).DurationValue
 }
 
 func (c *config) GossipListenPort() uint16 {
-	return uint16(c.kv[GOSSIP_LISTEN_PORT].Uint32Value)
+	return uint16(c.value(GOSSIP_LISTEN_PORT).Uint32Value)
 }
 
 func (c *config) GossipPeers() topologyProviderAdapter.TransportPeers {
@@ -361,85 +588,137 @@ func (c *config) GossipPeers() topologyProviderAdapter.TransportPeers {
 }
 
 func (c *config) GossipConnectionKeepAliveInterval() time.Duration {
-	return c.kv[GOSSIP_CONNECTION_KEEP_ALIVE_INTERVAL].DurationValue
+	return c.value(GOSSIP_CONNECTION_KEEP_ALIVE_INTERVAL).DurationValue
 }
 
 func (c *config) GossipNetworkTimeout() time.Duration {
-	return c.kv[GOSSIP_NETWORK_TIMEOUT].DurationValue
+	return c.value(GOSSIP_NETWORK_TIMEOUT).DurationValue
 }
 
 func (c *config) GossipReconnectInterval() time.Duration {
-	return c.kv[GOSSIP_RECONNECT_INTERVAL].DurationValue
+	return c.value(GOSSIP_RECONNECT_INTERVAL).DurationValue
 }
 
 func (c *config) BenchmarkConsensusRequiredQuorumPercentage() uint32 {
-	return c.kv[BENCHMARK_CONSENSUS_REQUIRED_QUORUM_PERCENTAGE].Uint32Value
+	return c.value(BENCHMARK_CONSENSUS_REQUIRED_QUORUM_PERCENTAGE).Uint32Value
 }
 
 func (c *config) LeanHelixConsensusMinimumCommitteeSize() uint32 {
-	return c.kv[LEAN_HELIX_CONSENSUS_MINIMUM_COMMITTEE_SIZE].Uint32Value
+	return c.value(LEAN_HELIX_CONSENSUS_MINIMUM_COMMITTEE_SIZE).Uint32Value
 }
 
 func (c *config) LeanHelixConsensusMaximumCommitteeSize() uint32 {
-	return c.kv[LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE].Uint32Value
+	return c.value(LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE).Uint32Value
 }
 
 func (c *config) InterNodeSyncAuditBlocksYoungerThan() time.Duration {
-	return c.kv[INTER_NODE_SYNC_AUDIT_BLOCKS_YOUNGER_THAN].DurationValue
+	return c.value(INTER_NODE_SYNC_AUDIT_BLOCKS_YOUNGER_THAN).DurationValue
 }
 
 func (c *config) EthereumEndpoint() string {
-	return c.kv[ETHEREUM_ENDPOINT].StringValue
+	return c.value(ETHEREUM_ENDPOINT).StringValue
 }
 
 func (c *config) EthereumFinalityTimeComponent() time.Duration {
-	return c.kv[ETHEREUM_FINALITY_TIME_COMPONENT].DurationValue
+	return c.value(ETHEREUM_FINALITY_TIME_COMPONENT).DurationValue
 }
 
 func (c *config) EthereumFinalityBlocksComponent() uint32 {
-	return c.kv[ETHEREUM_FINALITY_BLOCKS_COMPONENT].Uint32Value
+	return c.value(ETHEREUM_FINALITY_BLOCKS_COMPONENT).Uint32Value
 }
 
 func (c *config) LoggerHttpEndpoint() string {
-	return c.kv[LOGGER_HTTP_ENDPOINT].StringValue
+	return c.value(LOGGER_HTTP_ENDPOINT).StringValue
 }
 
 func (c *config) LoggerBulkSize() uint32 {
-	return c.kv[LOGGER_BULK_SIZE].Uint32Value
+	return c.value(LOGGER_BULK_SIZE).Uint32Value
 }
 
 func (c *config) LoggerFileTruncationInterval() time.Duration {
-	return c.kv[LOGGER_FILE_TRUNCATION_INTERVAL].DurationValue
+	return c.value(LOGGER_FILE_TRUNCATION_INTERVAL).DurationValue
 }
 
 func (c *config) LoggerFullLog() bool {
-	return c.kv[LOGGER_FULL_LOG].BoolValue
+	return c.value(LOGGER_FULL_LOG).BoolValue
 }
 
 func (c *config) BlockStorageFileSystemDataDir() string {
-	return c.kv[BLOCK_STORAGE_FILE_SYSTEM_DATA_DIR].StringValue
+	return c.value(BLOCK_STORAGE_FILE_SYSTEM_DATA_DIR).StringValue
 }
 
 func (c *config) BlockStorageFileSystemMaxBlockSizeInBytes() uint32 {
-	return c.kv[BLOCK_STORAGE_FILE_SYSTEM_MAX_BLOCK_SIZE_IN_BYTES].Uint32Value
+	return c.value(BLOCK_STORAGE_FILE_SYSTEM_MAX_BLOCK_SIZE_IN_BYTES).Uint32Value
 }
 
 func (c *config) Profiling() bool {
-	return c.kv[PROFILING].BoolValue
+	return c.value(PROFILING).BoolValue
 }
 
 func (c *config) HttpAddress() string {
-	return c.kv[HTTP_ADDRESS].StringValue
+	return c.value(HTTP_ADDRESS).StringValue
+}
+
+func (c *config) HttpSimulateTransactionEnabled() bool {
+	return c.value(HTTP_SIMULATE_TRANSACTION_ENABLED).BoolValue
+}
+
+func (c *config) HttpSimulateTransactionRateLimit() uint32 {
+	return c.value(HTTP_SIMULATE_TRANSACTION_RATE_LIMIT).Uint32Value
+}
+
+func (c *config) HttpConfigReloadEnabled() bool {
+	return c.value(HTTP_CONFIG_RELOAD_ENABLED).BoolValue
 }
 
 func (c *config) NTPEndpoint() string {
-	return c.kv[NTP_ENDPOINT].StringValue
+	return c.value(NTP_ENDPOINT).StringValue
+}
+
+func (c *config) TracingOtlpEndpoint() string {
+	return c.value(TRACING_OTLP_ENDPOINT).StringValue
+}
+
+func (c *config) TracingExportInterval() time.Duration {
+	return c.value(TRACING_EXPORT_INTERVAL).DurationValue
 }
 
 func (c *config) SignerEndpoint() string {
-	return c.kv[SIGNER_ENDPOINT].StringValue
+	return c.value(SIGNER_ENDPOINT).StringValue
+}
+
+func (c *config) SignerRequestTimeout() time.Duration {
+	return c.value(SIGNER_REQUEST_TIMEOUT).DurationValue
+}
+
+func (c *config) SignerRetryAttempts() uint32 {
+	return c.value(SIGNER_RETRY_ATTEMPTS).Uint32Value
+}
+
+func (c *config) SignerRetryBackoff() time.Duration {
+	return c.value(SIGNER_RETRY_BACKOFF).DurationValue
+}
+
+func (c *config) SignerHealthCheckInterval() time.Duration {
+	return c.value(SIGNER_HEALTH_CHECK_INTERVAL).DurationValue
+}
+
+func (c *config) SignerCircuitBreakerThreshold() uint32 {
+	return c.value(SIGNER_CIRCUIT_BREAKER_THRESHOLD).Uint32Value
+}
+
+func (c *config) SignerCircuitBreakerCooldown() time.Duration {
+	return c.value(SIGNER_CIRCUIT_BREAKER_COOLDOWN).DurationValue
+}
+
+func (c *config) SignerSwitchEndpoint() string {
+	return c.value(SIGNER_SWITCH_ENDPOINT).StringValue
+}
+
+func (c *config) SignerSwitchReferenceTime() uint32 {
+	return c.value(SIGNER_SWITCH_REFERENCE_TIME).Uint32Value
 }
 
 func (c *config) ExperimentalExternalProcessorPluginPath() string {
-	return c.kv[EXPERIMENTAL_EXTERNAL_PROCESSOR_PLUGIN_PATH].StringValue
+	return c.value(EXPERIMENTAL_EXTERNAL_PROCESSOR_PLUGIN_PATH).StringValue
 }
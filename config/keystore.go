@@ -0,0 +1,148 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/orbs-network/crypto-lib-go/crypto/ethereum/digest"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"strings"
+)
+
+const (
+	KEYSTORE_VERSION = 1
+
+	// the passphrase is read from this environment variable when no passphrase file is configured, it is not a
+	// config key so it can never end up in a config file
+	KEYSTORE_PASSPHRASE_ENV_VAR = "ORBS_KEYSTORE_PASSPHRASE"
+)
+
+// an encrypted node key, the private key is encrypted with aes-128-ctr under a key derived from the passphrase
+// with scrypt (the crypto section is the one of ethereum v3 keystores)
+type nodeKeystore struct {
+	Version int                 `json:"version"`
+	Address string              `json:"address"`
+	Crypto  keystore.CryptoJSON `json:"crypto"`
+}
+
+func GenerateNodeKey() (primitives.EcdsaSecp256K1PrivateKey, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not generate node key")
+	}
+	return crypto.FromECDSA(key), nil
+}
+
+func NodeAddressOfPrivateKey(privateKey primitives.EcdsaSecp256K1PrivateKey) (primitives.NodeAddress, error) {
+	key, err := crypto.ToECDSA(privateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid node private key")
+	}
+	return digest.CalcNodeAddressFromPublicKey(crypto.FromECDSAPub(&key.PublicKey)[1:]), nil
+}
+
+// EncryptNodeKey uses the standard scrypt parameters of ethereum keystores, unlocking takes about a second and 256MB
+func EncryptNodeKey(privateKey primitives.EcdsaSecp256K1PrivateKey, passphrase string) ([]byte, error) {
+	return encryptNodeKey(privateKey, passphrase, keystore.StandardScryptN, keystore.StandardScryptP)
+}
+
+func encryptNodeKey(privateKey primitives.EcdsaSecp256K1PrivateKey, passphrase string, scryptN int, scryptP int) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("keystore passphrase must not be empty")
+	}
+	address, err := NodeAddressOfPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := keystore.EncryptDataV3(privateKey, []byte(passphrase), scryptN, scryptP)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not encrypt node key")
+	}
+
+	return json.MarshalIndent(&nodeKeystore{
+		Version: KEYSTORE_VERSION,
+		Address: address.String(),
+		Crypto:  encrypted,
+	}, "", "  ")
+}
+
+func DecryptNodeKey(keystoreJson []byte, passphrase string) (primitives.NodeAddress, primitives.EcdsaSecp256K1PrivateKey, error) {
+	var ks nodeKeystore
+	if err := json.Unmarshal(keystoreJson, &ks); err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse keystore")
+	}
+	if ks.Version != KEYSTORE_VERSION {
+		return nil, nil, errors.Errorf("unsupported keystore version %d", ks.Version)
+	}
+
+	privateKey, err := keystore.DecryptDataV3(ks.Crypto, passphrase)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not decrypt keystore")
+	}
+
+	address, err := NodeAddressOfPrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if address.String() != strings.ToLower(ks.Address) {
+		return nil, nil, errors.Errorf("keystore address %s does not match its key %s", ks.Address, address)
+	}
+	return address, privateKey, nil
+}
+
+// ReadKeystorePassphrase reads the passphrase from the file when one is given, otherwise from ORBS_KEYSTORE_PASSPHRASE
+func ReadKeystorePassphrase(passphraseFile string, lookupEnv func(key string) (string, bool)) (string, error) {
+	if passphraseFile != "" {
+		contents, err := ioutil.ReadFile(passphraseFile)
+		if err != nil {
+			return "", errors.Wrap(err, "could not read keystore passphrase file")
+		}
+		return string(bytes.TrimRight(contents, "\r\n")), nil
+	}
+
+	if passphrase, ok := lookupEnv(KEYSTORE_PASSPHRASE_ENV_VAR); ok {
+		return passphrase, nil
+	}
+	return "", errors.Errorf("keystore passphrase is not set, configure a passphrase file or set %s", KEYSTORE_PASSPHRASE_ENV_VAR)
+}
+
+// the node keys are taken from the keystore when one is configured, a node address configured alongside must match it
+func unlockNodeKeystore(cfg mutableNodeConfig, lookupEnv func(key string) (string, bool)) error {
+	keystorePath := cfg.NodeKeystorePath()
+	if keystorePath == "" {
+		return nil
+	}
+	if len(cfg.NodePrivateKey()) != 0 {
+		return errors.New("node-private-key and node-keystore-path are mutually exclusive, remove the plaintext key from the config")
+	}
+
+	keystoreJson, err := ioutil.ReadFile(keystorePath)
+	if err != nil {
+		return errors.Wrap(err, "could not read node keystore")
+	}
+	passphrase, err := ReadKeystorePassphrase(cfg.NodeKeystorePassphraseFile(), lookupEnv)
+	if err != nil {
+		return err
+	}
+	address, privateKey, err := DecryptNodeKey(keystoreJson, passphrase)
+	if err != nil {
+		return errors.Wrapf(err, "could not unlock node keystore %s", keystorePath)
+	}
+
+	if len(cfg.NodeAddress()) != 0 && !bytes.Equal(cfg.NodeAddress(), address) {
+		return errors.Errorf("configured node address %s does not match the keystore address %s", cfg.NodeAddress(), address)
+	}
+	cfg.SetNodeAddress(address)
+	cfg.SetNodePrivateKey(privateKey)
+	return nil
+}
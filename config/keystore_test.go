@@ -0,0 +1,142 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	keystoreTestAddress    = "a328846cd5b4979d68a8c58a9bdfeee657b34de7"
+	keystoreTestPrivateKey = "901a1a0bfbe217593062a054e561e708707cb814a123474c25fd567a0fe088f8"
+)
+
+func withKeystoreFile(t *testing.T, passphrase string, f func(dir string, keystorePath string)) {
+	dir, err := ioutil.TempDir("", "keystore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	privateKey, _ := hex.DecodeString(keystoreTestPrivateKey)
+	keystoreJson, err := encryptNodeKey(privateKey, passphrase, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	keystorePath := filepath.Join(dir, "keystore.json")
+	require.NoError(t, ioutil.WriteFile(keystorePath, keystoreJson, 0600))
+	f(dir, keystorePath)
+}
+
+func noEnv(key string) (string, bool) {
+	return "", false
+}
+
+func passphraseEnv(passphrase string) func(key string) (string, bool) {
+	return func(key string) (string, bool) {
+		if key == KEYSTORE_PASSPHRASE_ENV_VAR {
+			return passphrase, true
+		}
+		return "", false
+	}
+}
+
+func TestKeystore_EncryptsAndDecryptsNodeKey(t *testing.T) {
+	privateKey, _ := hex.DecodeString(keystoreTestPrivateKey)
+	keystoreJson, err := encryptNodeKey(privateKey, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	require.NotContains(t, string(keystoreJson), keystoreTestPrivateKey, "keystore should not hold the plaintext key")
+	require.Contains(t, string(keystoreJson), keystoreTestAddress)
+
+	address, decrypted, err := DecryptNodeKey(keystoreJson, "secret")
+	require.NoError(t, err)
+	require.Equal(t, keystoreTestAddress, address.String())
+	require.EqualValues(t, privateKey, decrypted)
+
+	_, _, err = DecryptNodeKey(keystoreJson, "wrong")
+	require.Error(t, err, "should not decrypt with a wrong passphrase")
+
+	_, err = encryptNodeKey(privateKey, "", keystore.LightScryptN, keystore.LightScryptP)
+	require.Error(t, err, "should not encrypt with an empty passphrase")
+}
+
+func TestKeystore_GeneratedKeyHasMatchingAddress(t *testing.T) {
+	privateKey, err := GenerateNodeKey()
+	require.NoError(t, err)
+
+	keystoreJson, err := encryptNodeKey(privateKey, "secret", keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+
+	address, decrypted, err := DecryptNodeKey(keystoreJson, "secret")
+	require.NoError(t, err)
+	expectedAddress, err := NodeAddressOfPrivateKey(privateKey)
+	require.NoError(t, err)
+	require.Equal(t, expectedAddress, address)
+	require.EqualValues(t, privateKey, decrypted)
+}
+
+func TestKeystore_UnlocksNodeKeysIntoConfig(t *testing.T) {
+	withKeystoreFile(t, "secret", func(dir string, keystorePath string) {
+		cfg := defaultProductionConfig()
+		cfg.SetString(NODE_KEYSTORE_PATH, keystorePath)
+		require.NoError(t, unlockNodeKeystore(cfg, passphraseEnv("secret")))
+		require.Equal(t, keystoreTestAddress, cfg.NodeAddress().String())
+		require.Equal(t, keystoreTestPrivateKey, hex.EncodeToString(cfg.NodePrivateKey()))
+
+		passphraseFile := filepath.Join(dir, "passphrase")
+		require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600))
+		cfg = defaultProductionConfig()
+		cfg.SetString(NODE_KEYSTORE_PATH, keystorePath)
+		cfg.SetString(NODE_KEYSTORE_PASSPHRASE_FILE, passphraseFile)
+		require.NoError(t, unlockNodeKeystore(cfg, passphraseEnv("wrong")), "passphrase file should take precedence over the env var")
+		require.Equal(t, keystoreTestAddress, cfg.NodeAddress().String())
+	})
+}
+
+func TestKeystore_FailsToUnlock(t *testing.T) {
+	withKeystoreFile(t, "secret", func(dir string, keystorePath string) {
+		privateKey, _ := hex.DecodeString(keystoreTestPrivateKey)
+		otherAddress, _ := hex.DecodeString("d27e2e7398e2582f63d0800330010b3e58952ff6")
+
+		for name, test := range map[string]struct {
+			configure     func(cfg mutableNodeConfig)
+			lookupEnv     func(key string) (string, bool)
+			expectedError string
+		}{
+			"without a passphrase": {
+				lookupEnv:     noEnv,
+				expectedError: "keystore passphrase is not set",
+			},
+			"with a wrong passphrase": {
+				lookupEnv:     passphraseEnv("wrong"),
+				expectedError: "could not decrypt keystore",
+			},
+			"with a plaintext key also configured": {
+				configure:     func(cfg mutableNodeConfig) { cfg.SetNodePrivateKey(privateKey) },
+				lookupEnv:     passphraseEnv("secret"),
+				expectedError: "mutually exclusive",
+			},
+			"with a different node address configured": {
+				configure:     func(cfg mutableNodeConfig) { cfg.SetNodeAddress(otherAddress) },
+				lookupEnv:     passphraseEnv("secret"),
+				expectedError: "does not match the keystore address",
+			},
+		} {
+			cfg := defaultProductionConfig()
+			cfg.SetString(NODE_KEYSTORE_PATH, keystorePath)
+			if test.configure != nil {
+				test.configure(cfg)
+			}
+			err := unlockNodeKeystore(cfg, test.lookupEnv)
+			require.Error(t, err, name)
+			require.Contains(t, err.Error(), test.expectedError, name)
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"sort"
+	"sync"
+)
+
+type ReloadResult struct {
+	Applied         []string // keys whose new value is already in effect
+	RequiresRestart []string // keys which changed in the files but no service declared as hot reloadable
+}
+
+type hotReloadDeclaration struct {
+	keys     map[string]bool
+	onReload func(cfg NodeConfig)
+}
+
+// Reloader owns the node config read from the config files, services declare the keys they read on every use (or can
+// apply through a callback) as hot reloadable. Reload re-reads the files and updates only those keys in place, so all
+// holders of Config() see the new values. Changes to other keys, as well as to the node keys, topology and genesis
+// validators which are not kept as key values, take effect on the next restart only
+type Reloader struct {
+	configFiles ArrayFlags
+	httpAddress string
+	config      *config
+
+	mutex        sync.Mutex
+	declarations []*hotReloadDeclaration
+}
+
+// the node keystore is unlocked once here, reloads keep the node keys of the running node
+func NewReloader(configFiles ArrayFlags, httpAddress string) (*Reloader, error) {
+	cfg, err := readNodeConfigFromFiles(configFiles, httpAddress)
+	if err != nil {
+		return nil, err
+	}
+	if err := unlockNodeKeystore(cfg, os.LookupEnv); err != nil {
+		return nil, err
+	}
+
+	return &Reloader{
+		configFiles: configFiles,
+		httpAddress: httpAddress,
+		config:      cfg,
+	}, nil
+}
+
+func (r *Reloader) Config() NodeConfig {
+	return r.config
+}
+
+// onReload is called after a reload which changed one of the declared keys, it may be nil
+func (r *Reloader) DeclareHotReloadable(onReload func(cfg NodeConfig), keys ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	declaration := &hotReloadDeclaration{keys: make(map[string]bool), onReload: onReload}
+	for _, key := range keys {
+		declaration.keys[key] = true
+	}
+	r.declarations = append(r.declarations, declaration)
+}
+
+func (r *Reloader) Reload() (*ReloadResult, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	reloaded, err := readNodeConfigFromFiles(r.configFiles, r.httpAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read config files")
+	}
+	reloaded.SetNodeAddress(r.config.NodeAddress())
+	reloaded.SetNodePrivateKey(r.config.NodePrivateKey())
+	if err := ValidateNodeLogic(reloaded); err != nil {
+		return nil, errors.Wrap(err, "reloaded config is not valid")
+	}
+
+	result := &ReloadResult{}
+	var notified []*hotReloadDeclaration
+	for _, key := range changedKeys(r.config.values(), reloaded.values()) {
+		declarations := r.declarationsOf(key)
+		if len(declarations) == 0 {
+			result.RequiresRestart = append(result.RequiresRestart, key)
+			continue
+		}
+
+		r.config.Set(key, reloaded.value(key))
+		result.Applied = append(result.Applied, key)
+		notified = appendDeclarations(notified, declarations)
+	}
+
+	for _, declaration := range notified {
+		if declaration.onReload != nil {
+			declaration.onReload(r.config)
+		}
+	}
+
+	return result, nil
+}
+
+func (r *Reloader) declarationsOf(key string) (declarations []*hotReloadDeclaration) {
+	for _, declaration := range r.declarations {
+		if declaration.keys[key] {
+			declarations = append(declarations, declaration)
+		}
+	}
+	return
+}
+
+func appendDeclarations(declarations []*hotReloadDeclaration, added []*hotReloadDeclaration) []*hotReloadDeclaration {
+	for _, declaration := range added {
+		found := false
+		for _, existing := range declarations {
+			if existing == declaration {
+				found = true
+				break
+			}
+		}
+		if !found {
+			declarations = append(declarations, declaration)
+		}
+	}
+	return declarations
+}
+
+func changedKeys(current map[string]NodeConfigValue, reloaded map[string]NodeConfigValue) (keys []string) {
+	for key, value := range reloaded {
+		if current[key] != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range current {
+		if _, ok := reloaded[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
@@ -0,0 +1,129 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"encoding/hex"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const reloadableNodeKeys = `
+	"node-address": "a328846cd5b4979d68a8c58a9bdfeee657b34de7",
+	"node-private-key": "901a1a0bfbe217593062a054e561e708707cb814a123474c25fd567a0fe088f8",`
+
+func withConfigFile(t *testing.T, contents string, f func(path string)) {
+	dir, err := ioutil.TempDir("", "reloadable-config")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0644))
+	f(path)
+}
+
+func TestReloader_AppliesDeclaredKeysAndReportsOthers(t *testing.T) {
+	withConfigFile(t, `{`+reloadableNodeKeys+`
+	"transaction-pool-propagation-batching-timeout": "100ms",
+	"gossip-listen-port": 4400
+}`, func(path string) {
+		reloader, err := NewReloader(ArrayFlags{path}, ":8080")
+		require.NoError(t, err)
+		cfg := reloader.Config()
+
+		var reloadedWith NodeConfig
+		reloader.DeclareHotReloadable(func(c NodeConfig) { reloadedWith = c }, TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT)
+
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{`+reloadableNodeKeys+`
+	"transaction-pool-propagation-batching-timeout": "250ms",
+	"gossip-listen-port": 4401
+}`), 0644))
+
+		result, err := reloader.Reload()
+		require.NoError(t, err)
+		require.Equal(t, []string{TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT}, result.Applied)
+		require.Equal(t, []string{GOSSIP_LISTEN_PORT}, result.RequiresRestart)
+
+		require.Equal(t, 250*time.Millisecond, cfg.TransactionPoolPropagationBatchingTimeout(), "holders of the config should see the reloaded value")
+		require.EqualValues(t, 4400, cfg.GossipListenPort(), "keys which were not declared should keep their value until restart")
+		require.Equal(t, cfg, reloadedWith, "declaring service should be notified of the change")
+	})
+}
+
+func TestReloader_DoesNotNotifyWhenNothingChanged(t *testing.T) {
+	withConfigFile(t, `{`+reloadableNodeKeys+`
+	"logger-full-log": true
+}`, func(path string) {
+		reloader, err := NewReloader(ArrayFlags{path}, ":8080")
+		require.NoError(t, err)
+
+		notified := false
+		reloader.DeclareHotReloadable(func(c NodeConfig) { notified = true }, LOGGER_FULL_LOG)
+
+		result, err := reloader.Reload()
+		require.NoError(t, err)
+		require.Empty(t, result.Applied)
+		require.Empty(t, result.RequiresRestart)
+		require.False(t, notified)
+	})
+}
+
+func TestReloader_KeepsCurrentConfigWhenReloadedConfigIsInvalid(t *testing.T) {
+	withConfigFile(t, `{`+reloadableNodeKeys+`
+	"block-sync-no-commit-interval": "30s"
+}`, func(path string) {
+		reloader, err := NewReloader(ArrayFlags{path}, ":8080")
+		require.NoError(t, err)
+		reloader.DeclareHotReloadable(nil, BLOCK_SYNC_NO_COMMIT_INTERVAL)
+
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{`+reloadableNodeKeys+`
+	"block-sync-no-commit-interval": "1ms"
+}`), 0644))
+		_, err = reloader.Reload()
+		require.Error(t, err, "a reloaded config failing node logic validation should be rejected")
+
+		require.NoError(t, ioutil.WriteFile(path, []byte(`{"block-sync-no-commit-interval": `), 0644))
+		_, err = reloader.Reload()
+		require.Error(t, err, "a config file which does not parse should be rejected")
+
+		require.Equal(t, 30*time.Second, reloader.Config().BlockSyncNoCommitInterval())
+	})
+}
+
+func TestReloader_UnlocksKeystoreOnlyOnce(t *testing.T) {
+	withKeystoreFile(t, "secret", func(dir string, keystorePath string) {
+		passphraseFile := filepath.Join(dir, "passphrase")
+		require.NoError(t, ioutil.WriteFile(passphraseFile, []byte("secret\n"), 0600))
+
+		withConfigFile(t, `{
+	"node-keystore-path": "`+keystorePath+`",
+	"node-keystore-passphrase-file": "`+passphraseFile+`",
+	"logger-full-log": false
+}`, func(path string) {
+			reloader, err := NewReloader(ArrayFlags{path}, ":8080")
+			require.NoError(t, err)
+			reloader.DeclareHotReloadable(nil, LOGGER_FULL_LOG)
+
+			require.NoError(t, os.Remove(passphraseFile))
+			require.NoError(t, ioutil.WriteFile(path, []byte(`{
+	"node-keystore-path": "`+keystorePath+`",
+	"node-keystore-passphrase-file": "`+passphraseFile+`",
+	"logger-full-log": true
+}`), 0644))
+
+			result, err := reloader.Reload()
+			require.NoError(t, err, "reload should not unlock the keystore again")
+			require.Equal(t, []string{LOGGER_FULL_LOG}, result.Applied)
+			require.Equal(t, keystoreTestAddress, reloader.Config().NodeAddress().String(), "reload should keep the node keys unlocked on startup")
+			require.Equal(t, keystoreTestPrivateKey, hex.EncodeToString(reloader.Config().NodePrivateKey()))
+		})
+	})
+}
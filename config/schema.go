@@ -0,0 +1,405 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/pkg/errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type schemaType string
+
+const (
+	schemaUint32   schemaType = "uint32"
+	schemaDuration schemaType = "duration"
+	schemaString   schemaType = "string"
+	schemaBool     schemaType = "bool"
+	schemaHex      schemaType = "hex string"
+	schemaList     schemaType = "list of strings"
+	schemaJson     schemaType = "json"
+)
+
+const REDACTED_CONFIG_VALUE = "<redacted>"
+
+// schemaKey declares a key accepted in config files and as an ORBS_* environment variable. Keys held as key values
+// are parsed and printed according to their type, the others (node keys, topology, genesis validators) declare
+// their own parse and effective functions. Defaults are the ones of defaultProductionConfig
+type schemaKey struct {
+	name      string // as written in config files
+	key       string // the key value it populates, empty when it is kept outside the key values
+	valueType schemaType
+	secret    bool
+	doc       string
+	parse     func(cfg mutableNodeConfig, value interface{}) error
+	effective func(cfg *config) interface{}
+}
+
+func (k *schemaKey) envVar() string {
+	return ENV_VAR_PREFIX + convertKeyName(k.name)
+}
+
+func kvKey(key string, valueType schemaType, doc string) *schemaKey {
+	return &schemaKey{name: strings.ToLower(strings.Replace(key, "_", "-", -1)), key: key, valueType: valueType, doc: doc}
+}
+
+var configSchema = []*schemaKey{
+	{name: "node-address", valueType: schemaHex, doc: "the address of this node",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			address, err := parseHex(value)
+			cfg.SetNodeAddress(address)
+			return err
+		},
+		effective: func(cfg *config) interface{} { return cfg.NodeAddress().String() }},
+	{name: "node-private-key", valueType: schemaHex, secret: true, doc: "the private key of this node, not needed when a signer endpoint is configured",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			privateKey, err := parseHex(value)
+			cfg.SetNodePrivateKey(privateKey)
+			return err
+		},
+		effective: func(cfg *config) interface{} { return hex.EncodeToString(cfg.NodePrivateKey()) }},
+	kvKey(NODE_KEYSTORE_PATH, schemaString, "path of an encrypted keystore holding the node key, replaces node-private-key"),
+	kvKey(NODE_KEYSTORE_PASSPHRASE_FILE, schemaString, "path of a file holding the keystore passphrase, ORBS_KEYSTORE_PASSPHRASE is used when empty"),
+	{name: "active-consensus-algo", valueType: schemaUint32, doc: "the consensus algorithm, 1 for benchmark consensus and 2 for lean helix",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			algo, err := parseUint32Value(value)
+			cfg.SetActiveConsensusAlgo(consensus.ConsensusAlgoType(algo))
+			return err
+		},
+		effective: func(cfg *config) interface{} { return uint32(cfg.ActiveConsensusAlgo()) }},
+	{name: "benchmark-consensus-constant-leader", valueType: schemaHex, doc: "the address of the leader when running benchmark consensus",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			address, err := parseHex(value)
+			cfg.SetBenchmarkConsensusConstantLeader(address)
+			return err
+		},
+		effective: func(cfg *config) interface{} { return cfg.BenchmarkConsensusConstantLeader().String() }},
+	{name: "genesis-validator-addresses", valueType: schemaJson, doc: "list of hex addresses of the validators of the genesis committee",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			nodes, err := parseNodes(value)
+			cfg.SetGenesisValidatorNodes(nodes)
+			return err
+		},
+		effective: func(cfg *config) interface{} {
+			addresses := []string{}
+			for _, node := range cfg.GenesisValidatorNodes() {
+				addresses = append(addresses, node.NodeAddress().String())
+			}
+			sort.Strings(addresses)
+			return addresses
+		}},
+	{name: "topology-nodes", valueType: schemaJson, doc: "list of gossip peers, each an object with address, ip and port",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			peers, err := parsePeers(value)
+			cfg.SetGossipPeers(peers)
+			return err
+		},
+		effective: func(cfg *config) interface{} {
+			peers := []map[string]interface{}{}
+			for _, peer := range cfg.GossipPeers() {
+				peers = append(peers, map[string]interface{}{"address": peer.HexOrbsAddress(), "ip": peer.Endpoint(), "port": peer.Port()})
+			}
+			sort.Slice(peers, func(i, j int) bool {
+				return peers[i]["address"].(string) < peers[j]["address"].(string)
+			})
+			return peers
+		}},
+
+	kvKey(VIRTUAL_CHAIN_ID, schemaUint32, "the id of the virtual chain this node runs"),
+	kvKey(NETWORK_TYPE, schemaUint32, "the network type signed into transactions"),
+
+	kvKey(MANAGEMENT_FILE_PATH, schemaString, "path or url of the management file, empty to use the in memory management"),
+	kvKey(MANAGEMENT_MAX_FILE_SIZE, schemaUint32, "maximal size in bytes of a management file"),
+	kvKey(MANAGEMENT_POLLING_INTERVAL, schemaDuration, "how often management data is polled"),
+	kvKey(MANAGEMENT_CONSENSUS_GRACE_TIMEOUT, schemaDuration, "how long consensus may use a committee after management data stopped updating"),
+	kvKey(MANAGEMENT_NETWORK_LIVENESS_TIMEOUT, schemaDuration, "how long without management updates the network is considered alive"),
+	{name: "management-authority-addresses", key: MANAGEMENT_AUTHORITY_ADDRESSES, valueType: schemaList, doc: "hex addresses of the authorities allowed to sign management data",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			addresses, err := parseAddressList(value)
+			cfg.SetString(MANAGEMENT_AUTHORITY_ADDRESSES, addresses)
+			return err
+		}},
+	kvKey(MANAGEMENT_AUTHORITY_THRESHOLD, schemaUint32, "how many authority signatures management data needs"),
+	kvKey(MANAGEMENT_MAX_DATA_AGE, schemaDuration, "maximal age of management data before it is rejected as stale, 0 to disable"),
+	{name: "management-sources", key: MANAGEMENT_SOURCES, valueType: schemaList, doc: "paths or urls of additional management sources",
+		parse: func(cfg mutableNodeConfig, value interface{}) error {
+			sources, err := parseStringList(value)
+			cfg.SetString(MANAGEMENT_SOURCES, sources)
+			return err
+		}},
+	kvKey(MANAGEMENT_SOURCES_QUORUM, schemaUint32, "how many management sources must agree"),
+	kvKey(MANAGEMENT_HISTORIC_CACHE_DIR, schemaString, "dir of the management historic cache of file sources, defaults to a dir under the block storage dir"),
+	kvKey(MANAGEMENT_HISTORIC_CACHE_BUNDLE, schemaString, "path of a management historic bundle to seed the cache with, its pages are verified like documents read from the sources"),
+	kvKey(MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT, schemaString, "address of the guardians registration contract, guardians publish their gossip port in the ORBS_GOSSIP_PORT metadata"),
+	kvKey(MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT, schemaString, "address of the committee contract, setting it reads management data from ethereum"),
+	kvKey(MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT, schemaString, "address of the subscriptions contract"),
+	kvKey(MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT, schemaString, "address of the protocol contract"),
+	kvKey(MANAGEMENT_ETHEREUM_FROM_BLOCK, schemaUint32, "ethereum block to start reading management events from"),
+
+	kvKey(BENCHMARK_CONSENSUS_RETRY_INTERVAL, schemaDuration, "how often the benchmark consensus leader retries a block"),
+	kvKey(BENCHMARK_CONSENSUS_REQUIRED_QUORUM_PERCENTAGE, schemaUint32, "percentage of the committee benchmark consensus needs"),
+
+	kvKey(LEAN_HELIX_CONSENSUS_ROUND_TIMEOUT_INTERVAL, schemaDuration, "lean helix round timeout, before a leader change"),
+	kvKey(LEAN_HELIX_CONSENSUS_MINIMUM_COMMITTEE_SIZE, schemaUint32, "minimal lean helix committee size"),
+	kvKey(LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE, schemaUint32, "maximal lean helix committee size"),
+	kvKey(INTER_NODE_SYNC_AUDIT_BLOCKS_YOUNGER_THAN, schemaDuration, "synced blocks younger than this are audited"),
+	kvKey(LEAN_HELIX_SHOW_DEBUG, schemaBool, "log lean helix debug messages"),
+	kvKey(LEAN_HELIX_TIMELINE_MAX_HEIGHTS, schemaUint32, "how many heights the consensus timeline keeps"),
+
+	kvKey(BLOCK_SYNC_NUM_BLOCKS_IN_BATCH, schemaUint32, "blocks requested in a single sync batch"),
+	kvKey(BLOCK_SYNC_NO_COMMIT_INTERVAL, schemaDuration, "time without commits before block sync starts"),
+	kvKey(BLOCK_SYNC_COLLECT_RESPONSE_TIMEOUT, schemaDuration, "how long block sync collects availability responses"),
+	kvKey(BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT, schemaDuration, "how long block sync waits for a chunk of blocks"),
+	kvKey(BLOCK_SYNC_DESCENDING_ENABLED, schemaBool, "sync blocks from the top down"),
+	kvKey(BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE, schemaDuration, "maximal distance of a synced block reference time from now"),
+	kvKey(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT, schemaUint32, "height of a trusted checkpoint to fast sync from, 0 to disable"),
+	kvKey(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH, schemaHex, "hex hash of the block at the fast sync checkpoint height"),
+
+	kvKey(BLOCK_SYNC_SERVER_MAX_CONCURRENT_REQUESTORS, schemaUint32, "how many peers the sync server serves at once"),
+	kvKey(BLOCK_SYNC_SERVER_MAX_BYTES_PER_SECOND, schemaUint32, "bandwidth limit of the sync server, 0 for unlimited"),
+	kvKey(BLOCK_SYNC_SERVER_QUEUE_TIMEOUT, schemaDuration, "how long a sync request may wait for the sync server"),
+
+	kvKey(BLOCK_STORAGE_TRANSACTION_RECEIPT_QUERY_TIMESTAMP_GRACE, schemaDuration, "grace around the transaction timestamp when searching for its receipt"),
+	kvKey(BLOCK_STORAGE_FILE_SYSTEM_DATA_DIR, schemaString, "dir of the blocks file"),
+	kvKey(BLOCK_STORAGE_FILE_SYSTEM_MAX_BLOCK_SIZE_IN_BYTES, schemaUint32, "maximal size of a block in the blocks file"),
+
+	kvKey(CONSENSUS_CONTEXT_MAXIMUM_TRANSACTIONS_IN_BLOCK, schemaUint32, "maximal number of transactions in a block"),
+	kvKey(CONSENSUS_CONTEXT_SYSTEM_TIMESTAMP_ALLOWED_JITTER, schemaDuration, "allowed difference between a block timestamp and the local clock"),
+	kvKey(CONSENSUS_CONTEXT_TRIGGERS_ENABLED, schemaBool, "add the trigger transaction to blocks"),
+
+	kvKey(STATE_STORAGE_HISTORY_SNAPSHOT_NUM, schemaUint32, "how many state snapshots are kept"),
+
+	kvKey(BLOCK_TRACKER_GRACE_DISTANCE, schemaUint32, "how many blocks ahead a request may wait for"),
+	kvKey(BLOCK_TRACKER_GRACE_TIMEOUT, schemaDuration, "how long a request waits for a block ahead"),
+
+	kvKey(TRANSACTION_POOL_PENDING_POOL_SIZE_IN_BYTES, schemaUint32, "maximal size of the pending pool"),
+	kvKey(TRANSACTION_EXPIRATION_WINDOW, schemaDuration, "how long a transaction is valid after its timestamp"),
+	kvKey(TRANSACTION_POOL_FUTURE_TIMESTAMP_GRACE_TIMEOUT, schemaDuration, "how far in the future a transaction timestamp may be"),
+	kvKey(TRANSACTION_POOL_PENDING_POOL_CLEAR_EXPIRED_INTERVAL, schemaDuration, "how often expired transactions are removed from the pending pool"),
+	kvKey(TRANSACTION_POOL_COMMITTED_POOL_CLEAR_EXPIRED_INTERVAL, schemaDuration, "how often expired receipts are removed from the committed pool"),
+	kvKey(TRANSACTION_POOL_PROPAGATION_BATCH_SIZE, schemaUint32, "transactions forwarded to peers in a single batch"),
+	kvKey(TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT, schemaDuration, "how long transactions wait to be forwarded in a batch"),
+	kvKey(TRANSACTION_POOL_TIME_BETWEEN_EMPTY_BLOCKS, schemaDuration, "how long the pool waits for transactions before an empty block is closed"),
+	kvKey(TRANSACTION_POOL_NODE_SYNC_REJECT_TIME, schemaDuration, "transactions are rejected when the last block is older than this"),
+
+	kvKey(GOSSIP_LISTEN_PORT, schemaUint32, "tcp port gossip listens on"),
+	kvKey(GOSSIP_CONNECTION_KEEP_ALIVE_INTERVAL, schemaDuration, "how often keep alive messages are sent to peers"),
+	kvKey(GOSSIP_NETWORK_TIMEOUT, schemaDuration, "timeout of gossip network operations"),
+	kvKey(GOSSIP_RECONNECT_INTERVAL, schemaDuration, "how long to wait before reconnecting to a peer"),
+
+	kvKey(PUBLIC_API_SEND_TRANSACTION_TIMEOUT, schemaDuration, "how long send transaction waits for the transaction to be committed"),
+	kvKey(PUBLIC_API_NODE_SYNC_WARNING_TIME, schemaDuration, "the status reports a warning when the last block is older than this"),
+
+	kvKey(PROCESSOR_ARTIFACT_PATH, schemaString, "dir where deployed contracts are compiled"),
+	kvKey(PROCESSOR_ARTIFACT_STORE_PATH, schemaString, "dir where compiled contracts are stored between restarts"),
+	kvKey(PROCESSOR_ARTIFACT_STORE_SECRET, schemaString, "secret shared by the nodes using the artifact store, the store is not used without it"),
+	kvKey(PROCESSOR_PRE_WARM_DEPLOYED_CONTRACTS, schemaBool, "compile deployed contracts in the background at startup"),
+	kvKey(PROCESSOR_PRE_WARM_TIMEOUT, schemaDuration, "how long startup compilation of deployed contracts may take"),
+	kvKey(PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS, schemaBool, "reject deployed contracts which import forbidden packages"),
+	kvKey(PROCESSOR_PERFORM_WARM_UP_COMPILATION, schemaBool, "compile a contract at startup to warm the compiler cache"),
+	kvKey(PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL, schemaUint32, "instruction limit of a wasm contract call"),
+	kvKey(PROCESSOR_WASM_MAX_MEMORY_PAGES, schemaUint32, "memory limit of a wasm contract in pages"),
+
+	kvKey(PROCESSOR_NATIVE_SANDBOX_ENABLED, schemaBool, "run native contracts in sandboxed child processes"),
+	kvKey(PROCESSOR_NATIVE_SANDBOX_WORKERS, schemaUint32, "number of sandbox child processes"),
+	kvKey(PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES, schemaUint32, "memory limit of a sandbox child process"),
+	kvKey(PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL, schemaDuration, "cpu time limit of a sandboxed contract call"),
+	kvKey(PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT, schemaDuration, "wall time limit of a sandboxed contract call"),
+
+	{name: "ethereum-endpoint", key: ETHEREUM_ENDPOINT, valueType: schemaString, secret: true, doc: "url of the ethereum node, may hold an api key"},
+	kvKey(ETHEREUM_FINALITY_TIME_COMPONENT, schemaDuration, "how old an ethereum block must be to be considered final"),
+	kvKey(ETHEREUM_FINALITY_BLOCKS_COMPONENT, schemaUint32, "how many blocks deep an ethereum block must be to be considered final"),
+
+	{name: "logger-http-endpoint", key: LOGGER_HTTP_ENDPOINT, valueType: schemaString, secret: true, doc: "url logs are shipped to, may hold credentials"},
+	kvKey(LOGGER_BULK_SIZE, schemaUint32, "how many log lines are shipped together"),
+	kvKey(LOGGER_FILE_TRUNCATION_INTERVAL, schemaDuration, "how often the log file is truncated"),
+	kvKey(LOGGER_FULL_LOG, schemaBool, "log everything instead of only errors and consensus"),
+
+	kvKey(PROFILING, schemaBool, "expose pprof over http"),
+	kvKey(HTTP_ADDRESS, schemaString, "address the http server listens on, set by the --listen flag"),
+	kvKey(HTTP_SIMULATE_TRANSACTION_ENABLED, schemaBool, "serve /api/v1/simulate-transaction, which runs contracts for unauthenticated clients"),
+	kvKey(HTTP_SIMULATE_TRANSACTION_RATE_LIMIT, schemaUint32, "simulate-transaction requests served per second, zero for no limit"),
+	kvKey(HTTP_CONFIG_RELOAD_ENABLED, schemaBool, "serve /debug/config/reload, which lets unauthenticated clients reload the config files"),
+	kvKey(NTP_ENDPOINT, schemaString, "ntp server used to check the local clock"),
+	kvKey(TRACING_OTLP_ENDPOINT, schemaString, "url of an OTLP/HTTP collector spans are exported to, empty to disable exporting"),
+	kvKey(TRACING_EXPORT_INTERVAL, schemaDuration, "how often spans are exported to the collector"),
+
+	kvKey(SIGNER_ENDPOINT, schemaString, "url of the remote signer, the node private key is used when empty"),
+	kvKey(SIGNER_REQUEST_TIMEOUT, schemaDuration, "timeout of a single signer request"),
+	kvKey(SIGNER_RETRY_ATTEMPTS, schemaUint32, "how many times a failed signer request is retried"),
+	kvKey(SIGNER_RETRY_BACKOFF, schemaDuration, "wait between signer retries"),
+	kvKey(SIGNER_HEALTH_CHECK_INTERVAL, schemaDuration, "how often the signer health is checked"),
+	kvKey(SIGNER_CIRCUIT_BREAKER_THRESHOLD, schemaUint32, "consecutive signer failures which open the circuit breaker"),
+	kvKey(SIGNER_CIRCUIT_BREAKER_COOLDOWN, schemaDuration, "how long the signer circuit breaker stays open"),
+	kvKey(SIGNER_SWITCH_ENDPOINT, schemaString, "url of a signer to switch to, it must hold the same node key (switching endpoints, not rotating keys)"),
+	kvKey(SIGNER_SWITCH_REFERENCE_TIME, schemaUint32, "block reference time from which the switch signer endpoint is used"),
+
+	kvKey(EXPERIMENTAL_EXTERNAL_PROCESSOR_PLUGIN_PATH, schemaString, "path of the javascript processor plugin"),
+}
+
+// deprecated key names, kept for backwards-compatibility
+var configSchemaAliases = map[string]string{
+	"federation-nodes": "topology-nodes",
+	"gossip-port":      "gossip-listen-port",
+}
+
+var configSchemaByName = func() map[string]*schemaKey {
+	byName := make(map[string]*schemaKey)
+	for _, k := range configSchema {
+		byName[k.name] = k
+	}
+	for alias, name := range configSchemaAliases {
+		byName[alias] = byName[name]
+	}
+	return byName
+}()
+
+func (k *schemaKey) populate(cfg mutableNodeConfig, value interface{}) error {
+	if err := k.populateByType(cfg, value); err != nil {
+		return errors.Errorf("invalid value for config key %s (%s, %s): %s", k.name, k.valueType, k.doc, err)
+	}
+	return nil
+}
+
+func (k *schemaKey) populateByType(cfg mutableNodeConfig, value interface{}) error {
+	if k.parse != nil {
+		return k.parse(cfg, value)
+	}
+
+	switch k.valueType {
+	case schemaUint32:
+		i, err := parseUint32Value(value)
+		if err != nil {
+			return err
+		}
+		cfg.SetUint32(k.key, i)
+	case schemaDuration:
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("expected a duration string such as \"10s\" but got %s", describeValue(value))
+		}
+		duration, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		cfg.SetDuration(k.key, duration)
+	case schemaString:
+		s, ok := value.(string)
+		if !ok {
+			return errors.Errorf("expected a string but got %s", describeValue(value))
+		}
+		cfg.SetString(k.key, s)
+	case schemaBool:
+		b, ok := value.(bool)
+		if !ok {
+			return errors.Errorf("expected true or false but got %s", describeValue(value))
+		}
+		cfg.SetBool(k.key, b)
+	case schemaHex:
+		if _, err := parseHex(value); err != nil {
+			return err
+		}
+		cfg.SetString(k.key, value.(string))
+	default:
+		return errors.Errorf("no parser for type %s", k.valueType)
+	}
+	return nil
+}
+
+// the value as it would be written in a config file, secrets are redacted
+func (k *schemaKey) effectiveValue(cfg *config) interface{} {
+	var value interface{}
+	if k.effective != nil {
+		value = k.effective(cfg)
+	} else {
+		v := cfg.value(k.key)
+		switch k.valueType {
+		case schemaUint32:
+			value = v.Uint32Value
+		case schemaDuration:
+			value = v.DurationValue.String()
+		case schemaBool:
+			value = v.BoolValue
+		default:
+			value = v.StringValue
+		}
+	}
+
+	if k.secret && value != "" {
+		return REDACTED_CONFIG_VALUE
+	}
+	return value
+}
+
+// converts an environment variable to the value the key would have in a json config file
+func (k *schemaKey) envValue(s string) (interface{}, error) {
+	switch k.valueType {
+	case schemaUint32:
+		i, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, errors.Errorf("expected a whole number between 0 and %d but got %q", uint32(math.MaxUint32), s)
+		}
+		return float64(i), nil
+	case schemaBool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, errors.Errorf("expected true or false but got %q", s)
+		}
+		return b, nil
+	case schemaJson:
+		var value interface{}
+		if err := json.Unmarshal([]byte(s), &value); err != nil {
+			return nil, errors.Wrap(err, "expected a json value")
+		}
+		return value, nil
+	default:
+		return s, nil
+	}
+}
+
+func parseUint32Value(value interface{}) (uint32, error) {
+	if v, ok := value.(float64); ok {
+		if v < 0 || v > math.MaxUint32 || v != math.Trunc(v) {
+			return 0, errors.Errorf("expected a whole number between 0 and %d but got %v", uint32(math.MaxUint32), v)
+		}
+		return uint32(v), nil
+	}
+	return 0, errors.Errorf("expected a number but got %s", describeValue(value))
+}
+
+func parseHex(value interface{}) ([]byte, error) {
+	s, ok := value.(string)
+	if !ok {
+		return nil, errors.Errorf("expected a hex string but got %s", describeValue(value))
+	}
+	return hex.DecodeString(s)
+}
+
+func describeValue(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return fmt.Sprintf("the string %q", value)
+	case float64:
+		return fmt.Sprintf("the number %v", value)
+	case bool:
+		return fmt.Sprintf("the bool %v", value)
+	case []interface{}:
+		return "a list"
+	case map[string]interface{}:
+		return "an object"
+	}
+	return fmt.Sprintf("%v", value)
+}
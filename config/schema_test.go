@@ -0,0 +1,129 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSchema_DeclaresEveryDefaultKeyWithItsType(t *testing.T) {
+	for key, value := range defaultProductionConfig().(*config).values() {
+		var declared *schemaKey
+		for _, k := range configSchema {
+			if k.key == key {
+				declared = k
+			}
+		}
+		require.NotNil(t, declared, "key %s has a default but is not declared in the config schema", key)
+
+		switch declared.valueType {
+		case schemaUint32:
+			require.Equal(t, NodeConfigValue{Uint32Value: value.Uint32Value}, value, "key %s is declared as uint32", key)
+		case schemaDuration:
+			require.Equal(t, NodeConfigValue{DurationValue: value.DurationValue}, value, "key %s is declared as duration", key)
+		case schemaBool:
+			require.Equal(t, NodeConfigValue{BoolValue: value.BoolValue}, value, "key %s is declared as bool", key)
+		default:
+			require.Equal(t, NodeConfigValue{StringValue: value.StringValue}, value, "key %s is declared as a string", key)
+		}
+	}
+}
+
+func TestSchema_RejectsUnknownKeys(t *testing.T) {
+	_, err := newEmptyFileConfig(`{"gossip-listen-prot": 4400}`)
+	require.EqualError(t, err, "unknown config key gossip-listen-prot")
+}
+
+func TestSchema_RejectsValuesOfTheWrongType(t *testing.T) {
+	for source, expectedError := range map[string]string{
+		`{"gossip-listen-port": "4400"}`:                     "gossip-listen-port (uint32, tcp port gossip listens on): expected a number but got the string \"4400\"",
+		`{"block-sync-num-blocks-in-batch": 1.5}`:            "block-sync-num-blocks-in-batch (uint32, blocks requested in a single sync batch): expected a whole number",
+		`{"block-sync-num-blocks-in-batch": -1}`:             "expected a whole number between 0 and 4294967295 but got -1",
+		`{"block-sync-no-commit-interval": 18}`:              "expected a duration string such as \"10s\" but got the number 18",
+		`{"profiling": "true"}`:                              "expected true or false but got the string \"true\"",
+		`{"active-consensus-algo": "lean-helix"}`:            "active-consensus-algo (uint32",
+		`{"node-address": 7}`:                                "expected a hex string but got the number 7",
+		`{"genesis-validator-addresses": "a328846c"}`:        "expected a list of addresses but got the string",
+		`{"topology-nodes": [{"address": "a328846c"}]}`:      "peer port: expected a number but got null",
+		`{"topology-nodes": ["a328846c"]}`:                   "expected a peer object with address, ip and port",
+		`{"management-sources": [1]}`:                        "1 is not a string",
+		`{"topology-nodes": [{"address": "a3", "port": 1}]}`: "peer ip: expected a string but got null",
+	} {
+		_, err := newEmptyFileConfig(source)
+		require.Error(t, err, source)
+		require.Contains(t, err.Error(), expectedError, source)
+	}
+}
+
+func TestSchema_EnvOverrides(t *testing.T) {
+	env := map[string]string{
+		"ORBS_GOSSIP_LISTEN_PORT":               "4500",
+		"ORBS_BLOCK_SYNC_NO_COMMIT_INTERVAL":    "30s",
+		"ORBS_LOGGER_FULL_LOG":                  "true",
+		"ORBS_NODE_ADDRESS":                     "a328846cd5b4979d68a8c58a9bdfeee657b34de7",
+		"ORBS_MANAGEMENT_SOURCES":               "http://management-1/vc,http://management-2/vc",
+		"ORBS_TOPOLOGY_NODES":                   `[{"address": "a328846cd5b4979d68a8c58a9bdfeee657b34de7", "ip": "10.0.0.1", "port": 4400}]`,
+		"ORBS_SERVICE_HOST":                     "10.0.0.2",
+		"ORBS_PROCESSOR_NATIVE_SANDBOX_ENABLED": "1",
+	}
+	lookupEnv := func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+
+	cfg := defaultProductionConfig()
+	require.NoError(t, applyEnvOverrides(cfg, lookupEnv), "unknown ORBS_ variables should be ignored")
+
+	require.EqualValues(t, 4500, cfg.GossipListenPort())
+	require.Equal(t, 30*time.Second, cfg.BlockSyncNoCommitInterval())
+	require.True(t, cfg.LoggerFullLog())
+	require.Equal(t, "a328846cd5b4979d68a8c58a9bdfeee657b34de7", cfg.NodeAddress().String())
+	require.Equal(t, []string{"http://management-1/vc", "http://management-2/vc"}, cfg.ManagementSources())
+	require.Len(t, cfg.GossipPeers(), 1)
+	require.True(t, cfg.ProcessorNativeSandboxEnabled())
+
+	env = map[string]string{"ORBS_GOSSIP_LISTEN_PORT": "port"}
+	err := applyEnvOverrides(defaultProductionConfig(), lookupEnv)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "ORBS_GOSSIP_LISTEN_PORT")
+}
+
+func TestPrintConfig_RedactsSecretsAndCanBeParsedBack(t *testing.T) {
+	cfg, err := newFileConfig(defaultProductionConfig(), `{
+		"node-address": "a328846cd5b4979d68a8c58a9bdfeee657b34de7",
+		"node-private-key": "901a1a0bfbe217593062a054e561e708707cb814a123474c25fd567a0fe088f8",
+		"genesis-validator-addresses": ["a328846cd5b4979d68a8c58a9bdfeee657b34de7"],
+		"block-sync-no-commit-interval": "30s"
+	}`)
+	require.NoError(t, err)
+
+	printed := &bytes.Buffer{}
+	require.NoError(t, PrintConfig(cfg, printed))
+
+	var effective map[string]interface{}
+	require.NoError(t, json.Unmarshal(printed.Bytes(), &effective))
+	require.Equal(t, REDACTED_CONFIG_VALUE, effective["node-private-key"])
+	require.Equal(t, REDACTED_CONFIG_VALUE, effective["ethereum-endpoint"])
+	require.Equal(t, "30s", effective["block-sync-no-commit-interval"])
+	require.Len(t, effective, len(configSchema))
+
+	for name, value := range effective {
+		if value == REDACTED_CONFIG_VALUE {
+			delete(effective, name)
+		}
+	}
+	withoutSecrets, _ := json.Marshal(effective)
+	parsed, err := newEmptyFileConfig(string(withoutSecrets))
+	require.NoError(t, err, "the printed config without its secrets should be a valid config file")
+	require.Equal(t, cfg.NodeAddress(), parsed.NodeAddress())
+	require.Equal(t, cfg.BlockSyncNoCommitInterval(), parsed.BlockSyncNoCommitInterval())
+	require.Equal(t, cfg.GenesisValidatorNodes(), parsed.GenesisValidatorNodes())
+}
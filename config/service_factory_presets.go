@@ -46,6 +46,7 @@ func ForConsensusContextTests(triggersEnabled bool) ConsensusContextConfig {
 	cfg := emptyConfig()
 
 	cfg.SetBool(LEAN_HELIX_SHOW_DEBUG, true)
+	cfg.SetUint32(LEAN_HELIX_TIMELINE_MAX_HEIGHTS, 100)
 	cfg.SetUint32(VIRTUAL_CHAIN_ID, 42)
 	cfg.SetUint32(NETWORK_TYPE, uint32(protocol.NETWORK_TYPE_TEST_NET))
 	cfg.SetUint32(LEAN_HELIX_CONSENSUS_MINIMUM_COMMITTEE_SIZE, 4)
@@ -103,6 +104,7 @@ func ForLeanHelixConsensusTests(keyPair *testKeys.TestEcdsaSecp256K1KeyPair, aud
 	cfg.SetDuration(LEAN_HELIX_CONSENSUS_ROUND_TIMEOUT_INTERVAL, consensusRoundTimeoutInterval)
 	cfg.SetUint32(LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE, 22)
 	cfg.SetBool(LEAN_HELIX_SHOW_DEBUG, true)
+	cfg.SetUint32(LEAN_HELIX_TIMELINE_MAX_HEIGHTS, 100)
 	cfg.SetUint32(VIRTUAL_CHAIN_ID, 42)
 	cfg.SetUint32(NETWORK_TYPE, uint32(protocol.NETWORK_TYPE_TEST_NET))
 
@@ -131,3 +133,20 @@ func ForNativeProcessorTests(id primitives.VirtualChainId) NativeProcessorConfig
 	cfg.SetUint32(VIRTUAL_CHAIN_ID, uint32(id))
 	return cfg
 }
+
+func ForWasmProcessorTests(id primitives.VirtualChainId, maxInstructionsPerCall uint32, maxMemoryPages uint32) WasmProcessorConfig {
+	cfg := emptyConfig()
+	cfg.SetUint32(VIRTUAL_CHAIN_ID, uint32(id))
+	cfg.SetUint32(PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL, maxInstructionsPerCall)
+	cfg.SetUint32(PROCESSOR_WASM_MAX_MEMORY_PAGES, maxMemoryPages)
+	return cfg
+}
+
+func ForNativeSandboxTests(workers uint32, maxMemoryMegabytes uint32, maxCpuTimePerCall time.Duration, callTimeout time.Duration) NativeSandboxConfig {
+	cfg := emptyConfig()
+	cfg.SetUint32(PROCESSOR_NATIVE_SANDBOX_WORKERS, workers)
+	cfg.SetUint32(PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES, maxMemoryMegabytes)
+	cfg.SetDuration(PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL, maxCpuTimePerCall)
+	cfg.SetDuration(PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT, callTimeout)
+	return cfg
+}
@@ -21,11 +21,36 @@ func defaultProductionConfig() mutableNodeConfig {
 	cfg.SetUint32(VIRTUAL_CHAIN_ID, 42)
 	cfg.SetUint32(GOSSIP_LISTEN_PORT, 4400)
 
+	// the node key can be kept in an encrypted keystore instead of node-private-key, the passphrase is read from the
+	// passphrase file or from ORBS_KEYSTORE_PASSPHRASE
+	cfg.SetString(NODE_KEYSTORE_PATH, "")
+	cfg.SetString(NODE_KEYSTORE_PASSPHRASE_FILE, "")
+
 	cfg.SetDuration(MANAGEMENT_POLLING_INTERVAL, 10*time.Second)
 	cfg.SetUint32(MANAGEMENT_MAX_FILE_SIZE, 50 * (1<<20)) // 50 MB
 	cfg.SetDuration(MANAGEMENT_CONSENSUS_GRACE_TIMEOUT, 10*time.Minute)
 	cfg.SetDuration(MANAGEMENT_NETWORK_LIVENESS_TIMEOUT, 100*365*24*time.Hour) // TODO v2 POSV2 temp value that is private 2^62 nanos (100 years)
 
+	// management data is not required to be signed unless authorities are configured
+	cfg.SetString(MANAGEMENT_AUTHORITY_ADDRESSES, "")
+	cfg.SetUint32(MANAGEMENT_AUTHORITY_THRESHOLD, 1)
+	cfg.SetDuration(MANAGEMENT_MAX_DATA_AGE, 0)
+
+	// management can be read from several sources (file paths or urls), a quorum of them must agree on committee and topology
+	cfg.SetString(MANAGEMENT_SOURCES, "")
+	cfg.SetUint32(MANAGEMENT_SOURCES_QUORUM, 1)
+
+	// historic management pages are persisted (by default under the block storage dir) and can be pre-seeded from a bundle
+	cfg.SetString(MANAGEMENT_HISTORIC_CACHE_DIR, "")
+	cfg.SetString(MANAGEMENT_HISTORIC_CACHE_BUNDLE, "")
+
+	// management is read from the events of these contracts on ethereum once they are set
+	cfg.SetString(MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT, "")
+	cfg.SetString(MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT, "")
+	cfg.SetString(MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT, "")
+	cfg.SetString(MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT, "")
+	cfg.SetUint32(MANAGEMENT_ETHEREUM_FROM_BLOCK, 0)
+
 	// 2*slow_network_latency + avg_network_latency + 2*execution_time \  + empty block time
 	cfg.SetDuration(LEAN_HELIX_CONSENSUS_ROUND_TIMEOUT_INTERVAL, 14*time.Second)
 	cfg.SetDuration(BENCHMARK_CONSENSUS_RETRY_INTERVAL, 2*time.Second)
@@ -34,6 +59,9 @@ func defaultProductionConfig() mutableNodeConfig {
 	cfg.SetUint32(LEAN_HELIX_CONSENSUS_MAXIMUM_COMMITTEE_SIZE, 22)
 	cfg.SetBool(LEAN_HELIX_SHOW_DEBUG, false)
 
+	// consensus events of the last heights kept in memory for diagnostics
+	cfg.SetUint32(LEAN_HELIX_TIMELINE_MAX_HEIGHTS, 1000)
+
 	// if above round time, we'll have leader changes when no traffic
 	cfg.SetDuration(TRANSACTION_POOL_TIME_BETWEEN_EMPTY_BLOCKS, 9*time.Second)
 
@@ -67,6 +95,15 @@ func defaultProductionConfig() mutableNodeConfig {
 	cfg.SetDuration(BLOCK_SYNC_REFERENCE_MAX_ALLOWED_DISTANCE, 12*time.Hour)
 	// have block sync use descending order of blocks from top
 	cfg.SetBool(BLOCK_SYNC_DESCENDING_ENABLED, true)
+	// fast sync (trusting block proofs without re-executing) is disabled until a checkpoint height and hash are configured
+	cfg.SetUint32(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT, 0)
+	cfg.SetString(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH, "")
+
+	// limits on serving blocks to syncing peers so they do not starve this node's own consensus participation (0 means unlimited)
+	cfg.SetUint32(BLOCK_SYNC_SERVER_MAX_CONCURRENT_REQUESTORS, 4)
+	cfg.SetUint32(BLOCK_SYNC_SERVER_MAX_BYTES_PER_SECOND, 10*(1<<20))
+	// capped by BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT, a request queued for longer than that is no longer awaited by the requestor
+	cfg.SetDuration(BLOCK_SYNC_SERVER_QUEUE_TIMEOUT, 3*time.Second)
 
 	cfg.SetDuration(PUBLIC_API_SEND_TRANSACTION_TIMEOUT, 20*time.Second)
 
@@ -100,6 +137,25 @@ func defaultProductionConfig() mutableNodeConfig {
 	cfg.SetBool(PROCESSOR_SANITIZE_DEPLOYED_CONTRACTS, true)
 	cfg.SetBool(PROCESSOR_PERFORM_WARM_UP_COMPILATION, true)
 
+	// compiled contracts are only kept in the artifact path unless a store (for example a volume shared by nodes) and the
+	// secret authenticating the artifacts in it are set,
+	// deployed contracts can be compiled in the background on startup so the first transactions calling them are not slow
+	cfg.SetString(PROCESSOR_ARTIFACT_STORE_PATH, "")
+	cfg.SetString(PROCESSOR_ARTIFACT_STORE_SECRET, "")
+	cfg.SetBool(PROCESSOR_PRE_WARM_DEPLOYED_CONTRACTS, false)
+	cfg.SetDuration(PROCESSOR_PRE_WARM_TIMEOUT, 10*time.Minute)
+
+	// wasm contracts are interpreted, 20M instructions take about a second, memory pages are 64KB each
+	cfg.SetUint32(PROCESSOR_WASM_MAX_INSTRUCTIONS_PER_CALL, 20000000)
+	cfg.SetUint32(PROCESSOR_WASM_MAX_MEMORY_PAGES, 256)
+
+	// deployed native contracts run inside the node process unless the sandbox is enabled
+	cfg.SetBool(PROCESSOR_NATIVE_SANDBOX_ENABLED, false)
+	cfg.SetUint32(PROCESSOR_NATIVE_SANDBOX_WORKERS, 4)
+	cfg.SetUint32(PROCESSOR_NATIVE_SANDBOX_MAX_MEMORY_MEGABYTES, 512)
+	cfg.SetDuration(PROCESSOR_NATIVE_SANDBOX_MAX_CPU_TIME_PER_CALL, 5*time.Second)
+	cfg.SetDuration(PROCESSOR_NATIVE_SANDBOX_CALL_TIMEOUT, 30*time.Second)
+
 	cfg.SetActiveConsensusAlgo(consensus.CONSENSUS_ALGO_TYPE_BENCHMARK_CONSENSUS)
 	cfg.SetString(ETHEREUM_ENDPOINT, "http://localhost:8545")
 	cfg.SetString(PROCESSOR_ARTIFACT_PATH, filepath.Join(GetProjectSourceTmpPath(), "processor-artifacts"))
@@ -112,6 +168,25 @@ func defaultProductionConfig() mutableNodeConfig {
 	cfg.SetBool(PROFILING, false)
 	cfg.SetString(HTTP_ADDRESS, ":8080")
 
+	// simulating runs contracts for anyone who asks, so public nodes do not offer it unless configured to
+	cfg.SetBool(HTTP_SIMULATE_TRANSACTION_ENABLED, false)
+	cfg.SetUint32(HTTP_SIMULATE_TRANSACTION_RATE_LIMIT, 10)
+
+	// the config is reloaded on SIGHUP, reloading over http is for nodes whose http port is not public
+	cfg.SetBool(HTTP_CONFIG_RELOAD_ENABLED, false)
+
+	// spans are exported to an OTLP/HTTP collector (e.g. http://localhost:4318) once an endpoint is set
+	cfg.SetString(TRACING_OTLP_ENDPOINT, "")
+	cfg.SetDuration(TRACING_EXPORT_INTERVAL, 5*time.Second)
+
+	// remote signer sidecar, a few fast retries keep consensus within the round timeout
+	cfg.SetDuration(SIGNER_REQUEST_TIMEOUT, 2*time.Second)
+	cfg.SetUint32(SIGNER_RETRY_ATTEMPTS, 3)
+	cfg.SetDuration(SIGNER_RETRY_BACKOFF, 100*time.Millisecond)
+	cfg.SetDuration(SIGNER_HEALTH_CHECK_INTERVAL, 10*time.Second)
+	cfg.SetUint32(SIGNER_CIRCUIT_BREAKER_THRESHOLD, 10)
+	cfg.SetDuration(SIGNER_CIRCUIT_BREAKER_COOLDOWN, 5*time.Second)
+
 	return cfg
 }
 
@@ -257,6 +332,7 @@ func TemplateForGamma(
 
 	cfg.SetBool(PROFILING, profiling)
 	cfg.SetString(HTTP_ADDRESS, serverAddress)
+	cfg.SetBool(HTTP_SIMULATE_TRANSACTION_ENABLED, true)
 
 	cfg.SetDuration(MANAGEMENT_CONSENSUS_GRACE_TIMEOUT, time.Hour) // needs to be >> from TRANSACTION_POOL_TIME_BETWEEN_EMPTY_BLOCKS
 	cfg.SetDuration(BENCHMARK_CONSENSUS_RETRY_INTERVAL, 100*time.Millisecond)
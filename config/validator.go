@@ -40,6 +40,89 @@ func ValidateNodeLogic(cfg NodeConfig) error {
 			return err
 		}
 	}
+
+	if cfg.SignerSwitchEndpoint() != "" && cfg.SignerSwitchReferenceTime() == 0 {
+		return errors.New("signer switch reference time must be set when signer switch endpoint is set")
+	}
+
+	if err := validateFastSyncCheckpoint(cfg); err != nil {
+		return err
+	}
+	if err := validateManagementAuthorities(cfg); err != nil {
+		return err
+	}
+	if err := validateManagementEthereumContracts(cfg); err != nil {
+		return err
+	}
+	if err := validateManagementSources(cfg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func validateManagementSources(cfg NodeConfig) error {
+	sources := len(cfg.ManagementSources())
+	if sources < 2 {
+		return nil
+	}
+
+	quorum := cfg.ManagementSourcesQuorum()
+	if quorum < 1 || quorum > uint32(sources) {
+		return errors.Errorf("MANAGEMENT_SOURCES_QUORUM must be between 1 and the number of management sources (%d), got %d", sources, quorum)
+	}
+	return nil
+}
+
+func validateManagementEthereumContracts(cfg NodeConfig) error {
+	if !cfg.IsManagementFromEthereum() {
+		return nil
+	}
+
+	contracts := map[string][]byte{
+		MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT:     cfg.ManagementEthereumGuardiansContract(),
+		MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT:     cfg.ManagementEthereumCommitteeContract(),
+		MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT: cfg.ManagementEthereumSubscriptionsContract(),
+		MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT:      cfg.ManagementEthereumProtocolContract(),
+	}
+	for key, address := range contracts {
+		if len(address) != 20 {
+			return errors.Errorf("%s must be a hex encoded ethereum address when management is read from ethereum", key)
+		}
+	}
+	return nil
+}
+
+func validateManagementAuthorities(cfg NodeConfig) error {
+	authorities := cfg.ManagementAuthorityAddresses()
+	if len(authorities) == 0 {
+		return nil
+	}
+
+	for _, address := range authorities {
+		if len(address) != digest.NODE_ADDRESS_SIZE_BYTES {
+			return errors.Errorf("management authority address %s must be a hex encoded %d byte node address", hex.EncodeToString(address), digest.NODE_ADDRESS_SIZE_BYTES)
+		}
+	}
+	if cfg.ManagementAuthorityThreshold() == 0 || int(cfg.ManagementAuthorityThreshold()) > len(authorities) {
+		return errors.Errorf("management authority threshold %d must be between 1 and the number of management authorities (%d)", cfg.ManagementAuthorityThreshold(), len(authorities))
+	}
+	return nil
+}
+
+func validateFastSyncCheckpoint(cfg NodeConfig) error {
+	if cfg.BlockSyncFastSyncCheckpointHeight() == 0 {
+		if len(cfg.BlockSyncFastSyncCheckpointHash()) != 0 {
+			return errors.New("fast sync checkpoint hash is set but fast sync checkpoint height is not")
+		}
+		return nil
+	}
+
+	if len(cfg.BlockSyncFastSyncCheckpointHash()) != 32 {
+		return errors.Errorf("fast sync checkpoint hash must be a hex encoded 32 byte block hash when fast sync checkpoint height (%d) is set", cfg.BlockSyncFastSyncCheckpointHeight())
+	}
+	if !cfg.BlockSyncDescendingEnabled() {
+		return errors.New("fast sync checkpoint requires descending block sync, blocks below it are synced from it down")
+	}
 	return nil
 }
 
@@ -65,6 +65,69 @@ func TestValidateConfig_ErrorOnInvalidKeys(t *testing.T) {
 	})
 }
 
+func TestValidateConfig_SignerSwitchRequiresReferenceTime(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		cfg := defaultProductionConfig()
+		cfg.SetGenesisValidatorNodes(genesisValidators())
+		cfg.SetNodeAddress(defaultNodeAddress())
+		cfg.SetNodePrivateKey(defaultPrivateKey())
+
+		cfg.SetString(SIGNER_SWITCH_ENDPOINT, "http://signer-2:7777")
+		require.Error(t, ValidateNodeLogic(cfg), "switch endpoint without reference time should be rejected")
+
+		cfg.SetUint32(SIGNER_SWITCH_REFERENCE_TIME, 1600000000)
+		require.NoError(t, ValidateNodeLogic(cfg))
+	})
+}
+
+func TestValidateConfig_FastSyncCheckpointRequiresHeightAndHash(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		cfg := defaultProductionConfig()
+		cfg.SetGenesisValidatorNodes(genesisValidators())
+		cfg.SetNodeAddress(defaultNodeAddress())
+		cfg.SetNodePrivateKey(defaultPrivateKey())
+
+		cfg.SetUint32(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT, 1000)
+		require.Error(t, ValidateNodeLogic(cfg), "checkpoint height without a hash should be rejected")
+
+		cfg.SetString(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH, "not-a-hash")
+		require.Error(t, ValidateNodeLogic(cfg), "checkpoint hash which is not a sha256 hex string should be rejected")
+
+		cfg.SetString(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HASH, "7a6b1f0bfbe217593062a054e561e708707cb814a123474c25fd567a0fe088f8")
+		require.NoError(t, ValidateNodeLogic(cfg))
+
+		cfg.SetBool(BLOCK_SYNC_DESCENDING_ENABLED, false)
+		require.Error(t, ValidateNodeLogic(cfg), "checkpoint without descending block sync should be rejected")
+		cfg.SetBool(BLOCK_SYNC_DESCENDING_ENABLED, true)
+
+		cfg.SetUint32(BLOCK_SYNC_FAST_SYNC_CHECKPOINT_HEIGHT, 0)
+		require.Error(t, ValidateNodeLogic(cfg), "checkpoint hash without a height should be rejected")
+	})
+}
+
+func TestValidateConfig_ManagementAuthorityThreshold(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		cfg := defaultProductionConfig()
+		cfg.SetGenesisValidatorNodes(genesisValidators())
+		cfg.SetNodeAddress(defaultNodeAddress())
+		cfg.SetNodePrivateKey(defaultPrivateKey())
+
+		cfg.SetString(MANAGEMENT_AUTHORITY_ADDRESSES, "a328846cd5b4979d68a8c58a9bdfeee657b34de7,d27e2e7398e2582f63d0800330010b3e58952ff6")
+		cfg.SetUint32(MANAGEMENT_AUTHORITY_THRESHOLD, 2)
+		require.NoError(t, ValidateNodeLogic(cfg))
+
+		cfg.SetUint32(MANAGEMENT_AUTHORITY_THRESHOLD, 3)
+		require.Error(t, ValidateNodeLogic(cfg), "threshold above the number of authorities should be rejected")
+
+		cfg.SetUint32(MANAGEMENT_AUTHORITY_THRESHOLD, 0)
+		require.Error(t, ValidateNodeLogic(cfg), "zero threshold should be rejected when authorities are configured")
+
+		cfg.SetUint32(MANAGEMENT_AUTHORITY_THRESHOLD, 1)
+		cfg.SetString(MANAGEMENT_AUTHORITY_ADDRESSES, "a328846cd5b4979d68a8c58a9bdfeee657b34de7,not-an-address")
+		require.Error(t, ValidateNodeLogic(cfg), "an invalid authority address should be rejected")
+	})
+}
+
 func defaultNodeAddress() primitives.NodeAddress {
 	addr, _ := hex.DecodeString("a328846cd5b4979d68a8c58a9bdfeee657b34de7")
 	return primitives.NodeAddress(addr)
@@ -79,3 +142,43 @@ func wrongPrivateKey() primitives.EcdsaSecp256K1PrivateKey {
 	key, _ := hex.DecodeString("00001a0bfbe217593062a054e561e708707cb814a123474c25fd567a0fe088f8")
 	return primitives.EcdsaSecp256K1PrivateKey(key)
 }
+
+func TestValidateConfig_ManagementEthereumContracts(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		cfg := defaultProductionConfig()
+		cfg.SetGenesisValidatorNodes(genesisValidators())
+		cfg.SetNodeAddress(defaultNodeAddress())
+		cfg.SetNodePrivateKey(defaultPrivateKey())
+
+		cfg.SetString(MANAGEMENT_ETHEREUM_COMMITTEE_CONTRACT, "0x1000000000000000000000000000000000000002")
+		require.Error(t, ValidateNodeLogic(cfg), "all management contracts should be required once management is read from ethereum")
+
+		cfg.SetString(MANAGEMENT_ETHEREUM_GUARDIANS_CONTRACT, "0x1000000000000000000000000000000000000001")
+		cfg.SetString(MANAGEMENT_ETHEREUM_SUBSCRIPTIONS_CONTRACT, "0x1000000000000000000000000000000000000003")
+		cfg.SetString(MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT, "0x1000000000000000000000000000000000000004")
+		require.NoError(t, ValidateNodeLogic(cfg))
+		require.True(t, cfg.IsManagementFromEthereum())
+
+		cfg.SetString(MANAGEMENT_ETHEREUM_PROTOCOL_CONTRACT, "0x1234")
+		require.Error(t, ValidateNodeLogic(cfg), "a contract address which is not 20 bytes should be rejected")
+	})
+}
+
+func TestValidateConfig_ManagementSourcesQuorum(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		cfg := defaultProductionConfig()
+		cfg.SetGenesisValidatorNodes(genesisValidators())
+		cfg.SetNodeAddress(defaultNodeAddress())
+		cfg.SetNodePrivateKey(defaultPrivateKey())
+
+		cfg.SetString(MANAGEMENT_SOURCES, "http://management-1/vc,http://management-2/vc")
+		cfg.SetUint32(MANAGEMENT_SOURCES_QUORUM, 2)
+		require.NoError(t, ValidateNodeLogic(cfg))
+
+		cfg.SetUint32(MANAGEMENT_SOURCES_QUORUM, 3)
+		require.Error(t, ValidateNodeLogic(cfg), "quorum above the number of sources should be rejected")
+
+		cfg.SetUint32(MANAGEMENT_SOURCES_QUORUM, 0)
+		require.Error(t, ValidateNodeLogic(cfg), "zero quorum should be rejected")
+	})
+}
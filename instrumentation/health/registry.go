@@ -0,0 +1,161 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Severity decides what a check that is not ok means for the node as a whole
+type Severity string
+
+const (
+	SEVERITY_CRITICAL Severity = "critical" // failing means the node is broken (not live), syncing means it is not ready
+	SEVERITY_WARNING  Severity = "warning"  // failing or syncing means the node is not ready, it is still live
+	SEVERITY_INFO     Severity = "info"     // reported only, never affects liveness or readiness
+)
+
+type Status string
+
+const (
+	STATUS_OK      Status = "ok"
+	STATUS_SYNCING Status = "syncing" // the component works but has not caught up yet
+	STATUS_FAILING Status = "failing"
+)
+
+// the order of statuses from best to worst, the status of a report is the worst status of its checks
+var statusRank = map[Status]int{
+	STATUS_OK:      0,
+	STATUS_SYNCING: 1,
+	STATUS_FAILING: 2,
+}
+
+type Result struct {
+	Status  Status
+	Message string
+}
+
+func Ok(format string, args ...interface{}) Result {
+	return Result{Status: STATUS_OK, Message: fmt.Sprintf(format, args...)}
+}
+
+func Syncing(format string, args ...interface{}) Result {
+	return Result{Status: STATUS_SYNCING, Message: fmt.Sprintf(format, args...)}
+}
+
+func Failing(format string, args ...interface{}) Result {
+	return Result{Status: STATUS_FAILING, Message: fmt.Sprintf(format, args...)}
+}
+
+// Check is called on every report so it should only read state the component already keeps, not do remote calls
+type Check func(ctx context.Context) Result
+
+type Registry interface {
+	Register(name string, severity Severity, check Check)
+	Report(ctx context.Context) *Report
+}
+
+type CheckReport struct {
+	Name     string
+	Severity Severity
+	Status   Status
+	Message  string
+}
+
+type Report struct {
+	Timestamp time.Time
+	Status    Status
+	Live      bool
+	Ready     bool
+	Checks    []*CheckReport
+}
+
+type namedCheck struct {
+	name     string
+	severity Severity
+	check    Check
+}
+
+type inMemoryRegistry struct {
+	sync.RWMutex
+	checks []*namedCheck
+}
+
+func NewRegistry() Registry {
+	return &inMemoryRegistry{}
+}
+
+// Register replaces a check that was registered under the same name
+func (r *inMemoryRegistry) Register(name string, severity Severity, check Check) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, existing := range r.checks {
+		if existing.name == name {
+			existing.severity = severity
+			existing.check = check
+			return
+		}
+	}
+	r.checks = append(r.checks, &namedCheck{name: name, severity: severity, check: check})
+}
+
+func (r *inMemoryRegistry) Report(ctx context.Context) *Report {
+	r.RLock()
+	checks := make([]*namedCheck, len(r.checks))
+	copy(checks, r.checks)
+	r.RUnlock()
+
+	report := &Report{
+		Timestamp: time.Now(),
+		Status:    STATUS_OK,
+		Live:      true,
+		Ready:     true,
+	}
+	for _, c := range checks {
+		result := c.check(ctx)
+		report.Checks = append(report.Checks, &CheckReport{
+			Name:     c.name,
+			Severity: c.severity,
+			Status:   result.Status,
+			Message:  result.Message,
+		})
+		report.add(c.severity, result.Status)
+	}
+	return report
+}
+
+func (r *Report) add(severity Severity, status Status) {
+	if severity == SEVERITY_INFO || status == STATUS_OK {
+		return
+	}
+	if statusRank[status] > statusRank[r.Status] {
+		r.Status = status
+	}
+	r.Ready = false
+	if severity == SEVERITY_CRITICAL && status == STATUS_FAILING {
+		r.Live = false
+	}
+}
+
+// Summary describes the report in one line, naming the first check that is responsible for its status
+func (r *Report) Summary() string {
+	if r.Status == STATUS_OK {
+		return "OK"
+	}
+	for _, severity := range []Severity{SEVERITY_CRITICAL, SEVERITY_WARNING} {
+		for _, c := range r.Checks {
+			if c.Severity == severity && c.Status == r.Status {
+				return fmt.Sprintf("%s: %s", c.Name, c.Message)
+			}
+		}
+	}
+	return string(r.Status)
+}
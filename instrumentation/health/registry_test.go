@@ -0,0 +1,88 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package health
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func returning(result Result) Check {
+	return func(ctx context.Context) Result {
+		return result
+	}
+}
+
+func TestRegistry_ReportsOkWhenAllChecksPass(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_CRITICAL, returning(Ok("all good")))
+	r.Register("bar", SEVERITY_WARNING, returning(Ok("all good too")))
+
+	report := r.Report(context.Background())
+	require.Equal(t, STATUS_OK, report.Status)
+	require.True(t, report.Live)
+	require.True(t, report.Ready)
+	require.Equal(t, "OK", report.Summary())
+	require.Len(t, report.Checks, 2)
+	require.Equal(t, &CheckReport{Name: "foo", Severity: SEVERITY_CRITICAL, Status: STATUS_OK, Message: "all good"}, report.Checks[0])
+}
+
+func TestRegistry_SyncingNodeIsLiveButNotReady(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_CRITICAL, returning(Syncing("%d blocks behind", 10)))
+	r.Register("bar", SEVERITY_WARNING, returning(Ok("all good")))
+
+	report := r.Report(context.Background())
+	require.Equal(t, STATUS_SYNCING, report.Status)
+	require.True(t, report.Live)
+	require.False(t, report.Ready)
+	require.Equal(t, "foo: 10 blocks behind", report.Summary())
+}
+
+func TestRegistry_FailingCriticalCheckIsNotLive(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_WARNING, returning(Syncing("catching up")))
+	r.Register("bar", SEVERITY_CRITICAL, returning(Failing("broken")))
+
+	report := r.Report(context.Background())
+	require.Equal(t, STATUS_FAILING, report.Status)
+	require.False(t, report.Live)
+	require.False(t, report.Ready)
+	require.Equal(t, "bar: broken", report.Summary())
+}
+
+func TestRegistry_FailingWarningCheckIsLiveButNotReady(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_WARNING, returning(Failing("broken")))
+
+	report := r.Report(context.Background())
+	require.Equal(t, STATUS_FAILING, report.Status)
+	require.True(t, report.Live)
+	require.False(t, report.Ready)
+}
+
+func TestRegistry_InfoChecksDoNotAffectTheNode(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_INFO, returning(Failing("broken")))
+
+	report := r.Report(context.Background())
+	require.Equal(t, STATUS_OK, report.Status)
+	require.True(t, report.Live)
+	require.True(t, report.Ready)
+	require.Equal(t, STATUS_FAILING, report.Checks[0].Status, "info checks should still be reported")
+}
+
+func TestRegistry_RegisteringSameNameReplacesCheck(t *testing.T) {
+	r := NewRegistry()
+	r.Register("foo", SEVERITY_CRITICAL, returning(Failing("broken")))
+	r.Register("foo", SEVERITY_CRITICAL, returning(Ok("fixed")))
+
+	report := r.Report(context.Background())
+	require.Len(t, report.Checks, 1)
+	require.Equal(t, STATUS_OK, report.Status)
+}
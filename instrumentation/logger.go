@@ -62,11 +62,21 @@ func GetLogger(path string, silent bool, cfg config.NodeConfig) log.Logger {
 
 	logger := log.GetLogger().WithOutput(outputs...)
 
-	conditionalFilter := log.NewConditionalFilter(false, nil)
-
-	if !cfg.LoggerFullLog() {
-		conditionalFilter = log.NewConditionalFilter(true, log.Or(log.OnlyErrors(), log.MatchField(leanhelixconsensus.ConsensusLogTag)))
-	}
+	// the filter is always installed so it can be toggled at runtime, full log only decides whether it starts enabled
+	conditionalFilter := log.NewConditionalFilter(!cfg.LoggerFullLog(), log.Or(log.OnlyErrors(), log.MatchField(leanhelixconsensus.ConsensusLogTag)))
 
 	return logger.WithFilters(conditionalFilter)
 }
+
+// UpdateLoggerFilter turns the filter installed by GetLogger off when full log is configured and on otherwise
+func UpdateLoggerFilter(logger log.Logger, cfg config.NodeConfig) {
+	for _, f := range logger.Filters() {
+		if c, ok := f.(log.ConditionalFilter); ok {
+			if cfg.LoggerFullLog() {
+				c.Off()
+			} else {
+				c.On()
+			}
+		}
+	}
+}
@@ -19,19 +19,23 @@ type Gauge struct {
 }
 
 type gaugeExport struct {
-	Name  string
-	Value int64
+	Name   string
+	Value  int64
+	family string // the name shared by the members of a metric vector
+	labels []prometheusKeyValuePair
 }
 
 func (g *Gauge) Export() exportedMetric {
 	return gaugeExport{
-		g.name,
+		g.Key(),
 		atomic.LoadInt64(&g.value),
+		g.name,
+		g.labels,
 	}
 }
 
 func (g *Gauge) String() string {
-	return fmt.Sprintf("metric %s: %d\n", g.name, atomic.LoadInt64(&g.value))
+	return fmt.Sprintf("metric %s: %d\n", g.Key(), atomic.LoadInt64(&g.value))
 }
 
 func (g *Gauge) Inc() {
@@ -76,7 +80,7 @@ func (g gaugeExport) LogRow() []*log.Field {
 
 func (g gaugeExport) PrometheusRow() []*prometheusRow {
 	return []*prometheusRow{
-		{g.PrometheusName(), "", strconv.FormatInt(g.Value, 10)},
+		{g.PrometheusName(), g.labels, strconv.FormatInt(g.Value, 10)},
 	}
 }
 
@@ -85,5 +89,5 @@ func (g gaugeExport) PrometheusType() string {
 }
 
 func (g gaugeExport) PrometheusName() string {
-	return prometheusName(g.Name)
+	return prometheusName(g.family)
 }
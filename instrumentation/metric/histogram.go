@@ -17,6 +17,7 @@ import (
 type Histogram struct {
 	namedMetric
 	histo         *hdrhistogram.WindowedHistogram
+	buckets       *histogramBuckets
 	overflowCount int64
 }
 
@@ -24,17 +25,16 @@ func newHistogram(name string, max int64, n int) *Histogram {
 	return &Histogram{
 		namedMetric: namedMetric{name: name},
 		histo:       hdrhistogram.NewWindowed(n, 0, max, 1),
+		buckets:     newHistogramBuckets(max),
 	}
 }
 
 func (h *Histogram) RecordSince(t time.Time) {
-	d := time.Since(t).Nanoseconds()
-	if err := h.histo.Current.RecordValue(int64(d)); err != nil {
-		atomic.AddInt64(&h.overflowCount, 1)
-	}
+	h.Record(time.Since(t).Nanoseconds())
 }
 
 func (h *Histogram) Record(measurement int64) {
+	h.buckets.record(measurement)
 	if err := h.histo.Current.RecordValue(measurement); err != nil {
 		atomic.AddInt64(&h.overflowCount, 1)
 	}
@@ -52,7 +52,7 @@ func (h *Histogram) String() string {
 
 	return fmt.Sprintf(
 		"metric %s: [min=%f, p50=%f, p95=%f, p99=%f, max=%f, avg=%f, samples=%d, error rate=%f]\n",
-		h.name,
+		h.Key(),
 		toMillis(histo.Min()),
 		toMillis(histo.ValueAtQuantile(50)),
 		toMillis(histo.ValueAtQuantile(95)),
@@ -67,7 +67,7 @@ func (h *Histogram) Export() exportedMetric {
 	histo := h.histo.Merge()
 
 	return &histogramExport{
-		h.name,
+		h.Key(),
 		toMillis(histo.Min()),
 		toMillis(histo.ValueAtQuantile(50)),
 		toMillis(histo.ValueAtQuantile(95)),
@@ -75,6 +75,9 @@ func (h *Histogram) Export() exportedMetric {
 		toMillis(histo.Max()),
 		floatToMillis(histo.Mean()),
 		histo.TotalCount(),
+		h.name,
+		h.labels,
+		h.buckets.export(),
 	}
 }
 
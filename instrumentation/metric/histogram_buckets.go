@@ -0,0 +1,70 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metric
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+const HISTOGRAM_BUCKETS = 16
+
+// histogramBuckets count every recorded value since the node started. Unlike the windowed percentiles, the counts
+// never go down, so Prometheus can compute rates and quantiles over any range and aggregate them across nodes
+type histogramBuckets struct {
+	upperBounds []int64
+	counts      []int64 // counts[i] holds the values in (upperBounds[i-1], upperBounds[i]], the last one the values above all bounds
+	sum         int64
+}
+
+type histogramBucket struct {
+	upperBound int64
+	count      int64 // cumulative, includes the values of all lower buckets
+}
+
+type histogramBucketsExport struct {
+	buckets []histogramBucket
+	sum     int64
+	count   int64
+}
+
+// bounds grow exponentially up to the max value of the histogram, so they fit both latencies and sizes
+func newHistogramBuckets(max int64) *histogramBuckets {
+	var upperBounds []int64
+	for shift := HISTOGRAM_BUCKETS - 1; shift >= 0; shift-- {
+		bound := max >> uint(shift)
+		if bound > 0 && (len(upperBounds) == 0 || upperBounds[len(upperBounds)-1] < bound) {
+			upperBounds = append(upperBounds, bound)
+		}
+	}
+	return &histogramBuckets{
+		upperBounds: upperBounds,
+		counts:      make([]int64, len(upperBounds)+1),
+	}
+}
+
+func (b *histogramBuckets) record(value int64) {
+	i := sort.Search(len(b.upperBounds), func(i int) bool {
+		return b.upperBounds[i] >= value
+	})
+	atomic.AddInt64(&b.counts[i], 1)
+	atomic.AddInt64(&b.sum, value)
+}
+
+func (b *histogramBuckets) export() *histogramBucketsExport {
+	export := &histogramBucketsExport{
+		buckets: make([]histogramBucket, len(b.upperBounds)),
+		sum:     atomic.LoadInt64(&b.sum),
+	}
+	var cumulative int64
+	for i, upperBound := range b.upperBounds {
+		cumulative += atomic.LoadInt64(&b.counts[i])
+		export.buckets[i] = histogramBucket{upperBound: upperBound, count: cumulative}
+	}
+	export.count = cumulative + atomic.LoadInt64(&b.counts[len(b.upperBounds)])
+	return export
+}
@@ -14,6 +14,9 @@ type histogramExport struct {
 	Max     float64
 	Avg     float64
 	Samples int64
+	family  string // the name shared by the members of a metric vector
+	labels  []prometheusKeyValuePair
+	buckets *histogramBucketsExport
 }
 
 func (h histogramExport) LogRow() []*log.Field {
@@ -34,17 +37,29 @@ func (h histogramExport) LogRow() []*log.Field {
 	}
 }
 
+// Exported as a native Prometheus histogram, values are in the same unit as the percentiles of the log row
 func (h histogramExport) PrometheusRow() []*prometheusRow {
+	if h.buckets == nil {
+		return nil
+	}
+
 	name := h.PrometheusName()
-	return []*prometheusRow{
-		{name, "min", strconv.FormatFloat(h.Min, 'f', -1, 64)},
-		{name, "median", strconv.FormatFloat(h.P50, 'f', -1, 64)},
-		{name, "95p", strconv.FormatFloat(h.P95, 'f', -1, 64)},
-		{name, "99p", strconv.FormatFloat(h.P99, 'f', -1, 64)},
-		{name, "max", strconv.FormatFloat(h.Max, 'f', -1, 64)},
-		{name, "avg", strconv.FormatFloat(h.Avg, 'f', -1, 64)},
-		{name, "count", strconv.FormatFloat(float64(h.Samples), 'f', -1, 64)},
+	var rows []*prometheusRow
+	for _, bucket := range h.buckets.buckets {
+		rows = append(rows, &prometheusRow{name + "_bucket", h.withLabel("le", strconv.FormatFloat(toMillis(bucket.upperBound), 'f', -1, 64)), strconv.FormatInt(bucket.count, 10)})
 	}
+	rows = append(rows,
+		&prometheusRow{name + "_bucket", h.withLabel("le", "+Inf"), strconv.FormatInt(h.buckets.count, 10)},
+		&prometheusRow{name + "_sum", h.labels, strconv.FormatFloat(toMillis(h.buckets.sum), 'f', -1, 64)},
+		&prometheusRow{name + "_count", h.labels, strconv.FormatInt(h.buckets.count, 10)},
+	)
+	return rows
+}
+
+func (h histogramExport) withLabel(name string, value string) []prometheusKeyValuePair {
+	labels := make([]prometheusKeyValuePair, 0, len(h.labels)+1)
+	labels = append(labels, h.labels...)
+	return append(labels, prometheusKeyValuePair{name, value})
 }
 
 func (h histogramExport) PrometheusType() string {
@@ -52,7 +67,7 @@ func (h histogramExport) PrometheusType() string {
 }
 
 func (h histogramExport) PrometheusName() string {
-	return prometheusName(h.Name)
+	return prometheusName(h.family)
 }
 
 func toMillis(nanoseconds int64) float64 {
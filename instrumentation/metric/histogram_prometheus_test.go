@@ -10,6 +10,7 @@ package metric
 import (
 	"fmt"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 	"time"
 )
@@ -30,8 +31,54 @@ func Test_PrometheusFormatterForHistogramWithLabels(t *testing.T) {
 	promStr := r.ExportPrometheus()
 	require.Regexp(t, "# TYPE Some_Latency histogram", promStr)
 	for _, row := range metrics["Some.Latency"].PrometheusRow() {
-		expectedStr := fmt.Sprintf("Some_Latency{vcid=\"100000\",aggregation=\"%s\"} %s",
-			row.aggregationType, row.value)
-		require.Regexp(t, expectedStr, promStr)
+		require.Contains(t, promStr, row.String(prometheusKeyValuePair{"vcid", "100000"}))
 	}
+	require.Contains(t, promStr, `Some_Latency_bucket{vcid="100000",le="+Inf"} 1000`)
+	require.Contains(t, promStr, `Some_Latency_count{vcid="100000"} 1000`)
+	require.Contains(t, promStr, fmt.Sprintf(`Some_Latency_sum{vcid="100000"} %d`, 999*1000/2*1000))
+}
+
+func Test_PrometheusHistogramBucketsAreCumulative(t *testing.T) {
+	r := NewRegistry()
+	histo := r.NewHistogram("Some.Size", 1<<15)
+
+	histo.Record(1)
+	histo.Record(2)
+	histo.Record(3)
+	histo.Record(1 << 20) // above the max value, still counted in the +Inf bucket
+
+	promStr := r.ExportPrometheus()
+	require.Contains(t, promStr, `Some_Size_bucket{le="0.000001"} 1`)
+	require.Contains(t, promStr, `Some_Size_bucket{le="0.000002"} 2`)
+	require.Contains(t, promStr, `Some_Size_bucket{le="0.000004"} 3`)
+	require.Contains(t, promStr, `Some_Size_bucket{le="0.032768"} 3`)
+	require.Contains(t, promStr, `Some_Size_bucket{le="+Inf"} 4`)
+	require.Contains(t, promStr, `Some_Size_count 4`)
+}
+
+func Test_PrometheusHistogramBucketsDoNotRotate(t *testing.T) {
+	r := NewRegistry()
+	histo := r.NewLatency("Some.Latency", time.Second)
+
+	histo.Record(int64(time.Millisecond))
+	for i := 0; i < int(AGGREGATION_SPAN/ROTATE_INTERVAL); i++ {
+		histo.Rotate()
+	}
+
+	require.Contains(t, r.ExportPrometheus(), `Some_Latency_count 1`, "prometheus counters should never go down")
+	require.EqualValues(t, 0, r.ExportAll()["Some.Latency"].(*histogramExport).Samples, "percentiles should only cover the aggregation span")
+}
+
+func TestHistogramVec_ExportsBucketsPerMember(t *testing.T) {
+	r := NewRegistry()
+	vec := r.NewHistogramVec("Processor.Native.ProcessCallTime.Millis", 1<<10, "contract")
+	vec.WithLabels("BenchmarkToken").Record(1)
+	vec.WithLabels("BenchmarkToken").Record(2)
+	vec.WithLabels("_Elections").Record(1)
+
+	promStr := r.ExportPrometheus()
+	require.Equal(t, 1, strings.Count(promStr, "# TYPE Processor_Native_ProcessCallTime_Millis histogram"))
+	require.Contains(t, promStr, `Processor_Native_ProcessCallTime_Millis_count{contract="BenchmarkToken"} 2`)
+	require.Contains(t, promStr, `Processor_Native_ProcessCallTime_Millis_count{contract="_Elections"} 1`)
+	require.Contains(t, promStr, `Processor_Native_ProcessCallTime_Millis_bucket{contract="_Elections",le="+Inf"} 1`)
 }
@@ -26,7 +26,7 @@ func (h Histogram) String() string {
 }
 
 func (h Histogram) Export() exportedMetric {
-	return &histogramExport{}
+	return &histogramExport{Name: h.Key(), family: h.name, labels: h.labels}
 }
 
 func (h *Histogram) Rotate() {
@@ -12,9 +12,9 @@ import (
 )
 
 type prometheusRow struct {
-	name            string
-	aggregationType string
-	value           string
+	name   string
+	labels []prometheusKeyValuePair
+	value  string
 }
 
 type prometheusKeyValuePair struct {
@@ -27,15 +27,9 @@ func prometheusName(name string) string {
 }
 
 // For info on Prometheus labels, see: https://prometheus.io/docs/practices/naming/#labels
-func (r *prometheusRow) wrapLabels(pairs ...prometheusKeyValuePair) string {
+func wrapLabels(pairs ...prometheusKeyValuePair) string {
 	var labels []string
-	pairsCopy := pairs[:]
-
-	if len(r.aggregationType) > 0 {
-		pairsCopy = append(pairsCopy, prometheusKeyValuePair{"aggregation", r.aggregationType})
-	}
-
-	for _, p := range pairsCopy {
+	for _, p := range pairs {
 		labels = append(labels, p.name+`="`+p.value+`"`)
 	}
 
@@ -50,6 +44,10 @@ func quantileAsStr(quantile float64) string {
 	return strconv.FormatFloat(quantile, 'f', -1, 64)
 }
 
+// the registry labels (vcid, node) come first, followed by the labels of the metric and of the row itself
 func (r *prometheusRow) String(labelKeyValues ...prometheusKeyValuePair) string {
-	return r.name + r.wrapLabels(labelKeyValues...) + " " + r.value
+	pairs := make([]prometheusKeyValuePair, 0, len(labelKeyValues)+len(r.labels))
+	pairs = append(pairs, labelKeyValues...)
+	pairs = append(pairs, r.labels...)
+	return r.name + wrapLabels(pairs...) + " " + r.value
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/scribe/log"
 	"github.com/pkg/errors"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -29,6 +30,9 @@ type Factory interface {
 	NewGauge(name string) *Gauge
 	NewRate(name string) *Rate
 	NewText(name string, defaultValue ...string) *Text
+	NewGaugeVec(name string, labelNames ...string) *GaugeVec
+	NewHistogramVec(name string, maxValue int64, labelNames ...string) *HistogramVec
+	NewLatencyVec(name string, maxDuration time.Duration, labelNames ...string) *HistogramVec
 }
 
 type Registry interface {
@@ -53,17 +57,37 @@ type exportedMetric interface {
 type metric interface {
 	fmt.Stringer
 	Name() string
+	Key() string
 	Export() exportedMetric
 }
 
 type namedMetric struct {
-	name string
+	name   string
+	labels []prometheusKeyValuePair // set on the members of a metric vector
 }
 
 func (m *namedMetric) Name() string {
 	return m.name
 }
 
+// Key tells apart the members of a metric vector, which share a name. It is the name of the metric in /metrics and in the
+// log rows, where vector members keep the names they had before metrics were labeled: the label values go before the last
+// part of the name, e.g. Gossip.Topic.BlockSync.QueueSize. Only the Prometheus export uses the labels
+func (m *namedMetric) Key() string {
+	if len(m.labels) == 0 {
+		return m.name
+	}
+	values := make([]string, len(m.labels))
+	for i, label := range m.labels {
+		values[i] = label.value
+	}
+	lastPart := strings.LastIndex(m.name, ".")
+	if lastPart < 0 {
+		return m.name + "." + strings.Join(values, ".")
+	}
+	return m.name[:lastPart] + "." + strings.Join(values, ".") + m.name[lastPart:]
+}
+
 func NewRegistry() *inMemoryRegistry {
 	r := &inMemoryRegistry{}
 	r.mu.metrics = make(map[string]metric)
@@ -135,6 +159,24 @@ func (r *inMemoryRegistry) NewHistogram(name string, maxValue int64) *Histogram
 	return h
 }
 
+func (r *inMemoryRegistry) NewGaugeVec(name string, labelNames ...string) *GaugeVec {
+	return &GaugeVec{metricVec: newMetricVec(r, name, labelNames, func(labels []prometheusKeyValuePair) metric {
+		return &Gauge{namedMetric: namedMetric{name: name, labels: labels}}
+	})}
+}
+
+func (r *inMemoryRegistry) NewLatencyVec(name string, maxDuration time.Duration, labelNames ...string) *HistogramVec {
+	return r.NewHistogramVec(name, maxDuration.Nanoseconds(), labelNames...)
+}
+
+func (r *inMemoryRegistry) NewHistogramVec(name string, maxValue int64, labelNames ...string) *HistogramVec {
+	return &HistogramVec{metricVec: newMetricVec(r, name, labelNames, func(labels []prometheusKeyValuePair) metric {
+		h := newHistogram(name, maxValue, int(AGGREGATION_SPAN/ROTATE_INTERVAL))
+		h.labels = labels
+		return h
+	})}
+}
+
 func (r *inMemoryRegistry) NewText(name string, defaultValue ...string) *Text {
 	m := newText(name, defaultValue...)
 	r.register(m)
@@ -159,7 +201,7 @@ func (r *inMemoryRegistry) ExportAll() map[string]exportedMetric {
 
 	all := make(map[string]exportedMetric)
 	for _, m := range r.mu.metrics {
-		all[m.Name()] = m.Export()
+		all[m.Key()] = m.Export()
 	}
 
 	return all
@@ -201,16 +243,40 @@ func (r *inMemoryRegistry) labels() []prometheusKeyValuePair {
 	return labels
 }
 
+// The members of a metric vector are exported under a single TYPE line, as Prometheus requires all samples of a metric to be grouped together
 func MetricsToPrometheusStrings(metrics map[string]exportedMetric, labels []prometheusKeyValuePair) []string {
-	var rows []string
-	for _, v := range metrics {
-		if v.PrometheusType() != "" {
-			rows = append(rows, fmt.Sprintf("# TYPE %s %s", v.PrometheusName(), v.PrometheusType()))
+	var keys []string
+	for key := range metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var names []string
+	grouped := make(map[string][]exportedMetric)
+	for _, key := range keys {
+		v := metrics[key]
+		if v.PrometheusType() == "" {
+			continue
+		}
+		if _, seen := grouped[v.PrometheusName()]; !seen {
+			names = append(names, v.PrometheusName())
+		}
+		grouped[v.PrometheusName()] = append(grouped[v.PrometheusName()], v)
+	}
 
+	var rows []string
+	for _, name := range names {
+		var samples []string
+		group := grouped[name]
+		for _, v := range group {
 			for _, row := range v.PrometheusRow() {
-				rows = append(rows, row.String(labels...))
+				samples = append(samples, row.String(labels...))
 			}
 		}
+		if len(samples) > 0 {
+			rows = append(rows, fmt.Sprintf("# TYPE %s %s", name, group[0].PrometheusType()))
+			rows = append(rows, samples...)
+		}
 	}
 	return rows
 }
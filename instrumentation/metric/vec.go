@@ -0,0 +1,99 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metric
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"sync"
+)
+
+// metricVec holds the members of a labeled metric, e.g. a gauge per peer or a latency per contract.
+// Members are created and registered on first use, so label values should come from a bounded set
+type metricVec struct {
+	registry   *inMemoryRegistry
+	name       string
+	labelNames []string
+	create     func(labels []prometheusKeyValuePair) metric
+
+	mu      sync.Mutex
+	members map[string]metric
+}
+
+func newMetricVec(registry *inMemoryRegistry, name string, labelNames []string, create func(labels []prometheusKeyValuePair) metric) *metricVec {
+	return &metricVec{
+		registry:   registry,
+		name:       name,
+		labelNames: labelNames,
+		create:     create,
+		members:    make(map[string]metric),
+	}
+}
+
+func (v *metricVec) get(labelValues []string) metric {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key := v.key(labelValues)
+	if m, exists := v.members[key]; exists {
+		return m
+	}
+
+	labels := make([]prometheusKeyValuePair, len(v.labelNames))
+	for i, labelName := range v.labelNames {
+		labels[i] = prometheusKeyValuePair{labelName, labelValues[i]}
+	}
+	m := v.create(labels)
+	v.registry.register(m)
+	v.members[key] = m
+	return m
+}
+
+func (v *metricVec) remove(labelValues []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	key := v.key(labelValues)
+	if m, exists := v.members[key]; exists {
+		v.registry.Remove(m)
+		delete(v.members, key)
+	}
+}
+
+func (v *metricVec) key(labelValues []string) string {
+	if len(labelValues) != len(v.labelNames) {
+		panic(errors.Errorf("metric %s expects %d label values (%s), got %d", v.name, len(v.labelNames), strings.Join(v.labelNames, ", "), len(labelValues)))
+	}
+	return fmt.Sprintf("%q", labelValues)
+}
+
+type GaugeVec struct {
+	*metricVec
+}
+
+// WithLabels returns the gauge for the given label values, in the order the label names were declared
+func (v *GaugeVec) WithLabels(labelValues ...string) *Gauge {
+	return v.get(labelValues).(*Gauge)
+}
+
+func (v *GaugeVec) Remove(labelValues ...string) {
+	v.remove(labelValues)
+}
+
+type HistogramVec struct {
+	*metricVec
+}
+
+// WithLabels returns the histogram for the given label values, in the order the label names were declared
+func (v *HistogramVec) WithLabels(labelValues ...string) *Histogram {
+	return v.get(labelValues).(*Histogram)
+}
+
+func (v *HistogramVec) Remove(labelValues ...string) {
+	v.remove(labelValues)
+}
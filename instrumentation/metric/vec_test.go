@@ -0,0 +1,75 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package metric
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestGaugeVec_ReturnsSameGaugeForSameLabels(t *testing.T) {
+	r := NewRegistry()
+	vec := r.NewGaugeVec("Gossip.Topic.DroppedMessages", "topic")
+
+	vec.WithLabels("BlockSync").Inc()
+	vec.WithLabels("BlockSync").Inc()
+	vec.WithLabels("LeanHelixConsensus").Inc()
+
+	require.EqualValues(t, 2, vec.WithLabels("BlockSync").Value())
+	require.EqualValues(t, 1, vec.WithLabels("LeanHelixConsensus").Value())
+}
+
+func TestGaugeVec_ExportsEachMemberUnderItsLabels(t *testing.T) {
+	r := NewRegistry().WithVirtualChainId(42)
+	vec := r.NewGaugeVec("Gossip.Topic.DroppedMessages", "topic")
+	vec.WithLabels("BlockSync").Update(3)
+	vec.WithLabels("LeanHelixConsensus").Update(5)
+
+	all := r.ExportAll()
+	require.EqualValues(t, 3, all["Gossip.Topic.BlockSync.DroppedMessages"].(gaugeExport).Value)
+	require.EqualValues(t, 5, all["Gossip.Topic.LeanHelixConsensus.DroppedMessages"].(gaugeExport).Value)
+
+	promStr := r.ExportPrometheus()
+	require.Equal(t, 1, strings.Count(promStr, "# TYPE Gossip_Topic_DroppedMessages gauge"), "all members should share a single TYPE line")
+	require.Contains(t, promStr, `Gossip_Topic_DroppedMessages{vcid="42",topic="BlockSync"} 3`)
+	require.Contains(t, promStr, `Gossip_Topic_DroppedMessages{vcid="42",topic="LeanHelixConsensus"} 5`)
+}
+
+func TestGaugeVec_ExportsEachMemberUnderItsNameBeforeLabels(t *testing.T) {
+	r := NewRegistry()
+	r.NewGaugeVec("Gossip.OutgoingConnection.SendError.Count", "peer").WithLabels("a1b2c3").Update(7)
+	r.NewHistogramVec("Some.Histogram.Bytes", 100, "peer", "topic").WithLabels("a1b2c3", "BlockSync").Record(10)
+
+	all := r.ExportAll()
+	require.Contains(t, all, "Gossip.OutgoingConnection.SendError.a1b2c3.Count", "the label values should be part of the name like before metrics were labeled")
+	require.Equal(t, "Gossip.OutgoingConnection.SendError.a1b2c3.Count", all["Gossip.OutgoingConnection.SendError.a1b2c3.Count"].(gaugeExport).Name)
+	require.Contains(t, all, "Some.Histogram.a1b2c3.BlockSync.Bytes")
+	require.Equal(t, "Some.Histogram.a1b2c3.BlockSync.Bytes", all["Some.Histogram.a1b2c3.BlockSync.Bytes"].(*histogramExport).Name)
+	require.Equal(t, "Some_Histogram_Bytes", all["Some.Histogram.a1b2c3.BlockSync.Bytes"].PrometheusName(), "Prometheus should use labels instead")
+}
+
+func TestGaugeVec_RemoveUnregistersMember(t *testing.T) {
+	r := NewRegistry()
+	vec := r.NewGaugeVec("Gossip.OutgoingConnection.SendErrors.Count", "peer")
+	vec.WithLabels("a1b2c3").Inc()
+
+	vec.Remove("a1b2c3")
+	require.Empty(t, r.ExportAll())
+
+	require.EqualValues(t, 0, vec.WithLabels("a1b2c3").Value(), "a removed member should start over when used again")
+	require.Len(t, r.ExportAll(), 1)
+}
+
+func TestMetricVec_PanicsOnWrongNumberOfLabelValues(t *testing.T) {
+	r := NewRegistry()
+	vec := r.NewGaugeVec("Some.Gauge", "peer", "topic")
+
+	require.Panics(t, func() {
+		vec.WithLabels("a1b2c3")
+	})
+}
@@ -26,11 +26,15 @@ type Context struct {
 	created   time.Time
 	name      string
 	requestId string
+	traceId   TraceId
+	spanId    SpanId // the current span, spans started from this context are its children
+	span      *Span  // nil when the current span was started on another node
 }
 
 const RequestTraceName = "X-ORBS-NAME"
 const RequestTraceTime = "X-ORBS-CREATED"
 const RequestTraceRequestId = "X-ORBS-ID"
+const RequestTraceParent = "traceparent" // w3c trace context
 
 func ContextWithNodeId(ctx context.Context, nodeId string) context.Context {
 	return context.WithValue(ctx, NodeIdCtxKey, nodeId)
@@ -52,6 +56,11 @@ func NewFromRequest(ctx context.Context, request *http.Request) context.Context
 		created:   created,
 		requestId: request.Header.Get(RequestTraceRequestId),
 	}
+	if traceId, spanId, err := ParseTraceparent(request.Header.Get(RequestTraceParent)); err == nil {
+		traceContext.traceId, traceContext.spanId = traceId, spanId
+	} else {
+		traceContext.traceId = newTraceId()
+	}
 	return PropagateContext(ctx, traceContext)
 }
 
@@ -59,6 +68,7 @@ func (c *Context) WriteTraceToRequest(request *http.Request) {
 	request.Header.Set(RequestTraceName, c.name)
 	request.Header.Set(RequestTraceTime, c.created.Format(time.RFC3339Nano))
 	request.Header.Set(RequestTraceRequestId, c.requestId)
+	request.Header.Set(RequestTraceParent, c.Traceparent())
 }
 
 func NewContext(parent context.Context, name string) context.Context {
@@ -71,10 +81,49 @@ func NewContext(parent context.Context, name string) context.Context {
 		name:      name,
 		created:   now,
 		requestId: fmt.Sprintf("%s-%s-%d", name, nodeId, now.UnixNano()),
+		traceId:   newTraceId(),
+	}
+	// an entry point reached while handling a traced request (e.g. a gossip message) continues its trace
+	if existing, ok := FromContext(parent); ok && existing.traceId.IsValid() {
+		ep.traceId, ep.spanId = existing.traceId, existing.spanId
 	}
 	return context.WithValue(parent, entryPointKey, ep)
 }
 
+// NewRemoteContext rebuilds the trace context of a request received from another node
+func NewRemoteContext(name string, requestId string, traceId TraceId, spanId SpanId) *Context {
+	return &Context{
+		name:      name,
+		created:   time.Now(),
+		requestId: requestId,
+		traceId:   traceId,
+		spanId:    spanId,
+	}
+}
+
+func (c *Context) Name() string {
+	return c.name
+}
+
+func (c *Context) RequestId() string {
+	return c.requestId
+}
+
+func (c *Context) TraceId() TraceId {
+	return c.traceId
+}
+
+func (c *Context) SpanId() SpanId {
+	return c.spanId
+}
+
+func (c *Context) withSpan(span *Span) *Context {
+	child := *c
+	child.spanId = span.spanId
+	child.span = span
+	return &child
+}
+
 func PropagateContext(parent context.Context, tracingContext *Context) context.Context {
 	return context.WithValue(parent, entryPointKey, tracingContext)
 }
@@ -89,10 +138,14 @@ func (c *Context) NestedFields() []*log.Field {
 		return nil
 	}
 
-	return []*log.Field{
+	fields := []*log.Field{
 		log.String("entry-point", c.name),
 		log.String(RequestId, c.requestId),
 	}
+	if c.traceId.IsValid() {
+		fields = append(fields, log.String("trace-id", c.traceId.String()))
+	}
+	return fields
 }
 
 func LogFieldFrom(ctx context.Context) *log.Field {
@@ -92,3 +92,16 @@ func TestValidateRequestIdFormatWhenNodeIdNotAvailable(t *testing.T) {
 	require.Equal(t, fields[0], entryPoint, "expected entry point in request id to match")
 	require.Equal(t, fields[1], defaultNodeId, "expected node id in request id to match the default id")
 }
+
+func TestTranslateToRequestAndBack_ContinuesTrace(t *testing.T) {
+	ctx, span := NewNoopTracer().StartSpan(context.Background(), "foo")
+	ep, _ := FromContext(ctx)
+
+	request, _ := http.NewRequest("Get", "localhost", nil)
+	ep.WriteTraceToRequest(request)
+
+	ep2, ok := FromContext(NewFromRequest(context.Background(), request))
+	require.True(t, ok)
+	require.Equal(t, span.TraceId(), ep2.TraceId())
+	require.Equal(t, span.SpanId(), ep2.SpanId())
+}
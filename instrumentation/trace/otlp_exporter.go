@@ -0,0 +1,225 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package trace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const OTLP_TRACES_PATH = "/v1/traces"
+const OTLP_EXPORT_TIMEOUT = 5 * time.Second
+const OTLP_MAX_QUEUED_SPANS = 4096
+const OTLP_MAX_BATCH_SIZE = 512
+
+const otlpStatusCodeError = 2
+const otlpSpanKindInternal = 1
+
+// OtlpExporter batches ended spans and posts them to an OTLP/HTTP collector (json encoding), spans are dropped
+// rather than block the node when the collector falls behind
+type OtlpExporter struct {
+	govnr.TreeSupervisor
+	logger   log.Logger
+	url      string
+	resource []otlpAttribute
+	client   *http.Client
+	queue    chan *Span
+	dropped  uint64
+}
+
+// NewOtlpExporter exports to a collector such as http://localhost:4318, resource attributes (node address, virtual
+// chain) are attached to every exported batch
+func NewOtlpExporter(ctx context.Context, endpoint string, exportInterval time.Duration, resource map[string]string, logger log.Logger) *OtlpExporter {
+	e := &OtlpExporter{
+		logger:   logger.WithTags(log.String("component", "otlp-exporter")),
+		url:      strings.TrimSuffix(strings.TrimSuffix(endpoint, "/"), OTLP_TRACES_PATH) + OTLP_TRACES_PATH,
+		resource: otlpAttributes(resource),
+		client:   &http.Client{Timeout: OTLP_EXPORT_TIMEOUT},
+		queue:    make(chan *Span, OTLP_MAX_QUEUED_SPANS),
+	}
+
+	e.Supervise(govnr.Forever(ctx, "otlp span exporter", logfields.GovnrErrorer(e.logger), func() {
+		ticker := time.NewTicker(exportInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				e.flush(context.Background())
+				return
+			case <-ticker.C:
+				e.flush(ctx)
+			}
+		}
+	}))
+	return e
+}
+
+func (e *OtlpExporter) Export(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		atomic.AddUint64(&e.dropped, 1)
+	}
+}
+
+func (e *OtlpExporter) flush(ctx context.Context) {
+	if dropped := atomic.SwapUint64(&e.dropped, 0); dropped > 0 {
+		e.logger.Info("dropped spans, the otlp collector is not keeping up", log.Uint64("dropped-spans", dropped))
+	}
+
+	for {
+		batch := e.nextBatch()
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.post(ctx, batch); err != nil {
+			e.logger.Info("failed exporting spans", log.Error(err), log.String("url", e.url), log.Int("spans", len(batch)))
+			return
+		}
+	}
+}
+
+func (e *OtlpExporter) nextBatch() (batch []*Span) {
+	for len(batch) < OTLP_MAX_BATCH_SIZE {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+		default:
+			return
+		}
+	}
+	return
+}
+
+func (e *OtlpExporter) post(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(otlpRequestFor(e.resource, spans))
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest("POST", e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := e.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return errors.Errorf("collector responded with %s", response.Status)
+	}
+	return nil
+}
+
+// the json mapping of the OTLP ExportTraceServiceRequest, ids are hex and 64 bit integers are strings
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceId           string          `json:"traceId"`
+	SpanId            string          `json:"spanId"`
+	ParentSpanId      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            *otlpStatus     `json:"status,omitempty"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func otlpAttributes(values map[string]string) []otlpAttribute {
+	var attributes []otlpAttribute
+	for key, value := range values {
+		attributes = append(attributes, otlpAttribute{Key: key, Value: otlpAnyValue{StringValue: value}})
+	}
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Key < attributes[j].Key
+	})
+	return attributes
+}
+
+func otlpRequestFor(resource []otlpAttribute, spans []*Span) *otlpRequest {
+	scopeSpans := otlpScopeSpans{Scope: otlpScope{Name: "orbs-network-go"}}
+	for _, span := range spans {
+		scopeSpans.Spans = append(scopeSpans.Spans, otlpSpanOf(span))
+	}
+	return &otlpRequest{ResourceSpans: []otlpResourceSpans{{
+		Resource:   otlpResource{Attributes: resource},
+		ScopeSpans: []otlpScopeSpans{scopeSpans},
+	}}}
+}
+
+func otlpSpanOf(span *Span) otlpSpan {
+	span.mutex.Lock()
+	defer span.mutex.Unlock()
+
+	s := otlpSpan{
+		TraceId:           span.traceId.String(),
+		SpanId:            span.spanId.String(),
+		Name:              span.name,
+		Kind:              otlpSpanKindInternal,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+	}
+	if span.parentSpanId.IsValid() {
+		s.ParentSpanId = span.parentSpanId.String()
+	}
+	for _, attribute := range span.attributes {
+		s.Attributes = append(s.Attributes, otlpAttribute{Key: attribute.key, Value: otlpAnyValue{StringValue: attribute.value}})
+	}
+	if span.err != nil {
+		s.Status = &otlpStatus{Code: otlpStatusCodeError, Message: span.err.Error()}
+	}
+	return s
+}
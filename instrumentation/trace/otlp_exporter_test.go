@@ -0,0 +1,80 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestOtlpExporter_PostsEndedSpansToCollector(t *testing.T) {
+	requests := make(chan *otlpRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, OTLP_TRACES_PATH, r.URL.Path)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		body, _ := ioutil.ReadAll(r.Body)
+		request := &otlpRequest{}
+		require.NoError(t, json.Unmarshal(body, request))
+		requests <- request
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.GetLogger().WithOutput(log.NewFormattingOutput(ioutil.Discard, log.NewHumanReadableFormatter()))
+	exporter := NewOtlpExporter(ctx, server.URL, 10*time.Millisecond, map[string]string{"service.name": "orbs-node"}, logger)
+	tracer := NewTracer(exporter)
+
+	parentCtx, parent := tracer.StartSpan(context.Background(), "parent")
+	_, child := tracer.StartSpan(parentCtx, "child")
+	child.SetAttribute("block-height", "17")
+	child.SetError(errors.New("failed"))
+	child.End()
+	parent.End()
+
+	var request *otlpRequest
+	select {
+	case request = <-requests:
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "collector did not receive spans")
+	}
+
+	require.Len(t, request.ResourceSpans, 1)
+	require.Equal(t, []otlpAttribute{{Key: "service.name", Value: otlpAnyValue{StringValue: "orbs-node"}}}, request.ResourceSpans[0].Resource.Attributes)
+	spans := request.ResourceSpans[0].ScopeSpans[0].Spans
+	require.Len(t, spans, 2)
+
+	exportedChild, exportedParent := spans[0], spans[1]
+	require.Equal(t, "child", exportedChild.Name)
+	require.Equal(t, parent.TraceId().String(), exportedChild.TraceId)
+	require.Equal(t, parent.SpanId().String(), exportedChild.ParentSpanId)
+	require.Equal(t, []otlpAttribute{{Key: "block-height", Value: otlpAnyValue{StringValue: "17"}}}, exportedChild.Attributes)
+	require.Equal(t, &otlpStatus{Code: otlpStatusCodeError, Message: "failed"}, exportedChild.Status)
+
+	require.Equal(t, "parent", exportedParent.Name)
+	require.Empty(t, exportedParent.ParentSpanId)
+	require.Nil(t, exportedParent.Status)
+}
+
+func TestOtlpExporter_DropsSpansWhenQueueIsFull(t *testing.T) {
+	exporter := &OtlpExporter{queue: make(chan *Span, 1)}
+	_, span := NewNoopTracer().StartSpan(context.Background(), "foo")
+
+	exporter.Export(span)
+	exporter.Export(span)
+
+	require.Len(t, exporter.queue, 1)
+	require.EqualValues(t, 1, exporter.dropped)
+}
@@ -0,0 +1,190 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package trace
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+type TraceId [16]byte
+type SpanId [8]byte
+
+func (id TraceId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id TraceId) IsValid() bool {
+	return id != TraceId{}
+}
+
+func (id SpanId) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id SpanId) IsValid() bool {
+	return id != SpanId{}
+}
+
+func newTraceId() (id TraceId) {
+	_, _ = rand.Read(id[:])
+	return
+}
+
+func newSpanId() (id SpanId) {
+	_, _ = rand.Read(id[:])
+	return
+}
+
+// Traceparent formats the trace and current span as a w3c traceparent, always sampled
+func (c *Context) Traceparent() string {
+	return fmt.Sprintf("00-%s-%s-01", c.traceId, c.spanId)
+}
+
+func ParseTraceparent(traceparent string) (traceId TraceId, spanId SpanId, err error) {
+	parts := strings.Split(traceparent, "-")
+	if len(parts) != 4 || parts[0] != "00" {
+		return traceId, spanId, errors.Errorf("unsupported traceparent %q", traceparent)
+	}
+	// the lengths are checked before decoding since the traceparent may come from a peer
+	if len(parts[1]) != hex.EncodedLen(len(traceId)) {
+		return traceId, spanId, errors.Errorf("invalid trace id length in traceparent %q", traceparent)
+	}
+	if n, err := hex.Decode(traceId[:], []byte(parts[1])); err != nil || n != len(traceId) || !traceId.IsValid() {
+		return TraceId{}, spanId, errors.Errorf("invalid trace id in traceparent %q", traceparent)
+	}
+	if len(parts[2]) != hex.EncodedLen(len(spanId)) {
+		return TraceId{}, spanId, errors.Errorf("invalid span id length in traceparent %q", traceparent)
+	}
+	if n, err := hex.Decode(spanId[:], []byte(parts[2])); err != nil || n != len(spanId) || !spanId.IsValid() {
+		return TraceId{}, SpanId{}, errors.Errorf("invalid span id in traceparent %q", traceparent)
+	}
+	return traceId, spanId, nil
+}
+
+type SpanExporter interface {
+	Export(span *Span)
+}
+
+// Tracer starts spans that are children of the span held in the context and hands them to the exporter once ended,
+// a tracer without an exporter still propagates trace ids (to logs and to other nodes)
+type Tracer struct {
+	exporter SpanExporter
+}
+
+func NewTracer(exporter SpanExporter) *Tracer {
+	return &Tracer{exporter: exporter}
+}
+
+func NewNoopTracer() *Tracer {
+	return &Tracer{}
+}
+
+type spanAttribute struct {
+	key   string
+	value string
+}
+
+type Span struct {
+	exporter     SpanExporter
+	traceId      TraceId
+	spanId       SpanId
+	parentSpanId SpanId
+	name         string
+	start        time.Time
+
+	mutex      sync.Mutex
+	end        time.Time
+	attributes []spanAttribute
+	err        error
+}
+
+// StartSpan returns a context holding the new span, pass it on so nested spans and gossip messages become its children
+func (t *Tracer) StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	parent, ok := FromContext(ctx)
+	if !ok || !parent.traceId.IsValid() {
+		ctx = NewContext(ctx, name)
+		parent, _ = FromContext(ctx)
+	}
+
+	span := &Span{
+		exporter:     t.exporter,
+		traceId:      parent.traceId,
+		spanId:       newSpanId(),
+		parentSpanId: parent.spanId,
+		name:         name,
+		start:        time.Now(),
+	}
+	return PropagateContext(ctx, parent.withSpan(span)), span
+}
+
+// SpanFromContext returns the span started in this node that the context is in, or nil (which is safe to use)
+func SpanFromContext(ctx context.Context) *Span {
+	if c, ok := FromContext(ctx); ok {
+		return c.span
+	}
+	return nil
+}
+
+func (s *Span) SetAttribute(key string, value string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.attributes = append(s.attributes, spanAttribute{key: key, value: value})
+}
+
+// SetError marks the span as failed, nil errors are ignored so the result of a call can be passed as is
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.err = err
+}
+
+// End is safe to call more than once, only the first call records the span
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	if !s.end.IsZero() {
+		s.mutex.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mutex.Unlock()
+
+	if s.exporter != nil {
+		s.exporter.Export(s)
+	}
+}
+
+func (s *Span) Name() string {
+	return s.name
+}
+
+func (s *Span) TraceId() TraceId {
+	return s.traceId
+}
+
+func (s *Span) SpanId() SpanId {
+	return s.spanId
+}
+
+func (s *Span) ParentSpanId() SpanId {
+	return s.parentSpanId
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package trace
+
+import (
+	"context"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+type recordingExporter struct {
+	sync.Mutex
+	spans []*Span
+}
+
+func (e *recordingExporter) Export(span *Span) {
+	e.Lock()
+	defer e.Unlock()
+	e.spans = append(e.spans, span)
+}
+
+func TestStartSpan_NestedSpansShareTraceAndParent(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter)
+
+	ctx, parent := tracer.StartSpan(context.Background(), "parent")
+	require.True(t, parent.TraceId().IsValid())
+	require.False(t, parent.ParentSpanId().IsValid(), "a span started without a trace should be a root span")
+	require.Equal(t, parent, SpanFromContext(ctx))
+
+	childCtx, child := tracer.StartSpan(ctx, "child")
+	require.Equal(t, parent.TraceId(), child.TraceId())
+	require.Equal(t, parent.SpanId(), child.ParentSpanId())
+	require.NotEqual(t, parent.SpanId(), child.SpanId())
+	require.Equal(t, child, SpanFromContext(childCtx))
+
+	child.End()
+	parent.End()
+	parent.End()
+	require.Len(t, exporter.spans, 2, "each span should be exported once")
+	require.Equal(t, "child", exporter.spans[0].Name())
+	require.Equal(t, "parent", exporter.spans[1].Name())
+}
+
+func TestStartSpan_ContinuesTraceOfExistingContext(t *testing.T) {
+	ctx := NewContext(context.Background(), "foo")
+	ep, _ := FromContext(ctx)
+
+	_, span := NewNoopTracer().StartSpan(ctx, "bar")
+	require.Equal(t, ep.TraceId(), span.TraceId())
+
+	remote := NewRemoteContext("Gossip.Received", "some-request", span.TraceId(), span.SpanId())
+	remoteCtx := NewContext(PropagateContext(context.Background(), remote), "baz")
+	_, remoteSpan := NewNoopTracer().StartSpan(remoteCtx, "baz")
+	require.Equal(t, span.TraceId(), remoteSpan.TraceId(), "a new entry point should continue the trace of the context it was reached from")
+	require.Equal(t, span.SpanId(), remoteSpan.ParentSpanId())
+}
+
+func TestTraceparent_RoundTrips(t *testing.T) {
+	ctx, span := NewNoopTracer().StartSpan(context.Background(), "foo")
+	ep, _ := FromContext(ctx)
+
+	traceId, spanId, err := ParseTraceparent(ep.Traceparent())
+	require.NoError(t, err)
+	require.Equal(t, span.TraceId(), traceId)
+	require.Equal(t, span.SpanId(), spanId)
+
+	for _, invalid := range []string{
+		"",
+		"01-" + traceId.String() + "-" + spanId.String() + "-01",
+		"00-" + TraceId{}.String() + "-" + spanId.String() + "-01",
+		"00-abcd-" + spanId.String() + "-01",
+		"00-" + traceId.String() + "-xyz-01",
+		"00-" + traceId.String() + "ab-" + spanId.String() + "-01",
+		"00-" + traceId.String() + "-" + spanId.String() + "ab-01",
+		"00-" + traceId.String() + "-" + SpanId{}.String() + "-01",
+	} {
+		_, _, err := ParseTraceparent(invalid)
+		require.Error(t, err, "should not parse %q", invalid)
+	}
+}
+
+func TestSpan_NilSpanIsSafeToUse(t *testing.T) {
+	span := SpanFromContext(context.Background())
+	require.Nil(t, span)
+
+	span.SetAttribute("foo", "bar")
+	span.SetError(errors.New("failed"))
+	span.End()
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package main
+
+import (
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+const keysUsage = `usage: orbs-node keys <generate|import|export> --keystore path/to/keystore.json [--passphrase-file path/to/passphrase]
+
+  generate  creates a new node key and writes it encrypted to the keystore
+  import    encrypts the hex private key in --private-key-file into the keystore
+  export    prints the hex private key held in the keystore
+
+the passphrase is read from --passphrase-file, or from ` + config.KEYSTORE_PASSPHRASE_ENV_VAR + ` when no file is given
+`
+
+// runKeysCommand handles `orbs-node keys ...`, the node address is printed for generate and import
+func runKeysCommand(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(keysUsage)
+	}
+
+	flags := flag.NewFlagSet("keys "+args[0], flag.ContinueOnError)
+	keystorePath := flags.String("keystore", "", "path/to/keystore.json")
+	passphraseFile := flags.String("passphrase-file", "", "path/to/passphrase")
+	privateKeyFile := flags.String("private-key-file", "", "path/to/private-key (hex) to import")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+	if *keystorePath == "" {
+		return errors.New(keysUsage)
+	}
+
+	passphrase, err := config.ReadKeystorePassphrase(*passphraseFile, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "generate":
+		privateKey, err := config.GenerateNodeKey()
+		if err != nil {
+			return err
+		}
+		return writeKeystore(*keystorePath, privateKey, passphrase, out)
+	case "import":
+		if *privateKeyFile == "" {
+			return errors.New("import requires --private-key-file")
+		}
+		contents, err := ioutil.ReadFile(*privateKeyFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read private key file")
+		}
+		privateKey, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(contents)), "0x"))
+		if err != nil {
+			return errors.Wrap(err, "private key file should hold a hex private key")
+		}
+		return writeKeystore(*keystorePath, privateKey, passphrase, out)
+	case "export":
+		keystoreJson, err := ioutil.ReadFile(*keystorePath)
+		if err != nil {
+			return errors.Wrap(err, "could not read keystore")
+		}
+		_, privateKey, err := config.DecryptNodeKey(keystoreJson, passphrase)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, hex.EncodeToString(privateKey))
+		return err
+	default:
+		return errors.Errorf("unknown keys command %s\n%s", args[0], keysUsage)
+	}
+}
+
+// an existing keystore is never overwritten, the key it holds would be lost
+func writeKeystore(keystorePath string, privateKey primitives.EcdsaSecp256K1PrivateKey, passphrase string, out io.Writer) error {
+	address, err := config.NodeAddressOfPrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	keystoreJson, err := config.EncryptNodeKey(privateKey, passphrase)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(keystorePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Wrap(err, "could not create keystore")
+	}
+	defer f.Close()
+	if _, err := f.Write(keystoreJson); err != nil {
+		return errors.Wrap(err, "could not write keystore")
+	}
+
+	_, err = fmt.Fprintln(out, address.String())
+	return err
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/orbs-network/orbs-network-go/bootstrap"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation"
+	managementAdapter "github.com/orbs-network/orbs-network-go/services/management/adapter"
+	"github.com/orbs-network/orbs-network-go/services/processor/native"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sandbox"
 	"github.com/orbs-network/orbs-network-go/synchronization/supervised"
 	"github.com/orbs-network/scribe/log"
 	"github.com/pkg/errors"
@@ -20,6 +23,22 @@ import (
 )
 
 func main() {
+	if sandbox.IsChildProcess() {
+		if err := native.ServeSandbox(); err != nil {
+			fmt.Fprintln(os.Stderr, "native sandbox child failed:", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "keys" {
+		if err := runKeysCommand(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
 	logger := instrumentation.GetBootstrapCrashLogger()
 	var node *bootstrap.Node
 	func() { // context of bootstrap crash logging
@@ -33,6 +52,8 @@ func main() {
 		silentLog := flag.Bool("silent", false, "disable output to stdout")
 		pathToLog := flag.String("log", "", "path/to/node.log")
 		version := flag.Bool("version", false, "returns information about version")
+		printConfig := flag.Bool("print-config", false, "prints the effective configuration with secrets redacted, then exit")
+		exportManagementBundle := flag.String("export-management-bundle", "", "path/to/bundle.json to export the historic management cache to, then exit")
 
 		var configFiles config.ArrayFlags
 		flag.Var(&configFiles, "config", "path/to/config.json")
@@ -44,11 +65,28 @@ func main() {
 			os.Exit(0)
 		}
 
-		cfg, err := config.GetNodeConfigFromFiles(configFiles, *httpAddress)
+		reloader, err := config.NewReloader(configFiles, *httpAddress)
 		if err != nil {
 			logger.Error("error reading configuration", log.Error(err))
 			os.Exit(1)
 		}
+		cfg := reloader.Config()
+
+		if *printConfig {
+			if err := config.PrintConfig(cfg, os.Stdout); err != nil {
+				logger.Error("error printing configuration", log.Error(err))
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
+
+		if *exportManagementBundle != "" {
+			if err := exportManagementHistoricBundle(cfg.ManagementHistoricCacheDir(), *exportManagementBundle); err != nil {
+				logger.Error("error exporting management historic bundle", log.Error(err))
+				os.Exit(1)
+			}
+			os.Exit(0)
+		}
 
 		logger = instrumentation.GetLogger(*pathToLog, *silentLog, cfg)
 
@@ -57,6 +95,9 @@ func main() {
 			logger,
 		)
 
+		node.RegisterConfigReloader(reloader)
+		bootstrap.ListenToOSConfigReloadSignal(context.Background(), logger, reloader)
+
 		supervised.NewShutdownListener(logger, node).ListenToOSShutdownSignal()
 	}()
 	defer func() {
@@ -67,3 +108,12 @@ func main() {
 	}()
 	node.WaitUntilShutdown(context.Background())
 }
+
+func exportManagementHistoricBundle(cacheDir string, bundlePath string) error {
+	f, err := os.Create(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return managementAdapter.ExportHistoricBundle(cacheDir, f)
+}
@@ -56,6 +56,12 @@ func (s *Service) sourceHandleBlockAvailabilityRequest(ctx context.Context, mess
 		return nil
 	}
 
+	if !s.syncServer.canAdmit(message.Sender.SenderNodeAddress()) {
+		logger.Info("server is busy serving other nodes, not responding to availability request")
+		s.syncServer.skippedAvailabilityResponse()
+		return nil
+	}
+
 	response := &gossiptopics.BlockAvailabilityResponseInput{
 		RecipientNodeAddress: message.Sender.SenderNodeAddress(),
 		Message: &gossipmessages.BlockAvailabilityResponseMessage{
@@ -165,6 +171,13 @@ func (s *Service) sourceHandleBlockSyncRequest(ctx context.Context, message *gos
 		return err
 	}
 
+	release, err := s.syncServer.acquire(ctx, senderNodeAddress)
+	if err != nil {
+		logger.Info("block sync chunk request was not admitted", log.Error(err), log.Stringable("petitioner", senderNodeAddress))
+		return err
+	}
+	defer release()
+
 	var blocks []*protocol.BlockPairContainer
 	if requestSyncBlocksOrder == gossipmessages.SYNC_BLOCKS_ORDER_ASCENDING || requestSyncBlocksOrder == gossipmessages.SYNC_BLOCKS_ORDER_RESERVED {
 		if responseFrom > responseTo {
@@ -184,7 +197,7 @@ func (s *Service) sourceHandleBlockSyncRequest(ctx context.Context, message *gos
 	if err != nil {
 		return errors.Wrap(err, "block sync failed reading from block persistence")
 	}
-	chunkSize := uint(len(blocks))
+	chunkSize := uint(s.syncServer.limitChunkToBudget(blocks))
 	for {
 		if chunkSize > 0 {
 			responseTo = blocks[chunkSize-1].TransactionsBlock.Header.BlockHeight()
@@ -215,6 +228,10 @@ func (s *Service) sourceHandleBlockSyncRequest(ctx context.Context, message *gos
 			log.Stringable("blocks-order", response.Message.SignedChunkRange.BlocksOrder()),
 			log.Uint("chunk-size", chunkSize))
 
+		if err := s.syncServer.waitForBandwidth(ctx, blocks[:chunkSize]); err != nil {
+			return errors.Wrap(err, "block sync aborted while waiting for bandwidth")
+		}
+
 		_, err = s.gossip.SendBlockSyncResponse(ctx, response)
 		if err != nil {
 			if !gossip.IsDataExceedsCapacityError(err) { // A non chunk-size related error, return immediately
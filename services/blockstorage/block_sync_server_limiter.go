@@ -0,0 +1,270 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockstorage
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+var ErrSyncServerBusy = errors.New("block sync server is busy serving other requestors, request was not admitted")
+
+type syncServerLimiterConfig interface {
+	BlockSyncServerMaxConcurrentRequestors() uint32
+	BlockSyncServerMaxBytesPerSecond() uint32
+	BlockSyncServerQueueTimeout() time.Duration
+	BlockSyncCollectChunksTimeout() time.Duration
+}
+
+type syncServerMetrics struct {
+	activeRequestors      *metric.Gauge
+	queuedRequests        *metric.Gauge
+	rejectedRequests      *metric.Gauge
+	skippedAvailabilities *metric.Gauge
+	throttledTime         *metric.Histogram
+	sentBytes             *metric.Rate
+}
+
+func newSyncServerMetrics(m metric.Factory) *syncServerMetrics {
+	return &syncServerMetrics{
+		activeRequestors:      m.NewGauge("BlockSync.Server.ActiveRequestors.Count"),
+		queuedRequests:        m.NewGauge("BlockSync.Server.QueuedRequests.Count"),
+		rejectedRequests:      m.NewGauge("BlockSync.Server.RejectedRequests.Count"),
+		skippedAvailabilities: m.NewGauge("BlockSync.Server.SkippedAvailabilityResponses.Count"),
+		throttledTime:         m.NewLatency("BlockSync.Server.Throttled.Millis", time.Minute),
+		sentBytes:             m.NewRate("BlockSync.Server.SentBytes.PerSecond"),
+	}
+}
+
+type syncServerWaiter struct {
+	requestor string
+	admitted  chan struct{}
+}
+
+// syncServerLimiter is the admission control of the block sync source side: at most BlockSyncServerMaxConcurrentRequestors peers are served at once,
+// each peer is served one request at a time, waiting peers are admitted in arrival order (so a busy peer is not served again before the others had their turn),
+// and the blocks sent to all peers share a budget of BlockSyncServerMaxBytesPerSecond
+type syncServerLimiter struct {
+	config  syncServerLimiterConfig
+	metrics *syncServerMetrics
+
+	mutex        sync.Mutex
+	active       map[string]bool
+	waiting      []*syncServerWaiter
+	nextSendTime time.Time
+}
+
+func newSyncServerLimiter(config syncServerLimiterConfig, metricFactory metric.Factory) *syncServerLimiter {
+	return &syncServerLimiter{
+		config:  config,
+		metrics: newSyncServerMetrics(metricFactory),
+		active:  make(map[string]bool),
+	}
+}
+
+// a queued request is useless once the requestor stopped waiting for the chunk
+func (l *syncServerLimiter) queueTimeout() time.Duration {
+	timeout := l.config.BlockSyncServerQueueTimeout()
+	if chunksTimeout := l.config.BlockSyncCollectChunksTimeout(); chunksTimeout > 0 && (timeout == 0 || chunksTimeout < timeout) {
+		timeout = chunksTimeout
+	}
+	return timeout
+}
+
+// used when answering availability requests, peers are not offered blocks we could not serve before they give up on us
+func (l *syncServerLimiter) canAdmit(requestor primitives.NodeAddress) bool {
+	maxRequestors := int(l.config.BlockSyncServerMaxConcurrentRequestors())
+	if maxRequestors == 0 {
+		return true
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.active[requestor.KeyForMap()] {
+		return true
+	}
+	return len(l.active) < maxRequestors && len(l.waiting) == 0
+}
+
+func (l *syncServerLimiter) skippedAvailabilityResponse() {
+	l.metrics.skippedAvailabilities.Inc()
+}
+
+// blocks until the request is admitted, the returned release func must be called once the request was served
+func (l *syncServerLimiter) acquire(ctx context.Context, requestor primitives.NodeAddress) (func(), error) {
+	maxRequestors := int(l.config.BlockSyncServerMaxConcurrentRequestors())
+	if maxRequestors == 0 {
+		return func() {}, nil
+	}
+
+	key := requestor.KeyForMap()
+	release := func() { l.release(key) }
+
+	l.mutex.Lock()
+	if !l.active[key] && len(l.active) < maxRequestors {
+		l.active[key] = true
+		l.updateGaugesUnderMutex()
+		l.mutex.Unlock()
+		return release, nil
+	}
+	for _, w := range l.waiting {
+		if w.requestor == key {
+			l.mutex.Unlock()
+			l.metrics.rejectedRequests.Inc()
+			return nil, errors.Wrap(ErrSyncServerBusy, "requestor already has a queued request")
+		}
+	}
+	waiter := &syncServerWaiter{requestor: key, admitted: make(chan struct{})}
+	l.waiting = append(l.waiting, waiter)
+	l.updateGaugesUnderMutex()
+	l.mutex.Unlock()
+
+	var timeout <-chan time.Time
+	if queueTimeout := l.queueTimeout(); queueTimeout > 0 {
+		timer := time.NewTimer(queueTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-waiter.admitted:
+		return release, nil
+	case <-ctx.Done():
+		return nil, l.abandon(waiter, ctx.Err())
+	case <-timeout:
+		l.metrics.rejectedRequests.Inc()
+		return nil, l.abandon(waiter, ErrSyncServerBusy)
+	}
+}
+
+func (l *syncServerLimiter) abandon(waiter *syncServerWaiter, err error) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	for i, w := range l.waiting {
+		if w == waiter {
+			l.waiting = append(l.waiting[:i], l.waiting[i+1:]...)
+			l.updateGaugesUnderMutex()
+			return err
+		}
+	}
+
+	// admitted concurrently with giving up, hand the slot over to the next waiter
+	delete(l.active, waiter.requestor)
+	l.admitWaitingUnderMutex()
+	return err
+}
+
+func (l *syncServerLimiter) release(key string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	delete(l.active, key)
+	l.admitWaitingUnderMutex()
+}
+
+func (l *syncServerLimiter) admitWaitingUnderMutex() {
+	maxRequestors := int(l.config.BlockSyncServerMaxConcurrentRequestors())
+	remaining := l.waiting[:0]
+	for _, w := range l.waiting {
+		if len(l.active) < maxRequestors && !l.active[w.requestor] {
+			l.active[w.requestor] = true
+			close(w.admitted)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	for i := len(remaining); i < len(l.waiting); i++ {
+		l.waiting[i] = nil
+	}
+	l.waiting = remaining
+	l.updateGaugesUnderMutex()
+}
+
+func (l *syncServerLimiter) updateGaugesUnderMutex() {
+	l.metrics.activeRequestors.Update(int64(len(l.active)))
+	l.metrics.queuedRequests.Update(int64(len(l.waiting)))
+}
+
+// returns how many of the blocks (at least one) fit in a single second of the bandwidth budget
+func (l *syncServerLimiter) limitChunkToBudget(blocks []*protocol.BlockPairContainer) int {
+	budget := int(l.config.BlockSyncServerMaxBytesPerSecond())
+	if budget == 0 {
+		return len(blocks)
+	}
+
+	total := 0
+	for i, block := range blocks {
+		total += blockPairSizeInBytes(block)
+		if total > budget && i > 0 {
+			return i
+		}
+	}
+	return len(blocks)
+}
+
+// reserves bandwidth for sending the blocks, waiting until the shared budget allows the send
+func (l *syncServerLimiter) waitForBandwidth(ctx context.Context, blocks []*protocol.BlockPairContainer) error {
+	bytes := 0
+	for _, block := range blocks {
+		bytes += blockPairSizeInBytes(block)
+	}
+	l.metrics.sentBytes.Measure(int64(bytes))
+
+	budget := l.config.BlockSyncServerMaxBytesPerSecond()
+	if budget == 0 {
+		return nil
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	if l.nextSendTime.Before(now) {
+		l.nextSendTime = now
+	}
+	sendAt := l.nextSendTime
+	l.nextSendTime = l.nextSendTime.Add(time.Duration(int64(bytes) * int64(time.Second) / int64(budget)))
+	l.mutex.Unlock()
+
+	delay := sendAt.Sub(now)
+	if delay <= 0 {
+		return nil
+	}
+	l.metrics.throttledTime.Record(delay.Nanoseconds())
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func blockPairSizeInBytes(block *protocol.BlockPairContainer) int {
+	txBlock := block.TransactionsBlock
+	size := len(txBlock.Header.Raw()) + len(txBlock.Metadata.Raw()) + len(txBlock.BlockProof.Raw())
+	for _, tx := range txBlock.SignedTransactions {
+		size += len(tx.Raw())
+	}
+
+	rsBlock := block.ResultsBlock
+	size += len(rsBlock.Header.Raw()) + len(rsBlock.BlockProof.Raw())
+	for _, receipt := range rsBlock.TransactionReceipts {
+		size += len(receipt.Raw())
+	}
+	for _, diff := range rsBlock.ContractStateDiffs {
+		size += len(diff.Raw())
+	}
+	return size
+}
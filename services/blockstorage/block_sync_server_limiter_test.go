@@ -0,0 +1,201 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockstorage
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type limiterConfigForTests struct {
+	maxRequestors  uint32
+	maxBytesPerSec uint32
+	queueTimeout   time.Duration
+}
+
+func (c *limiterConfigForTests) BlockSyncServerMaxConcurrentRequestors() uint32 {
+	return c.maxRequestors
+}
+
+func (c *limiterConfigForTests) BlockSyncServerMaxBytesPerSecond() uint32 {
+	return c.maxBytesPerSec
+}
+
+func (c *limiterConfigForTests) BlockSyncServerQueueTimeout() time.Duration {
+	return c.queueTimeout
+}
+
+func (c *limiterConfigForTests) BlockSyncCollectChunksTimeout() time.Duration {
+	return 5 * time.Second
+}
+
+func requestor(i int) primitives.NodeAddress {
+	return keys.EcdsaSecp256K1KeyPairForTests(i).NodeAddress()
+}
+
+func acquireAsync(ctx context.Context, l *syncServerLimiter, address primitives.NodeAddress) chan func() {
+	admitted := make(chan func(), 1)
+	go func() {
+		release, err := l.acquire(ctx, address)
+		if err == nil {
+			admitted <- release
+		}
+	}()
+	return admitted
+}
+
+func requireAdmitted(t *testing.T, admitted chan func(), msg string) func() {
+	select {
+	case release := <-admitted:
+		return release
+	case <-time.After(time.Second):
+		require.Fail(t, msg)
+		return nil
+	}
+}
+
+func requireNotAdmitted(t *testing.T, admitted chan func(), msg string) {
+	select {
+	case <-admitted:
+		require.Fail(t, msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func waitForQueuedRequests(t *testing.T, l *syncServerLimiter, count int) {
+	require.Eventually(t, func() bool {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		return len(l.waiting) == count
+	}, time.Second, time.Millisecond)
+}
+
+func TestSyncServerLimiter_AdmitsUpToMaxConcurrentRequestors(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newSyncServerLimiter(&limiterConfigForTests{maxRequestors: 2}, metric.NewRegistry())
+
+	release1, err := l.acquire(ctx, requestor(1))
+	require.NoError(t, err)
+	_, err = l.acquire(ctx, requestor(2))
+	require.NoError(t, err)
+
+	require.False(t, l.canAdmit(requestor(3)), "availability should not be offered while all slots are taken")
+	require.True(t, l.canAdmit(requestor(1)), "an already served requestor should still be offered availability")
+
+	admitted := acquireAsync(ctx, l, requestor(3))
+	requireNotAdmitted(t, admitted, "third requestor should wait while all slots are taken")
+
+	release1()
+	requireAdmitted(t, admitted, "third requestor should be admitted once a slot was released")
+}
+
+func TestSyncServerLimiter_ServesEachRequestorOneRequestAtATime(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newSyncServerLimiter(&limiterConfigForTests{maxRequestors: 2}, metric.NewRegistry())
+
+	release, err := l.acquire(ctx, requestor(1))
+	require.NoError(t, err)
+
+	admitted := acquireAsync(ctx, l, requestor(1))
+	waitForQueuedRequests(t, l, 1)
+	requireNotAdmitted(t, admitted, "a second request of the same requestor should wait for the first one")
+
+	_, err = l.acquire(ctx, requestor(1))
+	require.True(t, errors.Is(err, ErrSyncServerBusy), "a third request of the same requestor should be rejected")
+
+	release()
+	requireAdmitted(t, admitted, "second request should be admitted once the first was served")
+}
+
+func TestSyncServerLimiter_AdmitsWaitingRequestorsInTurn(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newSyncServerLimiter(&limiterConfigForTests{maxRequestors: 1}, metric.NewRegistry())
+
+	releaseFirst, err := l.acquire(ctx, requestor(1))
+	require.NoError(t, err)
+
+	admitted2 := acquireAsync(ctx, l, requestor(2))
+	waitForQueuedRequests(t, l, 1)
+	admitted3 := acquireAsync(ctx, l, requestor(3))
+	waitForQueuedRequests(t, l, 2)
+
+	releaseFirst()
+	release2 := requireAdmitted(t, admitted2, "first waiting requestor should be admitted first")
+
+	admitted1 := acquireAsync(ctx, l, requestor(1))
+	waitForQueuedRequests(t, l, 2)
+
+	release2()
+	release3 := requireAdmitted(t, admitted3, "requestor 3 waited longer than requestor 1 and should be served before it")
+	requireNotAdmitted(t, admitted1, "requestor 1 should wait for its turn")
+
+	release3()
+	requireAdmitted(t, admitted1, "requestor 1 should eventually be served")
+}
+
+func TestSyncServerLimiter_RejectsRequestsQueuedForTooLong(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newSyncServerLimiter(&limiterConfigForTests{maxRequestors: 1, queueTimeout: 20 * time.Millisecond}, metric.NewRegistry())
+
+	_, err := l.acquire(ctx, requestor(1))
+	require.NoError(t, err)
+
+	_, err = l.acquire(ctx, requestor(2))
+	require.Equal(t, ErrSyncServerBusy, err)
+	require.Empty(t, l.waiting, "rejected request should leave the queue")
+	require.EqualValues(t, 1, l.metrics.rejectedRequests.Value())
+}
+
+func TestSyncServerLimiter_UnlimitedByDefault(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l := newSyncServerLimiter(&limiterConfigForTests{}, metric.NewRegistry())
+
+	for i := 0; i < 10; i++ {
+		_, err := l.acquire(ctx, requestor(1))
+		require.NoError(t, err)
+	}
+	require.True(t, l.canAdmit(requestor(2)))
+
+	blocks := []*protocol.BlockPairContainer{builders.BlockPair().Build(), builders.BlockPair().Build()}
+	require.Equal(t, 2, l.limitChunkToBudget(blocks))
+	require.NoError(t, l.waitForBandwidth(ctx, blocks))
+}
+
+func TestSyncServerLimiter_PacesSentBytesToBudget(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	block := builders.BlockPair().WithTransactions(3).Build()
+	blockSize := blockPairSizeInBytes(block)
+	blocks := []*protocol.BlockPairContainer{block, block, block}
+
+	// a budget of two blocks every 200 milliseconds
+	l := newSyncServerLimiter(&limiterConfigForTests{maxBytesPerSec: uint32(blockSize * 10)}, metric.NewRegistry())
+
+	require.Equal(t, 3, l.limitChunkToBudget(blocks), "chunk within a second of budget should not be cut")
+	l.config.(*limiterConfigForTests).maxBytesPerSec = uint32(blockSize * 2)
+	require.Equal(t, 2, l.limitChunkToBudget(blocks), "chunk should be cut to a second of budget")
+	l.config.(*limiterConfigForTests).maxBytesPerSec = uint32(blockSize * 10)
+
+	start := time.Now()
+	require.NoError(t, l.waitForBandwidth(ctx, blocks[:2]))
+	require.NoError(t, l.waitForBandwidth(ctx, blocks[:2]))
+	require.True(t, time.Since(start) >= 150*time.Millisecond, "second send should wait for the budget of the first one, waited %s", time.Since(start))
+}
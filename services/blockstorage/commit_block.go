@@ -18,11 +18,19 @@ import (
 )
 
 func (s *Service) NodeSyncCommitBlock(ctx context.Context, input *services.CommitBlockInput) (*services.CommitBlockOutput, error) {
-	return s.commitBlock(ctx, input, false)
+	ctx, span := s.tracer.StartSpan(ctx, "BlockStorage.NodeSyncCommitBlock")
+	defer span.End()
+	out, err := s.commitBlock(ctx, input, false)
+	span.SetError(err)
+	return out, err
 }
 
 func (s *Service) CommitBlock(ctx context.Context, input *services.CommitBlockInput) (*services.CommitBlockOutput, error) {
-	return s.commitBlock(ctx, input, true)
+	ctx, span := s.tracer.StartSpan(ctx, "BlockStorage.CommitBlock")
+	defer span.End()
+	out, err := s.commitBlock(ctx, input, true)
+	span.SetError(err)
+	return out, err
 }
 
 func (s *Service) commitBlock(ctx context.Context, input *services.CommitBlockInput, notifyNodeSync bool) (*services.CommitBlockOutput, error) {
@@ -31,6 +39,7 @@ func (s *Service) commitBlock(ctx context.Context, input *services.CommitBlockIn
 		return nil, fmt.Errorf("attempt to commit a nil block")
 	}
 	proposedBlockHeight := input.BlockPair.TransactionsBlock.Header.BlockHeight()
+	trace.SpanFromContext(ctx).SetAttribute("block-height", proposedBlockHeight.String())
 	logger.Info("Trying to commit a block", logfields.BlockHeight(proposedBlockHeight))
 
 	if err := s.validateProtocolVersion(input.BlockPair); err != nil {
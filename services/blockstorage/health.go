@@ -0,0 +1,70 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package blockstorage
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"time"
+)
+
+const minTimeSinceLastCommittedBlock = 10 * time.Minute
+const emptyBlockIntervalsSinceLastCommittedBlock = 10
+
+// A node that is behind the network is syncing, a node that is not behind the network and still did not
+// commit a block for a long time is failing. Both only affect readiness: when the whole network stops closing
+// blocks every node would fail the check, and restarting all of them would not help
+func (s *Service) RegisterHealthChecks(registry health.Registry) {
+	registry.Register("block-sync", health.SEVERITY_WARNING, s.blockSyncHealth)
+	registry.Register("last-committed-block", health.SEVERITY_WARNING, s.lastCommittedBlockHealth)
+}
+
+func (s *Service) blockSyncHealth(ctx context.Context) health.Result {
+	lastBlock, err := s.persistence.GetLastBlock()
+	if err != nil {
+		return health.Failing("could not read the last committed block: %s", err)
+	}
+	height := getBlockHeight(lastBlock)
+	networkHeight := s.nodeSync.NetworkHeight()
+
+	// a node within one sync batch of the network is in sync
+	if networkHeight > height+primitives.BlockHeight(s.config.BlockSyncNumBlocksInBatch()) {
+		return health.Syncing("%d blocks behind the network, at height %d of %d", networkHeight-height, height, networkHeight)
+	}
+	return health.Ok("at height %d, the highest height reported by peers is %d", height, networkHeight)
+}
+
+func (s *Service) lastCommittedBlockHealth(ctx context.Context) health.Result {
+	lastBlock, err := s.persistence.GetLastBlock()
+	if err != nil {
+		return health.Failing("could not read the last committed block: %s", err)
+	}
+	height := getBlockHeight(lastBlock)
+	networkHeight := s.nodeSync.NetworkHeight()
+
+	if height == 0 {
+		return health.Syncing("no blocks were committed yet, the highest height reported by peers is %d", networkHeight)
+	}
+
+	sinceLastBlock := time.Since(time.Unix(0, int64(getBlockTimestamp(lastBlock)))).Round(time.Second)
+	if sinceLastBlock <= s.maxTimeSinceLastCommittedBlock() {
+		return health.Ok("block %d was committed %s ago", height, sinceLastBlock)
+	}
+	if networkHeight > height {
+		return health.Syncing("block %d was committed %s ago, the network is at height %d", height, sinceLastBlock, networkHeight)
+	}
+	return health.Failing("block %d was committed %s ago and no peer reported a higher block", height, sinceLastBlock)
+}
+
+func (s *Service) maxTimeSinceLastCommittedBlock() time.Duration {
+	max := s.config.TransactionPoolTimeBetweenEmptyBlocks() * emptyBlockIntervalsSinceLastCommittedBlock
+	if max < minTimeSinceLastCommittedBlock {
+		return minTimeSinceLastCommittedBlock
+	}
+	return max
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/adapter"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/internodesync"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/servicesync"
@@ -27,6 +28,13 @@ import (
 
 var LogTag = log.Service("block-storage")
 
+// block sync reads these whenever it starts a timer, so a config reload takes effect without a restart
+var HotReloadableConfigKeys = []string{
+	config.BLOCK_SYNC_NO_COMMIT_INTERVAL,
+	config.BLOCK_SYNC_COLLECT_RESPONSE_TIMEOUT,
+	config.BLOCK_SYNC_COLLECT_CHUNKS_TIMEOUT,
+}
+
 type Service struct {
 	govnr.TreeSupervisor
 	persistence  adapter.BlockPersistence
@@ -35,6 +43,7 @@ type Service struct {
 	txPool       services.TransactionPool
 	config config.BlockStorageConfig
 	logger                  log.Logger
+	tracer                  *trace.Tracer
 	consensusBlocksHandlers struct {
 		sync.RWMutex
 		handlers []handlers.ConsensusBlocksHandler
@@ -43,6 +52,7 @@ type Service struct {
 	// lastCommittedBlock state variable is inside adapter.BlockPersistence (GetLastBlock)
 	nodeSync       *internodesync.BlockSync
 	metrics        *metrics
+	syncServer     *syncServerLimiter
 	notifyNodeSync chan struct{}
 }
 
@@ -65,6 +75,7 @@ func NewBlockStorage(
 	gossip gossiptopics.BlockSync,
 	parentLogger log.Logger,
 	metricFactory metric.Factory,
+	tracer *trace.Tracer,
 	blockPairReceivers []servicesync.BlockPairCommitter,
 ) *Service {
 
@@ -74,8 +85,10 @@ func NewBlockStorage(
 		persistence:    persistence,
 		gossip:         gossip,
 		logger:         logger,
+		tracer:         tracer,
 		config:         config,
 		metrics:        newMetrics(metricFactory),
+		syncServer:     newSyncServerLimiter(config, metricFactory),
 		notifyNodeSync: make(chan struct{}),
 	}
 
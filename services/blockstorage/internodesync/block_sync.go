@@ -17,6 +17,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/orbs-spec/types/go/services/gossiptopics"
 	"github.com/orbs-network/scribe/log"
+	"sync/atomic"
 	"time"
 )
 
@@ -38,6 +39,8 @@ type blockSyncConfig interface {
 	BlockSyncCollectChunksTimeout() time.Duration
 	BlockSyncDescendingEnabled() bool
 	BlockSyncReferenceMaxAllowedDistance() time.Duration
+	BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight
+	BlockSyncFastSyncCheckpointHash() primitives.Sha256
 }
 
 type SyncState struct {
@@ -146,6 +149,11 @@ func (bs *BlockSync) syncLoop(parent context.Context) {
 	}
 }
 
+// NetworkHeight is the highest last committed block height peers reported in their availability responses
+func (bs *BlockSync) NetworkHeight() primitives.BlockHeight {
+	return primitives.BlockHeight(atomic.LoadUint64(&bs.factory.networkHeight))
+}
+
 func (bs *BlockSync) HandleBlockCommitted(ctx context.Context) {
 	logger := bs.logger.WithTags(trace.LogFieldFrom(ctx))
 	//bs.UpdateStorageSyncState()
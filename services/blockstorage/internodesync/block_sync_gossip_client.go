@@ -20,11 +20,12 @@ import (
 const UNKNOWN_BLOCK_HEIGHT = primitives.BlockHeight(0)
 
 type blockSyncClient struct {
-	gossip      gossiptopics.BlockSync
-	storage     BlockSyncStorage
-	logger      log.Logger
-	batchSize   func() uint32
-	nodeAddress func() primitives.NodeAddress
+	gossip           gossiptopics.BlockSync
+	storage          BlockSyncStorage
+	logger           log.Logger
+	batchSize        func() uint32
+	nodeAddress      func() primitives.NodeAddress
+	checkpointHeight func() primitives.BlockHeight
 }
 
 func newBlockSyncGossipClient(
@@ -33,14 +34,16 @@ func newBlockSyncGossipClient(
 	l log.Logger,
 	batchSize func() uint32,
 	na func() primitives.NodeAddress,
+	checkpointHeight func() primitives.BlockHeight,
 	) *blockSyncClient {
 
 	return &blockSyncClient{
-		gossip:      g,
-		storage:     s,
-		logger:      l,
-		batchSize:   batchSize,
-		nodeAddress: na,
+		gossip:           g,
+		storage:          s,
+		logger:           l,
+		batchSize:        batchSize,
+		nodeAddress:      na,
+		checkpointHeight: checkpointHeight,
 	}
 }
 
@@ -51,7 +54,7 @@ func (c *blockSyncClient) petitionerUpdateConsensusAlgosAboutLastCommittedBlockI
 func (c *blockSyncClient) petitionerBroadcastBlockAvailabilityRequest(ctx context.Context, syncBlocksOrder gossipmessages.SyncBlocksOrder) error {
 	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
 	syncState := c.storage.GetSyncState()
-	from, to, err := getClientSyncRange(syncState, syncBlocksOrder, primitives.BlockHeight(c.batchSize()), c.logger)
+	from, to, err := getClientSyncRange(syncState, syncBlocksOrder, primitives.BlockHeight(c.batchSize()), c.checkpointHeight(), c.logger)
 	if err != nil {
 		return errors.Wrapf(err, "invalid block availability range request: from %d to %d, blocksOrder: %v", from, to, syncBlocksOrder)
 	}
@@ -88,7 +91,7 @@ func (c *blockSyncClient) petitionerBroadcastBlockAvailabilityRequest(ctx contex
 func (c *blockSyncClient) petitionerSendBlockSyncRequest(ctx context.Context, syncBlocksOrder gossipmessages.SyncBlocksOrder, blockType gossipmessages.BlockType, recipientNodeAddress primitives.NodeAddress) error {
 	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
 	syncState := c.storage.GetSyncState()
-	from, to, err := getClientSyncRange(syncState, syncBlocksOrder, primitives.BlockHeight(c.batchSize()), c.logger)
+	from, to, err := getClientSyncRange(syncState, syncBlocksOrder, primitives.BlockHeight(c.batchSize()), c.checkpointHeight(), c.logger)
 	if err != nil {
 		return errors.Wrapf(err, "invalid block availability range request: from %d to %d, blocksOrder: %v", from, to, syncBlocksOrder)
 	}
@@ -124,8 +127,8 @@ func (c *blockSyncClient) petitionerSendBlockSyncRequest(ctx context.Context, sy
 }
 
 
-// inclusive range
-func getClientSyncRange(syncState SyncState, syncBlocksOrder gossipmessages.SyncBlocksOrder, batchSize primitives.BlockHeight, logger log.Logger) (from primitives.BlockHeight, to primitives.BlockHeight, err error) {
+// inclusive range, until the node holds the fast sync checkpoint descending sync starts from it instead of from the network top
+func getClientSyncRange(syncState SyncState, syncBlocksOrder gossipmessages.SyncBlocksOrder, batchSize primitives.BlockHeight, checkpointHeight primitives.BlockHeight, logger log.Logger) (from primitives.BlockHeight, to primitives.BlockHeight, err error) {
 
 	topInOrder := syncState.InOrderHeight
 	lastSynced := syncState.LastSyncedHeight
@@ -151,6 +154,11 @@ func getClientSyncRange(syncState SyncState, syncBlocksOrder gossipmessages.Sync
 			if from < to {
 				err = errors.New("calculated -ascending- range instead of -descending-")
 			}
+		} else if checkpointHeight > syncState.TopHeight {
+			from = checkpointHeight
+			if (checkpointHeight >= batchSize) && (checkpointHeight-batchSize+1 > to) {
+				to = checkpointHeight - batchSize + 1
+			}
 		}
 	}
 	return
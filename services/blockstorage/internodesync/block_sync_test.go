@@ -10,6 +10,9 @@ import (
 	"context"
 	"github.com/orbs-network/orbs-network-go/synchronization"
 	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
+	"github.com/stretchr/testify/require"
 	"testing"
 )
 
@@ -56,3 +59,27 @@ func TestBlockSyncStaysInIdleOnBlockCommitExternalMessage(t *testing.T) {
 
 	})
 }
+
+func TestBlockSyncDescendingRangeStartsFromFastSyncCheckpoint(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		tests := []struct {
+			name       string
+			syncState  SyncState
+			checkpoint primitives.BlockHeight
+			from       primitives.BlockHeight
+			to         primitives.BlockHeight
+		}{
+			{"no checkpoint", SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}, 0, UNKNOWN_BLOCK_HEIGHT, 11},
+			{"checkpoint not held yet", SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}, 100, 100, 91},
+			{"checkpoint within a batch", SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}, 15, 15, 11},
+			{"checkpoint already held", SyncState{InOrderHeight: 10, TopHeight: 100, LastSyncedHeight: 100}, 100, 99, 90},
+			{"checkpoint below top", SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}, 5, UNKNOWN_BLOCK_HEIGHT, 11},
+		}
+		for _, test := range tests {
+			from, to, err := getClientSyncRange(test.syncState, gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING, 10, test.checkpoint, harness.Logger)
+			require.NoError(t, err, test.name)
+			require.Equal(t, test.from, from, "from of %s", test.name)
+			require.Equal(t, test.to, to, "to of %s", test.name)
+		}
+	})
+}
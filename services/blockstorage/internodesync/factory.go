@@ -13,6 +13,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
 	"github.com/orbs-network/orbs-spec/types/go/services/gossiptopics"
 	"github.com/orbs-network/scribe/log"
+	"sync/atomic"
 	"time"
 )
 
@@ -26,6 +27,7 @@ type stateFactory struct {
 	createWaitForChunksTimeoutTimer func() *synchronization.Timer
 	logger                          log.Logger
 	metrics                         *stateMetrics
+	networkHeight                   uint64 // highest last committed height reported by peers, accessed atomically
 }
 
 func NewStateFactory(
@@ -99,6 +101,15 @@ func (f *stateFactory) getSyncBlocksOrder() gossipmessages.SyncBlocksOrder {
 }
 
 
+func (f *stateFactory) observeNetworkHeight(height primitives.BlockHeight) {
+	for {
+		current := atomic.LoadUint64(&f.networkHeight)
+		if uint64(height) <= current || atomic.CompareAndSwapUint64(&f.networkHeight, current, uint64(height)) {
+			return
+		}
+	}
+}
+
 func (f *stateFactory) defaultCreateCollectTimeoutTimer() *synchronization.Timer {
 	return synchronization.NewTimer(f.config.BlockSyncCollectResponseTimeout())
 }
@@ -124,7 +135,7 @@ func (f *stateFactory) CreateIdleState() syncState {
 func (f *stateFactory) CreateCollectingAvailabilityResponseState() syncState {
 	return &collectingAvailabilityResponsesState{
 		factory:         f,
-		client:          newBlockSyncGossipClient(f.gossip, f.storage, f.logger, f.config.BlockSyncNumBlocksInBatch, f.config.NodeAddress),
+		client:          newBlockSyncGossipClient(f.gossip, f.storage, f.logger, f.config.BlockSyncNumBlocksInBatch, f.config.NodeAddress, f.config.BlockSyncFastSyncCheckpointHeight),
 		createTimer:     f.createCollectTimeoutTimer,
 		logger:          f.logger,
 		conduit:         f.conduit,
@@ -145,7 +156,7 @@ func (f *stateFactory) CreateWaitingForChunksState(sourceNodeAddress primitives.
 	return &waitingForChunksState{
 		sourceNodeAddress: sourceNodeAddress,
 		factory:           f,
-		client:            newBlockSyncGossipClient(f.gossip, f.storage, f.logger, f.config.BlockSyncNumBlocksInBatch, f.config.NodeAddress),
+		client:            newBlockSyncGossipClient(f.gossip, f.storage, f.logger, f.config.BlockSyncNumBlocksInBatch, f.config.NodeAddress, f.config.BlockSyncFastSyncCheckpointHeight),
 		createTimer:       f.createWaitForChunksTimeoutTimer,
 		logger:            f.logger,
 		conduit:           f.conduit,
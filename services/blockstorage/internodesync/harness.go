@@ -31,6 +31,8 @@ type blockSyncConfigForTests struct {
 	collectChunks     time.Duration
 	referenceDistance time.Duration
 	descendingEnabled bool
+	checkpointHeight  primitives.BlockHeight
+	checkpointHash    primitives.Sha256
 }
 
 func (c *blockSyncConfigForTests) NodeAddress() primitives.NodeAddress {
@@ -61,6 +63,14 @@ func (c *blockSyncConfigForTests) BlockSyncDescendingEnabled() bool {
 	return c.descendingEnabled
 }
 
+func (c *blockSyncConfigForTests) BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight {
+	return c.checkpointHeight
+}
+
+func (c *blockSyncConfigForTests) BlockSyncFastSyncCheckpointHash() primitives.Sha256 {
+	return c.checkpointHash
+}
+
 func newDefaultBlockSyncConfigForTests() *blockSyncConfigForTests {
 	return &blockSyncConfigForTests{
 		nodeAddress:       testKeys.EcdsaSecp256K1KeyPairForTests(1).NodeAddress(),
@@ -146,6 +156,12 @@ func (h *blockSyncHarness) withDescendingEnabled(isEnabled bool) *blockSyncHarne
 	return h
 }
 
+func (h *blockSyncHarness) withFastSyncCheckpoint(height primitives.BlockHeight, hash primitives.Sha256) *blockSyncHarness {
+	h.config.checkpointHeight = height
+	h.config.checkpointHash = hash
+	return h
+}
+
 func (h *blockSyncHarness) expectSyncOnStart() {
 	h.expectUpdateConsensusAlgosAboutLastCommittedBlockInLocalPersistence(10)
 	h.expectBroadcastOfBlockAvailabilityRequest()
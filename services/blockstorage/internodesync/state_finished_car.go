@@ -44,6 +44,9 @@ func (s *finishedCARState) processState(ctx context.Context) syncState {
 		return s.factory.CreateIdleState()
 	}
 	s.metrics.finishedWithSomeResponsesCount.Inc()
+	for _, response := range s.responses {
+		s.factory.observeNetworkHeight(response.SignedBatchRange.LastCommittedBlockHeight())
+	}
 	randomSourceIdx := rand.Intn(len(s.responses))
 	syncSource := s.responses[randomSourceIdx]
 	logger.Info("selecting from sync sources", log.Int("sources-count", c), log.Int("selected", randomSourceIdx), log.String("selected-address", syncSource.Sender.StringSenderNodeAddress()))
@@ -41,6 +41,24 @@ func TestStateFinishedCollectingAvailabilityResponses_MovesToWaitingForChunks(t
 	})
 }
 
+func TestStateFinishedCollectingAvailabilityResponses_RecordsHighestNetworkHeight(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			h := newBlockSyncHarness(harness.Logger)
+			responses := []*gossipmessages.BlockAvailabilityResponseMessage{
+				builders.BlockAvailabilityResponseInput().WithLastCommittedBlockHeight(120).Build().Message,
+				builders.BlockAvailabilityResponseInput().WithLastCommittedBlockHeight(150).Build().Message,
+			}
+			h.factory.CreateFinishedCARState(responses).processState(ctx)
+			require.EqualValues(t, 150, h.factory.networkHeight)
+
+			lowerResponse := builders.BlockAvailabilityResponseInput().WithLastCommittedBlockHeight(100).Build().Message
+			h.factory.CreateFinishedCARState([]*gossipmessages.BlockAvailabilityResponseMessage{lowerResponse}).processState(ctx)
+			require.EqualValues(t, 150, h.factory.networkHeight, "network height should not go down")
+		})
+	})
+}
+
 func TestStateFinishedCollectingAvailabilityResponses_ContextTerminationFlow(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		ctx, cancel := context.WithCancel(context.Background())
@@ -77,6 +77,12 @@ func (s *processingBlocksState) processState(ctx context.Context) syncState {
 		logger.Info("failed to verify the blocks chunk PoS received via sync", log.Error(err))
 		return s.factory.CreateCollectingAvailabilityResponseState()
 	}
+	linkedToCheckpoint, err := s.validateFastSyncCheckpointChain(s.blocks.BlockPairs, syncState, receivedSyncBlocksOrder)
+	if err != nil {
+		s.metrics.failedValidationBlocks.Inc()
+		logger.Info("failed to verify the blocks chunk against the fast sync checkpoint", log.Error(err))
+		return s.factory.CreateCollectingAvailabilityResponseState()
+	}
 
 	s.metrics.blocksRate.Measure(int64(numBlocks))
 
@@ -84,12 +90,15 @@ func (s *processingBlocksState) processState(ctx context.Context) syncState {
 		if !s.conduit.drainAndCheckForShutdown(ctx) {
 			return nil
 		}
-		prevBlockPair := s.getPrevBlock(index, receivedSyncBlocksOrder)
-		_, err := s.storage.ValidateBlockForCommit(ctx, &services.ValidateBlockForCommitInput{BlockPair: blockPair, PrevBlockPair: prevBlockPair})
-		if err != nil {
-			s.metrics.failedValidationBlocks.Inc()
-			logger.Info("failed to validate block received via sync", log.Error(err), logfields.BlockHeight(blockPair.TransactionsBlock.Header.BlockHeight()), log.Stringable("tx-block-header", blockPair.TransactionsBlock.Header)) // may be a valid failure if height isn't the next height
-			break
+		// blocks below the fast sync checkpoint were verified hash by hash back from it, their block proofs are not checked
+		if !linkedToCheckpoint || blockPair.TransactionsBlock.Header.BlockHeight() == s.factory.config.BlockSyncFastSyncCheckpointHeight() {
+			prevBlockPair := s.getPrevBlock(index, receivedSyncBlocksOrder)
+			_, err := s.storage.ValidateBlockForCommit(ctx, &services.ValidateBlockForCommitInput{BlockPair: blockPair, PrevBlockPair: prevBlockPair})
+			if err != nil {
+				s.metrics.failedValidationBlocks.Inc()
+				logger.Info("failed to validate block received via sync", log.Error(err), logfields.BlockHeight(blockPair.TransactionsBlock.Header.BlockHeight()), log.Stringable("tx-block-header", blockPair.TransactionsBlock.Header)) // may be a valid failure if height isn't the next height
+				break
+			}
 		}
 		_, err = s.storage.NodeSyncCommitBlock(ctx, &services.CommitBlockInput{BlockPair: blockPair})
 		if err != nil {
@@ -174,9 +183,11 @@ func (s *processingBlocksState) validatePosChain(blocks []*protocol.BlockPairCon
 				return err
 			}
 		} else if firstBlockHeight > syncState.TopHeight { // verify the first block reference complies with committee PoS honesty assumption
+			// unless the chunk starts at the fast sync checkpoint, whose hash anchors it instead, see validateFastSyncCheckpointChain
 			topBlockReference := firstBlock.TransactionsBlock.Header.ReferenceTime()
 			now := primitives.TimestampSeconds(time.Now().Unix())
-			if topBlockReference+primitives.TimestampSeconds(committeeValidityGraceTimeout/time.Second) < now {
+			startsAtCheckpoint := firstBlockHeight == s.factory.config.BlockSyncFastSyncCheckpointHeight()
+			if !startsAtCheckpoint && topBlockReference+primitives.TimestampSeconds(committeeValidityGraceTimeout/time.Second) < now {
 				return errors.New(fmt.Sprintf("block reference is not included in committee valid reference grace:  block reference (%d), now (%d), grace (%d)", topBlockReference, now, primitives.TimestampSeconds(committeeValidityGraceTimeout/time.Second)))
 			}
 		} else {
@@ -194,6 +205,63 @@ func (s *processingBlocksState) validatePosChain(blocks []*protocol.BlockPairCon
 	return nil
 }
 
+// a descending chunk is linked to the fast sync checkpoint when it starts at the checkpoint block, which must have the
+// configured hash, or continues a run of blocks which started at it. Its last block must point at the block the node holds
+// below it so every block up to the checkpoint is verified hash by hash before it is committed
+func (s *processingBlocksState) validateFastSyncCheckpointChain(blocks []*protocol.BlockPairContainer, syncState SyncState, receivedSyncBlocksOrder gossipmessages.SyncBlocksOrder) (bool, error) {
+	checkpointHeight := s.factory.config.BlockSyncFastSyncCheckpointHeight()
+	if checkpointHeight == 0 || receivedSyncBlocksOrder != gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING {
+		return false, nil
+	}
+
+	firstBlock := blocks[0]
+	firstBlockHeight := firstBlock.TransactionsBlock.Header.BlockHeight()
+	if firstBlockHeight > checkpointHeight {
+		return false, nil
+	}
+
+	if firstBlockHeight > syncState.TopHeight {
+		if firstBlockHeight != checkpointHeight {
+			return false, fmt.Errorf("blocks chunk starts at height %d instead of the fast sync checkpoint height %d", firstBlockHeight, checkpointHeight)
+		}
+		if err := verifyFastSyncCheckpoint(firstBlock, s.factory.config.BlockSyncFastSyncCheckpointHash()); err != nil {
+			return false, err
+		}
+	} else {
+		if syncState.TopHeight != checkpointHeight || firstBlockHeight != syncState.LastSyncedHeight-1 {
+			return false, nil
+		}
+		checkpointBlock, err := s.storage.GetBlock(checkpointHeight)
+		if err != nil {
+			return false, err
+		}
+		if verifyFastSyncCheckpoint(checkpointBlock, s.factory.config.BlockSyncFastSyncCheckpointHash()) != nil {
+			return false, nil // the blocks held were synced without the checkpoint, they are validated as usual
+		}
+	}
+
+	lastBlock := blocks[len(blocks)-1]
+	lastBlockHeight := lastBlock.TransactionsBlock.Header.BlockHeight()
+	if lastBlockHeight == syncState.InOrderHeight+1 && syncState.InOrderHeight > 0 {
+		prevBlock, err := s.storage.GetBlock(syncState.InOrderHeight)
+		if err != nil {
+			return false, err
+		}
+		if !verifyPrevHashPointer(lastBlock, prevBlock) {
+			return false, fmt.Errorf("prevBlockHash mismatch between block %d linked to the fast sync checkpoint and the block held below it", lastBlockHeight)
+		}
+	}
+	return true, nil
+}
+
+func verifyFastSyncCheckpoint(blockPair *protocol.BlockPairContainer, checkpointHash primitives.Sha256) error {
+	blockHash := digest.CalcBlockHash(blockPair.TransactionsBlock, blockPair.ResultsBlock)
+	if !bytes.Equal(blockHash, checkpointHash) {
+		return fmt.Errorf("block hash %s at fast sync checkpoint height %d does not match checkpoint hash %s", blockHash, blockPair.TransactionsBlock.Header.BlockHeight(), checkpointHash)
+	}
+	return nil
+}
+
 func verifyPrevHashPointer(blockPair *protocol.BlockPairContainer, prevBlockPair *protocol.BlockPairContainer) bool {
 	if !bytes.Equal(blockPair.TransactionsBlock.Header.PrevBlockHashPtr(), digest.CalcTransactionsBlockHash(prevBlockPair.TransactionsBlock)) {
 		return false
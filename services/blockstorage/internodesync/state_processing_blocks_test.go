@@ -8,12 +8,15 @@ package internodesync
 
 import (
 	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
+	"github.com/orbs-network/crypto-lib-go/crypto/hash"
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
+	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -312,6 +315,166 @@ func TestStateProcessingBlocksDescending_ValidatePosChainPrevHashFailure(t *test
 	})
 }
 
+func TestStateProcessingBlocksDescending_CommitsBlocksLinkedToFastSyncCheckpointWithoutValidatingTheirProofs(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blocks := chainOfBlocks(10, 20)
+			checkpoint := blocks[len(blocks)-1]
+			h := newBlockSyncHarness(harness.Logger).
+				withDescendingEnabled(true).
+				withFastSyncCheckpoint(20, digest.CalcBlockHash(checkpoint.TransactionsBlock, checkpoint.ResultsBlock))
+
+			syncState := SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}
+			h.storage.When("GetSyncState").Return(syncState).Times(1)
+			h.storage.When("GetBlock", primitives.BlockHeight(10)).Return(blocks[0])
+			h.storage.When("ValidateBlockForCommit", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.ValidateBlockForCommitInput) (*services.ValidateBlockForCommitOutput, error) {
+				require.EqualValues(t, 20, input.BlockPair.TransactionsBlock.Header.BlockHeight(), "only the checkpoint block should be validated by consensus")
+				return nil, nil
+			}).Times(1)
+			h.expectBlockCommitsToStorage(10)
+
+			chunk := blocks[1:]
+			reverse(chunk)
+			message := builders.BlockSyncResponseInput().
+				WithBlocksOrder(gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING).
+				WithBlocks(chunk).
+				WithFirstBlockHeight(20).
+				WithLastBlockHeight(11).
+				WithLastCommittedBlockHeight(30).
+				Build().Message
+
+			state := h.factory.CreateProcessingBlocksState(message)
+			nextState := state.processState(ctx)
+			require.IsType(t, &collectingAvailabilityResponsesState{}, nextState, "next state after commit should be collecting availability responses")
+			h.verifyMocks(t)
+		})
+	})
+}
+
+func TestStateProcessingBlocksDescending_FastSyncCheckpointHashMismatchReturnsToCollectingAvailabilityResponses(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blocks := chainOfBlocks(10, 20)
+			h := newBlockSyncHarness(harness.Logger).
+				withDescendingEnabled(true).
+				withFastSyncCheckpoint(20, hash.CalcSha256([]byte("some other block")))
+
+			syncState := SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}
+			h.storage.When("GetSyncState").Return(syncState).Times(1)
+			h.storage.When("GetBlock", mock.Any).Return(blocks[0])
+			h.storage.Never("ValidateBlockForCommit", mock.Any, mock.Any)
+			h.storage.Never("NodeSyncCommitBlock", mock.Any, mock.Any)
+
+			chunk := blocks[1:]
+			reverse(chunk)
+			message := builders.BlockSyncResponseInput().
+				WithBlocksOrder(gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING).
+				WithBlocks(chunk).
+				WithFirstBlockHeight(20).
+				WithLastBlockHeight(11).
+				WithLastCommittedBlockHeight(30).
+				Build().Message
+
+			state := h.factory.CreateProcessingBlocksState(message)
+			nextState := state.processState(ctx)
+			require.IsType(t, &collectingAvailabilityResponsesState{}, nextState, "next state after validation error should be collecting availability responses")
+			h.verifyMocks(t)
+		})
+	})
+}
+
+func TestStateProcessingBlocksDescending_BlocksNotLinkedToTheBlockHeldBelowFastSyncCheckpointAreNotCommitted(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blocks := chainOfBlocks(10, 20)
+			checkpoint := blocks[len(blocks)-1]
+			h := newBlockSyncHarness(harness.Logger).
+				withDescendingEnabled(true).
+				withFastSyncCheckpoint(20, digest.CalcBlockHash(checkpoint.TransactionsBlock, checkpoint.ResultsBlock))
+
+			syncState := SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}
+			h.storage.When("GetSyncState").Return(syncState).Times(1)
+			h.storage.When("GetBlock", primitives.BlockHeight(10)).Return(builders.BlockPair().WithHeight(10).Build()) // a different block 10
+			h.storage.Never("ValidateBlockForCommit", mock.Any, mock.Any)
+			h.storage.Never("NodeSyncCommitBlock", mock.Any, mock.Any)
+
+			chunk := blocks[1:]
+			reverse(chunk)
+			message := builders.BlockSyncResponseInput().
+				WithBlocksOrder(gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING).
+				WithBlocks(chunk).
+				WithFirstBlockHeight(20).
+				WithLastBlockHeight(11).
+				WithLastCommittedBlockHeight(30).
+				Build().Message
+
+			state := h.factory.CreateProcessingBlocksState(message)
+			nextState := state.processState(ctx)
+			require.IsType(t, &collectingAvailabilityResponsesState{}, nextState, "next state after validation error should be collecting availability responses")
+			h.verifyMocks(t)
+		})
+	})
+}
+
+func TestStateProcessingBlocksDescending_BlocksAboveFastSyncCheckpointWithStaleReferenceTimeAreNotCommitted(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blocks := chainOfBlocks(10, 20)
+			checkpoint := blocks[len(blocks)-1]
+			h := newBlockSyncHarness(harness.Logger).
+				withDescendingEnabled(true).
+				withFastSyncCheckpoint(20, digest.CalcBlockHash(checkpoint.TransactionsBlock, checkpoint.ResultsBlock))
+
+			syncState := SyncState{InOrderHeight: 10, TopHeight: 10, LastSyncedHeight: 10}
+			h.storage.When("GetSyncState").Return(syncState).Times(1)
+			h.storage.When("GetBlock", mock.Any).Return(blocks[0])
+			h.storage.Never("ValidateBlockForCommit", mock.Any, mock.Any)
+			h.storage.Never("NodeSyncCommitBlock", mock.Any, mock.Any)
+
+			var chunk []*protocol.BlockPairContainer
+			prevBlock := checkpoint
+			for i := 21; i <= 30; i++ {
+				blockPair := builders.BlockPair().
+					WithHeight(primitives.BlockHeight(i)).
+					WithReferenceTime(1). // long past the committee validity grace
+					WithPrevBlock(prevBlock).
+					Build()
+				prevBlock = blockPair
+				chunk = append(chunk, blockPair)
+			}
+			reverse(chunk)
+			message := builders.BlockSyncResponseInput().
+				WithBlocksOrder(gossipmessages.SYNC_BLOCKS_ORDER_DESCENDING).
+				WithBlocks(chunk).
+				WithFirstBlockHeight(30).
+				WithLastBlockHeight(21).
+				WithLastCommittedBlockHeight(30).
+				Build().Message
+
+			state := h.factory.CreateProcessingBlocksState(message)
+			nextState := state.processState(ctx)
+			require.IsType(t, &collectingAvailabilityResponsesState{}, nextState, "next state after validation error should be collecting availability responses")
+			h.verifyMocks(t)
+		})
+	})
+}
+
+// ascending chain of blocks linked by their previous block hashes
+func chainOfBlocks(from primitives.BlockHeight, to primitives.BlockHeight) []*protocol.BlockPairContainer {
+	var blocks []*protocol.BlockPairContainer
+	var prevBlock *protocol.BlockPairContainer
+	for i := from; i <= to; i++ {
+		blockPair := builders.BlockPair().
+			WithHeight(i).
+			WithBlockCreated(time.Unix(int64(i), 0)).
+			WithPrevBlock(prevBlock).
+			Build()
+		prevBlock = blockPair
+		blocks = append(blocks, blockPair)
+	}
+	return blocks
+}
+
 func reverse(arr []*protocol.BlockPairContainer) {
 	for i, j := 0, len(arr)-1; i < j; i, j = i+1, j-1 {
 		arr[i], arr[j] = arr[j], arr[i]
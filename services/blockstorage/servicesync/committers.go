@@ -26,10 +26,24 @@ type transactionPoolCommitter struct {
 	service services.TransactionPool
 }
 
+type referenceTimeTracker interface {
+	UpdateReferenceTime(referenceTime primitives.TimestampSeconds)
+}
+
+type signerCommitter struct {
+	serviceDesc
+	service referenceTimeTracker
+}
+
 func NewTxPoolCommitter(txPool services.TransactionPool) *transactionPoolCommitter {
 	return &transactionPoolCommitter{service: txPool, serviceDesc: serviceDesc{"tx-pool-sync"}}
 }
 
+// the signer only needs the reference time of the top block, it never asks for earlier blocks
+func NewSignerCommitter(signer referenceTimeTracker) *signerCommitter {
+	return &signerCommitter{service: signer, serviceDesc: serviceDesc{"signer-sync"}}
+}
+
 func NewStateStorageCommitter(stateStorage services.StateStorage) *stateStorageCommitter {
 	return &stateStorageCommitter{service: stateStorage, serviceDesc: serviceDesc{"state-storage-sync"}}
 }
@@ -51,6 +65,11 @@ func (tpc *transactionPoolCommitter) commitBlockPair(ctx context.Context, commit
 	return out.NextDesiredBlockHeight, err
 }
 
+func (sc *signerCommitter) commitBlockPair(ctx context.Context, committedBlockPair *protocol.BlockPairContainer) (primitives.BlockHeight, error) {
+	sc.service.UpdateReferenceTime(committedBlockPair.TransactionsBlock.Header.ReferenceTime())
+	return committedBlockPair.TransactionsBlock.Header.BlockHeight() + 1, nil
+}
+
 func (sd *serviceDesc) GetServiceName() string {
 	return sd.name
 }
@@ -245,3 +245,45 @@ func TestSourceRetriesSendingSmallerChunksOnChunkTooBigError(t *testing.T) {
 		harness.verifyMocks(t, 1)
 	})
 }
+
+func TestSourceDoesNotRespondToAvailabilityRequestsWhileServingMaxRequestors(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		harness := newBlockStorageHarness(parent).
+			withSyncServerMaxConcurrentRequestors(1).
+			withSyncBroadcast(1).
+			expectValidateConsensusAlgos().
+			start(ctx)
+
+		harness.commitSomeBlocks(ctx, 3)
+
+		servingStarted := make(chan struct{})
+		finishServing := make(chan struct{})
+		harness.gossip.When("SendBlockSyncResponse", mock.Any, mock.Any).Call(func(ctx context.Context, input *gossiptopics.BlockSyncResponseInput) (*gossiptopics.EmptyOutput, error) {
+			close(servingStarted)
+			<-finishServing
+			return nil, nil
+		}).Times(1)
+		harness.gossip.Never("SendBlockAvailabilityResponse", mock.Any, mock.Any)
+
+		servedRequest := builders.BlockSyncRequestInput().
+			WithSenderNodeAddress(keys.EcdsaSecp256K1KeyPairForTests(1).NodeAddress()).
+			WithFirstBlockHeight(1).
+			Build()
+		go func() {
+			_, _ = harness.blockStorage.HandleBlockSyncRequest(ctx, servedRequest)
+		}()
+		<-servingStarted
+
+		msg := builders.BlockAvailabilityRequestInput().
+			WithSenderNodeAddress(keys.EcdsaSecp256K1KeyPairForTests(2).NodeAddress()).
+			WithFirstBlockHeight(1).
+			WithLastCommittedBlockHeight(primitives.BlockHeight(2)).
+			WithLastBlockHeight(primitives.BlockHeight(2)).
+			Build()
+		_, err := harness.blockStorage.HandleBlockAvailabilityRequest(ctx, msg)
+		require.NoError(t, err, "expecting a happy flow (without sending the response)")
+
+		close(finishServing)
+		harness.verifyMocks(t, 1)
+	})
+}
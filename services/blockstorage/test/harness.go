@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/adapter/testkit"
 	"github.com/orbs-network/orbs-network-go/services/blockstorage/internodesync"
@@ -42,6 +43,12 @@ type configForBlockStorageTests struct {
 	queryGrace            time.Duration
 	queryExpirationWindow time.Duration
 	blockTrackerGrace     time.Duration
+	checkpointHeight      primitives.BlockHeight
+	checkpointHash        primitives.Sha256
+	serverMaxRequestors   uint32
+	serverMaxBytesPerSec  uint32
+	serverQueueTimeout    time.Duration
+	emptyBlocksInterval   time.Duration
 }
 
 func (c *configForBlockStorageTests) NodeAddress() primitives.NodeAddress {
@@ -76,6 +83,26 @@ func (c *configForBlockStorageTests) BlockSyncBlocksOrder() gossipmessages.SyncB
 	return c.syncBlocksOrder
 }
 
+func (c *configForBlockStorageTests) BlockSyncFastSyncCheckpointHeight() primitives.BlockHeight {
+	return c.checkpointHeight
+}
+
+func (c *configForBlockStorageTests) BlockSyncFastSyncCheckpointHash() primitives.Sha256 {
+	return c.checkpointHash
+}
+
+func (c *configForBlockStorageTests) BlockSyncServerMaxConcurrentRequestors() uint32 {
+	return c.serverMaxRequestors
+}
+
+func (c *configForBlockStorageTests) BlockSyncServerMaxBytesPerSecond() uint32 {
+	return c.serverMaxBytesPerSec
+}
+
+func (c *configForBlockStorageTests) BlockSyncServerQueueTimeout() time.Duration {
+	return c.serverQueueTimeout
+}
+
 func (c *configForBlockStorageTests) BlockStorageTransactionReceiptQueryTimestampGrace() time.Duration {
 	return c.queryGrace
 }
@@ -88,6 +115,10 @@ func (c *configForBlockStorageTests) BlockTrackerGraceTimeout() time.Duration {
 	return c.blockTrackerGrace
 }
 
+func (c *configForBlockStorageTests) TransactionPoolTimeBetweenEmptyBlocks() time.Duration {
+	return c.emptyBlocksInterval
+}
+
 type harness struct {
 	*with.ConcurrencyHarness
 
@@ -218,6 +249,17 @@ func (d *harness) withTransactionExpirationWindow(value time.Duration) *harness
 	return d
 }
 
+func (d *harness) withFastSyncCheckpoint(height primitives.BlockHeight, hash primitives.Sha256) *harness {
+	d.config.checkpointHeight = height
+	d.config.checkpointHash = hash
+	return d
+}
+
+func (d *harness) withSyncServerMaxConcurrentRequestors(maxRequestors uint32) *harness {
+	d.config.serverMaxRequestors = maxRequestors
+	return d
+}
+
 func (d *harness) withNodeAddress(address primitives.NodeAddress) *harness {
 	d.config.nodeAddress = address
 	return d
@@ -294,7 +336,7 @@ func (d *harness) start(ctx context.Context) *harness {
 	defer d.Unlock()
 	registry := metric.NewRegistry()
 
-	d.blockStorage = blockstorage.NewBlockStorage(ctx, d.config, d.storageAdapter, d.gossip, d.Logger, registry, nil)
+	d.blockStorage = blockstorage.NewBlockStorage(ctx, d.config, d.storageAdapter, d.gossip, d.Logger, registry, trace.NewNoopTracer(), nil)
 	d.blockStorage.RegisterConsensusBlocksHandler(d.consensus)
 
 	d.Supervise(d.blockStorage)
@@ -0,0 +1,69 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func checkStatus(report *health.Report, name string) health.Status {
+	for _, check := range report.Checks {
+		if check.Name == name {
+			return check.Status
+		}
+	}
+	return ""
+}
+
+func TestHealth_NodeWithoutBlocksIsSyncing(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		harness := newBlockStorageHarness(parent).withSyncBroadcast(1).expectValidateConsensusAlgos().start(ctx)
+		registry := health.NewRegistry()
+		harness.blockStorage.RegisterHealthChecks(registry)
+
+		report := registry.Report(ctx)
+		require.Equal(t, health.STATUS_SYNCING, checkStatus(report, "last-committed-block"))
+		require.Equal(t, health.STATUS_OK, checkStatus(report, "block-sync"))
+		require.True(t, report.Live)
+		require.False(t, report.Ready)
+	})
+}
+
+func TestHealth_NodeWithRecentBlocksIsHealthy(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		harness := newBlockStorageHarness(parent).withSyncBroadcast(1).expectValidateConsensusAlgos()
+		harness.setupCustomBlocksForInit()
+		harness.start(ctx)
+		registry := health.NewRegistry()
+		harness.blockStorage.RegisterHealthChecks(registry)
+
+		report := registry.Report(ctx)
+		require.Equal(t, health.STATUS_OK, report.Status, report.Summary())
+		require.True(t, report.Ready)
+	})
+}
+
+func TestHealth_NodeThatStoppedCommittingBlocksIsNotReady(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		harness := newBlockStorageHarness(parent).withSyncBroadcast(1).expectValidateConsensusAlgos()
+		_, _, _ = harness.storageAdapter.WriteNextBlock(builders.BlockPair().WithHeight(1).WithBlockCreated(time.Now().Add(-time.Hour)).Build())
+		harness.start(ctx)
+		registry := health.NewRegistry()
+		harness.blockStorage.RegisterHealthChecks(registry)
+
+		report := registry.Report(ctx)
+		require.Equal(t, health.STATUS_FAILING, checkStatus(report, "last-committed-block"))
+		require.True(t, report.Live, "a node that stopped committing blocks should not be restarted")
+		require.False(t, report.Ready)
+	})
+}
@@ -9,6 +9,8 @@ package test
 import (
 	"context"
 	"fmt"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
+	"github.com/orbs-network/crypto-lib-go/crypto/hash"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-network-go/test/with"
@@ -107,3 +109,31 @@ func TestValidateBlockWithInvalidHeight(t *testing.T) {
 		require.EqualError(t, err, "block height is 999, expected 2", "tx & rx block height was mutate, expected an error")
 	})
 }
+
+func TestValidateBlockAtFastSyncCheckpointWithMatchingHash(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		block := builders.BlockPair().Build()
+		harness := newBlockStorageHarness(parent).
+			withFastSyncCheckpoint(1, digest.CalcBlockHash(block.TransactionsBlock, block.ResultsBlock)).
+			withSyncBroadcast(1).
+			withValidateConsensusAlgos(1).
+			start(ctx)
+
+		_, err := harness.blockStorage.ValidateBlockForCommit(ctx, &services.ValidateBlockForCommitInput{BlockPair: block})
+		require.NoError(t, err, "block matching the checkpoint hash should be valid")
+	})
+}
+
+func TestValidateBlockAtFastSyncCheckpointWithMismatchingHash(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		harness := newBlockStorageHarness(parent).
+			allowingErrorsMatching("block does not match the configured fast sync checkpoint").
+			withFastSyncCheckpoint(1, hash.CalcSha256([]byte("some other block"))).
+			withSyncBroadcast(1).
+			expectValidateConsensusAlgos().
+			start(ctx)
+
+		_, err := harness.blockStorage.ValidateBlockForCommit(ctx, &services.ValidateBlockForCommitInput{BlockPair: builders.BlockPair().Build()})
+		require.Error(t, err, "block not matching the checkpoint hash should be rejected")
+	})
+}
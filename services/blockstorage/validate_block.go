@@ -7,8 +7,10 @@
 package blockstorage
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
@@ -21,6 +23,8 @@ import (
 
 // TODO Implement optimization for full structural validation here (https://github.com/orbs-network/orbs-network-go/issues/684)
 func (s *Service) ValidateBlockForCommit(ctx context.Context, input *services.ValidateBlockForCommitInput) (*services.ValidateBlockForCommitOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "BlockStorage.ValidateBlockForCommit")
+	defer span.End()
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	if protocolVersionError := s.validateProtocolVersion(input.BlockPair); protocolVersionError != nil {
@@ -31,6 +35,11 @@ func (s *Service) ValidateBlockForCommit(ctx context.Context, input *services.Va
 		return nil, blockHeightError
 	}
 
+	if checkpointError := s.validateFastSyncCheckpoint(input.BlockPair); checkpointError != nil {
+		logger.Error("block does not match the configured fast sync checkpoint", log.Error(checkpointError), logfields.BlockHeight(input.BlockPair.TransactionsBlock.Header.BlockHeight()))
+		return nil, checkpointError
+	}
+
 	logger.Info("ValidateBlockForCommit calling notifyConsensusAlgos with VERIFY_AND_UPDATE", logfields.BlockHeight(input.BlockPair.TransactionsBlock.Header.BlockHeight()))
 	if err := s.notifyConsensusAlgos(ctx, input.PrevBlockPair, input.BlockPair, handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_ONLY); err != nil {
 		if ctx.Err() == nil { // this may fail rightfully on graceful shutdown (ctx.Done), we don't want to report an error in this case
@@ -98,6 +107,23 @@ func (s *Service) validateConsecutiveBlockHeight(blockPair *protocol.BlockPairCo
 	return nil
 }
 
+// the block at the fast sync checkpoint height must match the configured hash, block sync verifies the blocks below it hash
+// by hash back from it and commits them without validating their block proofs
+func (s *Service) validateFastSyncCheckpoint(blockPair *protocol.BlockPairContainer) error {
+	checkpointHeight := s.config.BlockSyncFastSyncCheckpointHeight()
+	if checkpointHeight == 0 || blockPair.TransactionsBlock.Header.BlockHeight() != checkpointHeight {
+		return nil
+	}
+
+	blockHash := digest.CalcBlockHash(blockPair.TransactionsBlock, blockPair.ResultsBlock)
+	if !bytes.Equal(blockHash, s.config.BlockSyncFastSyncCheckpointHash()) {
+		return errors.Errorf("block hash %s at fast sync checkpoint height %d does not match checkpoint hash %s", blockHash, checkpointHeight, s.config.BlockSyncFastSyncCheckpointHash())
+	}
+
+	s.logger.Info("reached fast sync checkpoint, blocks above it are fully validated", logfields.BlockHeight(checkpointHeight))
+	return nil
+}
+
 func (s *Service) validateProtocolVersion(blockPair *protocol.BlockPairContainer) error {
 	txBlockHeader := blockPair.TransactionsBlock.Header
 	rsBlockHeader := blockPair.ResultsBlock.Header
@@ -0,0 +1,283 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package devconsensus
+
+import (
+	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
+	ethereumDigest "github.com/orbs-network/crypto-lib-go/crypto/ethereum/digest"
+	"github.com/orbs-network/crypto-lib-go/crypto/signer"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// not part of the protocol spec, the dev algo is activated by setting active-consensus-algo to this value
+const CONSENSUS_ALGO_TYPE_DEV_SINGLE_NODE = consensus.ConsensusAlgoType(100)
+
+const RETRY_INTERVAL = 100 * time.Millisecond // TODO move to configuration if the dev algo is used beyond local development
+
+var LogTag = log.Service("consensus-algo-dev")
+
+type Config interface {
+	NodeAddress() primitives.NodeAddress
+	ActiveConsensusAlgo() consensus.ConsensusAlgoType
+}
+
+// Service is a single node consensus algo for local development, every block it proposes is signed by the node and committed instantly
+type Service struct {
+	govnr.TreeSupervisor
+	blockStorage     services.BlockStorage
+	consensusContext services.ConsensusContext
+	signer           signer.Signer
+	logger           log.Logger
+	config           Config
+
+	mutex                        sync.RWMutex
+	lastCommittedBlockUnderMutex *protocol.BlockPairContainer
+
+	metrics *metrics
+}
+
+type metrics struct {
+	lastCommittedTime *metric.Gauge
+	failedRoundsRate  *metric.Rate
+}
+
+func newMetrics(m metric.Factory) *metrics {
+	return &metrics{
+		lastCommittedTime: m.NewGauge("ConsensusAlgo.Dev.LastCommitted.TimeNano"),
+		failedRoundsRate:  m.NewRate("ConsensusAlgo.Dev.FailedRounds.PerSecond"),
+	}
+}
+
+func NewDevConsensusAlgo(
+	ctx context.Context,
+	blockStorage services.BlockStorage,
+	consensusContext services.ConsensusContext,
+	signer signer.Signer,
+	parentLogger log.Logger,
+	config Config,
+	metricFactory metric.Factory,
+) *Service {
+
+	logger := parentLogger.WithTags(LogTag)
+
+	s := &Service{
+		blockStorage:     blockStorage,
+		consensusContext: consensusContext,
+		signer:           signer,
+		logger:           logger,
+		config:           config,
+		metrics:          newMetrics(metricFactory),
+	}
+
+	blockStorage.RegisterConsensusBlocksHandler(s)
+
+	if config.ActiveConsensusAlgo() == CONSENSUS_ALGO_TYPE_DEV_SINGLE_NODE {
+		logger.Info("NewDevConsensusAlgo() dev consensus is active algo, starting goroutine now")
+		s.Supervise(govnr.Forever(ctx, "Dev consensus main loop", logfields.GovnrErrorer(logger), func() {
+			s.consensusRoundRunLoop(ctx)
+		}))
+	}
+
+	return s
+}
+
+func (s *Service) HandleBlockConsensus(ctx context.Context, input *handlers.HandleBlockConsensusInput) (*handlers.HandleBlockConsensusOutput, error) {
+	if input.BlockType != protocol.BLOCK_TYPE_BLOCK_PAIR {
+		return nil, errors.Errorf("handler received unsupported block type %s", input.BlockType)
+	}
+
+	if input.Mode == handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_AND_UPDATE || input.Mode == handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_ONLY {
+		if err := s.validateBlockConsensus(input.BlockPair, input.PrevCommittedBlockPair); err != nil {
+			return nil, err
+		}
+	}
+
+	if input.Mode == handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_AND_UPDATE || input.Mode == handlers.HANDLE_BLOCK_CONSENSUS_MODE_UPDATE_ONLY {
+		if input.BlockPair != nil && input.BlockPair.TransactionsBlock.Header.BlockHeight() > s.lastCommittedBlockHeight() {
+			s.setLastCommittedBlock(input.BlockPair)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *Service) consensusRoundRunLoop(ctx context.Context) {
+	for {
+		roundCtx := trace.NewContext(ctx, "DevConsensus.Round")
+		if err := s.commitNextBlock(roundCtx); err != nil {
+			s.logger.WithTags(trace.LogFieldFrom(roundCtx)).Info("dev consensus round failed", log.Error(err))
+			s.metrics.failedRoundsRate.Measure(1)
+			select {
+			case <-ctx.Done():
+			case <-time.After(RETRY_INTERVAL):
+			}
+		}
+
+		if ctx.Err() != nil {
+			s.logger.Info("dev consensus run loop terminating with context")
+			return
+		}
+	}
+}
+
+// the consensus context blocks until there are transactions to order (or it is time for an empty block), so blocks are committed as soon as they are created
+func (s *Service) commitNextBlock(ctx context.Context) error {
+	lastCommittedBlock := s.lastCommittedBlock()
+
+	currentBlockHeight := primitives.BlockHeight(1)
+	var prevTxBlockHash, prevRxBlockHash primitives.Sha256
+	var prevBlockTimestamp primitives.TimestampNano
+	var prevBlockReferenceTime primitives.TimestampSeconds
+	if lastCommittedBlock != nil {
+		currentBlockHeight = lastCommittedBlock.TransactionsBlock.Header.BlockHeight() + 1
+		prevTxBlockHash = digest.CalcTransactionsBlockHash(lastCommittedBlock.TransactionsBlock)
+		prevRxBlockHash = digest.CalcResultsBlockHash(lastCommittedBlock.ResultsBlock)
+		prevBlockTimestamp = lastCommittedBlock.TransactionsBlock.Header.Timestamp()
+		prevBlockReferenceTime = lastCommittedBlock.TransactionsBlock.Header.ReferenceTime()
+	}
+
+	txOutput, err := s.consensusContext.RequestNewTransactionsBlock(ctx, &services.RequestNewTransactionsBlockInput{
+		CurrentBlockHeight:     currentBlockHeight,
+		PrevBlockHash:          prevTxBlockHash,
+		PrevBlockTimestamp:     prevBlockTimestamp,
+		PrevBlockReferenceTime: prevBlockReferenceTime,
+		BlockProposerAddress:   s.config.NodeAddress(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to request new transactions block")
+	}
+
+	rxOutput, err := s.consensusContext.RequestNewResultsBlock(ctx, &services.RequestNewResultsBlockInput{
+		CurrentBlockHeight:     currentBlockHeight,
+		PrevBlockHash:          prevRxBlockHash,
+		TransactionsBlock:      txOutput.TransactionsBlock,
+		PrevBlockTimestamp:     prevBlockTimestamp,
+		PrevBlockReferenceTime: prevBlockReferenceTime,
+		BlockProposerAddress:   s.config.NodeAddress(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to request new results block")
+	}
+
+	blockPair, err := s.signBlock(ctx, txOutput.TransactionsBlock, rxOutput.ResultsBlock)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign block")
+	}
+
+	if _, err := s.blockStorage.CommitBlock(ctx, &services.CommitBlockInput{BlockPair: blockPair}); err != nil {
+		return errors.Wrap(err, "failed to commit block")
+	}
+
+	s.setLastCommittedBlock(blockPair)
+	s.metrics.lastCommittedTime.Update(time.Now().UnixNano())
+	s.logger.WithTags(trace.LogFieldFrom(ctx)).Info("dev consensus committed a block", logfields.BlockHeight(currentBlockHeight), log.Int("num-transactions", len(blockPair.TransactionsBlock.SignedTransactions)))
+	return nil
+}
+
+// the block proof has the benchmark consensus structure, signed by this node only
+func (s *Service) signBlock(ctx context.Context, transactionsBlock *protocol.TransactionsBlockContainer, resultsBlock *protocol.ResultsBlockContainer) (*protocol.BlockPairContainer, error) {
+	blockPair := &protocol.BlockPairContainer{
+		TransactionsBlock: transactionsBlock,
+		ResultsBlock:      resultsBlock,
+	}
+
+	signedData := signedDataForBlockProof(blockPair)
+	sig, err := s.signer.Sign(ctx, signedData)
+	if err != nil {
+		return nil, err
+	}
+
+	blockPair.TransactionsBlock.BlockProof = (&protocol.TransactionsBlockProofBuilder{
+		Type:               protocol.TRANSACTIONS_BLOCK_PROOF_TYPE_BENCHMARK_CONSENSUS,
+		BenchmarkConsensus: &consensus.BenchmarkConsensusBlockProofBuilder{},
+	}).Build()
+
+	blockPair.ResultsBlock.BlockProof = (&protocol.ResultsBlockProofBuilder{
+		TransactionsBlockHash: digest.CalcTransactionsBlockHash(transactionsBlock),
+		Type:                  protocol.RESULTS_BLOCK_PROOF_TYPE_BENCHMARK_CONSENSUS,
+		BenchmarkConsensus: &consensus.BenchmarkConsensusBlockProofBuilder{
+			BlockRef: consensus.BenchmarkConsensusBlockRefBuilderFromRaw(signedData),
+			Nodes: []*consensus.BenchmarkConsensusSenderSignatureBuilder{{
+				SenderNodeAddress: s.config.NodeAddress(),
+				Signature:         sig,
+			}},
+		},
+	}).Build()
+
+	return blockPair, nil
+}
+
+func (s *Service) validateBlockConsensus(blockPair *protocol.BlockPairContainer, prevBlockPair *protocol.BlockPairContainer) error {
+	if blockPair == nil {
+		return errors.New("DevConsensus: validateBlockConsensus received an empty block")
+	}
+	if !blockPair.ResultsBlock.BlockProof.IsTypeBenchmarkConsensus() {
+		return errors.Errorf("DevConsensus: incorrect block proof type for results block height %d: %v", blockPair.ResultsBlock.Header.BlockHeight(), blockPair.ResultsBlock.BlockProof.Type())
+	}
+
+	if prevBlockPair != nil {
+		prevTxHash := digest.CalcTransactionsBlockHash(prevBlockPair.TransactionsBlock)
+		if !blockPair.TransactionsBlock.Header.PrevBlockHashPtr().Equal(prevTxHash) {
+			return errors.Errorf("DevConsensus: transactions prev block hash does not match prev block height %d: %s", prevBlockPair.TransactionsBlock.Header.BlockHeight(), prevTxHash)
+		}
+	}
+
+	signersIterator := blockPair.ResultsBlock.BlockProof.BenchmarkConsensus().NodesIterator()
+	if !signersIterator.HasNext() {
+		return errors.New("DevConsensus: block proof not signed")
+	}
+	blockSigner := signersIterator.NextNodes()
+	if !blockSigner.SenderNodeAddress().Equal(s.config.NodeAddress()) {
+		return errors.Errorf("DevConsensus: block proof not signed by this node: %s", blockSigner.SenderNodeAddress())
+	}
+	if err := ethereumDigest.VerifyNodeSignature(blockSigner.SenderNodeAddress(), signedDataForBlockProof(blockPair), blockSigner.Signature()); err != nil {
+		return errors.Wrapf(err, "DevConsensus: block proof signature is invalid: %s", blockSigner.Signature())
+	}
+
+	return nil
+}
+
+func signedDataForBlockProof(blockPair *protocol.BlockPairContainer) []byte {
+	return (&consensus.BenchmarkConsensusBlockRefBuilder{
+		PlaceholderType: consensus.BENCHMARK_CONSENSUS_VALID,
+		BlockHeight:     blockPair.TransactionsBlock.Header.BlockHeight(),
+		PlaceholderView: 1,
+		BlockHash:       digest.CalcBlockHash(blockPair.TransactionsBlock, blockPair.ResultsBlock),
+	}).Build().Raw()
+}
+
+func (s *Service) lastCommittedBlock() *protocol.BlockPairContainer {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.lastCommittedBlockUnderMutex
+}
+
+func (s *Service) lastCommittedBlockHeight() primitives.BlockHeight {
+	if block := s.lastCommittedBlock(); block != nil {
+		return block.TransactionsBlock.Header.BlockHeight()
+	}
+	return 0
+}
+
+func (s *Service) setLastCommittedBlock(blockPair *protocol.BlockPairContainer) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastCommittedBlockUnderMutex = blockPair
+}
@@ -0,0 +1,161 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package devconsensus
+
+import (
+	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
+	"github.com/orbs-network/crypto-lib-go/crypto/signer"
+	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type devConfigForTests struct {
+	keyPair *testKeys.TestEcdsaSecp256K1KeyPair
+	algo    consensus.ConsensusAlgoType
+}
+
+func (c *devConfigForTests) NodeAddress() primitives.NodeAddress {
+	return c.keyPair.NodeAddress()
+}
+
+func (c *devConfigForTests) NodePrivateKey() primitives.EcdsaSecp256K1PrivateKey {
+	return c.keyPair.PrivateKey()
+}
+
+func (c *devConfigForTests) SignerEndpoint() string {
+	return ""
+}
+
+func (c *devConfigForTests) ActiveConsensusAlgo() consensus.ConsensusAlgoType {
+	return c.algo
+}
+
+type harness struct {
+	*with.ConcurrencyHarness
+	blockStorage     *services.MockBlockStorage
+	consensusContext *services.MockConsensusContext
+	config           *devConfigForTests
+	committed        chan *protocol.BlockPairContainer
+}
+
+func newHarness(parent *with.ConcurrencyHarness, algo consensus.ConsensusAlgoType) *harness {
+	h := &harness{
+		ConcurrencyHarness: parent,
+		blockStorage:       &services.MockBlockStorage{},
+		consensusContext:   &services.MockConsensusContext{},
+		config:             &devConfigForTests{keyPair: testKeys.EcdsaSecp256K1KeyPairForTests(0), algo: algo},
+		committed:          make(chan *protocol.BlockPairContainer, 10),
+	}
+	h.blockStorage.When("RegisterConsensusBlocksHandler", mock.Any).Return().Times(1)
+	return h
+}
+
+func (h *harness) expectBlocksToBeCreatedAndCommitted() {
+	h.consensusContext.When("RequestNewTransactionsBlock", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.RequestNewTransactionsBlockInput) (*services.RequestNewTransactionsBlockOutput, error) {
+		block := builders.BlockPair().WithHeight(input.CurrentBlockHeight).WithPrevBlockHash(input.PrevBlockHash).Build()
+		return &services.RequestNewTransactionsBlockOutput{TransactionsBlock: block.TransactionsBlock}, nil
+	})
+	h.consensusContext.When("RequestNewResultsBlock", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.RequestNewResultsBlockInput) (*services.RequestNewResultsBlockOutput, error) {
+		block := builders.BlockPair().WithHeight(input.CurrentBlockHeight).WithPrevBlockHash(input.PrevBlockHash).Build()
+		return &services.RequestNewResultsBlockOutput{ResultsBlock: block.ResultsBlock}, nil
+	})
+	h.blockStorage.When("CommitBlock", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.CommitBlockInput) (*services.CommitBlockOutput, error) {
+		select {
+		case h.committed <- input.BlockPair:
+		case <-ctx.Done():
+		}
+		return nil, nil
+	})
+}
+
+func (h *harness) start(ctx context.Context) *Service {
+	sgnr, err := signer.New(h.config)
+	require.NoError(h.T, err)
+
+	s := NewDevConsensusAlgo(ctx, h.blockStorage, h.consensusContext, sgnr, h.Logger, h.config, metric.NewRegistry())
+	h.Supervise(s)
+	return s
+}
+
+func (h *harness) nextCommittedBlock(t *testing.T) *protocol.BlockPairContainer {
+	select {
+	case block := <-h.committed:
+		return block
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a block to be committed")
+		return nil
+	}
+}
+
+func TestDevConsensus_CommitsConsecutiveSignedBlocks(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		h := newHarness(parent, CONSENSUS_ALGO_TYPE_DEV_SINGLE_NODE)
+		h.expectBlocksToBeCreatedAndCommitted()
+		s := h.start(ctx)
+
+		first := h.nextCommittedBlock(t)
+		second := h.nextCommittedBlock(t)
+
+		require.EqualValues(t, 1, first.TransactionsBlock.Header.BlockHeight())
+		require.EqualValues(t, 2, second.TransactionsBlock.Header.BlockHeight())
+		require.EqualValues(t, digest.CalcTransactionsBlockHash(first.TransactionsBlock), second.TransactionsBlock.Header.PrevBlockHashPtr(), "second block should point to the first block")
+
+		require.NoError(t, s.validateBlockConsensus(second, first), "committed blocks should carry a valid block proof")
+	})
+}
+
+func TestDevConsensus_ContinuesFromLastCommittedBlockInStorage(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		h := newHarness(parent, consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX) // inactive so it does not start committing before being updated
+		s := h.start(ctx)
+
+		lastBlock := builders.BlockPair().WithHeight(7).Build()
+		_, err := s.HandleBlockConsensus(ctx, &handlers.HandleBlockConsensusInput{
+			Mode:      handlers.HANDLE_BLOCK_CONSENSUS_MODE_UPDATE_ONLY,
+			BlockType: protocol.BLOCK_TYPE_BLOCK_PAIR,
+			BlockPair: lastBlock,
+		})
+		require.NoError(t, err)
+
+		h.expectBlocksToBeCreatedAndCommitted()
+		require.NoError(t, s.commitNextBlock(ctx))
+		require.EqualValues(t, 8, h.nextCommittedBlock(t).TransactionsBlock.Header.BlockHeight())
+	})
+}
+
+func TestDevConsensus_RejectsBlocksNotSignedByThisNode(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, parent *with.ConcurrencyHarness) {
+		h := newHarness(parent, consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX)
+		s := h.start(ctx)
+
+		_, err := s.HandleBlockConsensus(ctx, &handlers.HandleBlockConsensusInput{
+			Mode:      handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_ONLY,
+			BlockType: protocol.BLOCK_TYPE_BLOCK_PAIR,
+			BlockPair: builders.BlockPair().WithHeight(1).WithBenchmarkConsensusBlockProof(h.config.keyPair).Build(),
+		})
+		require.NoError(t, err, "block signed by this node should be valid")
+
+		_, err = s.HandleBlockConsensus(ctx, &handlers.HandleBlockConsensusInput{
+			Mode:      handlers.HANDLE_BLOCK_CONSENSUS_MODE_VERIFY_ONLY,
+			BlockType: protocol.BLOCK_TYPE_BLOCK_PAIR,
+			BlockPair: builders.BlockPair().WithHeight(1).WithBenchmarkConsensusBlockProof(testKeys.EcdsaSecp256K1KeyPairForTests(1)).Build(),
+		})
+		require.Error(t, err, "block signed by another node should be rejected")
+	})
+}
@@ -13,6 +13,7 @@ import (
 	lh "github.com/orbs-network/lean-helix-go/services/interfaces"
 	lhprimitives "github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/services/processor"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/services"
@@ -52,6 +53,8 @@ func FromLeanHelixBlock(lhBlock lh.Block) *protocol.BlockPairContainer {
 	return nil
 }
 
+const MAX_PROPOSAL_ATTEMPTS = 3
+
 type blockProvider struct {
 	logger           log.Logger
 	blockStorage     services.BlockStorage
@@ -95,30 +98,42 @@ func (p *blockProvider) RequestNewBlockProposal(ctx context.Context, blockHeight
 
 	blockProposerAddress := primitives.NodeAddress(blockProposer) // TODO Noam maybe need an empty convertor in lhprimitives
 
-	// get tx
-	txOutput, err := p.consensusContext.RequestNewTransactionsBlock(ctx, &services.RequestNewTransactionsBlockInput{
-		CurrentBlockHeight:      currentBlockHeight,
-		PrevBlockHash:           prevTxBlockHash,
-		PrevBlockTimestamp:      prevBlockTimestamp,
-		MaxNumberOfTransactions: maxNumOfTransactions,
-		MaxBlockSizeKb:          maxBlockSize,
-		BlockProposerAddress:    blockProposerAddress,
-		PrevBlockReferenceTime:  prevBlockReferenceTime,
-	})
-	if err != nil {
-		return nil, nil
-	}
+	var txOutput *services.RequestNewTransactionsBlockOutput
+	var rxOutput *services.RequestNewResultsBlockOutput
+	for attempt := 1; ; attempt++ {
+		// get tx
+		var err error
+		txOutput, err = p.consensusContext.RequestNewTransactionsBlock(ctx, &services.RequestNewTransactionsBlockInput{
+			CurrentBlockHeight:      currentBlockHeight,
+			PrevBlockHash:           prevTxBlockHash,
+			PrevBlockTimestamp:      prevBlockTimestamp,
+			MaxNumberOfTransactions: maxNumOfTransactions,
+			MaxBlockSizeKb:          maxBlockSize,
+			BlockProposerAddress:    blockProposerAddress,
+			PrevBlockReferenceTime:  prevBlockReferenceTime,
+		})
+		if err != nil {
+			return nil, nil
+		}
 
-	// get rx
-	rxOutput, err := p.consensusContext.RequestNewResultsBlock(ctx, &services.RequestNewResultsBlockInput{
-		CurrentBlockHeight:   currentBlockHeight,
-		PrevBlockHash:        prevRxBlockHash,
-		TransactionsBlock:    txOutput.TransactionsBlock,
-		PrevBlockTimestamp:   prevBlockTimestamp,
-		BlockProposerAddress: blockProposerAddress, PrevBlockReferenceTime: prevBlockReferenceTime,
-	})
-	if err != nil {
-		return nil, nil
+		// get rx
+		rxOutput, err = p.consensusContext.RequestNewResultsBlock(ctx, &services.RequestNewResultsBlockInput{
+			CurrentBlockHeight:   currentBlockHeight,
+			PrevBlockHash:        prevRxBlockHash,
+			TransactionsBlock:    txOutput.TransactionsBlock,
+			PrevBlockTimestamp:   prevBlockTimestamp,
+			BlockProposerAddress: blockProposerAddress, PrevBlockReferenceTime: prevBlockReferenceTime,
+		})
+		if err == nil {
+			break
+		}
+
+		// the pre order of this node rejects a transaction it aborted, so the transactions pool drops it from the next
+		// transactions block instead of failing every proposal which includes it
+		if !processor.IsExecutionAborted(err) || attempt == MAX_PROPOSAL_ATTEMPTS || ctx.Err() != nil {
+			return nil, nil
+		}
+		p.logger.Info("RequestNewBlockProposal() dropping a transaction aborted by this node from the proposal", logfields.BlockHeight(currentBlockHeight), log.Error(err))
 	}
 
 	blockPair := &protocol.BlockPairContainer{
@@ -7,9 +7,16 @@
 package leanhelixconsensus
 
 import (
+	"context"
 	"github.com/orbs-network/crypto-lib-go/crypto/hash"
+	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -95,3 +102,47 @@ func TestValidLeanHelixBlockPair_FailOnNilBlockProof(t *testing.T) {
 func TestValidLeanHelixBlockPair_FailOnDifferentBlockProofsBetweenTransactionsAndResultsBlocks(t *testing.T) {
 	require.Error(t, validLeanHelixBlockPair(NewTestBlock().withDifferentTxAndRxBlockProofs().build()), "should return error if block proofs in transactions and results block are different")
 }
+
+func TestRequestNewBlockProposal_ProposesAgainWhenATransactionIsAbortedByTheNode(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blockPair := builders.BlockPair().Build()
+			consensusContext := &services.MockConsensusContext{}
+			consensusContext.When("RequestNewTransactionsBlock", mock.Any, mock.Any).Return(&services.RequestNewTransactionsBlockOutput{TransactionsBlock: blockPair.TransactionsBlock}, nil).Times(2)
+			resultsRequests := 0
+			consensusContext.When("RequestNewResultsBlock", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.RequestNewResultsBlockInput) (*services.RequestNewResultsBlockOutput, error) {
+				resultsRequests++
+				if resultsRequests == 1 {
+					return nil, errors.Wrap(processor.ErrExecutionAborted, "call exceeded the timeout")
+				}
+				return &services.RequestNewResultsBlockOutput{ResultsBlock: blockPair.ResultsBlock}, nil
+			}).Times(2)
+
+			p := NewBlockProvider(harness.Logger, nil, consensusContext)
+			block, blockHash := p.RequestNewBlockProposal(ctx, 1, nil, nil)
+
+			require.NotNil(t, block, "should propose a block without the aborted transaction")
+			require.NotEmpty(t, blockHash)
+			ok, err := consensusContext.Verify()
+			require.True(t, ok, "should request the transactions block again", err)
+		})
+	})
+}
+
+func TestRequestNewBlockProposal_DoesNotProposeAgainWhenResultsFail(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			blockPair := builders.BlockPair().Build()
+			consensusContext := &services.MockConsensusContext{}
+			consensusContext.When("RequestNewTransactionsBlock", mock.Any, mock.Any).Return(&services.RequestNewTransactionsBlockOutput{TransactionsBlock: blockPair.TransactionsBlock}, nil).Times(1)
+			consensusContext.When("RequestNewResultsBlock", mock.Any, mock.Any).Return(nil, errors.New("state unavailable")).Times(1)
+
+			p := NewBlockProvider(harness.Logger, nil, consensusContext)
+			block, _ := p.RequestNewBlockProposal(ctx, 1, nil, nil)
+
+			require.Nil(t, block)
+			ok, err := consensusContext.Verify()
+			require.True(t, ok, err)
+		})
+	})
+}
@@ -15,19 +15,22 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
 	"github.com/orbs-network/orbs-spec/types/go/services/gossiptopics"
 	"github.com/orbs-network/scribe/log"
+	"time"
 )
 
 type communication struct {
 	logger                  log.Logger
 	gossip                  gossiptopics.LeanHelix
+	timeline                *timeline
 	messageReceiversCounter int
 	//messageReceivers        map[int]leanhelix.MessageHandler
 }
 
-func NewCommunication(logger log.Logger, gossip gossiptopics.LeanHelix) *communication {
+func NewCommunication(logger log.Logger, gossip gossiptopics.LeanHelix, timeline *timeline) *communication {
 	return &communication{
-		logger: logger,
-		gossip: gossip,
+		logger:   logger,
+		gossip:   gossip,
+		timeline: timeline,
 		//messageReceivers:        make(map[int]leanhelix.MessageHandler),
 		messageReceiversCounter: 0,
 	}
@@ -58,6 +61,10 @@ func (comm *communication) SendConsensusMessage(ctx context.Context, lhtargets [
 			BlockPair: blockPair,
 		},
 	}
+	if lhMessage := lh.ToConsensusMessage(consensusRawMessage); lhMessage != nil {
+		comm.timeline.recordMessage(time.Now(), TIMELINE_DIRECTION_OUTGOING, lhMessage.MessageType(), lhMessage.BlockHeight(), lhMessage.View(), lhMessage.SenderMemberId())
+	}
+
 	_, err := comm.gossip.SendLeanHelixMessage(ctx, message)
 	return err
 }
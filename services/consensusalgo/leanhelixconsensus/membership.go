@@ -17,6 +17,7 @@ import (
 	"github.com/orbs-network/scribe/log"
 	"strconv"
 	"strings"
+	"sync"
 )
 
 type membership struct {
@@ -24,6 +25,12 @@ type membership struct {
 	consensusContext services.ConsensusContext
 	logger           log.Logger
 	maxCommitteeSize uint32
+
+	lastCommittee struct {
+		sync.RWMutex
+		blockHeight lhprimitives.BlockHeight
+		members     []lh.CommitteeMember
+	}
 }
 
 func NewMembership(logger log.Logger, memberId primitives.NodeAddress, consensusContext services.ConsensusContext, maxCommitteeSize uint32) *membership {
@@ -56,6 +63,11 @@ func (m *membership) RequestOrderedCommittee(ctx context.Context, blockHeight lh
 	}
 
 	committeeMembers := toMembers(res.NodeAddresses, res.Weights)
+	m.lastCommittee.Lock()
+	m.lastCommittee.blockHeight = blockHeight
+	m.lastCommittee.members = committeeMembers
+	m.lastCommittee.Unlock()
+
 	committeeMembersStr := toMembersString(res.NodeAddresses, res.Weights)
 	// random-seed printed as string for logz.io, do not change it back to log.Uint64()
 	m.logger.Info("Received committee members", logfields.BlockHeight(primitives.BlockHeight(blockHeight)), log.Uint32("prev-block-ref-time", uint32(prevBlockReferenceTime)), log.String("random-seed", strconv.FormatUint(seed, 10)), log.String("committee-members", committeeMembersStr))
@@ -63,6 +75,22 @@ func (m *membership) RequestOrderedCommittee(ctx context.Context, blockHeight lh
 	return committeeMembers, nil
 }
 
+// only the committee of the last height lean helix asked for is known, members of other heights are reported as not members
+func (m *membership) isLastCommitteeMember(blockHeight lhprimitives.BlockHeight, memberId lhprimitives.MemberId) bool {
+	m.lastCommittee.RLock()
+	defer m.lastCommittee.RUnlock()
+
+	if m.lastCommittee.blockHeight != blockHeight {
+		return false
+	}
+	for _, member := range m.lastCommittee.members {
+		if member.Id.Equal(memberId) {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *membership) RequestCommitteeForBlockProof(ctx context.Context, prevBlockReferenceTime lhprimitives.TimestampSeconds) ([]lh.CommitteeMember, error) {
 	res, err := m.consensusContext.RequestBlockProofOrderingCommittee(ctx, &services.RequestBlockProofCommitteeInput{
 		PrevBlockReferenceTime: primitives.TimestampSeconds(prevBlockReferenceTime),
@@ -14,6 +14,7 @@ import (
 	"github.com/orbs-network/lean-helix-go"
 	lhmetrics "github.com/orbs-network/lean-helix-go/instrumentation/metrics"
 	lh "github.com/orbs-network/lean-helix-go/services/interfaces"
+	lhprotocol "github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
@@ -31,16 +32,22 @@ import (
 
 var LogTag = log.Service("consensus-algo-lean-helix")
 
+// incoming messages wait for their timeline verification in this queue, messages arriving when it is full are not recorded
+const TIMELINE_VERIFICATION_QUEUE_SIZE = 100
+
 type Service struct {
 	govnr.TreeSupervisor
 	blockStorage     services.BlockStorage
 	membership       *membership
+	keyManager       *keyManager
 	com              *communication
 	blockProvider    *blockProvider
 	logger           log.Logger
 	config           config.LeanHelixConsensusConfig
 	metrics          *metrics
 	leanHelix        *leanhelix.MainLoop
+	timeline         *timeline
+	timelineQueue    chan *timelineMessage
 	lastCommitTime   time.Time
 	lastElectionTime time.Time
 }
@@ -79,7 +86,8 @@ func NewLeanHelixConsensusAlgo(
 	logger := parentLogger.WithTags(LogTag, trace.LogFieldFrom(ctx))
 
 	logger.Info("NewLeanHelixConsensusAlgo() start", log.String("node-address", config.NodeAddress().String()))
-	com := NewCommunication(logger, gossip)
+	timeline := newTimeline(config.LeanHelixTimelineMaxHeights())
+	com := NewCommunication(logger, gossip, timeline)
 	membership := NewMembership(logger, config.NodeAddress(), consensusContext, config.LeanHelixConsensusMaximumCommitteeSize())
 	mgr := NewKeyManager(logger, signer)
 
@@ -89,12 +97,16 @@ func NewLeanHelixConsensusAlgo(
 
 	s := &Service{
 		com:           com,
+		membership:    membership,
+		keyManager:    mgr,
 		blockStorage:  blockStorage,
 		logger:        logger,
 		config:        config,
 		blockProvider: provider,
 		metrics:       newMetrics(metricFactory),
 		leanHelix:     nil,
+		timeline:      timeline,
+		timelineQueue: make(chan *timelineMessage, TIMELINE_VERIFICATION_QUEUE_SIZE),
 	}
 
 	leanHelixConfig := &lh.Config{
@@ -114,6 +126,7 @@ func NewLeanHelixConsensusAlgo(
 	if config.ActiveConsensusAlgo() == consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX {
 		waiter := s.leanHelix.Run(ctx)
 		s.Supervise(waiter)
+		s.Supervise(s.recordVerifiedTimelineMessages(ctx))
 		gossip.RegisterLeanHelixHandler(s)
 	} else {
 		parentLogger.Info("NewLeanHelixConsensusAlgo() LeanHelix is not the active consensus algo so not starting its goroutine, only registering for block validation")
@@ -168,7 +181,7 @@ func (s *Service) HandleBlockConsensus(ctx context.Context, input *handlers.Hand
 				return nil, err
 			}
 			lhBlock = ToLeanHelixBlock(blockPair)
-
+			s.timeline.advanceTo(lhBlock.Height() + 1)
 		}
 
 		// do not add a "go" command here (so this step becomes async tell and doesn't block the block sync) because we want to control the sync rate
@@ -207,10 +220,76 @@ func (s *Service) HandleLeanHelixMessage(ctx context.Context, input *gossiptopic
 		Content: input.Message.Content,
 		Block:   ToLeanHelixBlock(input.Message.BlockPair),
 	}
+	if lhMessage := lh.ToConsensusMessage(consensusRawMessage); lhMessage != nil {
+		s.queueForTimeline(&timelineMessage{receivedAt: time.Now(), message: lhMessage})
+	}
 	s.leanHelix.HandleConsensusMessage(ctx, consensusRawMessage)
 	return nil, nil
 }
 
+type timelineMessage struct {
+	receivedAt time.Time
+	message    lh.ConsensusMessage
+}
+
+// never blocks the consensus messages, the timeline is for diagnostics only
+func (s *Service) queueForTimeline(m *timelineMessage) {
+	select {
+	case s.timelineQueue <- m:
+	default:
+	}
+}
+
+func (s *Service) recordVerifiedTimelineMessages(ctx context.Context) govnr.ShutdownWaiter {
+	return govnr.Forever(ctx, "lean helix timeline recorder", logfields.GovnrErrorer(s.logger), func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case m := <-s.timelineQueue:
+				s.recordIfVerified(m)
+			}
+		}
+	})
+}
+
+func (s *Service) recordIfVerified(m *timelineMessage) {
+	if s.isVerifiedForTimeline(m.message) {
+		s.timeline.recordMessage(m.receivedAt, TIMELINE_DIRECTION_INCOMING, m.message.MessageType(), m.message.BlockHeight(), m.message.View(), m.message.SenderMemberId())
+	}
+}
+
+// lean helix verifies messages internally without reporting the outcome, so the timeline repeats the cheap checks first and the
+// signature check last, away from the consensus messages handling
+func (s *Service) isVerifiedForTimeline(message lh.ConsensusMessage) bool {
+	if !s.timeline.inWindow(message.BlockHeight()) {
+		return false
+	}
+
+	if !s.membership.isLastCommitteeMember(message.BlockHeight(), message.SenderMemberId()) {
+		return false
+	}
+
+	var signedHeader []byte
+	var sender *lhprotocol.SenderSignature
+	switch m := message.(type) {
+	case *lh.PreprepareMessage:
+		signedHeader, sender = m.Content().SignedHeader().Raw(), m.Content().Sender()
+	case *lh.PrepareMessage:
+		signedHeader, sender = m.Content().SignedHeader().Raw(), m.Content().Sender()
+	case *lh.CommitMessage:
+		signedHeader, sender = m.Content().SignedHeader().Raw(), m.Content().Sender()
+	case *lh.ViewChangeMessage:
+		signedHeader, sender = m.Content().SignedHeader().Raw(), m.Content().Sender()
+	case *lh.NewViewMessage:
+		signedHeader, sender = m.Content().SignedHeader().Raw(), m.Content().Sender()
+	default:
+		return false
+	}
+
+	return s.keyManager.VerifyConsensusMessage(message.BlockHeight(), signedHeader, sender) == nil
+}
+
 func (s *Service) onCommit(ctx context.Context, block lh.Block, blockProof []byte) error {
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 	logger.Info("YEYYYY CONSENSUS!!!! will save to block storage", logfields.BlockHeight(primitives.BlockHeight(block.Height())))
@@ -227,6 +306,7 @@ func (s *Service) onCommit(ctx context.Context, block lh.Block, blockProof []byt
 		return err // TODO add metrics for storage failure
 	}
 	now := time.Now()
+	s.timeline.recordCommitted(now, block.Height())
 	s.metrics.lastCommittedTime.Update(now.UnixNano())
 	s.metrics.timeSinceLastCommitMillis.RecordSince(s.lastCommitTime)
 	s.lastCommitTime = now
@@ -254,11 +334,17 @@ func (s *Service) onElection(m lhmetrics.ElectionMetrics) {
 	s.metrics.currentLeaderMemberId.Update(string(memberIdStr))
 	s.metrics.currentElectionCount.Update(int64(m.CurrentView()))
 	now := time.Now()
+	s.timeline.recordElection(now, m.CurrentView(), m.CurrentLeaderMemberId())
 	s.metrics.timeSinceLastElectionMillis.RecordSince(s.lastElectionTime)
 	s.lastElectionTime = now
 	s.logger.Info("onElection()", log.String("lh-leader-member-id", memberIdStr), log.Int64("lh-view", int64(m.CurrentView())))
 }
 
+// exported as an untyped value so http handlers can serialize it without depending on this package
+func (s *Service) ExportConsensusTimeline(lastHeights int) interface{} {
+	return s.timeline.lastHeights(lastHeights)
+}
+
 func (s *Service) saveToBlockStorage(ctx context.Context, blockPair *protocol.BlockPairContainer) error {
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 	if blockPair.TransactionsBlock.Header.BlockHeight() == 0 {
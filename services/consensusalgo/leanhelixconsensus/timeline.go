@@ -0,0 +1,229 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelixconsensus
+
+import (
+	lhprimitives "github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	lhprotocol "github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	"sort"
+	"sync"
+	"time"
+)
+
+const TIMELINE_MAX_EVENTS_PER_HEIGHT = 2000
+
+// incoming messages are recorded only for heights this close ahead of the height currently being agreed on
+const TIMELINE_MAX_HEIGHTS_AHEAD = 2
+
+const (
+	TIMELINE_EVENT_PROPOSAL    = "proposal"
+	TIMELINE_EVENT_PREPARE     = "prepare"
+	TIMELINE_EVENT_COMMIT      = "commit"
+	TIMELINE_EVENT_VIEW_CHANGE = "view-change"
+	TIMELINE_EVENT_NEW_VIEW    = "new-view"
+	TIMELINE_EVENT_ELECTION    = "election"
+	TIMELINE_EVENT_COMMITTED   = "committed"
+)
+
+const (
+	TIMELINE_DIRECTION_INCOMING = "incoming"
+	TIMELINE_DIRECTION_OUTGOING = "outgoing"
+	TIMELINE_DIRECTION_LOCAL    = "local"
+)
+
+type TimelineEvent struct {
+	Time      time.Time
+	Type      string
+	Direction string
+	View      uint64
+	Sender    string `json:",omitempty"`
+}
+
+type HeightTimeline struct {
+	BlockHeight    uint64
+	FirstEventTime time.Time
+	CommitTime     time.Time `json:",omitempty"`
+	DurationMillis int64     `json:",omitempty"`
+	DroppedEvents  int       `json:",omitempty"`
+	Events         []*TimelineEvent
+}
+
+// timeline keeps the consensus events of the last maxHeights block heights in a ring buffer, oldest heights are evicted first
+type timeline struct {
+	sync.RWMutex
+	maxHeights    int
+	heights       []*HeightTimeline
+	next          int
+	byHeight      map[uint64]*HeightTimeline
+	currentHeight uint64
+}
+
+func newTimeline(maxHeights uint32) *timeline {
+	return &timeline{
+		maxHeights: int(maxHeights),
+		heights:    make([]*HeightTimeline, int(maxHeights)),
+		byHeight:   make(map[uint64]*HeightTimeline),
+	}
+}
+
+func timelineEventTypeFor(messageType lhprotocol.MessageType) string {
+	switch messageType {
+	case lhprotocol.LEAN_HELIX_PREPREPARE:
+		return TIMELINE_EVENT_PROPOSAL
+	case lhprotocol.LEAN_HELIX_PREPARE:
+		return TIMELINE_EVENT_PREPARE
+	case lhprotocol.LEAN_HELIX_COMMIT:
+		return TIMELINE_EVENT_COMMIT
+	case lhprotocol.LEAN_HELIX_VIEW_CHANGE:
+		return TIMELINE_EVENT_VIEW_CHANGE
+	case lhprotocol.LEAN_HELIX_NEW_VIEW:
+		return TIMELINE_EVENT_NEW_VIEW
+	default:
+		return messageType.String()
+	}
+}
+
+// incoming messages outside the window of recorded heights are ignored and never move the current height, so peers cannot evict the heights being watched
+func (t *timeline) recordMessage(now time.Time, direction string, messageType lhprotocol.MessageType, blockHeight lhprimitives.BlockHeight, view lhprimitives.View, sender lhprimitives.MemberId) {
+	incoming := direction == TIMELINE_DIRECTION_INCOMING
+	if incoming && !t.inWindow(blockHeight) {
+		return
+	}
+
+	t.record(uint64(blockHeight), !incoming, &TimelineEvent{
+		Time:      now,
+		Type:      timelineEventTypeFor(messageType),
+		Direction: direction,
+		View:      uint64(view),
+		Sender:    sender.String(),
+	})
+}
+
+// heights from the oldest one kept up to a few heights ahead of the height currently being agreed on
+func (t *timeline) inWindow(blockHeight lhprimitives.BlockHeight) bool {
+	t.RLock()
+	defer t.RUnlock()
+
+	height := uint64(blockHeight)
+	if height > t.currentHeight+TIMELINE_MAX_HEIGHTS_AHEAD {
+		return false
+	}
+	return height+uint64(t.maxHeights) > t.currentHeight
+}
+
+// called with heights known from committed blocks, also those committed by block sync
+func (t *timeline) advanceTo(blockHeight lhprimitives.BlockHeight) {
+	t.Lock()
+	defer t.Unlock()
+
+	if uint64(blockHeight) > t.currentHeight {
+		t.currentHeight = uint64(blockHeight)
+	}
+}
+
+// elections are not reported with a block height, they belong to the height currently being agreed on
+func (t *timeline) recordElection(now time.Time, view lhprimitives.View, leader lhprimitives.MemberId) {
+	t.RLock()
+	height := t.currentHeight
+	t.RUnlock()
+
+	t.record(height, false, &TimelineEvent{
+		Time:      now,
+		Type:      TIMELINE_EVENT_ELECTION,
+		Direction: TIMELINE_DIRECTION_LOCAL,
+		View:      uint64(view),
+		Sender:    leader.String(),
+	})
+}
+
+func (t *timeline) recordCommitted(now time.Time, blockHeight lhprimitives.BlockHeight) {
+	t.Lock()
+	defer t.Unlock()
+
+	h := t.heightTimeline(uint64(blockHeight), now)
+	if h == nil {
+		return
+	}
+	h.CommitTime = now
+	h.DurationMillis = now.Sub(h.FirstEventTime).Nanoseconds() / int64(time.Millisecond)
+	t.appendEvent(h, &TimelineEvent{Time: now, Type: TIMELINE_EVENT_COMMITTED, Direction: TIMELINE_DIRECTION_LOCAL})
+	if uint64(blockHeight) >= t.currentHeight {
+		t.currentHeight = uint64(blockHeight) + 1
+	}
+}
+
+func (t *timeline) record(blockHeight uint64, advance bool, event *TimelineEvent) {
+	t.Lock()
+	defer t.Unlock()
+
+	h := t.heightTimeline(blockHeight, event.Time)
+	if h == nil {
+		return
+	}
+	t.appendEvent(h, event)
+	if advance && blockHeight > t.currentHeight {
+		t.currentHeight = blockHeight
+	}
+}
+
+func (t *timeline) appendEvent(h *HeightTimeline, event *TimelineEvent) {
+	if len(h.Events) >= TIMELINE_MAX_EVENTS_PER_HEIGHT {
+		h.DroppedEvents++
+		return
+	}
+	h.Events = append(h.Events, event)
+}
+
+// must be called under lock, returns nil for heights that were already evicted from the ring
+func (t *timeline) heightTimeline(blockHeight uint64, now time.Time) *HeightTimeline {
+	if t.maxHeights == 0 {
+		return nil
+	}
+
+	if h, exists := t.byHeight[blockHeight]; exists {
+		return h
+	}
+
+	if evicted := t.heights[t.next]; evicted != nil {
+		if blockHeight < evicted.BlockHeight {
+			return nil
+		}
+		delete(t.byHeight, evicted.BlockHeight)
+	}
+
+	h := &HeightTimeline{
+		BlockHeight:    blockHeight,
+		FirstEventTime: now,
+	}
+	t.heights[t.next] = h
+	t.byHeight[blockHeight] = h
+	t.next = (t.next + 1) % t.maxHeights
+	return h
+}
+
+// returns copies of the timelines of the last count heights seen, ordered by ascending block height
+func (t *timeline) lastHeights(count int) []*HeightTimeline {
+	t.RLock()
+	defer t.RUnlock()
+
+	heights := make([]uint64, 0, len(t.byHeight))
+	for height := range t.byHeight {
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	if count > 0 && count < len(heights) {
+		heights = heights[len(heights)-count:]
+	}
+
+	result := make([]*HeightTimeline, 0, len(heights))
+	for _, height := range heights {
+		copied := *t.byHeight[height]
+		copied.Events = append([]*TimelineEvent(nil), copied.Events...)
+		result = append(result, &copied)
+	}
+	return result
+}
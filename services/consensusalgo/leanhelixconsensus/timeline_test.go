@@ -0,0 +1,169 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package leanhelixconsensus
+
+import (
+	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/signer"
+	lh "github.com/orbs-network/lean-helix-go/services/interfaces"
+	"github.com/orbs-network/lean-helix-go/services/messagesfactory"
+	lhprimitives "github.com/orbs-network/lean-helix-go/spec/types/go/primitives"
+	lhprotocol "github.com/orbs-network/lean-helix-go/spec/types/go/protocol"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTimeline_RecordsEventsPerHeight(t *testing.T) {
+	tl := newTimeline(10)
+	start := time.Now()
+	sender := lhprimitives.MemberId{0x01, 0x02}
+	tl.advanceTo(5)
+
+	tl.recordMessage(start, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPREPARE, 5, 0, sender)
+	tl.recordMessage(start.Add(time.Second), TIMELINE_DIRECTION_OUTGOING, lhprotocol.LEAN_HELIX_PREPARE, 5, 0, sender)
+	tl.recordElection(start.Add(2*time.Second), 1, sender)
+	tl.recordCommitted(start.Add(3*time.Second), 5)
+
+	heights := tl.lastHeights(10)
+	require.Len(t, heights, 1)
+	require.EqualValues(t, 5, heights[0].BlockHeight)
+	require.EqualValues(t, 3000, heights[0].DurationMillis)
+
+	var types []string
+	for _, event := range heights[0].Events {
+		types = append(types, event.Type)
+	}
+	require.Equal(t, []string{TIMELINE_EVENT_PROPOSAL, TIMELINE_EVENT_PREPARE, TIMELINE_EVENT_ELECTION, TIMELINE_EVENT_COMMITTED}, types)
+	require.Equal(t, sender.String(), heights[0].Events[0].Sender)
+	require.EqualValues(t, 1, heights[0].Events[2].View, "election should be recorded with its view")
+}
+
+func TestTimeline_EvictsOldestHeights(t *testing.T) {
+	tl := newTimeline(3)
+	now := time.Now()
+
+	for height := lhprimitives.BlockHeight(1); height <= 5; height++ {
+		tl.recordMessage(now, TIMELINE_DIRECTION_OUTGOING, lhprotocol.LEAN_HELIX_COMMIT, height, 0, nil)
+	}
+
+	heights := tl.lastHeights(0)
+	require.Len(t, heights, 3)
+	require.EqualValues(t, 3, heights[0].BlockHeight)
+	require.EqualValues(t, 5, heights[2].BlockHeight)
+
+	tl.recordMessage(now, TIMELINE_DIRECTION_OUTGOING, lhprotocol.LEAN_HELIX_COMMIT, 1, 0, nil)
+	require.EqualValues(t, 3, tl.lastHeights(0)[0].BlockHeight, "messages of evicted heights should be ignored")
+}
+
+func TestTimeline_ReturnsRequestedNumberOfLastHeights(t *testing.T) {
+	tl := newTimeline(10)
+	now := time.Now()
+
+	for height := lhprimitives.BlockHeight(1); height <= 5; height++ {
+		tl.recordCommitted(now, height)
+	}
+
+	heights := tl.lastHeights(2)
+	require.Len(t, heights, 2)
+	require.EqualValues(t, 4, heights[0].BlockHeight)
+	require.EqualValues(t, 5, heights[1].BlockHeight)
+}
+
+func TestTimeline_BoundsEventsPerHeight(t *testing.T) {
+	tl := newTimeline(1)
+	now := time.Now()
+
+	for i := 0; i < TIMELINE_MAX_EVENTS_PER_HEIGHT+3; i++ {
+		tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 1, 0, nil)
+	}
+
+	heights := tl.lastHeights(1)
+	require.Len(t, heights[0].Events, TIMELINE_MAX_EVENTS_PER_HEIGHT)
+	require.Equal(t, 3, heights[0].DroppedEvents)
+}
+
+func TestTimeline_IgnoresIncomingMessagesOutsideTheWindowOfHeights(t *testing.T) {
+	tl := newTimeline(3)
+	now := time.Now()
+	tl.advanceTo(10)
+
+	tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 7, 0, nil)
+	tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 10+TIMELINE_MAX_HEIGHTS_AHEAD+1, 0, nil)
+	tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 1000000, 0, nil)
+	require.Empty(t, tl.lastHeights(0), "messages of heights outside the window should be ignored")
+
+	tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 8, 0, nil)
+	tl.recordMessage(now, TIMELINE_DIRECTION_INCOMING, lhprotocol.LEAN_HELIX_PREPARE, 10+TIMELINE_MAX_HEIGHTS_AHEAD, 0, nil)
+	require.Len(t, tl.lastHeights(0), 2)
+
+	require.True(t, tl.inWindow(10+TIMELINE_MAX_HEIGHTS_AHEAD), "incoming messages should not move the current height")
+	require.False(t, tl.inWindow(10+TIMELINE_MAX_HEIGHTS_AHEAD+1), "incoming messages should not move the current height")
+}
+
+func TestTimeline_RecordsOnlyVerifiedMessagesOfCommitteeMembers(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			member := testKeys.EcdsaSecp256K1KeyPairForTests(0)
+			other := testKeys.EcdsaSecp256K1KeyPairForTests(1)
+			memberKeyManager := NewKeyManager(harness.Logger, signer.NewLocalSigner(member.PrivateKey()))
+			otherKeyManager := NewKeyManager(harness.Logger, signer.NewLocalSigner(other.PrivateKey()))
+
+			s := &Service{
+				timeline:   newTimeline(10),
+				membership: &membership{},
+				keyManager: memberKeyManager,
+			}
+			s.timeline.advanceTo(5)
+			s.membership.lastCommittee.blockHeight = 5
+			s.membership.lastCommittee.members = []lh.CommitteeMember{{Id: lhprimitives.MemberId(member.NodeAddress()), Weight: 1}}
+
+			signedByMember := messagesfactory.NewMessageFactory(0, memberKeyManager, lhprimitives.MemberId(member.NodeAddress()), 0)
+			require.True(t, s.isVerifiedForTimeline(signedByMember.CreatePrepareMessage(5, 0, nil)), "message of committee member should be recorded")
+			require.False(t, s.isVerifiedForTimeline(signedByMember.CreatePrepareMessage(6, 0, nil)), "message of a height with unknown committee should not be recorded")
+
+			signedByOther := messagesfactory.NewMessageFactory(0, otherKeyManager, lhprimitives.MemberId(other.NodeAddress()), 0)
+			require.False(t, s.isVerifiedForTimeline(signedByOther.CreatePrepareMessage(5, 0, nil)), "message of node outside the committee should not be recorded")
+
+			impersonatingMember := messagesfactory.NewMessageFactory(0, otherKeyManager, lhprimitives.MemberId(member.NodeAddress()), 0)
+			require.False(t, s.isVerifiedForTimeline(impersonatingMember.CreatePrepareMessage(5, 0, nil)), "message with invalid signature should not be recorded")
+		})
+	})
+}
+
+func TestTimeline_RecordsQueuedMessagesAtTheirReceiveTimeAndDropsThemWhenTheQueueIsFull(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			member := testKeys.EcdsaSecp256K1KeyPairForTests(0)
+			memberKeyManager := NewKeyManager(harness.Logger, signer.NewLocalSigner(member.PrivateKey()))
+
+			s := &Service{
+				timeline:      newTimeline(10),
+				timelineQueue: make(chan *timelineMessage, 1),
+				membership:    &membership{},
+				keyManager:    memberKeyManager,
+			}
+			s.timeline.advanceTo(5)
+			s.membership.lastCommittee.blockHeight = 5
+			s.membership.lastCommittee.members = []lh.CommitteeMember{{Id: lhprimitives.MemberId(member.NodeAddress()), Weight: 1}}
+
+			signedByMember := messagesfactory.NewMessageFactory(0, memberKeyManager, lhprimitives.MemberId(member.NodeAddress()), 0)
+			receivedAt := time.Unix(1000, 0)
+			s.queueForTimeline(&timelineMessage{receivedAt: receivedAt, message: signedByMember.CreatePrepareMessage(5, 0, nil)})
+			s.queueForTimeline(&timelineMessage{receivedAt: receivedAt, message: signedByMember.CreateCommitMessage(5, 0, nil)})
+			require.Len(t, s.timelineQueue, 1, "message arriving while the queue is full should be dropped without blocking")
+
+			s.recordIfVerified(<-s.timelineQueue)
+			heights := s.timeline.lastHeights(0)
+			require.Len(t, heights, 1)
+			require.Len(t, heights[0].Events, 1)
+			require.Equal(t, receivedAt, heights[0].Events[0].Time, "message should be recorded at the time it was received")
+		})
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package consensusalgo
+
+import (
+	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/signer"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/consensusalgo/benchmarkconsensus"
+	"github.com/orbs-network/orbs-network-go/services/consensusalgo/devconsensus"
+	"github.com/orbs-network/orbs-network-go/services/consensusalgo/leanhelixconsensus"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sort"
+	"sync"
+)
+
+type ConsensusAlgo interface {
+	services.ConsensusAlgo
+	govnr.ShutdownWaiter
+}
+
+// Dependencies are the node services a consensus algo wires itself to when created,
+// an algo registers its own gossip topic handler on Gossip and its block handler on BlockStorage
+type Dependencies struct {
+	Gossip           services.Gossip
+	BlockStorage     services.BlockStorage
+	ConsensusContext services.ConsensusContext
+	Signer           signer.Signer
+	Logger           log.Logger
+	Config           config.NodeConfig
+	MetricFactory    metric.Factory
+}
+
+type Factory func(ctx context.Context, deps *Dependencies) ConsensusAlgo
+
+type Registry struct {
+	mutex     sync.RWMutex
+	factories map[consensus.ConsensusAlgoType]Factory
+}
+
+// DefaultRegistry is used by the node to create the active consensus algo, algos registered on it before the node starts can be activated by config
+var DefaultRegistry = NewRegistryWithBuiltInAlgos()
+
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[consensus.ConsensusAlgoType]Factory),
+	}
+}
+
+func NewRegistryWithBuiltInAlgos() *Registry {
+	r := NewRegistry()
+	r.mustRegister(consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX, func(ctx context.Context, deps *Dependencies) ConsensusAlgo {
+		return leanhelixconsensus.NewLeanHelixConsensusAlgo(ctx, deps.Gossip, deps.BlockStorage, deps.ConsensusContext, deps.Signer, deps.Logger, deps.Config, deps.MetricFactory)
+	})
+	r.mustRegister(consensus.CONSENSUS_ALGO_TYPE_BENCHMARK_CONSENSUS, func(ctx context.Context, deps *Dependencies) ConsensusAlgo {
+		return benchmarkconsensus.NewBenchmarkConsensusAlgo(ctx, deps.Gossip, deps.BlockStorage, deps.ConsensusContext, deps.Signer, deps.Logger, deps.Config, deps.MetricFactory)
+	})
+	r.mustRegister(devconsensus.CONSENSUS_ALGO_TYPE_DEV_SINGLE_NODE, func(ctx context.Context, deps *Dependencies) ConsensusAlgo {
+		return devconsensus.NewDevConsensusAlgo(ctx, deps.BlockStorage, deps.ConsensusContext, deps.Signer, deps.Logger, deps.Config, deps.MetricFactory)
+	})
+	return r
+}
+
+func Register(algoType consensus.ConsensusAlgoType, factory Factory) error {
+	return DefaultRegistry.Register(algoType, factory)
+}
+
+func (r *Registry) Register(algoType consensus.ConsensusAlgoType, factory Factory) error {
+	if factory == nil {
+		return errors.Errorf("attempt to register a nil factory for consensus algo type %d", algoType)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.factories[algoType]; exists {
+		return errors.Errorf("consensus algo type %d is already registered", algoType)
+	}
+	r.factories[algoType] = factory
+	return nil
+}
+
+func (r *Registry) mustRegister(algoType consensus.ConsensusAlgoType, factory Factory) {
+	if err := r.Register(algoType, factory); err != nil {
+		panic(err)
+	}
+}
+
+func (r *Registry) Create(ctx context.Context, algoType consensus.ConsensusAlgoType, deps *Dependencies) (ConsensusAlgo, error) {
+	r.mutex.RLock()
+	factory, exists := r.factories[algoType]
+	r.mutex.RUnlock()
+
+	if !exists {
+		return nil, errors.Errorf("unknown consensus algo type %d, registered types are %d", algoType, r.RegisteredTypes())
+	}
+	return factory(ctx, deps), nil
+}
+
+func (r *Registry) RegisteredTypes() []consensus.ConsensusAlgoType {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	types := make([]consensus.ConsensusAlgoType, 0, len(r.factories))
+	for algoType := range r.factories {
+		types = append(types, algoType)
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	return types
+}
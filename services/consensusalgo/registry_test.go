@@ -0,0 +1,62 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package consensusalgo
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/services/consensusalgo/devconsensus"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const customAlgoType = consensus.ConsensusAlgoType(1000)
+
+type customAlgo struct {
+	govnr.TreeSupervisor
+	deps *Dependencies
+}
+
+func (a *customAlgo) HandleBlockConsensus(ctx context.Context, input *handlers.HandleBlockConsensusInput) (*handlers.HandleBlockConsensusOutput, error) {
+	return nil, nil
+}
+
+func TestRegistry_CreatesRegisteredAlgoWithDependencies(t *testing.T) {
+	r := NewRegistry()
+	require.NoError(t, r.Register(customAlgoType, func(ctx context.Context, deps *Dependencies) ConsensusAlgo {
+		return &customAlgo{deps: deps}
+	}))
+
+	deps := &Dependencies{}
+	algo, err := r.Create(context.Background(), customAlgoType, deps)
+	require.NoError(t, err)
+	require.Equal(t, deps, algo.(*customAlgo).deps, "factory should receive the node dependencies")
+}
+
+func TestRegistry_RejectsDuplicateRegistration(t *testing.T) {
+	r := NewRegistry()
+	factory := func(ctx context.Context, deps *Dependencies) ConsensusAlgo { return &customAlgo{} }
+
+	require.NoError(t, r.Register(customAlgoType, factory))
+	require.Error(t, r.Register(customAlgoType, factory))
+	require.Error(t, r.Register(customAlgoType+1, nil), "nil factories should be rejected")
+}
+
+func TestRegistry_FailsToCreateUnknownAlgo(t *testing.T) {
+	_, err := NewRegistry().Create(context.Background(), customAlgoType, &Dependencies{})
+	require.Error(t, err)
+}
+
+func TestRegistry_BuiltInAlgosAreRegistered(t *testing.T) {
+	require.Equal(t, []consensus.ConsensusAlgoType{
+		consensus.CONSENSUS_ALGO_TYPE_BENCHMARK_CONSENSUS,
+		consensus.CONSENSUS_ALGO_TYPE_LEAN_HELIX,
+		devconsensus.CONSENSUS_ALGO_TYPE_DEV_SINGLE_NODE,
+	}, NewRegistryWithBuiltInAlgos().RegisteredTypes())
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package consensuscontext
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+)
+
+// the due calls depend only on the state of the previous block and the height and timestamp of the current block header
+// so every validator computes the same list the block proposer did
+func (s *service) callGetDueCallsSystemContract(ctx context.Context, blockHeight primitives.BlockHeight, blockTimestamp primitives.TimestampNano, currentBlockReferenceTime primitives.TimestampSeconds, prevBlockReferenceTime primitives.TimestampSeconds) ([]uint64, error) {
+	systemContractName := primitives.ContractName(triggers_systemcontract.CONTRACT_NAME)
+	systemMethodName := primitives.MethodName(triggers_systemcontract.METHOD_GET_DUE_CALLS)
+
+	output, err := s.virtualMachine.CallSystemContract(ctx, &services.CallSystemContractInput{
+		BlockHeight:               blockHeight,
+		BlockTimestamp:            blockTimestamp,
+		ContractName:              systemContractName,
+		MethodName:                systemMethodName,
+		CurrentBlockReferenceTime: currentBlockReferenceTime,
+		PrevBlockReferenceTime:    prevBlockReferenceTime,
+		InputArgumentArray:        protocol.ArgumentsArrayEmpty(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if output.CallResult != protocol.EXECUTION_RESULT_SUCCESS {
+		return nil, errors.Errorf("GetDueCalls call system %s.%s call result is %s", systemContractName, systemMethodName, output.CallResult)
+	}
+
+	argIterator := output.OutputArgumentArray.ArgumentsIterator()
+	if !argIterator.HasNext() {
+		return nil, errors.Errorf("GetDueCalls call system %s.%s returned corrupt output value", systemContractName, systemMethodName)
+	}
+	arg0 := argIterator.NextArguments()
+	if !arg0.IsTypeUint64ArrayValue() {
+		return nil, errors.Errorf("GetDueCalls call system %s.%s returned corrupt output value", systemContractName, systemMethodName)
+	}
+
+	var dueCalls []uint64
+	itr := arg0.Uint64ArrayValueIterator()
+	for itr.HasNext() {
+		dueCalls = append(dueCalls, itr.NextUint64())
+	}
+	return dueCalls, nil
+}
@@ -48,7 +48,10 @@ func (s *service) createTransactionsBlock(ctx context.Context, input *services.R
 		return nil, errors.New("transactions pool GetTransactionsForOrdering returned proposed block timestamp of zero")
 	}
 
-	transactionsForBlock := s.updateTransactions(proposedTransactions.SignedTransactions, proposedProtocolVersion.ProtocolVersion, proposedBlockTimestamp)
+	transactionsForBlock, err := s.updateTransactions(ctx, proposedTransactions.SignedTransactions, proposedProtocolVersion.ProtocolVersion, input.CurrentBlockHeight, proposedBlockTimestamp, proposedReferenceTime, prevBlockReferenceTime)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to add system transactions to new block")
+	}
 	txCount := len(transactionsForBlock)
 
 	merkleTransactionsRoot, err := digest.CalcTransactionsMerkleRoot(transactionsForBlock)
@@ -129,9 +132,42 @@ func (s *service) createTriggerTransaction(protocolVersion primitives.ProtocolVe
 	}).Build()
 }
 
-func (s *service) updateTransactions(txs []*protocol.SignedTransaction, protocolVersion primitives.ProtocolVersion, blockTime primitives.TimestampNano) []*protocol.SignedTransaction {
+func (s *service) createScheduledCallTransaction(protocolVersion primitives.ProtocolVersion, blockTime primitives.TimestampNano, scheduleId uint64) *protocol.SignedTransaction {
+	inputArguments := (&protocol.ArgumentArrayBuilder{
+		Arguments: []*protocol.ArgumentBuilder{
+			{Type: protocol.ARGUMENT_TYPE_UINT_64_VALUE, Uint64Value: scheduleId},
+		},
+	}).Build()
+
+	return (&protocol.SignedTransactionBuilder{
+		Transaction: &protocol.TransactionBuilder{
+			ProtocolVersion:    protocolVersion,
+			VirtualChainId:     s.config.VirtualChainId(),
+			Timestamp:          blockTime,
+			ContractName:       primitives.ContractName(triggers_systemcontract.CONTRACT_NAME),
+			MethodName:         primitives.MethodName(triggers_systemcontract.METHOD_RUN_SCHEDULED),
+			InputArgumentArray: inputArguments.Raw(),
+		},
+	}).Build()
+}
+
+func isScheduledCallsActive(protocolVersion primitives.ProtocolVersion) bool {
+	return protocolVersion >= triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION
+}
+
+// the calls scheduled by contracts run right before the trigger transaction which advances their schedules
+func (s *service) updateTransactions(ctx context.Context, txs []*protocol.SignedTransaction, protocolVersion primitives.ProtocolVersion, blockHeight primitives.BlockHeight, blockTime primitives.TimestampNano, currentBlockReferenceTime primitives.TimestampSeconds, prevBlockReferenceTime primitives.TimestampSeconds) ([]*protocol.SignedTransaction, error) {
 	if s.config.ConsensusContextTriggersEnabled() {
+		if isScheduledCallsActive(protocolVersion) {
+			dueCalls, err := s.callGetDueCallsSystemContract(ctx, blockHeight, blockTime, currentBlockReferenceTime, prevBlockReferenceTime)
+			if err != nil {
+				return nil, err
+			}
+			for _, scheduleId := range dueCalls {
+				txs = append(txs, s.createScheduledCallTransaction(protocolVersion, blockTime, scheduleId))
+			}
+		}
 		txs = append(txs, s.createTriggerTransaction(protocolVersion, blockTime))
 	}
-	return txs
+	return txs, nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
 )
@@ -26,9 +27,28 @@ func newHarnessWithConfigOnly(enableTriggers bool) *service {
 	}
 }
 
-func requireTransactionToBeATriggerTransaction(t *testing.T, tx *protocol.SignedTransaction, cfg config.ConsensusContextConfig) {
+func newHarnessWithDueCalls(dueCalls ...uint64) *service {
+	s := newHarnessWithConfigOnly(true)
+	virtualMachine := &services.MockVirtualMachine{}
+	virtualMachine.When("CallSystemContract", mock.Any, mock.Any).Return(dueCallsOutput(dueCalls...), nil)
+	s.virtualMachine = virtualMachine
+	return s
+}
+
+func dueCallsOutput(dueCalls ...uint64) *services.CallSystemContractOutput {
+	return &services.CallSystemContractOutput{
+		OutputArgumentArray: (&protocol.ArgumentArrayBuilder{
+			Arguments: []*protocol.ArgumentBuilder{
+				{Type: protocol.ARGUMENT_TYPE_UINT_64_ARRAY_VALUE, Uint64ArrayValue: dueCalls},
+			},
+		}).Build(),
+		CallResult: protocol.EXECUTION_RESULT_SUCCESS,
+	}
+}
+
+func requireTransactionToBeATriggerTransaction(t *testing.T, tx *protocol.SignedTransaction, protocolVersion primitives.ProtocolVersion, cfg config.ConsensusContextConfig) {
 	require.Empty(t, tx.Signature())
-	require.Equal(t, config.MAXIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE, tx.Transaction().ProtocolVersion())
+	require.Equal(t, protocolVersion, tx.Transaction().ProtocolVersion())
 	require.Equal(t, cfg.VirtualChainId(), tx.Transaction().VirtualChainId())
 	require.Equal(t, primitives.ContractName(triggers_systemcontract.CONTRACT_NAME), tx.Transaction().ContractName())
 	require.Equal(t, primitives.MethodName(triggers_systemcontract.METHOD_TRIGGER), tx.Transaction().MethodName())
@@ -39,18 +59,59 @@ func requireTransactionToBeATriggerTransaction(t *testing.T, tx *protocol.Signed
 func TestConsensusContextCreateBlock_UpdateDoesntAddTriggerWhenDisabled(t *testing.T) {
 	s := newHarnessWithConfigOnly(false)
 	txs := []*protocol.SignedTransaction{builders.Transaction().Build()}
-	outputTxs := s.updateTransactions(txs, 1, 0)
+	outputTxs, err := s.updateTransactions(context.Background(), txs, 1, 1, 0, 0, 0)
+	require.NoError(t, err)
 	require.Equal(t, len(txs), len(outputTxs), "should not add txs")
 	require.EqualValues(t, txs[0], outputTxs[0], "should be same tx")
 }
 
 func TestConsensusContextCreateBlock_UpdateAddTriggerWhenEnabled(t *testing.T) {
-	s := newHarnessWithConfigOnly(true)
+	s := newHarnessWithDueCalls()
 	txs := []*protocol.SignedTransaction{builders.Transaction().Build()}
-	outputTxs := s.updateTransactions(txs, 1, 6)
+	outputTxs, err := s.updateTransactions(context.Background(), txs, 1, 1, 6, 0, 0)
+	require.NoError(t, err)
 	require.Equal(t, len(txs)+1, len(outputTxs), "should not add txs")
 	require.EqualValues(t, txs[0], outputTxs[0], "should be same tx")
-	requireTransactionToBeATriggerTransaction(t, outputTxs[1], s.config)
+	requireTransactionToBeATriggerTransaction(t, outputTxs[1], config.MAXIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE, s.config)
+}
+
+func TestConsensusContextCreateBlock_UpdateAddsNoScheduledCallsBeforeTheProtocolVersion(t *testing.T) {
+	s := newHarnessWithDueCalls(3, 7)
+	txs := []*protocol.SignedTransaction{builders.Transaction().Build()}
+	outputTxs, err := s.updateTransactions(context.Background(), txs, triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION-1, 1, 6, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(txs)+1, len(outputTxs), "should only add the trigger")
+	requireTransactionToBeATriggerTransaction(t, outputTxs[1], triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION-1, s.config)
+}
+
+func TestConsensusContextCreateBlock_UpdateAddsDueScheduledCallsBeforeTrigger(t *testing.T) {
+	s := newHarnessWithDueCalls(3, 7)
+	txs := []*protocol.SignedTransaction{builders.Transaction().Build()}
+	outputTxs, err := s.updateTransactions(context.Background(), txs, triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION, 1, 6, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, len(txs)+3, len(outputTxs), "should add a tx per due call and the trigger")
+	require.EqualValues(t, txs[0], outputTxs[0], "should be same tx")
+	for i, scheduleId := range []uint64{3, 7} {
+		tx := outputTxs[i+1]
+		require.Empty(t, tx.Signature())
+		require.Empty(t, tx.Transaction().Signer().Raw())
+		require.Equal(t, primitives.TimestampNano(6), tx.Transaction().Timestamp(), "scheduled call should have the block time")
+		require.Equal(t, primitives.MethodName(triggers_systemcontract.METHOD_RUN_SCHEDULED), tx.Transaction().MethodName())
+		args, err := protocol.ArgumentArrayReader(tx.Transaction().InputArgumentArray()).ToNatives()
+		require.NoError(t, err)
+		require.Equal(t, []interface{}{scheduleId}, args)
+	}
+	requireTransactionToBeATriggerTransaction(t, outputTxs[3], triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION, s.config)
+}
+
+func TestConsensusContextCreateBlock_UpdateFailsWhenDueCallsAreUnavailable(t *testing.T) {
+	s := newHarnessWithConfigOnly(true)
+	virtualMachine := &services.MockVirtualMachine{}
+	virtualMachine.When("CallSystemContract", mock.Any, mock.Any).Return(nil, errors.New("state unavailable"))
+	s.virtualMachine = virtualMachine
+
+	_, err := s.updateTransactions(context.Background(), nil, triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION, 1, 6, 0, 0)
+	require.Error(t, err, "should not propose a block without its scheduled calls")
 }
 
 func TestConsensusContextCreateBlock_proposeBlockReference_FailsWhenPrevIsHigherThanCurrent(t *testing.T) {
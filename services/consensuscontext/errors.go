@@ -32,3 +32,4 @@ var ErrTriggerEnabledAndTriggerMissing = errors.New("ErrTriggerEnabledAndTrigger
 var ErrTriggerEnabledAndTriggerNotLast = errors.New("ErrTriggerEnabledAndTriggerNotLast A Trigger Transaction exists that is not the correct place (last)")
 var ErrTriggerEnabledAndTriggerInvalid = errors.New("ErrTriggerEnabledAndTriggerInvalid Trigger Transaction has some invalid values")
 var ErrTriggerEnabledAndTriggerInvalidTime = errors.New("ErrTriggerEnabledAndTriggerInvalidTime Trigger Transaction should have same time as block")
+var ErrMismatchedScheduledCalls = errors.New("ErrMismatchedScheduledCalls Scheduled call transactions before the trigger do not match the calls due in this block")
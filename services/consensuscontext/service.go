@@ -14,6 +14,7 @@ import (
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/scribe/log"
+	"strconv"
 	"time"
 )
 
@@ -48,6 +49,7 @@ type service struct {
 	management      services.Management
 	config          config.ConsensusContextConfig
 	logger          log.Logger
+	tracer          *trace.Tracer
 
 	metrics *metrics
 }
@@ -60,6 +62,7 @@ func NewConsensusContext(
 	config config.ConsensusContextConfig,
 	logger log.Logger,
 	metricFactory metric.Factory,
+	tracer *trace.Tracer,
 ) services.ConsensusContext {
 
 	return &service{
@@ -69,20 +72,27 @@ func NewConsensusContext(
 		management:      management,
 		config:          config,
 		logger:          logger.WithTags(LogTag),
+		tracer:          tracer,
 		metrics:         newMetrics(metricFactory),
 	}
 }
 
 func (s *service) RequestNewTransactionsBlock(ctx context.Context, input *services.RequestNewTransactionsBlockInput) (*services.RequestNewTransactionsBlockOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "ConsensusContext.RequestNewTransactionsBlock")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 	logger.Info("starting to create transactions block", logfields.BlockHeight(input.CurrentBlockHeight))
 	txBlock, err := s.createTransactionsBlock(ctx, input)
 	if err != nil {
+		span.SetError(err)
 		logger.Info("failed to create transactions block", log.Error(err))
 		return nil, err
 	}
 
 	s.metrics.transactionsRate.Measure(int64(len(txBlock.SignedTransactions)))
+	span.SetAttribute("num-transactions", strconv.Itoa(len(txBlock.SignedTransactions)))
 	logger.Info("created Transactions block", log.Int("num-transactions", len(txBlock.SignedTransactions)), logfields.BlockHeight(input.CurrentBlockHeight))
 	s.printTxHash(logger, txBlock)
 	return &services.RequestNewTransactionsBlockOutput{
@@ -91,10 +101,15 @@ func (s *service) RequestNewTransactionsBlock(ctx context.Context, input *servic
 }
 
 func (s *service) RequestNewResultsBlock(ctx context.Context, input *services.RequestNewResultsBlockInput) (*services.RequestNewResultsBlockOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "ConsensusContext.RequestNewResultsBlock")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	rxBlock, err := s.createResultsBlock(ctx, input)
 	if err != nil {
+		span.SetError(err)
 		return nil, err
 	}
 
@@ -9,6 +9,7 @@ package test
 import (
 	"context"
 	"github.com/orbs-network/crypto-lib-go/crypto/hash"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/services"
@@ -63,6 +64,7 @@ func TestReturnAllAvailableTransactionsFromTransactionPool_WithTriggers(t *testi
 			txCount := uint32(2)
 			txCountWithTrigger := txCount + 1
 			h.expectTxPoolToReturnXTransactions(txCount)
+			h.expectScheduledCallsToBeDue()
 
 			txBlock, err := h.requestTransactionsBlock(ctx)
 
@@ -74,6 +76,29 @@ func TestReturnAllAvailableTransactionsFromTransactionPool_WithTriggers(t *testi
 	})
 }
 
+func TestReturnAllAvailableTransactionsFromTransactionPool_WithScheduledCalls(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			h := newHarness(harness.Logger, true)
+			h.management.Reset()
+			setManagementValues(h.management, triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION, primitives.TimestampSeconds(time.Now().Unix()), 50)
+			txCount := uint32(2)
+			h.expectTxPoolToReturnXTransactions(txCount)
+			h.expectScheduledCallsToBeDue(1, 2)
+
+			txBlock, err := h.requestTransactionsBlock(ctx)
+
+			require.NoError(t, err, "request transactions block failed")
+			require.Len(t, txBlock.SignedTransactions, int(txCount)+3, "block should have the scheduled calls and the trigger")
+			for _, tx := range txBlock.SignedTransactions[txCount : txCount+2] {
+				require.EqualValues(t, triggers_systemcontract.METHOD_RUN_SCHEDULED, tx.Transaction().MethodName(), "scheduled calls should come before the trigger")
+			}
+
+			h.verifyTransactionsRequestedFromTransactionPool(t)
+		})
+	})
+}
+
 func TestCreateBlock_CreateTxsBlockFailsWithBadGenesis(t *testing.T) {
 	with.Context(func(ctx context.Context) {
 		with.Logging(t, func(harness *with.LoggingHarness) {
@@ -13,6 +13,7 @@ import (
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/consensuscontext"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -110,6 +111,18 @@ func (h *harness) expectVirtualMachineToReturnXTransactionReceipts(receiptsCount
 	h.virtualMachine.When("ProcessTransactionSet", mock.Any, mock.Any).Return(output, nil)
 }
 
+func (h *harness) expectScheduledCallsToBeDue(dueCalls ...uint64) {
+	output := &services.CallSystemContractOutput{
+		OutputArgumentArray: (&protocol.ArgumentArrayBuilder{
+			Arguments: []*protocol.ArgumentBuilder{
+				{Type: protocol.ARGUMENT_TYPE_UINT_64_ARRAY_VALUE, Uint64ArrayValue: dueCalls},
+			},
+		}).Build(),
+		CallResult: protocol.EXECUTION_RESULT_SUCCESS,
+	}
+	h.virtualMachine.When("CallSystemContract", mock.Any, mock.Any).Return(output, nil)
+}
+
 func (h *harness) verifyTransactionsRequestedFromTransactionPool(t *testing.T) {
 	ok, _ := h.transactionPool.Verify()
 
@@ -152,7 +165,7 @@ func newHarness(logger log.Logger, enableTriggers bool) *harness {
 		machine,
 		state,
 		management,
-		cfg, logger, metricFactory)
+		cfg, logger, metricFactory, trace.NewNoopTracer())
 
 	return &harness{
 		transactionPool: txPool,
@@ -38,6 +38,7 @@ func TestValidateTransactionsBlockOnValidBlock(t *testing.T) {
 		with.Logging(t, func(harness *with.LoggingHarness) {
 			s := newHarness(harness.Logger, true)
 			s.transactionPool.When("ValidateTransactionsForOrdering", mock.Any, mock.Any).Return(nil, nil)
+			s.expectScheduledCallsToBeDue()
 			input := txInputs(s.config)
 
 			_, err := s.service.ValidateTransactionsBlock(ctx, input)
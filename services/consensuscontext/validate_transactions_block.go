@@ -133,6 +133,9 @@ func validateTxTransactionOrdering(ctx context.Context, cfg config.ConsensusCont
 			return ErrTriggerEnabledAndTriggerMissing
 		}
 		txs = txs[:len(txs)-1]
+		if isScheduledCallsActive(transactionBlock.Header.ProtocolVersion()) {
+			txs = txs[:len(txs)-len(scheduledCallTransactions(transactionBlock.SignedTransactions))]
+		}
 	}
 
 	validationInput := &services.ValidateTransactionsForOrderingInput{
@@ -209,6 +212,47 @@ func validateTransactionsBlockIsTxTrigger(signedTransaction *protocol.SignedTran
 	return false
 }
 
+// the scheduled calls are the unsigned runScheduled transactions right before the trigger transaction (which must be last)
+func scheduledCallTransactions(txs []*protocol.SignedTransaction) []*protocol.SignedTransaction {
+	last := len(txs) - 1
+	first := last
+	for first > 0 && isTxScheduledCall(txs[first-1]) {
+		first--
+	}
+	return txs[first:last]
+}
+
+func isTxScheduledCall(signedTransaction *protocol.SignedTransaction) bool {
+	transaction := signedTransaction.Transaction()
+	return transaction.ContractName().Equal(primitives.ContractName(triggers_systemcontract.CONTRACT_NAME)) &&
+		transaction.MethodName().Equal(primitives.MethodName(triggers_systemcontract.METHOD_RUN_SCHEDULED)) &&
+		len(transaction.Signer().Raw()) == 0
+}
+
+func (s *service) validateTransactionsBlockScheduledCalls(ctx context.Context, prevBlockReferenceTime primitives.TimestampSeconds, transactionsBlock *protocol.TransactionsBlockContainer) error {
+	header := transactionsBlock.Header
+	if !s.config.ConsensusContextTriggersEnabled() || !isScheduledCallsActive(header.ProtocolVersion()) {
+		return nil
+	}
+
+	dueCalls, err := s.callGetDueCallsSystemContract(ctx, header.BlockHeight(), header.Timestamp(), header.ReferenceTime(), prevBlockReferenceTime)
+	if err != nil {
+		return err
+	}
+
+	scheduledTxs := scheduledCallTransactions(transactionsBlock.SignedTransactions)
+	if len(scheduledTxs) != len(dueCalls) {
+		return errors.Wrapf(ErrMismatchedScheduledCalls, "expected %d actual %d", len(dueCalls), len(scheduledTxs))
+	}
+	for i, scheduleId := range dueCalls {
+		expectedTx := s.createScheduledCallTransaction(header.ProtocolVersion(), header.Timestamp(), scheduleId)
+		if !bytes.Equal(scheduledTxs[i].Raw(), expectedTx.Raw()) {
+			return errors.Wrapf(ErrMismatchedScheduledCalls, "scheduled call %d does not run schedule %d", i, scheduleId)
+		}
+	}
+	return nil
+}
+
 func validateTransactionsBlockTxTriggerIsValidTime(signedTransaction *protocol.SignedTransaction, blockTime primitives.TimestampNano) bool {
 	return signedTransaction.Transaction().Timestamp() == blockTime
 }
@@ -230,6 +274,10 @@ func validateTransactionsBlockTxTriggerIsValid(signedTransaction *protocol.Signe
 }
 
 func (s *service) ValidateTransactionsBlock(ctx context.Context, input *services.ValidateTransactionsBlockInput) (*services.ValidateTransactionsBlockOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "ConsensusContext.ValidateTransactionsBlock")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+
 	vctx := &txValidatorContext{
 		virtualChainId:         s.config.VirtualChainId(),
 		allowedTimestampJitter: s.config.ConsensusContextSystemTimestampAllowedJitter(),
@@ -284,5 +332,9 @@ func (s *service) ValidateTransactionsBlock(ctx context.Context, input *services
 		return nil, err7
 	}
 
+	if err8 := s.validateTransactionsBlockScheduledCalls(ctx, prevBlockReferenceTime, input.TransactionsBlock); err8 != nil { // trigger validator must be before scheduled calls validator
+		return nil, err8
+	}
+
 	return &services.ValidateTransactionsBlockOutput{}, nil
 }
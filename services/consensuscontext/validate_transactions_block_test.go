@@ -11,6 +11,7 @@ import (
 	"github.com/orbs-network/crypto-lib-go/crypto/hash"
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -167,6 +168,95 @@ func TestConsensusContextValidateTransactionsBlockTriggerTransactionNotForwarded
 	})
 }
 
+func TestConsensusContextValidateTransactionsBlockScheduledCallsNotForwardedToPreOrder(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		s := newHarnessWithConfigOnly(true)
+		tx := builders.TransferTransaction().Build()
+		pv := primitives.ProtocolVersion(triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION)
+		block := transactionsBlockWithTransactions(pv, tx, s.createScheduledCallTransaction(pv, 6, 1), builders.TriggerTransaction().Build())
+		txPool := &services.MockTransactionPool{}
+		txPool.When("ValidateTransactionsForOrdering", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.ValidateTransactionsForOrderingInput) {
+			require.Equal(t, []*protocol.SignedTransaction{tx}, input.SignedTransactions)
+		}).Return(nil, nil)
+		err := validateTxTransactionOrdering(ctx, s.config, txPool.ValidateTransactionsForOrdering, block)
+		require.NoError(t, err)
+
+		ok, err := txPool.Verify()
+		require.True(t, ok)
+		require.NoError(t, err)
+	})
+}
+
+func TestConsensusContextValidateTransactionsBlockScheduledCalls(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		pv := primitives.ProtocolVersion(triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION)
+		s := newHarnessWithDueCalls(3, 7)
+		tx := builders.TransferTransaction().Build()
+		triggerTx := builders.TriggerTransaction().Build()
+		scheduledTx := func(scheduleId uint64) *protocol.SignedTransaction {
+			return s.createScheduledCallTransaction(pv, 6, scheduleId)
+		}
+
+		tests := []struct {
+			name           string
+			txs            []*protocol.SignedTransaction
+			expectedToPass bool
+		}{
+			{"all due calls before trigger", []*protocol.SignedTransaction{tx, scheduledTx(3), scheduledTx(7), triggerTx}, true},
+			{"missing due call", []*protocol.SignedTransaction{tx, scheduledTx(3), triggerTx}, false},
+			{"no due calls", []*protocol.SignedTransaction{tx, triggerTx}, false},
+			{"call which is not due", []*protocol.SignedTransaction{tx, scheduledTx(3), scheduledTx(8), triggerTx}, false},
+			{"due calls out of order", []*protocol.SignedTransaction{tx, scheduledTx(7), scheduledTx(3), triggerTx}, false},
+			{"extra call", []*protocol.SignedTransaction{scheduledTx(1), scheduledTx(3), scheduledTx(7), triggerTx}, false},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := s.validateTransactionsBlockScheduledCalls(ctx, 0, transactionsBlockWithTransactions(pv, tt.txs...))
+				if tt.expectedToPass {
+					require.NoError(t, err, tt.name)
+				} else {
+					require.Error(t, err, tt.name)
+					require.Equal(t, ErrMismatchedScheduledCalls, errors.Cause(err), "validation should fail error", err)
+				}
+			})
+		}
+	})
+}
+
+func TestConsensusContextValidateTransactionsBlockScheduledCallsBeforeTheProtocolVersion(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		pv := primitives.ProtocolVersion(triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION - 1)
+		s := newHarnessWithDueCalls(3)
+		tx := builders.TransferTransaction().Build()
+		scheduledTx := s.createScheduledCallTransaction(pv, 6, 3)
+		block := transactionsBlockWithTransactions(pv, tx, scheduledTx, builders.TriggerTransaction().Build())
+
+		require.NoError(t, s.validateTransactionsBlockScheduledCalls(ctx, 0, transactionsBlockWithTransactions(pv, tx, builders.TriggerTransaction().Build())), "due calls should not be expected")
+
+		txPool := &services.MockTransactionPool{}
+		txPool.When("ValidateTransactionsForOrdering", mock.Any, mock.Any).Call(func(ctx context.Context, input *services.ValidateTransactionsForOrderingInput) {
+			require.Equal(t, []*protocol.SignedTransaction{tx, scheduledTx}, input.SignedTransactions, "a scheduled call should be ordered as any transaction")
+		}).Return(nil, nil)
+		err := validateTxTransactionOrdering(ctx, s.config, txPool.ValidateTransactionsForOrdering, block)
+		require.NoError(t, err)
+
+		ok, err := txPool.Verify()
+		require.True(t, ok)
+		require.NoError(t, err)
+	})
+}
+
+func transactionsBlockWithTransactions(protocolVersion primitives.ProtocolVersion, txs ...*protocol.SignedTransaction) *protocol.TransactionsBlockContainer {
+	return &protocol.TransactionsBlockContainer{
+		Header: (&protocol.TransactionsBlockHeaderBuilder{
+			ProtocolVersion: protocolVersion,
+			BlockHeight:     1,
+			Timestamp:       6,
+		}).Build(),
+		SignedTransactions: txs,
+	}
+}
+
 func TestConsensusContextValidateTransactionsBlockTriggerDisabledTransactionNotRemovedForForwardedToPreOrder(t *testing.T) {
 	with.Context(func(ctx context.Context) {
 		cfg := config.ForConsensusContextTests(false)
@@ -35,6 +35,7 @@ type ethereumAdapterConfig interface {
 type EthereumConnection interface {
 	CallContract(ctx context.Context, contractAddress []byte, packedInput []byte, blockNumber *big.Int) (packedOutput []byte, err error)
 	GetTransactionLogs(ctx context.Context, txHash primitives.Uint256, eventSignature []byte) ([]*TransactionLog, error)
+	FilterLogs(ctx context.Context, contractAddress []byte, eventSignature []byte, fromBlock *big.Int, toBlock *big.Int) ([]*TransactionLog, error)
 	HeaderByNumber(ctx context.Context, number *big.Int) (*BlockNumberAndTime, error)
 }
 
@@ -9,10 +9,12 @@ package adapter
 import (
 	"bytes"
 	"context"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/pkg/errors"
+	"math/big"
 )
 
 type TransactionLog struct {
@@ -22,6 +24,7 @@ type TransactionLog struct {
 	RepackedData    []byte
 	BlockNumber     uint64
 	TxIndex         uint32
+	LogIndex        uint32
 }
 
 func (c *connectorCommon) GetTransactionLogs(ctx context.Context, txHash primitives.Uint256, eventSignature []byte) ([]*TransactionLog, error) {
@@ -59,6 +62,45 @@ func (c *connectorCommon) GetTransactionLogs(ctx context.Context, txHash primiti
 	return eventLogs, nil
 }
 
+// FilterLogs returns the logs of one event emitted by a contract in the inclusive block range, ordered as in the chain
+func (c *connectorCommon) FilterLogs(ctx context.Context, contractAddress []byte, eventSignature []byte, fromBlock *big.Int, toBlock *big.Int) ([]*TransactionLog, error) {
+	client, err := c.getContractCaller()
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{common.BytesToAddress(contractAddress)},
+		Topics:    [][]common.Hash{{common.BytesToHash(eventSignature)}},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error filtering logs of contract %x from block %s to block %s", contractAddress, fromBlock, toBlock)
+	}
+
+	var eventLogs []*TransactionLog
+	for _, log := range logs {
+		if log.Removed {
+			continue
+		}
+		var topics [][]byte
+		for _, topic := range log.Topics {
+			topics = append(topics, topic.Bytes())
+		}
+		eventLogs = append(eventLogs, &TransactionLog{
+			PackedTopics:    topics,
+			Data:            log.Data,
+			BlockNumber:     log.BlockNumber,
+			TxIndex:         uint32(log.TxIndex),
+			LogIndex:        uint32(log.Index),
+			ContractAddress: log.Address.Bytes(),
+		})
+	}
+
+	return eventLogs, nil
+}
+
 func matchesEvent(log *types.Log, eventSignature []byte) bool {
 	for _, topic := range log.Topics {
 		if bytes.Equal(topic.Bytes(), eventSignature) {
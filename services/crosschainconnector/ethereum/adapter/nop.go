@@ -18,6 +18,10 @@ func (n *NopEthereumAdapter) GetTransactionLogs(ctx context.Context, txHash prim
 	return nil, errors.Errorf("I'm the NOP Ethereum Connector")
 }
 
+func (n *NopEthereumAdapter) FilterLogs(ctx context.Context, contractAddress []byte, eventSignature []byte, fromBlock *big.Int, toBlock *big.Int) ([]*TransactionLog, error) {
+	return nil, errors.Errorf("I'm the NOP Ethereum Connector")
+}
+
 func (n *NopEthereumAdapter) HeaderByNumber(ctx context.Context, number *big.Int) (*BlockNumberAndTime, error) {
 	return nil, errors.Errorf("I'm the NOP Ethereum Connector")
 }
@@ -9,6 +9,7 @@ package adapter
 import (
 	"context"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/synchronization"
 	"github.com/orbs-network/scribe/log"
@@ -42,6 +43,7 @@ func createConnectionStatusMetrics(registry metric.Registry) *metrics {
 func (c *EthereumRpcConnection) ReportConnectionStatus(ctx context.Context) {
 	statusMetrics := createConnectionStatusMetrics(c.registry)
 	statusMetrics.endpoint.Update(c.config.EthereumEndpoint())
+	c.statusMetrics = statusMetrics
 
 	c.Supervise(synchronization.NewPeriodicalTrigger(ctx, "Ethereum connector status reporter", synchronization.NewTimeTicker(30*time.Second), c.logger, func() {
 		if err := c.updateConnectionStatus(ctx, statusMetrics); err != nil {
@@ -80,3 +82,27 @@ func (c *EthereumRpcConnection) updateConnectionStatus(ctx context.Context, m *m
 
 	return ethError
 }
+
+// RegisterHealthChecks reports the status found by the last connection status check, call it after ReportConnectionStatus
+func (c *EthereumRpcConnection) RegisterHealthChecks(registry health.Registry) {
+	m := c.statusMetrics
+	registry.Register("ethereum-connection", health.SEVERITY_INFO, func(ctx context.Context) health.Result {
+		if m == nil || c.config.EthereumEndpoint() == "" {
+			return health.Ok("ethereum connection status is not reported")
+		}
+		return connectionStatusHealth(m)
+	})
+}
+
+func connectionStatusHealth(m *metrics) health.Result {
+	switch m.syncStatus.Value() {
+	case STATUS_FAILED:
+		return health.Failing("ethereum node at %s is unreachable", m.endpoint.Value())
+	case STATUS_IN_PROGRESS:
+		return health.Syncing("ethereum node is syncing, last block is %d", m.lastBlock.Value())
+	}
+	if m.receiptsRetrievalStatus.Value() != STATUS_SUCCESS {
+		return health.Failing("ethereum node does not return transaction receipts")
+	}
+	return health.Ok("ethereum node is synced, last block is %d", m.lastBlock.Value())
+}
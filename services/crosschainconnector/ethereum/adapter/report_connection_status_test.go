@@ -10,6 +10,7 @@ import (
 	"context"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/stretchr/testify/require"
@@ -55,3 +56,18 @@ func TestReportingFailure(t *testing.T) {
 		require.Error(t, err, "require some error from the update flow, config is a lie")
 	})
 }
+
+func TestConnectionStatusHealth(t *testing.T) {
+	m := createConnectionStatusMetrics(metric.NewRegistry())
+	require.Equal(t, health.STATUS_FAILING, connectionStatusHealth(m).Status, "connection should be failing before it was checked")
+
+	m.syncStatus.Update(STATUS_IN_PROGRESS)
+	require.Equal(t, health.STATUS_SYNCING, connectionStatusHealth(m).Status)
+
+	m.syncStatus.Update(STATUS_SUCCESS)
+	require.Equal(t, health.STATUS_FAILING, connectionStatusHealth(m).Status, "connection should be failing while receipts are not returned")
+
+	m.receiptsRetrievalStatus.Update(STATUS_SUCCESS)
+	m.lastBlock.Update(17)
+	require.Equal(t, health.Ok("ethereum node is synced, last block is 17"), connectionStatusHealth(m))
+}
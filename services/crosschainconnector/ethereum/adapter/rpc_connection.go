@@ -20,8 +20,9 @@ type EthereumRpcConnection struct {
 	govnr.TreeSupervisor
 	connectorCommon
 
-	config   ethereumAdapterConfig
-	registry metric.Registry
+	config        ethereumAdapterConfig
+	registry      metric.Registry
+	statusMetrics *metrics
 }
 
 func NewEthereumRpcConnection(config ethereumAdapterConfig, logger log.Logger, registry metric.Registry) *EthereumRpcConnection {
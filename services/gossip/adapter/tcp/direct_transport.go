@@ -10,6 +10,7 @@ import (
 	"context"
 	"github.com/orbs-network/govnr"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -84,6 +85,30 @@ func (t *DirectTransport) allOutgoingQueuesEnabled() bool {
 	return true
 }
 
+// a peer counts as connected once its outgoing queue is enabled, which happens when the connection to it is established
+func (t *DirectTransport) RegisterHealthChecks(registry health.Registry) {
+	registry.Register("gossip-peers", health.SEVERITY_WARNING, func(ctx context.Context) health.Result {
+		t.outgoingConnections.RLock()
+		defer t.outgoingConnections.RUnlock()
+
+		peers := len(t.outgoingConnections.activeConnections)
+		connected := 0
+		for _, client := range t.outgoingConnections.activeConnections {
+			if !client.queue.disabled() {
+				connected++
+			}
+		}
+
+		if peers == 0 {
+			return health.Ok("there are no gossip peers in the topology")
+		}
+		if connected == 0 {
+			return health.Failing("not connected to any of %d gossip peers", peers)
+		}
+		return health.Ok("connected to %d of %d gossip peers", connected, peers)
+	})
+}
+
 func (t *DirectTransport) GracefulShutdown(shutdownContext context.Context) {
 	t.logger.Info("Shutting down")
 	t.outgoingConnections.GracefulShutdown(shutdownContext)
@@ -11,6 +11,7 @@ import (
 	"encoding/hex"
 	"github.com/orbs-network/govnr"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/orbs-network-go/services/gossip/adapter/testkit"
@@ -101,6 +102,32 @@ func TestDirectTransport_SupportsTopologyChangeInRuntime(t *testing.T) {
 	})
 }
 
+func TestDirectTransport_ReportsConnectedPeersHealth(t *testing.T) {
+	with.Concurrency(t, func(ctx context.Context, harness *with.ConcurrencyHarness) {
+		node1 := aNode(ctx, harness.Logger)
+		node2 := aNode(ctx, harness.Logger)
+		unreachableNode := aNode(ctx, harness.Logger)
+		superviseAll(harness, node1, node2)
+		defer shutdownAll(ctx, node1, node2)
+		unreachableNode.transport.GracefulShutdown(ctx)
+
+		registry := health.NewRegistry()
+		node1.transport.RegisterHealthChecks(registry)
+		require.True(t, registry.Report(ctx).Ready, "a node without peers should be healthy")
+
+		node1.updateTopology(ctx, aTopologyContaining(node1, unreachableNode))
+		report := registry.Report(ctx)
+		require.False(t, report.Ready, "a node that is not connected to any of its peers should not be ready")
+		require.True(t, report.Live, "a node that is not connected to any of its peers should still be live")
+
+		node1.updateTopology(ctx, aTopologyContaining(node1, node2))
+		require.True(t, test.Eventually(test.EVENTUALLY_ADAPTER_TIMEOUT, func() bool {
+			return registry.Report(ctx).Ready
+		}), "a node that is connected to its peers should be ready")
+		require.Equal(t, "connected to 1 of 1 gossip peers", registry.Report(ctx).Checks[0].Message)
+	})
+}
+
 func TestDirectTransport_SupportsBroadcastTransmissions(t *testing.T) {
 	with.Concurrency(t, func(ctx context.Context, harness *with.ConcurrencyHarness) {
 		node1 := aNode(ctx, harness.Logger)
@@ -29,7 +29,6 @@ type timingsConfig interface {
 
 type outgoingConnection struct {
 	logger         log.Logger
-	metricRegistry metric.Registry
 	config         timingsConfig
 	sharedMetrics  *outgoingConnectionMetrics // TODO this is smelly, see how we can restructure metrics so that an outgoing connection doesn't have to share the parent metrics
 	queue          *transportQueue
@@ -42,25 +41,24 @@ type outgoingConnection struct {
 	closed chan struct{}
 }
 
-func newOutgoingConnection(peer adapter.TransportPeer, parentLogger log.Logger, metricFactory metric.Registry, sharedMetrics *outgoingConnectionMetrics, transportConfig timingsConfig) *outgoingConnection {
+func newOutgoingConnection(peer adapter.TransportPeer, parentLogger log.Logger, sharedMetrics *outgoingConnectionMetrics, transportConfig timingsConfig) *outgoingConnection {
 	networkAddress := fmt.Sprintf("%s:%d", peer.Endpoint(), peer.Port())
 	hexAddressSliceForLogging := peer.HexOrbsAddress()[:6]
 
 	logger := parentLogger.WithTags(log.String("peer-node-address", hexAddressSliceForLogging), log.String("peer-network-address", networkAddress))
 
-	queue := NewTransportQueue(SEND_QUEUE_MAX_BYTES, SEND_QUEUE_MAX_MESSAGES, metricFactory, hexAddressSliceForLogging)
+	queue := NewTransportQueue(SEND_QUEUE_MAX_BYTES, SEND_QUEUE_MAX_MESSAGES, sharedMetrics.peerQueueUsage.WithLabels(hexAddressSliceForLogging))
 	queue.networkAddress = networkAddress
 	queue.Disable() // until connection is established
 
 	client := &outgoingConnection{
 		logger:          logger,
 		sharedMetrics:   sharedMetrics,
-		metricRegistry:  metricFactory,
 		config:          transportConfig,
 		queue:           queue,
 		peerHexAddress:  hexAddressSliceForLogging,
-		sendErrors:      metricFactory.NewGauge(fmt.Sprintf("Gossip.OutgoingConnection.SendError.%s.Count", hexAddressSliceForLogging)),
-		sendQueueErrors: metricFactory.NewGauge(fmt.Sprintf("Gossip.OutgoingConnection.EnqueueErrors.%s.Count", hexAddressSliceForLogging)),
+		sendErrors:      sharedMetrics.peerSendErrors.WithLabels(hexAddressSliceForLogging),
+		sendQueueErrors: sharedMetrics.peerSendQueueErrors.WithLabels(hexAddressSliceForLogging),
 	}
 
 	return client
@@ -156,9 +154,9 @@ func shouldKeepAlive(ctx context.Context) bool {
 
 func (c *outgoingConnection) onDisconnect(logger log.Logger) bool {
 	logger.Info("client loop stopped since a disconnect was requested (topology change or system shutdown)")
-	c.metricRegistry.Remove(c.sendErrors)
-	c.metricRegistry.Remove(c.sendQueueErrors)
-	c.metricRegistry.Remove(c.queue.usagePercentageMetric)
+	c.sharedMetrics.peerSendErrors.Remove(c.peerHexAddress)
+	c.sharedMetrics.peerSendQueueErrors.Remove(c.peerHexAddress)
+	c.sharedMetrics.peerQueueUsage.Remove(c.peerHexAddress)
 	return false
 }
 
@@ -113,7 +113,7 @@ func (s *serverStub) readSomeBytes() int {
 func (s *serverStub) createClientAndConnect(ctx context.Context, t testing.TB, logger log.Logger, keepAliveInterval time.Duration) *outgoingConnection {
 	registry := metric.NewRegistry()
 	peer := adapter.NewGossipPeer(s.port, "127.0.0.1", "012345")
-	client := newOutgoingConnection(peer, logger, registry, createOutgoingConnectionMetrics(registry), &timeouts{keepAliveInterval: keepAliveInterval})
+	client := newOutgoingConnection(peer, logger, createOutgoingConnectionMetrics(registry), &timeouts{keepAliveInterval: keepAliveInterval})
 	client.connect(ctx)
 	s.acceptClientConnection(t)
 	return client
@@ -18,6 +18,10 @@ type outgoingConnectionMetrics struct {
 	sendQueueErrors *metric.Gauge
 	activeCount     *metric.Gauge
 
+	peerSendErrors      *metric.GaugeVec
+	peerSendQueueErrors *metric.GaugeVec
+	peerQueueUsage      *metric.GaugeVec
+
 	messageSize *metric.Histogram
 }
 
@@ -52,7 +56,12 @@ func createOutgoingConnectionMetrics(registry metric.Registry) *outgoingConnecti
 		KeepaliveErrors: registry.NewGauge("Gossip.OutgoingConnection.KeepaliveErrors.Count"),
 		sendQueueErrors: registry.NewGauge("Gossip.OutgoingConnection.SendQueueErrors.Count"),
 		activeCount:     registry.NewGauge("Gossip.OutgoingConnection.Active.Count"),
-		messageSize:     registry.NewHistogram("Gossip.OutgoingConnection.MessageSize.Bytes", MAX_PAYLOAD_SIZE_BYTES),
+
+		peerSendErrors:      registry.NewGaugeVec("Gossip.OutgoingConnection.SendError.Count", "peer"),
+		peerSendQueueErrors: registry.NewGaugeVec("Gossip.OutgoingConnection.EnqueueErrors.Count", "peer"),
+		peerQueueUsage:      registry.NewGaugeVec("Gossip.OutgoingConnection.Queue.Usage.Percent", "peer"),
+
+		messageSize: registry.NewHistogram("Gossip.OutgoingConnection.MessageSize.Bytes", MAX_PAYLOAD_SIZE_BYTES),
 	}
 }
 
@@ -99,7 +108,7 @@ func (c *outgoingConnections) updateTopology(bgCtx context.Context, newTopology
 func (c *outgoingConnections) connectForeverUnderLock(bgCtx context.Context, peerNodeAddress string, peer adapter.TransportPeer) {
 	if c.nodeAddress.KeyForMap() != peerNodeAddress {
 		c.peerTopology[peerNodeAddress] = peer
-		client := newOutgoingConnection(peer, c.logger, c.metricRegistry, c.metrics, c.config)
+		client := newOutgoingConnection(peer, c.logger, c.metrics, c.config)
 		c.activeConnections[peerNodeAddress] = client
 		client.connect(bgCtx)
 	}
@@ -8,7 +8,6 @@ package tcp
 
 import (
 	"context"
-	"fmt"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/gossip/adapter"
 	"github.com/orbs-network/scribe/log"
@@ -31,16 +30,15 @@ type transportQueue struct {
 	logger                log.Logger
 }
 
-func NewTransportQueue(maxSizeBytes int, maxSizeMessages int, metricFactory metric.Factory, peerNodeAddress string) *transportQueue {
+func NewTransportQueue(maxSizeBytes int, maxSizeMessages int, usagePercentageMetric *metric.Gauge) *transportQueue {
 	q := &transportQueue{
-		channel:     make(chan *adapter.TransportData, maxSizeMessages),
-		maxBytes:    maxSizeBytes,
-		maxMessages: maxSizeMessages,
+		channel:               make(chan *adapter.TransportData, maxSizeMessages),
+		maxBytes:              maxSizeBytes,
+		maxMessages:           maxSizeMessages,
+		usagePercentageMetric: usagePercentageMetric,
 	}
 	q.protected.bytesLeft = maxSizeBytes
 
-	q.usagePercentageMetric = metricFactory.NewGauge(fmt.Sprintf("Gossip.OutgoingConnection.Queue.Usage.%s.Percent", peerNodeAddress))
-
 	return q
 }
 
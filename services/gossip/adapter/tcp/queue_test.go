@@ -16,8 +16,6 @@ import (
 	"time"
 )
 
-const someAddress = ""
-
 func TestQueue_PushAndPopMultiple(t *testing.T) {
 	with.Context(func(ctx context.Context) {
 		q := aQueue(t, 1000, 1000)
@@ -175,5 +173,5 @@ func buf(len int) []byte {
 }
 
 func aQueue(t testing.TB, maxSizeInBytes int, maxNumOfMessages int) *transportQueue {
-	return NewTransportQueue(maxSizeInBytes, maxNumOfMessages, metric.NewRegistry(), someAddress)
+	return NewTransportQueue(maxSizeInBytes, maxNumOfMessages, metric.NewRegistry().NewGauge("Gossip.OutgoingConnection.Queue.Usage.Percent"))
 }
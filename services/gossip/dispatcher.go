@@ -81,14 +81,28 @@ func (c *meteredTopicChannel) drain() {
 	}
 }
 
-func newMeteredTopicChannel(name string, registry metric.Registry, logger log.Logger, topicBufferSize int) *meteredTopicChannel {
-	sizeGauge := registry.NewGauge("Gossip.Topic." + name + ".QueueSize")
+type topicMetrics struct {
+	size            *metric.GaugeVec
+	inQueue         *metric.GaugeVec
+	droppedMessages *metric.GaugeVec
+}
+
+func newTopicMetrics(registry metric.Registry) *topicMetrics {
+	return &topicMetrics{
+		size:            registry.NewGaugeVec("Gossip.Topic.QueueSize", "topic"),
+		inQueue:         registry.NewGaugeVec("Gossip.Topic.MessagesInQueue", "topic"),
+		droppedMessages: registry.NewGaugeVec("Gossip.Topic.DroppedMessages", "topic"),
+	}
+}
+
+func newMeteredTopicChannel(name string, metrics *topicMetrics, logger log.Logger, topicBufferSize int) *meteredTopicChannel {
+	sizeGauge := metrics.size.WithLabels(name)
 	sizeGauge.Update(int64(topicBufferSize))
 	return &meteredTopicChannel{
 		ch:              make(chan gossipMessage, topicBufferSize),
 		size:            sizeGauge,
-		inQueue:         registry.NewGauge("Gossip.Topic." + name + ".MessagesInQueue"),
-		droppedMessages: registry.NewGauge("Gossip.Topic." + name + ".DroppedMessages"),
+		inQueue:         metrics.inQueue.WithLabels(name),
+		droppedMessages: metrics.droppedMessages.WithLabels(name),
 		name:            fmt.Sprintf("%s topic handler", name),
 		logger:          logger.WithTags(log.String("gossip-topic", name)),
 	}
@@ -105,12 +119,12 @@ type gossipMessageDispatcher struct {
 // In fact, Block Sync should create a new one-off goroutine per "server request", Consensus should read messages immediately and store them in its own queue,
 // and Transaction Relay shouldn't block for long anyway.
 func newMessageDispatcher(registry metric.Registry, logger log.Logger) (d *gossipMessageDispatcher) {
-
+	metrics := newTopicMetrics(registry)
 	d = &gossipMessageDispatcher{
-		transactionRelay:   newMeteredTopicChannel("TransactionRelay", registry, logger, 200),   // transaction pool might block on adding new transactions, for instance while committing a block
-		blockSync:          newMeteredTopicChannel("BlockSync", registry, logger, 10),           // low value assuming that handling block sync messages doesn't block
-		leanHelix:          newMeteredTopicChannel("LeanHelixConsensus", registry, logger, 100), // handlers performs I/O operations and require buffering of requests
-		benchmarkConsensus: newMeteredTopicChannel("BenchmarkConsensus", registry, logger, 20),  // under heavy load benchmark consensus has been observed to slow down, failing to pick messages up from the topic fast enough
+		transactionRelay:   newMeteredTopicChannel("TransactionRelay", metrics, logger, 200),   // transaction pool might block on adding new transactions, for instance while committing a block
+		blockSync:          newMeteredTopicChannel("BlockSync", metrics, logger, 10),           // low value assuming that handling block sync messages doesn't block
+		leanHelix:          newMeteredTopicChannel("LeanHelixConsensus", metrics, logger, 100), // handlers performs I/O operations and require buffering of requests
+		benchmarkConsensus: newMeteredTopicChannel("BenchmarkConsensus", metrics, logger, 20),  // under heavy load benchmark consensus has been observed to slow down, failing to pick messages up from the topic fast enough
 	}
 	return
 }
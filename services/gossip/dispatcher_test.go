@@ -0,0 +1,39 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package gossip
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMessageDispatcher_ReportsMetricsPerTopic(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		registry := metric.NewRegistry()
+		d := newMessageDispatcher(registry, parent.Logger)
+
+		header := gossipmessages.HeaderReader(aHeaderWithTopic())
+		for i := 0; i < 201; i++ { // the transaction relay topic buffers 200 messages
+			_ = d.transactionRelay.send(context.Background(), header, nil)
+		}
+
+		all := registry.ExportAll()
+		require.Contains(t, all, "Gossip.Topic.TransactionRelay.QueueSize", "/metrics should keep the names it had before metrics were labeled")
+		require.Contains(t, all, "Gossip.Topic.BlockSync.DroppedMessages")
+
+		metrics := registry.ExportPrometheus()
+		require.Contains(t, metrics, `Gossip_Topic_QueueSize{topic="TransactionRelay"} 200`)
+		require.Contains(t, metrics, `Gossip_Topic_QueueSize{topic="BlockSync"} 10`)
+		require.Contains(t, metrics, `Gossip_Topic_MessagesInQueue{topic="TransactionRelay"} 200`)
+		require.Contains(t, metrics, `Gossip_Topic_DroppedMessages{topic="TransactionRelay"} 1`)
+		require.Contains(t, metrics, `Gossip_Topic_DroppedMessages{topic="BlockSync"} 0`)
+	})
+}
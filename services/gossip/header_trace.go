@@ -0,0 +1,89 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package gossip
+
+import (
+	"context"
+	"github.com/orbs-network/membuffers/go"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/gossip/adapter"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
+)
+
+// The trace context travels in the message header as two fields appended after the fields of the spec
+// (a traceparent and the request id of the sender), laid out as membuffers lays out newer bytes fields so
+// nodes that don't know them ignore them. They are not proper spec fields because the header is generated
+// from orbs-spec, an external module, so the layout of the spec fields is repeated in headerWithTraceScheme
+const headerTraceFieldAlignment = 4
+const headerTraceFieldSizeLength = 4
+const maxHeaderRequestIdLength = 256
+
+const (
+	headerTraceparentField = 5
+	headerRequestIdField   = 6
+)
+
+// the scheme of gossipmessages.Header followed by the trace fields
+var headerWithTraceScheme = []membuffers.FieldType{membuffers.TypeUint32, membuffers.TypeUint32, membuffers.TypeBytesArray, membuffers.TypeUint16, membuffers.TypeUnion, membuffers.TypeBytes, membuffers.TypeBytes}
+var headerWithTraceUnions = [][]membuffers.FieldType{{membuffers.TypeUint16, membuffers.TypeUint16, membuffers.TypeUint16, membuffers.TypeUint16}}
+
+func (s *service) send(ctx context.Context, data *adapter.TransportData) error {
+	if tracingContext, ok := trace.FromContext(ctx); ok && tracingContext.TraceId().IsValid() && len(data.Payloads) > 0 {
+		payloads := make([][]byte, len(data.Payloads))
+		copy(payloads, data.Payloads)
+		payloads[0] = appendTraceContextToHeader(payloads[0], tracingContext)
+		data.Payloads = payloads
+	}
+	return s.transport.Send(ctx, data)
+}
+
+func appendTraceContextToHeader(header []byte, tracingContext *trace.Context) []byte {
+	withTrace := make([]byte, len(header))
+	copy(withTrace, header)
+	withTrace = appendHeaderTraceField(withTrace, []byte(tracingContext.Traceparent()))
+	return appendHeaderTraceField(withTrace, []byte(tracingContext.RequestId()))
+}
+
+func appendHeaderTraceField(buf []byte, content []byte) []byte {
+	for len(buf)%headerTraceFieldAlignment != 0 {
+		buf = append(buf, 0)
+	}
+	size := make([]byte, headerTraceFieldSizeLength)
+	membuffers.WriteOffset(size, membuffers.Offset(len(content)))
+	return append(append(buf, size...), content...)
+}
+
+// readTraceContextFromHeader returns false for headers of nodes that don't propagate trace context, and for trace
+// context a peer sent that is not valid
+func readTraceContextFromHeader(header *gossipmessages.Header) (tracingContext *trace.Context, ok bool) {
+	// membuffers does not guard against field sizes which overflow its offsets, a peer must not be able to crash the node
+	defer func() {
+		if recover() != nil {
+			tracingContext, ok = nil, false
+		}
+	}()
+
+	raw := header.Raw()
+	withTrace := &membuffers.InternalMessage{}
+	withTrace.Init(raw, membuffers.Offset(len(raw)), headerWithTraceScheme, headerWithTraceUnions)
+	if !withTrace.IsValid() {
+		return nil, false
+	}
+
+	// missing fields are read as empty, the contents are copied since the header buffer belongs to the transport
+	traceparent := string(withTrace.GetBytes(headerTraceparentField))
+	requestId := string(withTrace.GetBytes(headerRequestIdField))
+	if traceparent == "" || len(requestId) > maxHeaderRequestIdLength {
+		return nil, false
+	}
+
+	traceId, spanId, err := trace.ParseTraceparent(traceparent)
+	if err != nil {
+		return nil, false
+	}
+	return trace.NewRemoteContext("Gossip.Received", requestId, traceId, spanId), true
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package gossip
+
+import (
+	"context"
+	"github.com/orbs-network/membuffers/go"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/consensus"
+	"github.com/orbs-network/orbs-spec/types/go/protocol/gossipmessages"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func aHeaderWithTopic() []byte {
+	return (&gossipmessages.HeaderBuilder{
+		Topic:                  gossipmessages.HEADER_TOPIC_TRANSACTION_RELAY,
+		TransactionRelay:       gossipmessages.TRANSACTION_RELAY_FORWARDED_TRANSACTIONS,
+		VirtualChainId:         42,
+		RecipientMode:          gossipmessages.RECIPIENT_LIST_MODE_LIST,
+		RecipientNodeAddresses: []primitives.NodeAddress{{0x1}},
+	}).Build().Raw()
+}
+
+func TestHeaderTrace_RoundTripsTraceContext(t *testing.T) {
+	ctx, span := trace.NewNoopTracer().StartSpan(context.Background(), "foo")
+	tracingContext, _ := trace.FromContext(ctx)
+
+	raw := appendTraceContextToHeader(aHeaderWithTopic(), tracingContext)
+	header := gossipmessages.HeaderReader(raw)
+	require.True(t, header.IsValid(), "header with trace context should remain a valid header")
+	require.True(t, header.IsTopicTransactionRelay())
+	require.Equal(t, gossipmessages.TRANSACTION_RELAY_FORWARDED_TRANSACTIONS, header.TransactionRelay())
+	require.EqualValues(t, 42, header.VirtualChainId())
+	require.Equal(t, gossipmessages.RECIPIENT_LIST_MODE_LIST, header.RecipientMode())
+
+	remote, ok := readTraceContextFromHeader(header)
+	require.True(t, ok, "trace context should be read from the header")
+	require.Equal(t, span.TraceId(), remote.TraceId())
+	require.Equal(t, span.SpanId(), remote.SpanId(), "the sending span should become the parent of the receiving side")
+	require.Equal(t, tracingContext.RequestId(), remote.RequestId())
+}
+
+func TestHeaderTrace_HeaderWithoutTraceContext(t *testing.T) {
+	header := gossipmessages.HeaderReader(aHeaderWithTopic())
+	require.True(t, header.IsValid())
+
+	_, ok := readTraceContextFromHeader(header)
+	require.False(t, ok, "headers of nodes that don't propagate trace context should not carry one")
+}
+
+func TestHeaderTrace_ReadForEveryTopic(t *testing.T) {
+	ctx, span := trace.NewNoopTracer().StartSpan(context.Background(), "foo")
+	tracingContext, _ := trace.FromContext(ctx)
+
+	for _, builder := range []*gossipmessages.HeaderBuilder{
+		{Topic: gossipmessages.HEADER_TOPIC_TRANSACTION_RELAY, TransactionRelay: gossipmessages.TRANSACTION_RELAY_FORWARDED_TRANSACTIONS},
+		{Topic: gossipmessages.HEADER_TOPIC_BLOCK_SYNC, BlockSync: gossipmessages.BLOCK_SYNC_REQUEST},
+		{Topic: gossipmessages.HEADER_TOPIC_LEAN_HELIX, LeanHelix: consensus.LEAN_HELIX_MESSAGE_TYPE_LEAN_HELIX},
+		{Topic: gossipmessages.HEADER_TOPIC_BENCHMARK_CONSENSUS, BenchmarkConsensus: consensus.BENCHMARK_CONSENSUS_COMMIT},
+	} {
+		builder.RecipientMode = gossipmessages.RECIPIENT_LIST_MODE_BROADCAST
+		header := gossipmessages.HeaderReader(appendTraceContextToHeader(builder.Build().Raw(), tracingContext))
+
+		remote, ok := readTraceContextFromHeader(header)
+		require.True(t, ok, "trace context should be read for topic %s", header.StringTopic())
+		require.Equal(t, span.TraceId(), remote.TraceId())
+	}
+}
+
+func TestHeaderTrace_IgnoresInvalidTraceContextOfPeers(t *testing.T) {
+	ctx, span := trace.NewNoopTracer().StartSpan(context.Background(), "foo")
+	tracingContext, _ := trace.FromContext(ctx)
+	withTrace := appendTraceContextToHeader(aHeaderWithTopic(), tracingContext)
+	traceparent := tracingContext.Traceparent()
+
+	for name, raw := range map[string][]byte{
+		"truncated trace field":        withTrace[:len(withTrace)-3],
+		"trace id of the wrong length": appendHeaderTraceField(appendHeaderTraceField(aHeaderWithTopic(), []byte("00-"+span.TraceId().String()+"ab-"+span.SpanId().String()+"-01")), nil),
+		"zero span id":                 appendHeaderTraceField(appendHeaderTraceField(aHeaderWithTopic(), []byte("00-"+span.TraceId().String()+"-"+trace.SpanId{}.String()+"-01")), nil),
+		"request id too long":          appendHeaderTraceField(appendHeaderTraceField(aHeaderWithTopic(), []byte(traceparent)), make([]byte, maxHeaderRequestIdLength+1)),
+	} {
+		require.NotPanics(t, func() {
+			_, ok := readTraceContextFromHeader(gossipmessages.HeaderReader(raw))
+			require.False(t, ok, "trace context with %s should be ignored", name)
+		})
+	}
+
+	t.Log("a field size overflowing the offsets of membuffers, so the field seems to end at the start of the header")
+	overflowing := appendHeaderTraceField(aHeaderWithTopic(), []byte(traceparent))
+	contentStart := len(overflowing) - len(traceparent)
+	membuffers.WriteOffset(overflowing[contentStart-headerTraceFieldSizeLength:], membuffers.Offset(uint64(1<<32)-uint64(contentStart)))
+	require.NotPanics(t, func() {
+		_, ok := readTraceContextFromHeader(gossipmessages.HeaderReader(overflowing))
+		require.False(t, ok)
+	})
+}
@@ -97,6 +97,11 @@ func (s *service) OnTransportMessageReceived(ctx context.Context, payloads [][]b
 		return
 	}
 
+	if tracingContext, ok := readTraceContextFromHeader(header); ok {
+		ctx = trace.PropagateContext(ctx, tracingContext)
+		logger = s.logger.WithTags(trace.LogFieldFrom(ctx))
+	}
+
 	s.messageDispatcher.dispatch(ctx, logger, header, payloads[1:])
 }
 
@@ -47,7 +47,7 @@ func (s *service) BroadcastBenchmarkConsensusCommit(ctx context.Context, input *
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress: s.config.NodeAddress(),
 		RecipientMode:     gossipmessages.RECIPIENT_LIST_MODE_BROADCAST,
 		Payloads:          payloads,
@@ -86,7 +86,7 @@ func (s *service) SendBenchmarkConsensusCommitted(ctx context.Context, input *go
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress:      s.config.NodeAddress(),
 		RecipientMode:          gossipmessages.RECIPIENT_LIST_MODE_LIST,
 		RecipientNodeAddresses: []primitives.NodeAddress{input.RecipientNodeAddress},
@@ -62,7 +62,7 @@ func (s *service) BroadcastBlockAvailabilityRequest(ctx context.Context, input *
 	if err != nil {
 		return nil, err
 	}
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress: s.config.NodeAddress(),
 		RecipientMode:     gossipmessages.RECIPIENT_LIST_MODE_BROADCAST,
 		Payloads:          payloads,
@@ -98,7 +98,7 @@ func (s *service) SendBlockAvailabilityResponse(ctx context.Context, input *goss
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress:      s.config.NodeAddress(),
 		RecipientMode:          gossipmessages.RECIPIENT_LIST_MODE_LIST,
 		RecipientNodeAddresses: []primitives.NodeAddress{input.RecipientNodeAddress},
@@ -136,7 +136,7 @@ func (s *service) SendBlockSyncRequest(ctx context.Context, input *gossiptopics.
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress:      s.config.NodeAddress(),
 		RecipientMode:          gossipmessages.RECIPIENT_LIST_MODE_LIST,
 		RecipientNodeAddresses: []primitives.NodeAddress{input.RecipientNodeAddress},
@@ -178,7 +178,7 @@ func (s *service) SendBlockSyncResponse(ctx context.Context, input *gossiptopics
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress:      s.config.NodeAddress(),
 		RecipientMode:          gossipmessages.RECIPIENT_LIST_MODE_LIST,
 		RecipientNodeAddresses: []primitives.NodeAddress{input.RecipientNodeAddress},
@@ -52,7 +52,7 @@ func (s *service) SendLeanHelixMessage(ctx context.Context, input *gossiptopics.
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress:      s.config.NodeAddress(),
 		RecipientMode:          input.RecipientsList.RecipientMode,
 		RecipientNodeAddresses: input.RecipientsList.RecipientNodeAddresses,
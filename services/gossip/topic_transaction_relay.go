@@ -49,7 +49,7 @@ func (s *service) BroadcastForwardedTransactions(ctx context.Context, input *gos
 		return nil, err
 	}
 
-	return nil, s.transport.Send(ctx, &adapter.TransportData{
+	return nil, s.send(ctx, &adapter.TransportData{
 		SenderNodeAddress: s.config.NodeAddress(),
 		RecipientMode:     gossipmessages.RECIPIENT_LIST_MODE_BROADCAST,
 		Payloads:          payloads,
@@ -0,0 +1,538 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	ethereumAdapter "github.com/orbs-network/orbs-network-go/services/crosschainconnector/ethereum/adapter"
+	"github.com/orbs-network/orbs-network-go/services/crosschainconnector/ethereum/timestampfinder"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"math/big"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ethereum nodes limit the range of a single log query, longer ranges are scanned in chunks
+const ETHEREUM_PROVIDER_MAX_BLOCKS_PER_QUERY = 10000
+
+const (
+	GUARDIAN_DATA_UPDATED_EVENT     = "GuardianDataUpdated"
+	GUARDIAN_METADATA_CHANGED_EVENT = "GuardianMetadataChanged"
+	COMMITTEE_SNAPSHOT_EVENT        = "CommitteeSnapshot"
+	SUBSCRIPTION_CHANGED_EVENT      = "SubscriptionChanged"
+	PROTOCOL_VERSION_CHANGED_EVENT  = "ProtocolVersionChanged"
+)
+
+// guardians publish the port their node listens on for gossip as a metadata entry of their registration
+const GUARDIAN_GOSSIP_PORT_METADATA_KEY = "ORBS_GOSSIP_PORT"
+
+const ManagementContractsEventsAbi = `[
+{"anonymous":false,"inputs":[{"indexed":true,"name":"guardian","type":"address"},{"indexed":false,"name":"isRegistered","type":"bool"},{"indexed":false,"name":"ip","type":"bytes4"},{"indexed":false,"name":"orbsAddr","type":"address"},{"indexed":false,"name":"name","type":"string"},{"indexed":false,"name":"website","type":"string"},{"indexed":false,"name":"registrationTime","type":"uint256"}],"name":"GuardianDataUpdated","type":"event"},
+{"anonymous":false,"inputs":[{"indexed":true,"name":"guardian","type":"address"},{"indexed":false,"name":"key","type":"string"},{"indexed":false,"name":"newValue","type":"string"},{"indexed":false,"name":"oldValue","type":"string"}],"name":"GuardianMetadataChanged","type":"event"},
+{"anonymous":false,"inputs":[{"indexed":false,"name":"addrs","type":"address[]"},{"indexed":false,"name":"weights","type":"uint256[]"},{"indexed":false,"name":"certification","type":"bool[]"}],"name":"CommitteeSnapshot","type":"event"},
+{"anonymous":false,"inputs":[{"indexed":true,"name":"vcId","type":"uint256"},{"indexed":false,"name":"owner","type":"address"},{"indexed":false,"name":"name","type":"string"},{"indexed":false,"name":"genRefTime","type":"uint256"},{"indexed":false,"name":"tier","type":"string"},{"indexed":false,"name":"rate","type":"uint256"},{"indexed":false,"name":"expiresAt","type":"uint256"},{"indexed":false,"name":"isCertified","type":"bool"},{"indexed":false,"name":"deploymentSubset","type":"string"}],"name":"SubscriptionChanged","type":"event"},
+{"anonymous":false,"inputs":[{"indexed":false,"name":"deploymentSubset","type":"string"},{"indexed":false,"name":"currentVersion","type":"uint256"},{"indexed":false,"name":"nextVersion","type":"uint256"},{"indexed":false,"name":"fromTimestamp","type":"uint256"}],"name":"ProtocolVersionChanged","type":"event"}
+]`
+
+type guardianDataUpdatedEvent struct {
+	IsRegistered     bool
+	Ip               [4]byte
+	OrbsAddr         common.Address
+	Name             string
+	Website          string
+	RegistrationTime *big.Int
+}
+
+type guardianMetadataChangedEvent struct {
+	Key      string
+	NewValue string
+	OldValue string
+}
+
+type committeeSnapshotEvent struct {
+	Addrs         []common.Address
+	Weights       []*big.Int
+	Certification []bool
+}
+
+type subscriptionChangedEvent struct {
+	Owner            common.Address
+	Name             string
+	GenRefTime       *big.Int
+	Tier             string
+	Rate             *big.Int
+	ExpiresAt        *big.Int
+	IsCertified      bool
+	DeploymentSubset string
+}
+
+type protocolVersionChangedEvent struct {
+	DeploymentSubset string
+	CurrentVersion   *big.Int
+	NextVersion      *big.Int
+	FromTimestamp    *big.Int
+}
+
+type EthereumProviderConfig interface {
+	VirtualChainId() primitives.VirtualChainId
+	EthereumFinalityTimeComponent() time.Duration
+	EthereumFinalityBlocksComponent() uint32
+	ManagementEthereumGuardiansContract() []byte
+	ManagementEthereumCommitteeContract() []byte
+	ManagementEthereumSubscriptionsContract() []byte
+	ManagementEthereumProtocolContract() []byte
+	ManagementEthereumFromBlock() uint32
+}
+
+type EthereumManagementConnection interface {
+	FilterLogs(ctx context.Context, contractAddress []byte, eventSignature []byte, fromBlock *big.Int, toBlock *big.Int) ([]*ethereumAdapter.TransactionLog, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethereumAdapter.BlockNumberAndTime, error)
+}
+
+type managementEventLog struct {
+	eventName string
+	log       *ethereumAdapter.TransactionLog
+}
+
+type guardianRecord struct {
+	orbsAddress  primitives.NodeAddress
+	ip           [4]byte
+	isRegistered bool
+}
+
+type guardianGossipPorts map[common.Address]uint32
+
+// EthereumProvider reads the management data directly from the events of the management contracts on ethereum. Only
+// finality safe blocks are read, so events scanned once are kept and every poll only scans the blocks added since
+type EthereumProvider struct {
+	logger          log.Logger
+	config          EthereumProviderConfig
+	connection      EthereumManagementConnection
+	contractABI     abi.ABI
+	blockTimeGetter timestampfinder.BlockTimeGetter
+	timestampFinder timestampfinder.TimestampFinder
+
+	mutex             sync.Mutex
+	scannedUntilBlock int64
+	eventLogs         []*managementEventLog
+	blockTimes        map[uint64]primitives.TimestampSeconds
+}
+
+func NewEthereumProvider(connection EthereumManagementConnection, config EthereumProviderConfig, logger log.Logger, metricFactory metric.Factory) *EthereumProvider {
+	contractABI, err := abi.JSON(strings.NewReader(ManagementContractsEventsAbi))
+	if err != nil {
+		panic(errors.Wrap(err, "management contracts events abi is invalid").Error())
+	}
+
+	blockTimeGetter := timestampfinder.NewEthereumBasedBlockTimeGetter(connection)
+	return &EthereumProvider{
+		logger:          logger,
+		config:          config,
+		connection:      connection,
+		contractABI:     contractABI,
+		blockTimeGetter: blockTimeGetter,
+		timestampFinder: timestampfinder.NewTimestampFinder(blockTimeGetter, logger, metricFactory),
+		blockTimes:      make(map[uint64]primitives.TimestampSeconds),
+	}
+}
+
+func (mp *EthereumProvider) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	isHistoric := referenceTime != 0
+	if !isHistoric {
+		referenceTime = primitives.TimestampSeconds(time.Now().Unix())
+	}
+
+	safeBlock, err := mp.getFinalitySafeBlock(ctx, referenceTime)
+	if err != nil {
+		mp.logger.Error("Provider failed to find finality safe ethereum block", log.Error(err))
+		return nil, err
+	}
+
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+
+	if err := mp.scanUntil(ctx, safeBlock.BlockNumber); err != nil {
+		mp.logger.Error("Provider failed to read management events", log.Error(err))
+		return nil, err
+	}
+
+	// the current data is as of the last block read, a historic request is answered as of the requested time
+	currentReference := primitives.TimestampSeconds(time.Duration(safeBlock.BlockTimeNano) / time.Second)
+	if isHistoric {
+		currentReference = referenceTime
+	}
+
+	managementData, err := mp.buildData(ctx, mp.eventLogsUntil(safeBlock.BlockNumber), currentReference)
+	if err != nil {
+		mp.logger.Error("Provider failed to build management data from events", log.Error(err))
+		return nil, err
+	}
+
+	return managementData, nil
+}
+
+func (mp *EthereumProvider) getFinalitySafeBlock(ctx context.Context, referenceTime primitives.TimestampSeconds) (*timestampfinder.BlockNumberAndTime, error) {
+	augmentedReferenceTimestamp := primitives.TimestampNano(time.Duration(referenceTime)*time.Second - mp.config.EthereumFinalityTimeComponent())
+
+	// the finder only looks below the latest block, when nothing newer was mined the latest block is the one
+	blockNumberAndTime, err := mp.blockTimeGetter.GetTimestampForLatestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if blockNumberAndTime == nil {
+		return nil, errors.New("ethereum has no header for the latest block")
+	}
+	if blockNumberAndTime.BlockTimeNano > augmentedReferenceTimestamp {
+		blockNumberAndTime, err = mp.timestampFinder.FindBlockByTimestamp(ctx, augmentedReferenceTimestamp)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resultBlock := blockNumberAndTime.BlockNumber - int64(mp.config.EthereumFinalityBlocksComponent())
+	if resultBlock < 1 {
+		return nil, errors.Errorf("there are not enough blocks to reach a finality safe block, finality safe block is %v", resultBlock)
+	}
+
+	result, err := mp.blockTimeGetter.GetTimestampForBlockNumber(ctx, big.NewInt(resultBlock))
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, errors.Errorf("ethereum has no header for block %d", resultBlock)
+	}
+	return result, nil
+}
+
+func (mp *EthereumProvider) managementContracts() map[string][]byte {
+	return map[string][]byte{
+		GUARDIAN_DATA_UPDATED_EVENT:     mp.config.ManagementEthereumGuardiansContract(),
+		GUARDIAN_METADATA_CHANGED_EVENT: mp.config.ManagementEthereumGuardiansContract(),
+		COMMITTEE_SNAPSHOT_EVENT:        mp.config.ManagementEthereumCommitteeContract(),
+		SUBSCRIPTION_CHANGED_EVENT:      mp.config.ManagementEthereumSubscriptionsContract(),
+		PROTOCOL_VERSION_CHANGED_EVENT:  mp.config.ManagementEthereumProtocolContract(),
+	}
+}
+
+// scans the events of all management contracts in chunks up to the given block, a chunk is kept only if all of its
+// queries succeeded so a failed scan is retried from the same block on the next call
+func (mp *EthereumProvider) scanUntil(ctx context.Context, toBlock int64) error {
+	fromBlock := mp.scannedUntilBlock + 1
+	if fromBlock < int64(mp.config.ManagementEthereumFromBlock()) {
+		fromBlock = int64(mp.config.ManagementEthereumFromBlock())
+	}
+
+	for chunkStart := fromBlock; chunkStart <= toBlock; chunkStart += ETHEREUM_PROVIDER_MAX_BLOCKS_PER_QUERY {
+		chunkEnd := chunkStart + ETHEREUM_PROVIDER_MAX_BLOCKS_PER_QUERY - 1
+		if chunkEnd > toBlock {
+			chunkEnd = toBlock
+		}
+
+		var chunkLogs []*managementEventLog
+		for eventName, contractAddress := range mp.managementContracts() {
+			logs, err := mp.connection.FilterLogs(ctx, contractAddress, mp.contractABI.Events[eventName].ID().Bytes(), big.NewInt(chunkStart), big.NewInt(chunkEnd))
+			if err != nil {
+				return errors.Wrapf(err, "failed reading %s events", eventName)
+			}
+			for _, l := range logs {
+				chunkLogs = append(chunkLogs, &managementEventLog{eventName: eventName, log: l})
+			}
+		}
+
+		sort.SliceStable(chunkLogs, func(i, j int) bool {
+			return isLogBefore(chunkLogs[i].log, chunkLogs[j].log)
+		})
+		mp.eventLogs = append(mp.eventLogs, chunkLogs...)
+		mp.scannedUntilBlock = chunkEnd
+	}
+
+	return nil
+}
+
+func isLogBefore(a *ethereumAdapter.TransactionLog, b *ethereumAdapter.TransactionLog) bool {
+	if a.BlockNumber != b.BlockNumber {
+		return a.BlockNumber < b.BlockNumber
+	}
+	if a.TxIndex != b.TxIndex {
+		return a.TxIndex < b.TxIndex
+	}
+	return a.LogIndex < b.LogIndex
+}
+
+func (mp *EthereumProvider) eventLogsUntil(blockNumber int64) []*managementEventLog {
+	count := sort.Search(len(mp.eventLogs), func(i int) bool {
+		return mp.eventLogs[i].log.BlockNumber > uint64(blockNumber)
+	})
+	return mp.eventLogs[:count]
+}
+
+func (mp *EthereumProvider) getBlockTime(ctx context.Context, blockNumber uint64) (primitives.TimestampSeconds, error) {
+	if blockTime, ok := mp.blockTimes[blockNumber]; ok {
+		return blockTime, nil
+	}
+
+	block, err := mp.blockTimeGetter.GetTimestampForBlockNumber(ctx, new(big.Int).SetUint64(blockNumber))
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed reading time of block %d", blockNumber)
+	}
+	if block == nil {
+		return 0, errors.Errorf("ethereum has no header for block %d", blockNumber)
+	}
+
+	blockTime := primitives.TimestampSeconds(time.Duration(block.BlockTimeNano) / time.Second)
+	mp.blockTimes[blockNumber] = blockTime
+	return blockTime, nil
+}
+
+// replays the events in chain order, each event takes effect as of the time of its block. protocol versions are
+// scheduled per deployment subset, only those of the subset the virtual chain is subscribed to at the time are kept
+func (mp *EthereumProvider) buildData(ctx context.Context, eventLogs []*managementEventLog, currentReference primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	guardians := make(map[common.Address]*guardianRecord)
+	gossipPorts := make(guardianGossipPorts)
+	deploymentSubset, err := mp.firstDeploymentSubset(eventLogs)
+	if err != nil {
+		return nil, err
+	}
+	var committeeTerms []management.CommitteeTerm
+	var subscriptionTerms []management.SubscriptionTerm
+	var protocolVersionTerms []management.ProtocolVersionTerm
+	var genesisReference primitives.TimestampSeconds
+	var pendingExpiry primitives.TimestampSeconds
+
+	for _, eventLog := range eventLogs {
+		blockTime, err := mp.getBlockTime(ctx, eventLog.log.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		switch eventLog.eventName {
+		case GUARDIAN_DATA_UPDATED_EVENT:
+			var event guardianDataUpdatedEvent
+			if err := mp.unpack(&event, eventLog); err != nil {
+				return nil, err
+			}
+			if len(eventLog.log.PackedTopics) < 2 {
+				return nil, errors.Errorf("%s event in block %d is missing the guardian topic", eventLog.eventName, eventLog.log.BlockNumber)
+			}
+			guardians[common.BytesToAddress(eventLog.log.PackedTopics[1])] = &guardianRecord{
+				orbsAddress:  primitives.NodeAddress(event.OrbsAddr.Bytes()),
+				ip:           event.Ip,
+				isRegistered: event.IsRegistered,
+			}
+
+		case GUARDIAN_METADATA_CHANGED_EVENT:
+			var event guardianMetadataChangedEvent
+			if err := mp.unpack(&event, eventLog); err != nil {
+				return nil, err
+			}
+			if len(eventLog.log.PackedTopics) < 2 {
+				return nil, errors.Errorf("%s event in block %d is missing the guardian topic", eventLog.eventName, eventLog.log.BlockNumber)
+			}
+			if event.Key == GUARDIAN_GOSSIP_PORT_METADATA_KEY {
+				gossipPorts.update(common.BytesToAddress(eventLog.log.PackedTopics[1]), event.NewValue, mp.logger)
+			}
+
+		case COMMITTEE_SNAPSHOT_EVENT:
+			var event committeeSnapshotEvent
+			if err := mp.unpack(&event, eventLog); err != nil {
+				return nil, err
+			}
+			committeeTerm, err := mp.toCommitteeTerm(&event, guardians, blockTime)
+			if err != nil {
+				return nil, errors.Wrapf(err, "bad committee in block %d", eventLog.log.BlockNumber)
+			}
+			committeeTerms = append(committeeTerms, *committeeTerm)
+
+		case SUBSCRIPTION_CHANGED_EVENT:
+			if len(eventLog.log.PackedTopics) < 2 {
+				return nil, errors.Errorf("%s event in block %d is missing the virtual chain topic", eventLog.eventName, eventLog.log.BlockNumber)
+			}
+			if new(big.Int).SetBytes(eventLog.log.PackedTopics[1]).Uint64() != uint64(mp.config.VirtualChainId()) {
+				continue
+			}
+			var event subscriptionChangedEvent
+			if err := mp.unpack(&event, eventLog); err != nil {
+				return nil, err
+			}
+			if len(subscriptionTerms) == 0 {
+				genesisReference = primitives.TimestampSeconds(event.GenRefTime.Uint64())
+			}
+			deploymentSubset = event.DeploymentSubset
+			if pendingExpiry != 0 && pendingExpiry <= blockTime {
+				subscriptionTerms = append(subscriptionTerms, management.SubscriptionTerm{AsOfReference: pendingExpiry, IsActive: false})
+			}
+			expiresAt := primitives.TimestampSeconds(event.ExpiresAt.Uint64())
+			isActive := expiresAt > blockTime
+			subscriptionTerms = append(subscriptionTerms, management.SubscriptionTerm{AsOfReference: blockTime, IsActive: isActive})
+			pendingExpiry = 0
+			if isActive {
+				pendingExpiry = expiresAt
+			}
+
+		case PROTOCOL_VERSION_CHANGED_EVENT:
+			var event protocolVersionChangedEvent
+			if err := mp.unpack(&event, eventLog); err != nil {
+				return nil, err
+			}
+			if event.DeploymentSubset != deploymentSubset {
+				continue
+			}
+			protocolVersionTerms = scheduleProtocolVersion(protocolVersionTerms, primitives.TimestampSeconds(event.FromTimestamp.Uint64()), primitives.ProtocolVersion(event.NextVersion.Uint64()))
+		}
+	}
+
+	if len(committeeTerms) == 0 {
+		return nil, errors.New("cannot start virtual chain with no committee data.")
+	}
+
+	if len(subscriptionTerms) == 0 {
+		return nil, errors.New("cannot start virtual chain with no subscription data.")
+	}
+	// a subscription which was not renewed becomes inactive when it expires, even if that is still ahead
+	if pendingExpiry != 0 {
+		subscriptionTerms = append(subscriptionTerms, management.SubscriptionTerm{AsOfReference: pendingExpiry, IsActive: false})
+	}
+
+	if len(protocolVersionTerms) == 0 {
+		protocolVersionTerms = append(protocolVersionTerms, management.ProtocolVersionTerm{AsOfReference: 0, Version: config.MINIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE})
+	}
+
+	return &management.VirtualChainManagementData{
+		CurrentReference:   currentReference,
+		GenesisReference:   genesisReference,
+		StartPageReference: 0,
+		EndPageReference:   currentReference,
+		CurrentTopology:    mp.toTopology(guardians, gossipPorts),
+		Committees:         committeeTerms,
+		Subscriptions:      subscriptionTerms,
+		ProtocolVersions:   protocolVersionTerms,
+	}, nil
+}
+
+// protocol versions scheduled before the virtual chain subscribed apply to the subset it first subscribed to
+func (mp *EthereumProvider) firstDeploymentSubset(eventLogs []*managementEventLog) (string, error) {
+	for _, eventLog := range eventLogs {
+		if eventLog.eventName != SUBSCRIPTION_CHANGED_EVENT || len(eventLog.log.PackedTopics) < 2 ||
+			new(big.Int).SetBytes(eventLog.log.PackedTopics[1]).Uint64() != uint64(mp.config.VirtualChainId()) {
+			continue
+		}
+		var event subscriptionChangedEvent
+		if err := mp.unpack(&event, eventLog); err != nil {
+			return "", err
+		}
+		return event.DeploymentSubset, nil
+	}
+	return "", nil
+}
+
+func (mp *EthereumProvider) unpack(out interface{}, eventLog *managementEventLog) error {
+	if err := mp.contractABI.Events[eventLog.eventName].Inputs.Unpack(out, eventLog.log.Data); err != nil {
+		return errors.Wrapf(err, "failed unpacking %s event in block %d", eventLog.eventName, eventLog.log.BlockNumber)
+	}
+	return nil
+}
+
+// committee members are the orbs addresses of the guardians, ordered the same way the file provider orders them
+func (mp *EthereumProvider) toCommitteeTerm(event *committeeSnapshotEvent, guardians map[common.Address]*guardianRecord, blockTime primitives.TimestampSeconds) (*management.CommitteeTerm, error) {
+	if len(event.Addrs) != len(event.Weights) {
+		return nil, errors.Errorf("committee has %d members but %d weights", len(event.Addrs), len(event.Weights))
+	}
+
+	term := &management.CommitteeTerm{AsOfReference: blockTime}
+	for i, guardianAddress := range event.Addrs {
+		guardian, ok := guardians[guardianAddress]
+		if !ok {
+			mp.logger.Info("committee member has no guardian data, ignoring it", log.String("guardian", guardianAddress.Hex()))
+			continue
+		}
+		if event.Weights[i].Sign() == 0 {
+			mp.logger.Info("committee member has no weight, ignoring it", log.String("guardian", guardianAddress.Hex()))
+			continue
+		}
+		term.Members = append(term.Members, guardian.orbsAddress)
+		term.Weights = append(term.Weights, primitives.Weight(event.Weights[i].Uint64()))
+	}
+
+	if len(term.Members) == 0 {
+		return nil, errors.New("committee has no valid members")
+	}
+
+	sort.Sort(committeeByAddress(*term))
+	return term, nil
+}
+
+type committeeByAddress management.CommitteeTerm
+
+func (c committeeByAddress) Len() int { return len(c.Members) }
+func (c committeeByAddress) Less(i, j int) bool {
+	return bytes.Compare(c.Members[i], c.Members[j]) > 0
+}
+func (c committeeByAddress) Swap(i, j int) {
+	c.Members[i], c.Members[j] = c.Members[j], c.Members[i]
+	c.Weights[i], c.Weights[j] = c.Weights[j], c.Weights[i]
+}
+
+// a port which is not valid removes the port of the guardian, the file provider accepts the same range
+func (ports guardianGossipPorts) update(guardian common.Address, value string, logger log.Logger) {
+	port, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || port < 1024 {
+		logger.Info("guardian gossip port is not valid, ignoring it", log.String("guardian", guardian.Hex()), log.String("port", value))
+		delete(ports, guardian)
+		return
+	}
+	ports[guardian] = uint32(port)
+}
+
+// all registered guardians with an ip and a gossip port take part in gossip
+func (mp *EthereumProvider) toTopology(guardians map[common.Address]*guardianRecord, gossipPorts guardianGossipPorts) []*services.GossipPeer {
+	topology := make([]*services.GossipPeer, 0, len(guardians))
+	for guardianAddress, guardian := range guardians {
+		if !guardian.isRegistered {
+			continue
+		}
+		if guardian.ip == [4]byte{} {
+			mp.logger.Info("registered guardian has no ip, it is left out of the topology", log.String("guardian", guardianAddress.Hex()))
+			continue
+		}
+		port, ok := gossipPorts[guardianAddress]
+		if !ok {
+			mp.logger.Info("registered guardian has no gossip port, it is left out of the topology", log.String("guardian", guardianAddress.Hex()))
+			continue
+		}
+		topology = append(topology, &services.GossipPeer{
+			Address:  guardian.orbsAddress,
+			Endpoint: net.IP(guardian.ip[:]).String(),
+			Port:     port,
+		})
+	}
+
+	sort.Slice(topology, func(i, j int) bool {
+		return bytes.Compare(topology[i].Address, topology[j].Address) < 0
+	})
+	return topology
+}
+
+// a new protocol version upgrade replaces upgrades which were scheduled to happen at the same time or later
+func scheduleProtocolVersion(terms []management.ProtocolVersionTerm, asOf primitives.TimestampSeconds, version primitives.ProtocolVersion) []management.ProtocolVersionTerm {
+	for len(terms) > 0 && terms[len(terms)-1].AsOfReference >= asOf {
+		terms = terms[:len(terms)-1]
+	}
+	return append(terms, management.ProtocolVersionTerm{AsOfReference: asOf, Version: version})
+}
@@ -0,0 +1,406 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	ethereumAdapter "github.com/orbs-network/orbs-network-go/services/crosschainconnector/ethereum/adapter"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const ETHEREUM_PROVIDER_TEST_BLOCK_INTERVAL = 10 // seconds
+
+var (
+	guardiansContract     = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	committeeContract     = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	subscriptionsContract = common.HexToAddress("0x1000000000000000000000000000000000000003")
+	protocolContract      = common.HexToAddress("0x1000000000000000000000000000000000000004")
+)
+
+type ecfg struct {
+	vcId primitives.VirtualChainId
+}
+
+func (tc *ecfg) VirtualChainId() primitives.VirtualChainId    { return tc.vcId }
+func (tc *ecfg) EthereumFinalityTimeComponent() time.Duration { return 0 }
+func (tc *ecfg) EthereumFinalityBlocksComponent() uint32      { return 2 }
+func (tc *ecfg) ManagementEthereumGuardiansContract() []byte  { return guardiansContract.Bytes() }
+func (tc *ecfg) ManagementEthereumCommitteeContract() []byte  { return committeeContract.Bytes() }
+func (tc *ecfg) ManagementEthereumSubscriptionsContract() []byte {
+	return subscriptionsContract.Bytes()
+}
+func (tc *ecfg) ManagementEthereumProtocolContract() []byte { return protocolContract.Bytes() }
+func (tc *ecfg) ManagementEthereumFromBlock() uint32        { return 1 }
+
+// a chain with a block every 10 seconds, the latest block is mined now
+type fakeManagementChain struct {
+	t           *testing.T
+	contractABI abi.ABI
+	genesisTime int64
+
+	sync.Mutex
+	latestBlock   int64
+	logs          []*ethereumAdapter.TransactionLog
+	filterQueries [][2]int64
+}
+
+func newFakeManagementChain(t *testing.T, latestBlock int64) *fakeManagementChain {
+	contractABI, err := abi.JSON(strings.NewReader(ManagementContractsEventsAbi))
+	require.NoError(t, err)
+	return &fakeManagementChain{
+		t:           t,
+		contractABI: contractABI,
+		latestBlock: latestBlock,
+		genesisTime: time.Now().Unix() - latestBlock*ETHEREUM_PROVIDER_TEST_BLOCK_INTERVAL,
+	}
+}
+
+func (c *fakeManagementChain) blockTime(blockNumber int64) primitives.TimestampSeconds {
+	return primitives.TimestampSeconds(c.genesisTime + blockNumber*ETHEREUM_PROVIDER_TEST_BLOCK_INTERVAL)
+}
+
+func (c *fakeManagementChain) emit(blockNumber uint64, contract common.Address, eventName string, indexed []byte, args ...interface{}) {
+	event := c.contractABI.Events[eventName]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	require.NoError(c.t, err)
+
+	topics := [][]byte{event.ID().Bytes()}
+	if indexed != nil {
+		topics = append(topics, common.LeftPadBytes(indexed, 32))
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.logs = append(c.logs, &ethereumAdapter.TransactionLog{
+		ContractAddress: contract.Bytes(),
+		PackedTopics:    topics,
+		Data:            data,
+		BlockNumber:     blockNumber,
+		LogIndex:        uint32(len(c.logs)),
+	})
+}
+
+// guardians are registered with the default gossip port
+func (c *fakeManagementChain) emitGuardian(blockNumber uint64, guardian common.Address, orbsAddress common.Address, ip [4]byte, isRegistered bool) {
+	c.emit(blockNumber, guardiansContract, GUARDIAN_DATA_UPDATED_EVENT, guardian.Bytes(), isRegistered, ip, orbsAddress, "name", "website", big.NewInt(0))
+	c.emitGossipPort(blockNumber, guardian, "4400")
+}
+
+func (c *fakeManagementChain) emitGossipPort(blockNumber uint64, guardian common.Address, port string) {
+	c.emit(blockNumber, guardiansContract, GUARDIAN_METADATA_CHANGED_EVENT, guardian.Bytes(), GUARDIAN_GOSSIP_PORT_METADATA_KEY, port, "")
+}
+
+func (c *fakeManagementChain) emitCommittee(blockNumber uint64, guardians []common.Address, weights []int64) {
+	var bigWeights []*big.Int
+	var certification []bool
+	for _, weight := range weights {
+		bigWeights = append(bigWeights, big.NewInt(weight))
+		certification = append(certification, false)
+	}
+	c.emit(blockNumber, committeeContract, COMMITTEE_SNAPSHOT_EVENT, nil, guardians, bigWeights, certification)
+}
+
+func (c *fakeManagementChain) emitSubscription(blockNumber uint64, vcId uint64, genesis primitives.TimestampSeconds, expiresAt primitives.TimestampSeconds) {
+	c.emitSubscriptionToSubset(blockNumber, vcId, genesis, expiresAt, "main")
+}
+
+func (c *fakeManagementChain) emitSubscriptionToSubset(blockNumber uint64, vcId uint64, genesis primitives.TimestampSeconds, expiresAt primitives.TimestampSeconds, deploymentSubset string) {
+	c.emit(blockNumber, subscriptionsContract, SUBSCRIPTION_CHANGED_EVENT, new(big.Int).SetUint64(vcId).Bytes(),
+		common.Address{}, "vc", big.NewInt(int64(genesis)), "defaultTier", big.NewInt(0), big.NewInt(int64(expiresAt)), false, deploymentSubset)
+}
+
+func (c *fakeManagementChain) emitProtocolVersion(blockNumber uint64, version int64, fromTimestamp primitives.TimestampSeconds) {
+	c.emitProtocolVersionOfSubset(blockNumber, version, fromTimestamp, "main")
+}
+
+func (c *fakeManagementChain) emitProtocolVersionOfSubset(blockNumber uint64, version int64, fromTimestamp primitives.TimestampSeconds, deploymentSubset string) {
+	c.emit(blockNumber, protocolContract, PROTOCOL_VERSION_CHANGED_EVENT, nil, deploymentSubset, big.NewInt(version-1), big.NewInt(version), big.NewInt(int64(fromTimestamp)))
+}
+
+func (c *fakeManagementChain) FilterLogs(ctx context.Context, contractAddress []byte, eventSignature []byte, fromBlock *big.Int, toBlock *big.Int) ([]*ethereumAdapter.TransactionLog, error) {
+	c.Lock()
+	defer c.Unlock()
+	c.filterQueries = append(c.filterQueries, [2]int64{fromBlock.Int64(), toBlock.Int64()})
+
+	var result []*ethereumAdapter.TransactionLog
+	for _, l := range c.logs {
+		if bytes.Equal(l.ContractAddress, contractAddress) && bytes.Equal(l.PackedTopics[0], eventSignature) &&
+			l.BlockNumber >= fromBlock.Uint64() && l.BlockNumber <= toBlock.Uint64() {
+			result = append(result, l)
+		}
+	}
+	return result, nil
+}
+
+func (c *fakeManagementChain) HeaderByNumber(ctx context.Context, number *big.Int) (*ethereumAdapter.BlockNumberAndTime, error) {
+	c.Lock()
+	defer c.Unlock()
+	blockNumber := c.latestBlock
+	if number != nil {
+		blockNumber = number.Int64()
+	}
+	return &ethereumAdapter.BlockNumberAndTime{BlockNumber: blockNumber, TimeInSeconds: uint64(c.blockTime(blockNumber))}, nil
+}
+
+func (c *fakeManagementChain) mine(blocks int64) {
+	c.Lock()
+	defer c.Unlock()
+	c.latestBlock += blocks
+	c.genesisTime -= blocks * ETHEREUM_PROVIDER_TEST_BLOCK_INTERVAL
+}
+
+func newEthereumProviderForTests(parent *with.LoggingHarness, chain *fakeManagementChain) *EthereumProvider {
+	return NewEthereumProvider(chain, &ecfg{vcId: 42}, parent.Logger, metric.NewRegistry())
+}
+
+func TestEthereumProvider_BuildsManagementDataFromEvents(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian1, guardian2 := common.HexToAddress("0xa1"), common.HexToAddress("0xa2")
+			orbs1, orbs2 := common.HexToAddress("0xb1"), common.HexToAddress("0xb2")
+
+			chain.emitGuardian(10, guardian1, orbs1, [4]byte{10, 0, 0, 1}, true)
+			chain.emitGuardian(10, guardian2, orbs2, [4]byte{10, 0, 0, 2}, true)
+			chain.emitGossipPort(10, guardian2, "4500")
+			chain.emitCommittee(11, []common.Address{guardian1, guardian2}, []int64{100, 200})
+			chain.emitSubscription(12, 42, 1000, chain.blockTime(1000))
+			chain.emitProtocolVersion(13, 2, 5000)
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+
+			require.EqualValues(t, chain.blockTime(98), data.CurrentReference, "current reference should be the time of the finality safe block")
+			require.EqualValues(t, data.CurrentReference, data.EndPageReference)
+			require.EqualValues(t, 0, data.StartPageReference)
+			require.EqualValues(t, 1000, data.GenesisReference)
+
+			require.Len(t, data.CurrentTopology, 2)
+			require.EqualValues(t, orbs1.Bytes(), data.CurrentTopology[0].Address)
+			require.EqualValues(t, "10.0.0.1", data.CurrentTopology[0].Endpoint)
+			require.EqualValues(t, 4400, data.CurrentTopology[0].Port)
+			require.EqualValues(t, 4500, data.CurrentTopology[1].Port, "each peer should be reached on the port it published")
+
+			require.Len(t, data.Committees, 1)
+			require.EqualValues(t, chain.blockTime(11), data.Committees[0].AsOfReference)
+			require.EqualValues(t, []primitives.NodeAddress{orbs2.Bytes(), orbs1.Bytes()}, data.Committees[0].Members, "members should be ordered as the file provider orders them")
+			require.EqualValues(t, []primitives.Weight{200, 100}, data.Committees[0].Weights, "weights should stay aligned with their members")
+
+			require.EqualValues(t, []management.SubscriptionTerm{
+				{AsOfReference: chain.blockTime(12), IsActive: true},
+				{AsOfReference: chain.blockTime(1000), IsActive: false},
+			}, data.Subscriptions)
+			require.EqualValues(t, []management.ProtocolVersionTerm{{AsOfReference: 5000, Version: 2}}, data.ProtocolVersions)
+		})
+	})
+}
+
+func TestEthereumProvider_IgnoresEventsAfterFinalitySafeBlock(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitSubscription(12, 42, 1000, chain.blockTime(1000))
+			chain.emitCommittee(99, []common.Address{guardian}, []int64{300})
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, data.Committees, 1, "committee of a block which is not finality safe should not be read")
+			require.EqualValues(t, []management.ProtocolVersionTerm{{AsOfReference: 0, Version: config.MINIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE}}, data.ProtocolVersions)
+		})
+	})
+}
+
+func TestEthereumProvider_HistoricReference(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitSubscription(12, 42, 1000, chain.blockTime(1000))
+			chain.emitCommittee(50, []common.Address{guardian}, []int64{300})
+			provider := newEthereumProviderForTests(parent, chain)
+
+			historicReference := chain.blockTime(40)
+			data, err := provider.Get(ctx, historicReference)
+			require.NoError(t, err)
+			require.Len(t, data.Committees, 1)
+			require.EqualValues(t, historicReference, data.CurrentReference)
+
+			data, err = provider.Get(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, data.Committees, 2)
+
+			data, err = provider.Get(ctx, historicReference)
+			require.NoError(t, err)
+			require.Len(t, data.Committees, 1, "events already scanned for a later reference should not leak into a historic one")
+		})
+	})
+}
+
+func TestEthereumProvider_ScansOnlyNewBlocks(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitSubscription(12, 42, 1000, chain.blockTime(1000))
+			provider := newEthereumProviderForTests(parent, chain)
+
+			_, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+
+			chain.mine(5)
+			chain.emitCommittee(102, []common.Address{guardian}, []int64{300})
+			chain.filterQueries = nil
+
+			data, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, data.Committees, 2)
+			for _, query := range chain.filterQueries {
+				require.EqualValues(t, [2]int64{99, 103}, query, "only blocks added since the last scan should be queried")
+			}
+		})
+	})
+}
+
+func TestEthereumProvider_SubscriptionOfOtherVirtualChainIsIgnored(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitSubscription(12, 43, 1000, chain.blockTime(1000))
+			parent.AllowErrorsMatching("Provider failed to build management data from events")
+
+			_, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.EqualError(t, err, "cannot start virtual chain with no subscription data.")
+		})
+	})
+}
+
+func TestEthereumProvider_SubscriptionRenewalAndExpiry(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitSubscription(12, 42, 1000, chain.blockTime(20))
+			chain.emitSubscription(30, 42, 1000, chain.blockTime(40))
+			chain.emitSubscription(35, 42, 1000, chain.blockTime(1000))
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+			require.EqualValues(t, []management.SubscriptionTerm{
+				{AsOfReference: chain.blockTime(12), IsActive: true},
+				{AsOfReference: chain.blockTime(20), IsActive: false},
+				{AsOfReference: chain.blockTime(30), IsActive: true},
+				{AsOfReference: chain.blockTime(35), IsActive: true},
+				{AsOfReference: chain.blockTime(1000), IsActive: false},
+			}, data.Subscriptions)
+		})
+	})
+}
+
+func TestEthereumProvider_CommitteeSkipsUnknownGuardiansAndUnregisteredLeaveTopology(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian1, guardian2 := common.HexToAddress("0xa1"), common.HexToAddress("0xa2")
+
+			chain.emitGuardian(10, guardian1, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitGuardian(10, guardian2, common.HexToAddress("0xb2"), [4]byte{10, 0, 0, 2}, true)
+			chain.emitGuardian(20, guardian2, common.HexToAddress("0xb2"), [4]byte{10, 0, 0, 2}, false)
+			chain.emitCommittee(21, []common.Address{guardian1, common.HexToAddress("0xa3")}, []int64{100, 200})
+			chain.emitSubscription(22, 42, 1000, chain.blockTime(1000))
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, data.CurrentTopology, 1)
+			require.EqualValues(t, []primitives.Weight{100}, data.Committees[0].Weights)
+		})
+	})
+}
+
+func TestEthereumProvider_ProtocolVersionRescheduling(t *testing.T) {
+	terms := scheduleProtocolVersion(nil, 100, 2)
+	terms = scheduleProtocolVersion(terms, 200, 3)
+	terms = scheduleProtocolVersion(terms, 150, 4)
+
+	require.EqualValues(t, []management.ProtocolVersionTerm{{AsOfReference: 100, Version: 2}, {AsOfReference: 150, Version: 4}}, terms)
+}
+
+func TestEthereumProvider_GuardianWithoutValidGossipPortLeavesTopology(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian1, guardian2, guardian3 := common.HexToAddress("0xa1"), common.HexToAddress("0xa2"), common.HexToAddress("0xa3")
+
+			chain.emitGuardian(10, guardian1, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emit(10, guardiansContract, GUARDIAN_DATA_UPDATED_EVENT, guardian2.Bytes(), true, [4]byte{10, 0, 0, 2}, common.HexToAddress("0xb2"), "name", "website", big.NewInt(0))
+			chain.emitGuardian(10, guardian3, common.HexToAddress("0xb3"), [4]byte{10, 0, 0, 3}, true)
+			chain.emitGossipPort(11, guardian3, "not a port")
+			chain.emitCommittee(12, []common.Address{guardian1, guardian2, guardian3}, []int64{100, 200, 300})
+			chain.emitSubscription(13, 42, 1000, chain.blockTime(1000))
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+			require.Len(t, data.CurrentTopology, 1, "guardians without a valid gossip port should be left out of the topology")
+			require.EqualValues(t, common.HexToAddress("0xb1").Bytes(), data.CurrentTopology[0].Address)
+			require.Len(t, data.Committees[0].Members, 3, "the gossip port should not affect the committee")
+		})
+	})
+}
+
+func TestEthereumProvider_ProtocolVersionOfOtherDeploymentSubsetIsIgnored(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			chain := newFakeManagementChain(t, 100)
+			guardian := common.HexToAddress("0xa1")
+
+			chain.emitGuardian(10, guardian, common.HexToAddress("0xb1"), [4]byte{10, 0, 0, 1}, true)
+			chain.emitCommittee(11, []common.Address{guardian}, []int64{100})
+			chain.emitProtocolVersionOfSubset(12, 2, 4000, "canary")
+			chain.emitSubscriptionToSubset(13, 42, 1000, chain.blockTime(1000), "canary")
+			chain.emitProtocolVersionOfSubset(14, 3, 5000, "main")
+			chain.emitSubscriptionToSubset(15, 42, 1000, chain.blockTime(1000), "main")
+			chain.emitProtocolVersionOfSubset(16, 4, 6000, "canary")
+			chain.emitProtocolVersionOfSubset(17, 4, 7000, "main")
+
+			data, err := newEthereumProviderForTests(parent, chain).Get(ctx, 0)
+			require.NoError(t, err)
+			require.EqualValues(t, []management.ProtocolVersionTerm{
+				{AsOfReference: 4000, Version: 2},
+				{AsOfReference: 7000, Version: 4},
+			}, data.ProtocolVersions, "only versions of the subset the virtual chain is subscribed to at the time should be scheduled")
+		})
+	})
+}
@@ -23,24 +23,43 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 )
 
 type FileConfig interface {
 	VirtualChainId() primitives.VirtualChainId
 	ManagementFilePath() string
 	ManagementMaxFileSize() uint32
+	ManagementAuthorityAddresses() []primitives.NodeAddress
+	ManagementAuthorityThreshold() uint32
+	ManagementMaxDataAge() time.Duration
 }
 
 type FileProvider struct {
 	logger log.Logger
 	config FileConfig
+	path   string
+
+	mutex              sync.Mutex
+	lastCurrentRefTime uint64
 }
 
 func NewFileProvider(config FileConfig, logger log.Logger) *FileProvider {
-	return &FileProvider{config: config, logger: logger}
+	return NewFileProviderForPath(config, config.ManagementFilePath(), logger)
+}
+
+// NewFileProviderForPath reads the management data from the given file path or url instead of the configured one
+func NewFileProviderForPath(config FileConfig, path string, logger log.Logger) *FileProvider {
+	return &FileProvider{config: config, path: path, logger: logger.WithTags(log.String("management-source", path))}
 }
 
 func (mp *FileProvider) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	managementData, _, err := mp.GetWithDocument(ctx, referenceTime)
+	return managementData, err
+}
+
+func (mp *FileProvider) GetWithDocument(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, []byte, error) {
 	path := mp.generatePath(referenceTime)
 	var contents []byte
 	var err error
@@ -48,31 +67,34 @@ func (mp *FileProvider) Get(ctx context.Context, referenceTime primitives.Timest
 	if strings.HasPrefix(path, "http") {
 		if contents, err = mp.readUrl(path); err != nil {
 			mp.logger.Error("Provider url reading error", log.Error(err))
-			return nil, err
+			return nil, nil, err
 		}
 	} else {
 		if contents, err = mp.readFile(path); err != nil {
 			mp.logger.Error("Provider path file reading error", log.Error(err))
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
-	isHistoric := referenceTime != 0
-	managementData, parseErr := mp.parseData(contents, isHistoric)
+	managementData, parseErr := mp.ParseDocument(contents, referenceTime)
 	if parseErr != nil {
 		mp.logger.Error("Provider file parsing error", log.Error(parseErr))
-		return nil, parseErr
+		return nil, nil, parseErr
 	}
 
-	return managementData, nil
+	return managementData, contents, nil
+}
+
+func (mp *FileProvider) ParseDocument(document []byte, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	return mp.parseData(document, referenceTime)
 }
 
 func (mp *FileProvider) generatePath(referenceTime primitives.TimestampSeconds) string {
 	var path string
 	if referenceTime == 0 {
-		path = mp.config.ManagementFilePath()
+		path = mp.path
 	} else {
-		path = fmt.Sprintf("%s/%d", mp.config.ManagementFilePath(), referenceTime)
+		path = fmt.Sprintf("%s/%d", mp.path, referenceTime)
 	}
 	return path
 }
@@ -161,7 +183,14 @@ type mgmt struct {
 	VirtualChains    map[string]vc
 }
 
-func (mp *FileProvider) parseData(contents []byte, isHistoric bool) (*management.VirtualChainManagementData, error) {
+// a reference time of 0 asks for the current data, any other for the historic page covering it
+func (mp *FileProvider) parseData(contents []byte, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	isHistoric := referenceTime != 0
+	contents, err := mp.unwrapSignedData(contents)
+	if err != nil {
+		return nil, err
+	}
+
 	var data mgmt
 	if err := json.Unmarshal(contents, &data); err != nil {
 		return nil, errors.Wrapf(err, "could not unmarshal vcs data")
@@ -188,6 +217,10 @@ func (mp *FileProvider) parseData(contents []byte, isHistoric bool) (*management
 		}
 	}
 
+	if err := mp.verifyFreshness(data.CurrentRefTime, data.PageStartRefTime, data.PageEndRefTime, referenceTime); err != nil {
+		return nil, err
+	}
+
 	topology, err := parseTopology(vcData.CurrentTopology)
 	if err != nil {
 		return nil, err
@@ -205,6 +238,7 @@ func (mp *FileProvider) parseData(contents []byte, isHistoric bool) (*management
 
 	protocolVersions := parseProtocolVersion(vcData.ProtocolVersionEvents)
 
+	mp.updateLastCurrentRefTime(data.CurrentRefTime, isHistoric)
 	return &management.VirtualChainManagementData{
 		CurrentReference:   primitives.TimestampSeconds(data.CurrentRefTime),
 		GenesisReference:   primitives.TimestampSeconds(vcData.GenesisRefTime),
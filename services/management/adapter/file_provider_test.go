@@ -17,6 +17,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestManagementFileProvider_GeneratePath(t *testing.T) {
@@ -44,7 +45,7 @@ func TestManagementFileProvider_NoMatchVc(t *testing.T) {
 		"44": { 
 		}
 	}
-}`), false)
+}`), 0)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "could not find current vc in data")
 		})
@@ -64,7 +65,7 @@ func TestManagementFileProvider_BadCurrentInPage(t *testing.T) {
 		"42": { 
 		}
 	}
-}`), false)
+}`), 0)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "data: CurrentRefTime (3) ")
 
@@ -76,7 +77,7 @@ func TestManagementFileProvider_BadCurrentInPage(t *testing.T) {
 		"42": { 
 		}
 	}
-}`), false)
+}`), 0)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "data: CurrentRefTime (2) ")
 
@@ -88,7 +89,7 @@ func TestManagementFileProvider_BadCurrentInPage(t *testing.T) {
 		"42": { 
 		}
 	}
-}`), true)
+}`), 3)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "historic data : CurrentRefTime (4) ")
 
@@ -100,7 +101,7 @@ func TestManagementFileProvider_BadCurrentInPage(t *testing.T) {
 		"42": { 
 		}
 	}
-}`), true)
+}`), 3)
 			require.Error(t, err)
 			require.Contains(t, err.Error(), "historic data : CurrentRefTime (4) ")
 		})
@@ -187,8 +188,11 @@ func requireCommitteeToBeSameAsStatic(t *testing.T, c []management.CommitteeTerm
 }
 
 type fconfig struct {
-	vcId primitives.VirtualChainId
-	path string
+	vcId        primitives.VirtualChainId
+	path        string
+	authorities []primitives.NodeAddress
+	threshold   uint32
+	maxDataAge  time.Duration
 }
 
 func newConfig(vcId primitives.VirtualChainId, path string) *fconfig {
@@ -209,3 +213,15 @@ func (tc *fconfig) ManagementFilePath() string {
 func (tc *fconfig) ManagementMaxFileSize() uint32 {
 	return 1 << 20 * 50
 }
+
+func (tc *fconfig) ManagementAuthorityAddresses() []primitives.NodeAddress {
+	return tc.authorities
+}
+
+func (tc *fconfig) ManagementAuthorityThreshold() uint32 {
+	return tc.threshold
+}
+
+func (tc *fconfig) ManagementMaxDataAge() time.Duration {
+	return tc.maxDataAge
+}
@@ -0,0 +1,311 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+type HistoricCacheConfig interface {
+	ManagementHistoricCacheDir() string
+	ManagementHistoricCacheBundle() string
+}
+
+// DocumentProvider is a provider whose data is parsed from documents, such as signed files, which can be verified again
+// when read back from an untrusted store
+type DocumentProvider interface {
+	management.Provider
+	GetWithDocument(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, []byte, error)
+	ParseDocument(document []byte, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error)
+}
+
+// an exported bundle holds every page of a historic cache, it is used to pre-seed the cache of another node
+type historicBundle struct {
+	Pages []historicBundlePage
+}
+
+type historicBundlePage struct {
+	Start    primitives.TimestampSeconds
+	End      primitives.TimestampSeconds
+	Document json.RawMessage
+}
+
+type historicPage struct {
+	start primitives.TimestampSeconds
+	end   primitives.TimestampSeconds
+}
+
+func (p historicPage) fileName() string {
+	return fmt.Sprintf("%d-%d.json", p.start, p.end)
+}
+
+func (p historicPage) contains(other historicPage) bool {
+	return p.start <= other.start && other.end <= p.end
+}
+
+// HistoricCacheProvider persists the document of every historic page read through it to a local directory, indexed by
+// the reference range the page covers. Historic requests inside a persisted range are served from disk without asking
+// the provider, so a node can resync old blocks while its management source is down. Persisted and imported documents
+// are verified by the provider again every time they are read. Current data is always read from the provider
+type HistoricCacheProvider struct {
+	logger   log.Logger
+	provider DocumentProvider
+	dir      string
+
+	mutex sync.Mutex
+	pages []historicPage // ordered by start
+
+	metrics struct {
+		pages  *metric.Gauge
+		hits   *metric.Gauge
+		misses *metric.Gauge
+	}
+}
+
+func NewHistoricCacheProvider(config HistoricCacheConfig, provider DocumentProvider, logger log.Logger, metricFactory metric.Factory) (*HistoricCacheProvider, error) {
+	hp := &HistoricCacheProvider{
+		logger:   logger.WithTags(log.String("management-cache", config.ManagementHistoricCacheDir())),
+		provider: provider,
+		dir:      config.ManagementHistoricCacheDir(),
+	}
+	hp.metrics.pages = metricFactory.NewGauge("Management.HistoricCache.Pages.Count")
+	hp.metrics.hits = metricFactory.NewGauge("Management.HistoricCache.Hits.Count")
+	hp.metrics.misses = metricFactory.NewGauge("Management.HistoricCache.Misses.Count")
+
+	if err := os.MkdirAll(hp.dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "could not create management historic cache dir %s", hp.dir)
+	}
+	if err := hp.loadIndex(); err != nil {
+		return nil, err
+	}
+
+	if bundlePath := config.ManagementHistoricCacheBundle(); bundlePath != "" {
+		if err := hp.importBundle(bundlePath); err != nil {
+			return nil, err
+		}
+	}
+
+	return hp, nil
+}
+
+func (hp *HistoricCacheProvider) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	if referenceTime == 0 {
+		return hp.provider.Get(ctx, 0)
+	}
+
+	if data, err := hp.read(referenceTime); err != nil {
+		hp.logger.Info("failed reading management page from historic cache, reading from provider", log.Error(err))
+	} else if data != nil {
+		hp.metrics.hits.Inc()
+		return data, nil
+	}
+
+	hp.metrics.misses.Inc()
+	data, document, err := hp.provider.GetWithDocument(ctx, referenceTime)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := hp.write(data, document); err != nil {
+		hp.logger.Info("failed persisting management page to historic cache", log.Error(err))
+	}
+	return data, nil
+}
+
+func (hp *HistoricCacheProvider) loadIndex() error {
+	files, err := ioutil.ReadDir(hp.dir)
+	if err != nil {
+		return errors.Wrapf(err, "could not list management historic cache dir %s", hp.dir)
+	}
+
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+	for _, file := range files {
+		var page historicPage
+		if _, err := fmt.Sscanf(file.Name(), "%d-%d.json", &page.start, &page.end); err != nil || page.fileName() != file.Name() {
+			continue
+		}
+		hp.pages = append(hp.pages, page)
+	}
+	sort.Slice(hp.pages, func(i, j int) bool {
+		return hp.pages[i].start < hp.pages[j].start
+	})
+	hp.metrics.pages.Update(int64(len(hp.pages)))
+	return nil
+}
+
+// a page which fails verification is removed so the page read from the provider instead can take its place
+func (hp *HistoricCacheProvider) read(referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	page, document, err := hp.readDocument(referenceTime)
+	if err != nil || document == nil {
+		return nil, err
+	}
+
+	data, err := hp.provider.ParseDocument(document, referenceTime)
+	if err != nil {
+		if removeErr := hp.remove(page); removeErr != nil {
+			return nil, errors.Wrapf(err, "could not remove unverified management page (%s)", removeErr)
+		}
+		return nil, errors.Wrapf(err, "removed unverified management page %s", page.fileName())
+	}
+	return data, nil
+}
+
+func (hp *HistoricCacheProvider) readDocument(referenceTime primitives.TimestampSeconds) (historicPage, []byte, error) {
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+
+	for _, page := range hp.pages {
+		if page.start > referenceTime {
+			break
+		}
+		if referenceTime <= page.end {
+			path := filepath.Join(hp.dir, page.fileName())
+			document, err := ioutil.ReadFile(path)
+			if err != nil {
+				return page, nil, errors.Wrapf(err, "could not read management page %s", path)
+			}
+			return page, document, nil
+		}
+	}
+	return historicPage{}, nil, nil
+}
+
+func (hp *HistoricCacheProvider) remove(removed historicPage) error {
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+
+	if err := os.Remove(filepath.Join(hp.dir, removed.fileName())); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "could not remove management page %s", removed.fileName())
+	}
+
+	var pages []historicPage
+	for _, page := range hp.pages {
+		if page != removed {
+			pages = append(pages, page)
+		}
+	}
+	hp.pages = pages
+	hp.metrics.pages.Update(int64(len(hp.pages)))
+	return nil
+}
+
+// data is the one the provider parsed and verified from the document, pages covered by the new page are removed so a
+// provider returning ever growing pages does not fill the dir
+func (hp *HistoricCacheProvider) write(data *management.VirtualChainManagementData, document []byte) error {
+	if data.StartPageReference > data.EndPageReference || len(data.Committees) == 0 {
+		return errors.Errorf("management page %d-%d is not valid", data.StartPageReference, data.EndPageReference)
+	}
+	newPage := historicPage{start: data.StartPageReference, end: data.EndPageReference}
+
+	hp.mutex.Lock()
+	defer hp.mutex.Unlock()
+
+	var pages []historicPage
+	for _, page := range hp.pages {
+		if page.contains(newPage) {
+			return nil
+		}
+		if newPage.contains(page) {
+			if err := os.Remove(filepath.Join(hp.dir, page.fileName())); err != nil && !os.IsNotExist(err) {
+				return errors.Wrapf(err, "could not remove management page %s", page.fileName())
+			}
+			continue
+		}
+		pages = append(pages, page)
+	}
+
+	if err := writeFileAtomically(filepath.Join(hp.dir, newPage.fileName()), document); err != nil {
+		return err
+	}
+
+	pages = append(pages, newPage)
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].start < pages[j].start
+	})
+	hp.pages = pages
+	hp.metrics.pages.Update(int64(len(hp.pages)))
+	return nil
+}
+
+func (hp *HistoricCacheProvider) importBundle(bundlePath string) error {
+	contents, err := ioutil.ReadFile(bundlePath)
+	if err != nil {
+		return errors.Wrapf(err, "could not read management historic bundle %s", bundlePath)
+	}
+
+	var bundle historicBundle
+	if err := json.Unmarshal(contents, &bundle); err != nil {
+		return errors.Wrapf(err, "could not unmarshal management historic bundle %s", bundlePath)
+	}
+
+	for _, page := range bundle.Pages {
+		data, err := hp.provider.ParseDocument(page.Document, page.Start)
+		if err != nil {
+			return errors.Wrapf(err, "could not verify page %d-%d of management historic bundle %s", page.Start, page.End, bundlePath)
+		}
+		if err := hp.write(data, page.Document); err != nil {
+			return errors.Wrapf(err, "could not import management historic bundle %s", bundlePath)
+		}
+	}
+	hp.logger.Info("imported management historic bundle", log.String("bundle", bundlePath), log.Int("pages", len(bundle.Pages)))
+	return nil
+}
+
+// ExportHistoricBundle writes all pages of the historic cache in the given dir as a bundle another node can be seeded with
+func ExportHistoricBundle(cacheDir string, w io.Writer) error {
+	files, err := ioutil.ReadDir(cacheDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not list management historic cache dir %s", cacheDir)
+	}
+
+	var bundle historicBundle
+	for _, file := range files {
+		var page historicPage
+		if _, err := fmt.Sscanf(file.Name(), "%d-%d.json", &page.start, &page.end); err != nil || page.fileName() != file.Name() {
+			continue
+		}
+		path := filepath.Join(cacheDir, file.Name())
+		document, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrapf(err, "could not read management page %s", path)
+		}
+		if !json.Valid(document) {
+			return errors.Errorf("management page %s is not a json document", path)
+		}
+		bundle.Pages = append(bundle.Pages, historicBundlePage{Start: page.start, End: page.end, Document: document})
+	}
+
+	sort.Slice(bundle.Pages, func(i, j int) bool {
+		return bundle.Pages[i].Start < bundle.Pages[j].Start
+	})
+	return json.NewEncoder(w).Encode(bundle)
+}
+
+func writeFileAtomically(path string, contents []byte) error {
+	tmpPath := path + ".tmp"
+	if err := ioutil.WriteFile(tmpPath, contents, 0644); err != nil {
+		return errors.Wrapf(err, "could not write %s", tmpPath)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.Wrapf(err, "could not rename %s", tmpPath)
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type hcfg struct {
+	dir    string
+	bundle string
+}
+
+func (tc *hcfg) ManagementHistoricCacheDir() string {
+	return tc.dir
+}
+
+func (tc *hcfg) ManagementHistoricCacheBundle() string {
+	return tc.bundle
+}
+
+// serves documents signed by the management authority of pages of 100 seconds around the reference, counting the historic requests
+type pagingSource struct {
+	*FileProvider
+	t                *testing.T
+	historicRequests int
+	down             bool
+}
+
+func newPagingSource(t *testing.T, logger log.Logger) *pagingSource {
+	return &pagingSource{FileProvider: NewFileProvider(newAuthorityConfig(1, 0), logger), t: t}
+}
+
+func (s *pagingSource) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	data, _, err := s.GetWithDocument(ctx, referenceTime)
+	return data, err
+}
+
+func (s *pagingSource) GetWithDocument(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, []byte, error) {
+	if s.down {
+		return nil, nil, errors.New("management source is down")
+	}
+	start := referenceTime / 100 * 100
+	if referenceTime == 0 {
+		start = 1000
+	} else {
+		s.historicRequests++
+	}
+	document := signedBy(s.t, managementPage(start, start+99, primitives.NodeAddress{byte(start / 100)}), 0)
+	data, err := s.ParseDocument(document, referenceTime)
+	return data, document, err
+}
+
+func managementPage(start primitives.TimestampSeconds, end primitives.TimestampSeconds, member primitives.NodeAddress) []byte {
+	return []byte(fmt.Sprintf(`{
+	"CurrentRefTime": %d,
+	"PageStartRefTime": %d,
+	"PageEndRefTime": %d,
+	"VirtualChains": {
+		"42": {
+			"CurrentTopology": [{"OrbsAddress":"a328846cd5b4979d68a8c58a9bdfeee657b34de7","ip":"192.168.199.2","port":4400}],
+			"CommitteeEvents": [{"RefTime": %d, "Committee": [{"OrbsAddress": "%s", "Weight": 1}]}],
+			"SubscriptionEvents": [{"RefTime": 0, "Data": {"Status": "active"}}]
+		}
+	}
+}`, end, start, end, start, member))
+}
+
+func withHistoricCacheDir(t *testing.T, f func(dir string)) {
+	dir, err := ioutil.TempDir("", "management-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	f(dir)
+}
+
+func TestHistoricCacheProvider_ServesHistoricPagesFromDisk(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			withHistoricCacheDir(t, func(dir string) {
+				source := newPagingSource(t, parent.Logger)
+				provider, err := NewHistoricCacheProvider(&hcfg{dir: dir}, source, parent.Logger, metric.NewRegistry())
+				require.NoError(t, err)
+
+				first, err := provider.Get(ctx, 150)
+				require.NoError(t, err)
+				_, err = provider.Get(ctx, 120)
+				require.NoError(t, err)
+				_, err = provider.Get(ctx, 250)
+				require.NoError(t, err)
+				require.Equal(t, 2, source.historicRequests, "a reference inside a persisted page should not reach the provider")
+
+				source.down = true
+				restarted, err := NewHistoricCacheProvider(&hcfg{dir: dir}, source, parent.Logger, metric.NewRegistry())
+				require.NoError(t, err)
+				data, err := restarted.Get(ctx, 199)
+				require.NoError(t, err, "persisted pages should be served after a restart while the source is down")
+				require.Equal(t, first, data)
+
+				_, err = restarted.Get(ctx, 0)
+				require.Error(t, err, "current data should always be read from the provider")
+			})
+		})
+	})
+}
+
+func TestHistoricCacheProvider_ReplacesCoveredPages(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withHistoricCacheDir(t, func(dir string) {
+			source := newPagingSource(t, parent.Logger)
+			provider, err := NewHistoricCacheProvider(&hcfg{dir: dir}, source, parent.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			smallDocument := signedBy(t, managementPage(1, 50, primitives.NodeAddress{0x01}), 0)
+			small, err := source.ParseDocument(smallDocument, 1)
+			require.NoError(t, err)
+			largeDocument := signedBy(t, managementPage(1, 90, primitives.NodeAddress{0x01}), 0)
+			large, err := source.ParseDocument(largeDocument, 1)
+			require.NoError(t, err)
+
+			require.NoError(t, provider.write(small, smallDocument))
+			require.NoError(t, provider.write(large, largeDocument))
+			require.NoError(t, provider.write(small, smallDocument), "a page covered by a persisted page should be ignored")
+
+			files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+			require.NoError(t, err)
+			require.Equal(t, []string{filepath.Join(dir, "1-90.json")}, files)
+		})
+	})
+}
+
+func TestHistoricCacheProvider_SeedsFromExportedBundle(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			withHistoricCacheDir(t, func(exportingDir string) {
+				exporting, err := NewHistoricCacheProvider(&hcfg{dir: exportingDir}, newPagingSource(t, parent.Logger), parent.Logger, metric.NewRegistry())
+				require.NoError(t, err)
+				expected, err := exporting.Get(ctx, 150)
+				require.NoError(t, err)
+				_, err = exporting.Get(ctx, 350)
+				require.NoError(t, err)
+
+				bundle := &bytes.Buffer{}
+				require.NoError(t, ExportHistoricBundle(exportingDir, bundle))
+				bundlePath := filepath.Join(exportingDir, "bundle")
+				require.NoError(t, ioutil.WriteFile(bundlePath, bundle.Bytes(), 0644))
+
+				withHistoricCacheDir(t, func(seededDir string) {
+					source := newPagingSource(t, parent.Logger)
+					source.down = true
+					seeded, err := NewHistoricCacheProvider(&hcfg{dir: seededDir, bundle: bundlePath}, source, parent.Logger, metric.NewRegistry())
+					require.NoError(t, err)
+
+					data, err := seeded.Get(ctx, 120)
+					require.NoError(t, err)
+					require.Equal(t, expected, data)
+					_, err = seeded.Get(ctx, 399)
+					require.NoError(t, err)
+				})
+			})
+		})
+	})
+}
+
+func TestHistoricCacheProvider_VerifiesPersistedPagesWhenReadingThem(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			withHistoricCacheDir(t, func(dir string) {
+				source := newPagingSource(t, parent.Logger)
+				provider, err := NewHistoricCacheProvider(&hcfg{dir: dir}, source, parent.Logger, metric.NewRegistry())
+				require.NoError(t, err)
+				_, err = provider.Get(ctx, 150)
+				require.NoError(t, err)
+
+				forged, err := SignManagementData(managementPage(100, 199, primitives.NodeAddress{0x66}), testKeys.EcdsaSecp256K1KeyPairForTests(5).PrivateKey())
+				require.NoError(t, err)
+				require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "100-199.json"), forged, 0644))
+
+				data, err := provider.Get(ctx, 150)
+				require.NoError(t, err)
+				require.Equal(t, primitives.NodeAddress{0x01}, data.Committees[0].Members[0], "a page modified on disk should be read from the provider again")
+				require.Equal(t, 2, source.historicRequests)
+
+				source.down = true
+				_, err = provider.Get(ctx, 150)
+				require.NoError(t, err, "the page read again from the provider should be persisted instead of the modified one")
+			})
+		})
+	})
+}
+
+func TestHistoricCacheProvider_RejectsBundleWithPagesNotSignedByAuthorities(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		withHistoricCacheDir(t, func(dir string) {
+			forged, err := SignManagementData(managementPage(100, 199, primitives.NodeAddress{0x66}), testKeys.EcdsaSecp256K1KeyPairForTests(5).PrivateKey())
+			require.NoError(t, err)
+			contents, err := json.Marshal(historicBundle{Pages: []historicBundlePage{{Start: 100, End: 199, Document: forged}}})
+			require.NoError(t, err)
+			bundlePath := filepath.Join(dir, "bundle")
+			require.NoError(t, ioutil.WriteFile(bundlePath, contents, 0644))
+
+			_, err = NewHistoricCacheProvider(&hcfg{dir: dir, bundle: bundlePath}, newPagingSource(t, parent.Logger), parent.Logger, metric.NewRegistry())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "could not verify page 100-199")
+		})
+	})
+}
@@ -0,0 +1,230 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MultiProviderConfig interface {
+	ManagementSourcesQuorum() uint32
+}
+
+type ManagementSource struct {
+	Name     string
+	Provider management.Provider
+}
+
+type managementSource struct {
+	ManagementSource
+	metrics struct {
+		lastUpdateTime           *metric.Gauge
+		lastSuccessfulUpdateTime *metric.Gauge
+		currentRefTime           *metric.Gauge
+		name                     *metric.Text
+	}
+}
+
+type sourceResult struct {
+	data     *management.VirtualChainManagementData
+	document []byte
+	err      error
+}
+
+// MultiProvider queries all management sources and returns the data a quorum of them agrees on, all of the data is
+// compared. A failing source is skipped as long as the remaining sources still reach the quorum, when
+// several data versions reach the quorum the one of the earliest source in the list is preferred
+type MultiProvider struct {
+	logger  log.Logger
+	config  MultiProviderConfig
+	sources []*managementSource
+
+	metrics struct {
+		agreeingSources *metric.Gauge
+	}
+}
+
+func NewMultiProvider(config MultiProviderConfig, sources []ManagementSource, logger log.Logger, metricFactory metric.Factory) *MultiProvider {
+	mp := &MultiProvider{config: config, logger: logger}
+	for i, source := range sources {
+		s := &managementSource{ManagementSource: source}
+		s.metrics.lastUpdateTime = metricFactory.NewGauge(fmt.Sprintf("Management.Source.%d.LastUpdateTime", i))
+		s.metrics.lastSuccessfulUpdateTime = metricFactory.NewGauge(fmt.Sprintf("Management.Source.%d.LastSuccessfulUpdateTime", i))
+		s.metrics.currentRefTime = metricFactory.NewGauge(fmt.Sprintf("Management.Source.%d.CurrentRefTime", i))
+		s.metrics.name = metricFactory.NewText(fmt.Sprintf("Management.Source.%d.Name", i), source.Name)
+		mp.sources = append(mp.sources, s)
+	}
+	mp.metrics.agreeingSources = metricFactory.NewGauge("Management.Source.Agreeing.Count")
+	return mp
+}
+
+func (mp *MultiProvider) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	result, err := mp.getAgreed(ctx, referenceTime)
+	if err != nil {
+		return nil, err
+	}
+	return result.data, nil
+}
+
+// the document returned is the one of the preferred source among those agreeing with the quorum
+func (mp *MultiProvider) GetWithDocument(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, []byte, error) {
+	result, err := mp.getAgreed(ctx, referenceTime)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.document == nil {
+		return nil, nil, errors.New("management sources agreeing with the quorum do not provide documents")
+	}
+	return result.data, result.document, nil
+}
+
+// the documents of all sources are verified against the same authorities, so any source which provides documents can parse them
+func (mp *MultiProvider) ParseDocument(document []byte, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	for _, source := range mp.sources {
+		if documentProvider, ok := source.Provider.(DocumentProvider); ok {
+			return documentProvider.ParseDocument(document, referenceTime)
+		}
+	}
+	return nil, errors.New("no management source provides documents")
+}
+
+func (mp *MultiProvider) getAgreed(ctx context.Context, referenceTime primitives.TimestampSeconds) (*sourceResult, error) {
+	results := mp.getFromAllSources(ctx, referenceTime)
+
+	var agreements [][]int
+	agreementIndexByKey := make(map[string]int)
+	var failures []string
+	for i, result := range results {
+		if result == nil || result.err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", mp.sources[i].Name, errOf(result)))
+			continue
+		}
+		key := agreementKey(result.data)
+		index, ok := agreementIndexByKey[key]
+		if !ok {
+			index = len(agreements)
+			agreementIndexByKey[key] = index
+			agreements = append(agreements, nil)
+		}
+		agreements[index] = append(agreements[index], i)
+	}
+
+	// agreements are ordered by their earliest source so a stable sort keeps the preferred one first among equals
+	sort.SliceStable(agreements, func(i, j int) bool {
+		return len(agreements[i]) > len(agreements[j])
+	})
+
+	quorum := int(mp.config.ManagementSourcesQuorum())
+	if quorum < 1 {
+		quorum = 1
+	}
+	if len(agreements) == 0 || len(agreements[0]) < quorum {
+		agreeing := 0
+		if len(agreements) > 0 {
+			agreeing = len(agreements[0])
+		}
+		err := errors.Errorf("management sources did not reach quorum, %d of the required %d agree (%d disagreeing versions, failures: %s)",
+			agreeing, quorum, len(agreements), strings.Join(failures, "; "))
+		mp.logger.Error("Provider sources did not reach quorum", log.Error(err))
+		return nil, err
+	}
+
+	if len(failures) > 0 || len(agreements) > 1 {
+		mp.logger.Info("some management sources failed or disagree with the quorum", log.String("failures", strings.Join(failures, "; ")), log.Int("versions", len(agreements)))
+	}
+	if referenceTime == 0 {
+		mp.metrics.agreeingSources.Update(int64(len(agreements[0])))
+	}
+
+	return results[agreements[0][0]], nil
+}
+
+func (mp *MultiProvider) getFromAllSources(ctx context.Context, referenceTime primitives.TimestampSeconds) []*sourceResult {
+	results := make([]*sourceResult, len(mp.sources))
+
+	var wg sync.WaitGroup
+	for i, source := range mp.sources {
+		wg.Add(1)
+		i, source := i, source
+		govnr.Once(logfields.GovnrErrorer(mp.logger), func() {
+			defer wg.Done()
+			result := &sourceResult{}
+			if documentProvider, ok := source.Provider.(DocumentProvider); ok {
+				result.data, result.document, result.err = documentProvider.GetWithDocument(ctx, referenceTime)
+			} else {
+				result.data, result.err = source.Provider.Get(ctx, referenceTime)
+			}
+			results[i] = result
+		})
+	}
+	wg.Wait()
+
+	if referenceTime == 0 {
+		now := time.Now().Unix()
+		for i, source := range mp.sources {
+			source.metrics.lastUpdateTime.Update(now)
+			if results[i] != nil && results[i].err == nil {
+				source.metrics.lastSuccessfulUpdateTime.Update(now)
+				source.metrics.currentRefTime.Update(int64(results[i].data.CurrentReference))
+			}
+		}
+	}
+
+	return results
+}
+
+func errOf(result *sourceResult) error {
+	if result == nil {
+		return errors.New("source panicked")
+	}
+	return result.err
+}
+
+// sources agree when every field of their data is the same, topology order does not matter
+func agreementKey(data *management.VirtualChainManagementData) string {
+	var key strings.Builder
+	fmt.Fprintf(&key, "%d,%d,%d,%d|", data.CurrentReference, data.GenesisReference, data.StartPageReference, data.EndPageReference)
+
+	for _, term := range data.Committees {
+		fmt.Fprintf(&key, "%d:", term.AsOfReference)
+		for i, member := range term.Members {
+			fmt.Fprintf(&key, "%s=%d,", member, term.Weights[i])
+		}
+		key.WriteString(";")
+	}
+	key.WriteString("|")
+
+	peers := make([]string, 0, len(data.CurrentTopology))
+	for _, peer := range data.CurrentTopology {
+		peers = append(peers, fmt.Sprintf("%s@%s:%d", peer.Address, peer.Endpoint, peer.Port))
+	}
+	sort.Strings(peers)
+	key.WriteString(strings.Join(peers, ","))
+	key.WriteString("|")
+
+	for _, term := range data.Subscriptions {
+		fmt.Fprintf(&key, "%d=%t;", term.AsOfReference, term.IsActive)
+	}
+	key.WriteString("|")
+
+	for _, term := range data.ProtocolVersions {
+		fmt.Fprintf(&key, "%d=%d;", term.AsOfReference, term.Version)
+	}
+	return key.String()
+}
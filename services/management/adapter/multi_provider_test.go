@@ -0,0 +1,204 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/management"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type mcfg struct {
+	quorum uint32
+}
+
+func (tc *mcfg) ManagementSourcesQuorum() uint32 {
+	return tc.quorum
+}
+
+type stubSource struct {
+	data *management.VirtualChainManagementData
+	err  error
+}
+
+func (s *stubSource) Get(ctx context.Context, referenceTime primitives.TimestampSeconds) (*management.VirtualChainManagementData, error) {
+	return s.data, s.err
+}
+
+func managementDataWithCommittee(currentReference primitives.TimestampSeconds, members ...primitives.NodeAddress) *management.VirtualChainManagementData {
+	var weights []primitives.Weight
+	for range members {
+		weights = append(weights, 1)
+	}
+	return &management.VirtualChainManagementData{
+		CurrentReference: currentReference,
+		EndPageReference: currentReference,
+		CurrentTopology:  []*services.GossipPeer{{Address: primitives.NodeAddress{0x01}, Endpoint: "10.0.0.1", Port: 4400}},
+		Committees:       []management.CommitteeTerm{{AsOfReference: 0, Members: members, Weights: weights}},
+	}
+}
+
+func newMultiProviderForTests(parent *with.LoggingHarness, registry metric.Registry, quorum uint32, sources ...management.Provider) *MultiProvider {
+	var managementSources []ManagementSource
+	for i, source := range sources {
+		managementSources = append(managementSources, ManagementSource{Name: string('a' + rune(i)), Provider: source})
+	}
+	return NewMultiProvider(&mcfg{quorum: quorum}, managementSources, parent.Logger, registry)
+}
+
+func TestMultiProvider_FailsOverToNextSource(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			expected := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+			provider := newMultiProviderForTests(parent, metric.NewRegistry(), 1,
+				&stubSource{err: errors.New("url unreachable")},
+				&stubSource{data: expected})
+
+			data, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+			require.Equal(t, expected, data)
+		})
+	})
+}
+
+func TestMultiProvider_PrefersEarliestSourceAmongAgreeing(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			first := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+			second := managementDataWithCommittee(101, primitives.NodeAddress{0x01})
+			provider := newMultiProviderForTests(parent, metric.NewRegistry(), 1, &stubSource{data: first}, &stubSource{data: second})
+
+			data, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+			require.Equal(t, first, data, "the earliest source should be preferred when both versions reach the quorum")
+		})
+	})
+}
+
+func TestMultiProvider_ReturnsDataOfQuorum(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			agreed := managementDataWithCommittee(100, primitives.NodeAddress{0x02})
+			provider := newMultiProviderForTests(parent, metric.NewRegistry(), 2,
+				&stubSource{data: managementDataWithCommittee(100, primitives.NodeAddress{0x01})},
+				&stubSource{data: agreed},
+				&stubSource{data: managementDataWithCommittee(100, primitives.NodeAddress{0x02})})
+
+			data, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+			require.Equal(t, agreed, data)
+		})
+	})
+}
+
+func TestMultiProvider_FailsWithoutQuorum(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			parent.AllowErrorsMatching("Provider sources did not reach quorum")
+			provider := newMultiProviderForTests(parent, metric.NewRegistry(), 2,
+				&stubSource{data: managementDataWithCommittee(100, primitives.NodeAddress{0x01})},
+				&stubSource{data: managementDataWithCommittee(100, primitives.NodeAddress{0x02})},
+				&stubSource{err: errors.New("url unreachable")})
+
+			_, err := provider.Get(ctx, 0)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "1 of the required 2 agree")
+			require.Contains(t, err.Error(), "c: url unreachable")
+		})
+	})
+}
+
+func TestMultiProvider_TopologyOrderDoesNotMatter(t *testing.T) {
+	first := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+	second := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+	peer := &services.GossipPeer{Address: primitives.NodeAddress{0x02}, Endpoint: "10.0.0.2", Port: 4400}
+	first.CurrentTopology = append(first.CurrentTopology, peer)
+	second.CurrentTopology = append([]*services.GossipPeer{peer}, second.CurrentTopology...)
+
+	require.Equal(t, agreementKey(first), agreementKey(second))
+
+	second.Committees[0].Weights[0] = 2
+	require.NotEqual(t, agreementKey(first), agreementKey(second), "committee weights should be part of the agreement")
+}
+
+func TestMultiProvider_AllDataIsPartOfTheAgreement(t *testing.T) {
+	changes := map[string]func(data *management.VirtualChainManagementData){
+		"current reference": func(data *management.VirtualChainManagementData) { data.CurrentReference++ },
+		"genesis reference": func(data *management.VirtualChainManagementData) { data.GenesisReference++ },
+		"page start":        func(data *management.VirtualChainManagementData) { data.StartPageReference++ },
+		"page end":          func(data *management.VirtualChainManagementData) { data.EndPageReference++ },
+		"topology port":     func(data *management.VirtualChainManagementData) { data.CurrentTopology[0].Port++ },
+		"committee member": func(data *management.VirtualChainManagementData) {
+			data.Committees[0].Members[0] = primitives.NodeAddress{0x09}
+		},
+		"subscription":      func(data *management.VirtualChainManagementData) { data.Subscriptions[0].IsActive = false },
+		"subscription term": func(data *management.VirtualChainManagementData) { data.Subscriptions[0].AsOfReference++ },
+		"protocol version":  func(data *management.VirtualChainManagementData) { data.ProtocolVersions[0].Version++ },
+		"protocol term":     func(data *management.VirtualChainManagementData) { data.ProtocolVersions[0].AsOfReference++ },
+		"added subscription": func(data *management.VirtualChainManagementData) {
+			data.Subscriptions = append(data.Subscriptions, data.Subscriptions[0])
+		},
+	}
+
+	for name, change := range changes {
+		first := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+		first.Subscriptions = []management.SubscriptionTerm{{AsOfReference: 0, IsActive: true}}
+		first.ProtocolVersions = []management.ProtocolVersionTerm{{AsOfReference: 0, Version: 1}}
+		second := managementDataWithCommittee(100, primitives.NodeAddress{0x01})
+		second.Subscriptions = []management.SubscriptionTerm{{AsOfReference: 0, IsActive: true}}
+		second.ProtocolVersions = []management.ProtocolVersionTerm{{AsOfReference: 0, Version: 1}}
+		require.Equal(t, agreementKey(first), agreementKey(second))
+
+		change(second)
+		require.NotEqual(t, agreementKey(first), agreementKey(second), "%s should be part of the agreement", name)
+	}
+}
+
+func TestMultiProvider_UpdatesPerSourceFreshnessMetrics(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			registry := metric.NewRegistry()
+			provider := newMultiProviderForTests(parent, registry, 1,
+				&stubSource{data: managementDataWithCommittee(100, primitives.NodeAddress{0x01})},
+				&stubSource{err: errors.New("url unreachable")})
+
+			_, err := provider.Get(ctx, 0)
+			require.NoError(t, err)
+
+			require.NotZero(t, registry.Get("Management.Source.0.LastSuccessfulUpdateTime").(*metric.Gauge).Value())
+			require.EqualValues(t, 100, registry.Get("Management.Source.0.CurrentRefTime").(*metric.Gauge).Value())
+			require.NotZero(t, registry.Get("Management.Source.1.LastUpdateTime").(*metric.Gauge).Value())
+			require.Zero(t, registry.Get("Management.Source.1.LastSuccessfulUpdateTime").(*metric.Gauge).Value())
+			require.EqualValues(t, 1, registry.Get("Management.Source.Agreeing.Count").(*metric.Gauge).Value())
+		})
+	})
+}
+
+func TestMultiProvider_ReturnsDocumentOfAgreedDataForTheHistoricCache(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			provider := newMultiProviderForTests(parent, metric.NewRegistry(), 2,
+				newPagingSource(t, parent.Logger),
+				newPagingSource(t, parent.Logger))
+
+			data, document, err := provider.GetWithDocument(ctx, 150)
+			require.NoError(t, err)
+			parsed, err := provider.ParseDocument(document, 150)
+			require.NoError(t, err)
+			require.Equal(t, data, parsed)
+
+			_, _, err = newMultiProviderForTests(parent, metric.NewRegistry(), 1, &stubSource{data: data}).GetWithDocument(ctx, 150)
+			require.Error(t, err, "sources which do not provide documents cannot be cached")
+		})
+	})
+}
@@ -0,0 +1,143 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/orbs-network/crypto-lib-go/crypto/ethereum/digest"
+	"github.com/orbs-network/crypto-lib-go/crypto/ethereum/signature"
+	"github.com/orbs-network/crypto-lib-go/crypto/hash"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// a signed management document wraps the original document, every signature is over the exact bytes of Data
+type signedManagementData struct {
+	Data       json.RawMessage
+	Signatures []managementDataSignature
+}
+
+type managementDataSignature struct {
+	SignerAddress string
+	Signature     string
+}
+
+// SignManagementData wraps a management document with the signatures of the given authority keys
+func SignManagementData(data []byte, privateKeys ...primitives.EcdsaSecp256K1PrivateKey) ([]byte, error) {
+	// the document is compacted since this is how it is embedded by the json encoder
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, data); err != nil {
+		return nil, errors.Wrap(err, "management data is not valid json")
+	}
+	data = compacted.Bytes()
+
+	signed := signedManagementData{Data: data}
+	for _, privateKey := range privateKeys {
+		sig, err := digest.SignAsNode(privateKey, data)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not sign management data")
+		}
+		publicKey, err := signature.RecoverEcdsaSecp256K1(hash.CalcSha256(data), sig)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not recover management data signer")
+		}
+		signed.Signatures = append(signed.Signatures, managementDataSignature{
+			SignerAddress: hex.EncodeToString(digest.CalcNodeAddressFromPublicKey(publicKey)),
+			Signature:     hex.EncodeToString(sig),
+		})
+	}
+	return json.Marshal(signed)
+}
+
+// unwrapSignedData returns the management document inside the contents. When authorities are configured it must be
+// signed by at least threshold of them, otherwise signatures are ignored and plain documents are accepted as well
+func (mp *FileProvider) unwrapSignedData(contents []byte) ([]byte, error) {
+	var signed signedManagementData
+	isSigned := json.Unmarshal(contents, &signed) == nil && len(signed.Data) != 0
+
+	authorities := mp.config.ManagementAuthorityAddresses()
+	if len(authorities) == 0 {
+		if isSigned {
+			return signed.Data, nil
+		}
+		return contents, nil
+	}
+
+	if !isSigned {
+		return nil, errors.New("management data is not signed")
+	}
+
+	validSigners := make(map[string]bool)
+	for _, s := range signed.Signatures {
+		signerAddress, err := hex.DecodeString(s.SignerAddress)
+		if err != nil || !isManagementAuthority(authorities, signerAddress) {
+			continue
+		}
+		sig, err := hex.DecodeString(s.Signature)
+		if err != nil {
+			continue
+		}
+		if digest.VerifyNodeSignature(signerAddress, signed.Data, sig) == nil {
+			validSigners[string(signerAddress)] = true
+		}
+	}
+
+	threshold := mp.config.ManagementAuthorityThreshold()
+	if uint32(len(validSigners)) < threshold {
+		return nil, errors.Errorf("management data is signed by %d of the required %d authorities", len(validSigners), threshold)
+	}
+	return signed.Data, nil
+}
+
+func isManagementAuthority(authorities []primitives.NodeAddress, address primitives.NodeAddress) bool {
+	for _, authority := range authorities {
+		if authority.Equal(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// a current document must not be older than the configured max age nor older than the last current document accepted,
+// so a stale signed document cannot be replayed to roll back the committee. historic documents are old by nature, the
+// page range they sign must cover the requested reference time so a page of another period cannot be replayed instead
+func (mp *FileProvider) verifyFreshness(currentRefTime uint64, pageStartRefTime uint64, pageEndRefTime uint64, referenceTime primitives.TimestampSeconds) error {
+	if referenceTime != 0 {
+		if uint64(referenceTime) < pageStartRefTime || uint64(referenceTime) > pageEndRefTime {
+			return errors.Errorf("historic management data covers PageStartRefTime (%d) to PageEndRefTime (%d) which does not include the requested reference time (%d)", pageStartRefTime, pageEndRefTime, referenceTime)
+		}
+		return nil
+	}
+
+	if maxAge := mp.config.ManagementMaxDataAge(); maxAge > 0 {
+		age := time.Since(time.Unix(int64(currentRefTime), 0))
+		if age > maxAge {
+			return errors.Errorf("management data is stale: CurrentRefTime (%d) is %s old, more than the allowed %s", currentRefTime, age, maxAge)
+		}
+	}
+
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	if currentRefTime < mp.lastCurrentRefTime {
+		return errors.Errorf("management data is stale: CurrentRefTime (%d) is older than previously read (%d)", currentRefTime, mp.lastCurrentRefTime)
+	}
+	return nil
+}
+
+func (mp *FileProvider) updateLastCurrentRefTime(currentRefTime uint64, isHistoric bool) {
+	if isHistoric {
+		return
+	}
+	mp.mutex.Lock()
+	defer mp.mutex.Unlock()
+	if currentRefTime > mp.lastCurrentRefTime {
+		mp.lastCurrentRefTime = currentRefTime
+	}
+}
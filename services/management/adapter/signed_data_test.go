@@ -0,0 +1,141 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"fmt"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func managementDataAt(currentRefTime int64) []byte {
+	return []byte(fmt.Sprintf(`{
+	"CurrentRefTime": %d,
+	"PageStartRefTime": 0,
+	"PageEndRefTime": %d,
+	"VirtualChains": {
+		"42": {
+			"CurrentTopology": [{"OrbsAddress":"a328846cd5b4979d68a8c58a9bdfeee657b34de7","ip":"192.168.199.2","port":4400}],
+			"SubscriptionEvents": [{"RefTime": 0, "Data": {"Status": "active"}}]
+		}
+	}
+}`, currentRefTime, currentRefTime))
+}
+
+func signedBy(t *testing.T, data []byte, keyIndexes ...int) []byte {
+	var privateKeys []primitives.EcdsaSecp256K1PrivateKey
+	for _, i := range keyIndexes {
+		privateKeys = append(privateKeys, testKeys.EcdsaSecp256K1KeyPairForTests(i).PrivateKey())
+	}
+	signed, err := SignManagementData(data, privateKeys...)
+	require.NoError(t, err)
+	return signed
+}
+
+func newAuthorityConfig(threshold uint32, authorityKeyIndexes ...int) *fconfig {
+	cfg := newConfig(42, "")
+	for _, i := range authorityKeyIndexes {
+		cfg.authorities = append(cfg.authorities, testKeys.EcdsaSecp256K1KeyPairForTests(i).NodeAddress())
+	}
+	cfg.threshold = threshold
+	return cfg
+}
+
+func TestManagementFileProvider_AcceptsDataSignedByThreshold(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		fileProvider := NewFileProvider(newAuthorityConfig(2, 0, 1, 2), parent.Logger)
+
+		data, err := fileProvider.parseData(signedBy(t, managementDataAt(100), 0, 2), 0)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, data.CurrentReference)
+		require.Len(t, data.CurrentTopology, 1)
+	})
+}
+
+func TestManagementFileProvider_RejectsDataBelowThreshold(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		fileProvider := NewFileProvider(newAuthorityConfig(2, 0, 1, 2), parent.Logger)
+
+		_, err := fileProvider.parseData(signedBy(t, managementDataAt(100), 1), 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "signed by 1 of the required 2 authorities")
+
+		_, err = fileProvider.parseData(signedBy(t, managementDataAt(100), 1, 1), 0)
+		require.Error(t, err, "the same authority signing twice should count once")
+
+		_, err = fileProvider.parseData(signedBy(t, managementDataAt(100), 1, 5), 0)
+		require.Error(t, err, "signatures of keys which are not authorities should not count")
+	})
+}
+
+func TestManagementFileProvider_RejectsUnsignedOrTamperedData(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		fileProvider := NewFileProvider(newAuthorityConfig(1, 0), parent.Logger)
+
+		_, err := fileProvider.parseData(managementDataAt(100), 0)
+		require.EqualError(t, err, "management data is not signed")
+
+		signed := signedBy(t, managementDataAt(100), 0)
+		tampered := []byte(string(signed[:len(signed)/2]) + "9" + string(signed[len(signed)/2+1:]))
+		_, err = fileProvider.parseData(tampered, 0)
+		require.Error(t, err)
+	})
+}
+
+func TestManagementFileProvider_UnwrapsSignedDataWhenNoAuthoritiesConfigured(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		fileProvider := NewFileProvider(newConfig(42, ""), parent.Logger)
+
+		data, err := fileProvider.parseData(signedBy(t, managementDataAt(100), 7), 0)
+		require.NoError(t, err)
+		require.EqualValues(t, 100, data.CurrentReference)
+	})
+}
+
+func TestManagementFileProvider_RejectsStaleData(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		cfg := newAuthorityConfig(1, 0)
+		cfg.maxDataAge = time.Hour
+		fileProvider := NewFileProvider(cfg, parent.Logger)
+		now := time.Now().Unix()
+
+		_, err := fileProvider.parseData(signedBy(t, managementDataAt(now-7200), 0), 0)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "management data is stale")
+
+		_, err = fileProvider.parseData(signedBy(t, managementDataAt(now), 0), 0)
+		require.NoError(t, err)
+
+		_, err = fileProvider.parseData(signedBy(t, managementDataAt(now-60), 0), 0)
+		require.Error(t, err, "data older than the last data read should be rejected as a replay")
+		require.Contains(t, err.Error(), "older than previously read")
+
+		_, err = fileProvider.parseData(signedBy(t, managementDataAt(now-7200), 0), primitives.TimestampSeconds(now-7300))
+		require.NoError(t, err, "historic data should not be checked for freshness")
+	})
+}
+
+func TestManagementFileProvider_RejectsHistoricDataNotCoveringTheReferenceTime(t *testing.T) {
+	with.Logging(t, func(parent *with.LoggingHarness) {
+		fileProvider := NewFileProvider(newAuthorityConfig(1, 0), parent.Logger)
+		page := signedBy(t, managementDataAt(1000), 0)
+
+		_, err := fileProvider.parseData(page, 500)
+		require.NoError(t, err)
+
+		_, err = fileProvider.parseData(page, 1000)
+		require.NoError(t, err, "the page end should be covered")
+
+		_, err = fileProvider.parseData(page, 1001)
+		require.Error(t, err, "a page signed for an earlier period should not be accepted for a later reference time")
+		require.Contains(t, err.Error(), "does not include the requested reference time (1001)")
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -85,10 +86,11 @@ type service struct {
 
 	sync.RWMutex
 	data *VirtualChainManagementData
+	lastSuccessfulUpdate time.Time
 	cachedHistoricData *VirtualChainManagementData // data holder cannot be nil !
 }
 
-func NewManagement(parentCtx context.Context, config Config, provider Provider, topologyConsumer TopologyConsumer, parentLogger log.Logger, metricFactory metric.Factory) *service {
+func NewManagement(parentCtx context.Context, config Config, provider Provider, topologyConsumer TopologyConsumer, parentLogger log.Logger, metricFactory metric.Factory) (*service, error) {
 	logger := parentLogger.WithTags(log.String("service", "management"))
 	s := &service{
 		logger:           logger,
@@ -100,8 +102,7 @@ func NewManagement(parentCtx context.Context, config Config, provider Provider,
 
 	err := s.update(parentCtx)
 	if err != nil {
-		s.logger.Error("management provider failed to initializing the topology", log.Error(err))
-		panic(fmt.Sprintf("failed initializing management provider, err=%s", err.Error())) // can't continue if no management
+		return nil, errors.Wrap(err, "management provider failed to initialize the topology") // can't continue if no management
 	}
 
 	s.initMetrics(metricFactory)
@@ -110,7 +111,7 @@ func NewManagement(parentCtx context.Context, config Config, provider Provider,
 		s.Supervise(s.startPollingForUpdates(parentCtx))
 	}
 
-	return s
+	return s, nil
 }
 
 /*
@@ -268,6 +269,7 @@ func (s *service) write(newData *VirtualChainManagementData) {
 	s.Lock()
 	defer s.Unlock()
 	s.data = newData
+	s.lastSuccessfulUpdate = time.Now()
 }
 
 func (s *service) update(ctx context.Context) error {
@@ -297,6 +299,28 @@ func (s *service) startPollingForUpdates(bgCtx context.Context) govnr.ShutdownWa
 	})
 }
 
+/*
+ * Health
+ */
+const maxPollingIntervalsSinceLastSuccessfulUpdate = 20
+
+func (s *service) RegisterHealthChecks(registry health.Registry) {
+	registry.Register("management", health.SEVERITY_WARNING, func(ctx context.Context) health.Result {
+		pollingInterval := s.config.ManagementPollingInterval()
+		if pollingInterval <= 0 {
+			return health.Ok("management is not polled for updates")
+		}
+
+		s.RLock()
+		sinceLastUpdate := time.Since(s.lastSuccessfulUpdate)
+		s.RUnlock()
+		if sinceLastUpdate > pollingInterval*maxPollingIntervalsSinceLastSuccessfulUpdate {
+			return health.Failing("last successful management update was %s ago", sinceLastUpdate.Round(time.Second))
+		}
+		return health.Ok("last successful management update was %s ago", sinceLastUpdate.Round(time.Second))
+	})
+}
+
 /*
  * Metrics
  */
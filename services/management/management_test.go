@@ -3,11 +3,13 @@ package management
 import (
 	"context"
 	"github.com/orbs-network/lean-helix-go/test"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"sync"
 	"testing"
@@ -55,7 +57,8 @@ func TestManagement_GetCommitteeNotSupportFuture(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 
 			committee := getCommitteeOrNil(cp, ctx, ACurrentRef + 2000)
 			require.Nil(t, committee, "should not get a committee")
@@ -67,7 +70,8 @@ func TestManagement_GetCommitteeWhenOnlyOneTerm(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 
 			committee := getCommitteeOrNil(cp, ctx, ACurrentRef)
 			require.EqualValues(t, testKeys.NodeAddressesForTests()[:4], committee, "wrong committee values")
@@ -82,7 +86,8 @@ func TestManagement_GetCommitteeAfterAnUpdateExists(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			termChangeHeight := ACurrentRef + 10
 			cp.addCommittee(termChangeHeight, testKeys.NodeAddressesForTests()[1:5])
 
@@ -102,7 +107,8 @@ func TestManagement_GetCommitteeWhenTwoChangesOneAfterOther(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			termChangeHeight := ACurrentRef + 10
 			cp.addCommittee(termChangeHeight, testKeys.NodeAddressesForTests()[1:5])
 			cp.addCommittee(termChangeHeight+1, testKeys.NodeAddressesForTests()[5:9])
@@ -123,7 +129,8 @@ func TestManagement_GetCommitteeWhenTwoChangesClose(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			termChangeHeight := ACurrentRef + 10
 			cp.addCommittee(termChangeHeight, testKeys.NodeAddressesForTests()[1:5])
 			cp.addCommittee(termChangeHeight+2, testKeys.NodeAddressesForTests()[5:9])
@@ -147,7 +154,8 @@ func TestManagement_GetCommitteeWhenTwoInSameRef(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			termChangeHeight := ACurrentRef + 10
 			cp.addCommittee(termChangeHeight, testKeys.NodeAddressesForTests()[1:5])
 			cp.addCommittee(termChangeHeight, testKeys.NodeAddressesForTests()[5:9])
@@ -172,7 +180,8 @@ func TestManagement_InternalDataUpdateLogic(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 
 			committee := getCommitteeOrNil(cp, ctx, ACurrentRef)
 			require.EqualValues(t, testKeys.NodeAddressesForTests()[:4], committee, "wrong committee values")
@@ -185,7 +194,7 @@ func TestManagement_InternalDataUpdateLogic(t *testing.T) {
 			committee = getCommitteeOrNil(cp, ctx, ACurrentRef)
 			require.EqualValues(t, testKeys.NodeAddressesForTests()[:4], committee, "wrong committee values")
 
-			err := cp.update(ctx) // manual update of service
+			err = cp.update(ctx) // manual update of service
 			require.NoError(t, err)
 			committee = getCommitteeOrNil(cp, ctx, ACurrentRef)
 			require.EqualValues(t, testKeys.NodeAddressesForTests()[1:5], committee, "wrong committee values")
@@ -197,7 +206,8 @@ func TestManagement_InternalHistoricData_UpdateLogic(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 
 			require.Nil(t, cp.cachedHistoricData.Committees, "cached reference was not empty to begin with")
 
@@ -223,7 +233,8 @@ func TestManagement_GetCommitteeWithHistoric(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			require.Nil(t, cp.cachedHistoricData.Committees, "cached reference was not empty to begin with")
 
 			committee := getCommitteeOrNil(cp, ctx, AHistoricRef)
@@ -241,7 +252,8 @@ func TestManagement_GetCommitteeWithTwoHistoricCalls(t *testing.T) {
 	with.Logging(t, func(harness *with.LoggingHarness) {
 		test.WithContext(func(ctx context.Context) {
 			p := newStaticProvider()
-			cp := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
 			require.Nil(t, cp.cachedHistoricData.Committees, "cached reference was not empty to begin with")
 
 			committee := getCommitteeOrNil(cp, ctx, AHistoricRef)
@@ -258,6 +270,30 @@ func TestManagement_GetCommitteeWithTwoHistoricCalls(t *testing.T) {
 	})
 }
 
+func TestManagement_ReportsStaleManagementAsFailing(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		test.WithContext(func(ctx context.Context) {
+			p := newStaticProvider()
+			cp, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+			registry := health.NewRegistry()
+			cp.RegisterHealthChecks(registry)
+			require.True(t, registry.Report(ctx).Ready, "management that is not polled should be healthy")
+
+			cp.config = &cfg{pollingInterval: time.Hour}
+			require.True(t, registry.Report(ctx).Ready, "management that was just updated should be healthy")
+
+			cp.Lock()
+			cp.lastSuccessfulUpdate = time.Now().Add(-30 * time.Hour)
+			cp.Unlock()
+			report := registry.Report(ctx)
+			require.False(t, report.Ready, "stale management should fail readiness")
+			require.True(t, report.Live, "stale management should not fail liveness")
+			require.Equal(t, health.STATUS_FAILING, report.Checks[0].Status)
+		})
+	})
+}
+
 // helpers
 func (s*service) addCommittee(ref primitives.TimestampSeconds, committee []primitives.NodeAddress) {
 	s.Lock()
@@ -278,6 +314,27 @@ func getCommitteeOrNil(m *service, ctx context.Context, reference primitives.Tim
 	return committee.Members
 }
 
+type failingProvider struct{}
+
+func (fp *failingProvider) Get(ctx context.Context, ref primitives.TimestampSeconds) (*VirtualChainManagementData, error) {
+	return nil, errors.New("management sources did not reach quorum")
+}
+
+func (fp *failingProvider) UpdateTopology(ctx context.Context, input *services.UpdateTopologyInput) (*services.UpdateTopologyOutput, error) {
+	return nil, nil
+}
+
+func TestManagement_ReturnsErrorWhenProviderFailsOnStart(t *testing.T) {
+	with.Logging(t, func(harness *with.LoggingHarness) {
+		test.WithContext(func(ctx context.Context) {
+			p := &failingProvider{}
+			_, err := NewManagement(ctx, newConfig(), p, p, harness.Logger, metric.NewRegistry())
+			require.Error(t, err)
+			require.Contains(t, err.Error(), "did not reach quorum")
+		})
+	})
+}
+
 type staticProvider struct {
 	sync.RWMutex
 	ref primitives.TimestampSeconds
@@ -331,12 +388,13 @@ func (sp *staticProvider) UpdateTopology(ctx context.Context, input *services.Up
 }
 
 type cfg struct {
+	pollingInterval time.Duration
 }
 
 func newConfig() *cfg {
 	return &cfg{}
 }
 
-func (tc *cfg) ManagementPollingInterval() time.Duration { // no auto update
-	return 0
+func (tc *cfg) ManagementPollingInterval() time.Duration { // no auto update by default
+	return tc.pollingInterval
 }
@@ -0,0 +1,45 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package processor
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+)
+
+const (
+	ABI_PERMISSION_PUBLIC = "PUBLIC"
+	ABI_PERMISSION_SYSTEM = "SYSTEM"
+)
+
+// ContractAbiProvider is implemented by processors which can describe the contracts they run,
+// the execution context is needed to read the code of deployed contracts
+type ContractAbiProvider interface {
+	GetContractAbi(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName primitives.ContractName) (*ContractAbi, error)
+}
+
+// ContractAbi is the machine readable description of a contract, argument types are named like the protocol argument
+// types (uint32, uint64, string, bytes, bool, uint256, bytes20, bytes32 and their arrays, e.g. uint32Array)
+type ContractAbi struct {
+	Name       string       `json:"name"`
+	Permission string       `json:"permission"`
+	Methods    []*MethodAbi `json:"methods"`
+	Events     []*EventAbi  `json:"events"`
+}
+
+type MethodAbi struct {
+	Name       string   `json:"name"`
+	Permission string   `json:"permission"`
+	Arguments  []string `json:"arguments"`
+	Variadic   bool     `json:"variadic,omitempty"` // the last argument may be repeated any number of times
+	Returns    []string `json:"returns"`
+}
+
+type EventAbi struct {
+	Name      string   `json:"name"`
+	Arguments []string `json:"arguments"`
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/types"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+var abiTypeNames = map[reflect.Type]string{
+	reflect.TypeOf(uint32(0)):    "uint32",
+	reflect.TypeOf(uint64(0)):    "uint64",
+	reflect.TypeOf(""):           "string",
+	reflect.TypeOf([]byte{}):     "bytes",
+	reflect.TypeOf(false):        "bool",
+	reflect.TypeOf(&big.Int{}):   "uint256",
+	reflect.TypeOf([20]byte{}):   "bytes20",
+	reflect.TypeOf([32]byte{}):   "bytes32",
+	reflect.TypeOf([]uint32{}):   "uint32Array",
+	reflect.TypeOf([]uint64{}):   "uint64Array",
+	reflect.TypeOf([]string{}):   "stringArray",
+	reflect.TypeOf([][]byte{}):   "bytesArray",
+	reflect.TypeOf([]bool{}):     "boolArray",
+	reflect.TypeOf([]*big.Int{}): "uint256Array",
+	reflect.TypeOf([][20]byte{}): "bytes20Array",
+	reflect.TypeOf([][32]byte{}): "bytes32Array",
+}
+
+// describes the contract using the same reflection verifyMethodInputArgs and createMethodOutputArgs rely on when calling it,
+// the name is left for the caller since contracts do not know it
+func newContractAbi(contractInfo *sdkContext.ContractInfo) (*processor.ContractAbi, error) {
+	contractAbi := &processor.ContractAbi{
+		Permission: protocol.ExecutionPermissionScope(contractInfo.Permission).String(),
+		Methods:    []*processor.MethodAbi{},
+		Events:     []*processor.EventAbi{},
+	}
+
+	for _, methods := range []struct {
+		instances  []interface{}
+		permission string
+	}{
+		{contractInfo.PublicMethods, processor.ABI_PERMISSION_PUBLIC},
+		{contractInfo.SystemMethods, processor.ABI_PERMISSION_SYSTEM},
+	} {
+		for _, method := range methods.instances {
+			methodAbi, err := newMethodAbi(method, methods.permission)
+			if err != nil {
+				return nil, err
+			}
+			contractAbi.Methods = append(contractAbi.Methods, methodAbi)
+		}
+	}
+
+	for _, event := range contractInfo.EventsMethods {
+		name, err := types.GetContractMethodNameFromFunction(event)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid event method")
+		}
+		arguments, err := abiTypesOf(reflect.TypeOf(event).NumIn(), reflect.TypeOf(event).In)
+		if err != nil {
+			return nil, errors.Wrapf(err, "event '%s'", name)
+		}
+		contractAbi.Events = append(contractAbi.Events, &processor.EventAbi{Name: name, Arguments: arguments})
+	}
+
+	sort.Slice(contractAbi.Methods, func(i, j int) bool { return contractAbi.Methods[i].Name < contractAbi.Methods[j].Name })
+	sort.Slice(contractAbi.Events, func(i, j int) bool { return contractAbi.Events[i].Name < contractAbi.Events[j].Name })
+	return contractAbi, nil
+}
+
+func newMethodAbi(method interface{}, permission string) (*processor.MethodAbi, error) {
+	name, err := types.GetContractMethodNameFromFunction(method)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid method")
+	}
+	methodType := reflect.TypeOf(method)
+
+	argumentTypeOf := methodType.In
+	if methodType.IsVariadic() {
+		// the variadic slice is passed as separate arguments of its element type
+		last := methodType.NumIn() - 1
+		argumentTypeOf = func(i int) reflect.Type {
+			if i == last {
+				return methodType.In(i).Elem()
+			}
+			return methodType.In(i)
+		}
+	}
+
+	arguments, err := abiTypesOf(methodType.NumIn(), argumentTypeOf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "method '%s' argument", name)
+	}
+	returns, err := abiTypesOf(methodType.NumOut(), methodType.Out)
+	if err != nil {
+		return nil, errors.Wrapf(err, "method '%s' output", name)
+	}
+
+	return &processor.MethodAbi{
+		Name:       name,
+		Permission: permission,
+		Arguments:  arguments,
+		Variadic:   methodType.IsVariadic(),
+		Returns:    returns,
+	}, nil
+}
+
+func abiTypesOf(count int, typeOf func(i int) reflect.Type) ([]string, error) {
+	names := []string{}
+	for i := 0; i < count; i++ {
+		name, found := abiTypeNames[typeOf(i)]
+		if !found {
+			return nil, errors.Errorf("%d has unsupported type %s", i, typeOf(i))
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/stretchr/testify/require"
+	"math/big"
+	"testing"
+)
+
+func sum(values ...uint64) uint64 {
+	return 0
+}
+
+func balances(owner [20]byte, tokens [][32]byte) ([]*big.Int, bool) {
+	return nil, false
+}
+
+func average(values ...float64) float64 {
+	return 0
+}
+
+func TestNewContractAbi_DescribesArgumentTypes(t *testing.T) {
+	contractAbi, err := newContractAbi(&sdkContext.ContractInfo{
+		PublicMethods: []interface{}{sum, balances},
+		Permission:    sdkContext.PERMISSION_SCOPE_SERVICE,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, []*processor.MethodAbi{
+		{Name: "balances", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"bytes20", "bytes32Array"}, Returns: []string{"uint256Array", "bool"}},
+		{Name: "sum", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"uint64"}, Variadic: true, Returns: []string{"uint64"}},
+	}, contractAbi.Methods)
+}
+
+func TestNewContractAbi_FailsOnUnsupportedTypes(t *testing.T) {
+	_, err := newContractAbi(&sdkContext.ContractInfo{
+		PublicMethods: []interface{}{average},
+		Permission:    sdkContext.PERMISSION_SCOPE_SERVICE,
+	})
+	require.EqualError(t, err, "method 'average' argument: 0 has unsupported type float64")
+}
@@ -0,0 +1,170 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/orbs-network/crypto-lib-go/crypto/hash"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ArtifactStore keeps compiled contracts by content address so they can be shared between nodes and survive restarts,
+// a shared object can only be loaded by a node built with the same toolchain and dependencies so they are part of the address
+type ArtifactStore interface {
+	// Import copies the artifact to the given path once it is verified, it returns false if the store does not have it
+	Import(address string, targetPath string) (bool, error)
+	// Export adds the artifact at the given path to the store, exporting an address which already exists does nothing
+	Export(address string, sourcePath string) error
+}
+
+// ArtifactAddress identifies the shared object built from the given code, goMod is the content of the go.mod the code
+// was built with
+func ArtifactAddress(hashOfCode string, goMod []byte) string {
+	toolchain := strings.Join(append([]string{runtime.Version(), runtime.GOOS, runtime.GOARCH}, addRaceFlagIfNeeded(nil)...), " ")
+	return hex.EncodeToString(hash.CalcSha256([]byte(hashOfCode), []byte(toolchain), goMod))
+}
+
+type directoryArtifactStore struct {
+	path   string
+	secret []byte
+}
+
+// NewDirectoryArtifactStore stores artifacts as files in a directory, which may be a volume shared by several nodes. Every
+// artifact has a sum file holding the hash of the shared object and a mac of the secret over it and the address, so only
+// nodes knowing the secret can add artifacts and an artifact cannot be moved to the address of other code
+func NewDirectoryArtifactStore(path string, secret []byte) ArtifactStore {
+	return &directoryArtifactStore{path: path, secret: secret}
+}
+
+func (s *directoryArtifactStore) Import(address string, targetPath string) (bool, error) {
+	sum, err := ioutil.ReadFile(s.sumPath(address))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Wrapf(err, "could not read sum of artifact %s", address)
+	}
+
+	hashOfArtifact, err := s.verifySum(address, sum)
+	if err != nil {
+		return false, errors.Wrapf(err, "could not import artifact %s", address)
+	}
+	if _, err := copyFileAtomically(s.artifactPath(address), targetPath, hashOfArtifact); err != nil {
+		return false, errors.Wrapf(err, "could not import artifact %s", address)
+	}
+	return true, nil
+}
+
+func (s *directoryArtifactStore) Export(address string, sourcePath string) error {
+	if _, err := os.Stat(s.sumPath(address)); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.path, 0755); err != nil {
+		return errors.Wrap(err, "could not create artifact store directory")
+	}
+	hashOfArtifact, err := copyFileAtomically(sourcePath, s.artifactPath(address), nil)
+	if err != nil {
+		return errors.Wrapf(err, "could not export artifact %s", address)
+	}
+	if err := writeFileAtomically(s.sumPath(address), s.sum(address, hashOfArtifact)); err != nil {
+		return errors.Wrapf(err, "could not export sum of artifact %s", address)
+	}
+	return nil
+}
+
+func (s *directoryArtifactStore) artifactPath(address string) string {
+	return filepath.Join(s.path, address+".so")
+}
+
+func (s *directoryArtifactStore) sumPath(address string) string {
+	return filepath.Join(s.path, address+".sum")
+}
+
+// the sum is the hex of the hash of the artifact followed by the hex of the mac
+func (s *directoryArtifactStore) sum(address string, hashOfArtifact []byte) []byte {
+	return []byte(hex.EncodeToString(hashOfArtifact) + hex.EncodeToString(s.mac(address, hashOfArtifact)))
+}
+
+func (s *directoryArtifactStore) verifySum(address string, sum []byte) ([]byte, error) {
+	decoded, err := hex.DecodeString(string(bytes.TrimSpace(sum)))
+	if err != nil || len(decoded) != 2*sha256.Size {
+		return nil, errors.New("artifact sum is malformed")
+	}
+
+	hashOfArtifact, mac := decoded[:sha256.Size], decoded[sha256.Size:]
+	if !hmac.Equal(mac, s.mac(address, hashOfArtifact)) {
+		return nil, errors.New("artifact sum was not created with the secret of the store")
+	}
+	return hashOfArtifact, nil
+}
+
+func (s *directoryArtifactStore) mac(address string, hashOfArtifact []byte) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(address))
+	mac.Write(hashOfArtifact)
+	return mac.Sum(nil)
+}
+
+// readers never see a partially written file, the copy is renamed into place once complete. When an expected hash is
+// given the copy is only renamed into place if it matches, the hash of the copy is returned
+func copyFileAtomically(sourcePath string, targetPath string, expectedHash []byte) ([]byte, error) {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	defer source.Close()
+
+	target, err := ioutil.TempFile(filepath.Dir(targetPath), filepath.Base(targetPath)+".tmp")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(target.Name()) // fails harmlessly once renamed
+
+	hasher := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(target, hasher), source); err != nil {
+		target.Close()
+		return nil, err
+	}
+	if err = target.Close(); err != nil {
+		return nil, err
+	}
+	hashOfCopy := hasher.Sum(nil)
+	if expectedHash != nil && !bytes.Equal(hashOfCopy, expectedHash) {
+		return nil, errors.Errorf("hash of artifact %x does not match its sum %x", hashOfCopy, expectedHash)
+	}
+	if err = os.Chmod(target.Name(), 0755); err != nil {
+		return nil, err
+	}
+	return hashOfCopy, os.Rename(target.Name(), targetPath)
+}
+
+func writeFileAtomically(targetPath string, content []byte) error {
+	target, err := ioutil.TempFile(filepath.Dir(targetPath), filepath.Base(targetPath)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(target.Name()) // fails harmlessly once renamed
+
+	if _, err = target.Write(content); err != nil {
+		target.Close()
+		return err
+	}
+	if err = target.Close(); err != nil {
+		return err
+	}
+	return os.Rename(target.Name(), targetPath)
+}
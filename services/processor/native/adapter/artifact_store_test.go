@@ -0,0 +1,130 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func withArtifactStore(t *testing.T, f func(store ArtifactStore, dir string)) {
+	dir, err := ioutil.TempDir("", "artifact-store")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	f(NewDirectoryArtifactStore(filepath.Join(dir, "store"), []byte("secret")), dir)
+}
+
+func exportArtifact(t *testing.T, store ArtifactStore, dir string, address string, content string) {
+	sourcePath := filepath.Join(dir, "source.so")
+	require.NoError(t, ioutil.WriteFile(sourcePath, []byte(content), 0600))
+	require.NoError(t, store.Export(address, sourcePath))
+}
+
+func TestArtifactStore_ImportOfMissingArtifact(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		imported, err := store.Import(ArtifactAddress("hash", nil), filepath.Join(dir, "target.so"))
+		require.NoError(t, err)
+		require.False(t, imported)
+
+		_, err = os.Stat(filepath.Join(dir, "target.so"))
+		require.True(t, os.IsNotExist(err), "nothing should be written when the artifact is missing")
+	})
+}
+
+func TestArtifactStore_ExportThenImport(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		address := ArtifactAddress("hash", []byte("module foo"))
+		sourcePath := filepath.Join(dir, "source.so")
+		require.NoError(t, ioutil.WriteFile(sourcePath, []byte("shared object"), 0600))
+
+		require.NoError(t, store.Export(address, sourcePath))
+
+		targetPath := filepath.Join(dir, "target.so")
+		imported, err := store.Import(address, targetPath)
+		require.NoError(t, err)
+		require.True(t, imported)
+
+		content, err := ioutil.ReadFile(targetPath)
+		require.NoError(t, err)
+		require.Equal(t, []byte("shared object"), content)
+	})
+}
+
+func TestArtifactStore_ExportOfExistingArtifactKeepsIt(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		address := ArtifactAddress("hash", nil)
+		firstPath := filepath.Join(dir, "first.so")
+		secondPath := filepath.Join(dir, "second.so")
+		require.NoError(t, ioutil.WriteFile(firstPath, []byte("first"), 0600))
+		require.NoError(t, ioutil.WriteFile(secondPath, []byte("second"), 0600))
+
+		require.NoError(t, store.Export(address, firstPath))
+		require.NoError(t, store.Export(address, secondPath))
+
+		targetPath := filepath.Join(dir, "target.so")
+		_, err := store.Import(address, targetPath)
+		require.NoError(t, err)
+		content, err := ioutil.ReadFile(targetPath)
+		require.NoError(t, err)
+		require.Equal(t, []byte("first"), content, "an exported artifact should never be replaced")
+	})
+}
+
+func TestArtifactStore_ImportOfModifiedArtifactFails(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		address := ArtifactAddress("hash", nil)
+		exportArtifact(t, store, dir, address, "shared object")
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "store", address+".so"), []byte("modified shared object"), 0600))
+
+		targetPath := filepath.Join(dir, "target.so")
+		imported, err := store.Import(address, targetPath)
+		require.Error(t, err)
+		require.False(t, imported)
+
+		_, err = os.Stat(targetPath)
+		require.True(t, os.IsNotExist(err), "a modified artifact should not be written")
+	})
+}
+
+func TestArtifactStore_ImportOfArtifactExportedWithOtherSecretFails(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		address := ArtifactAddress("hash", nil)
+		otherStore := NewDirectoryArtifactStore(filepath.Join(dir, "store"), []byte("other secret"))
+		exportArtifact(t, otherStore, dir, address, "shared object")
+
+		imported, err := store.Import(address, filepath.Join(dir, "target.so"))
+		require.Error(t, err)
+		require.False(t, imported)
+	})
+}
+
+func TestArtifactStore_ImportOfArtifactMovedToOtherAddressFails(t *testing.T) {
+	withArtifactStore(t, func(store ArtifactStore, dir string) {
+		address := ArtifactAddress("hash", nil)
+		otherAddress := ArtifactAddress("other-hash", nil)
+		exportArtifact(t, store, dir, address, "shared object")
+		for _, extension := range []string{".so", ".sum"} {
+			require.NoError(t, os.Rename(filepath.Join(dir, "store", address+extension), filepath.Join(dir, "store", otherAddress+extension)))
+		}
+
+		imported, err := store.Import(otherAddress, filepath.Join(dir, "target.so"))
+		require.Error(t, err)
+		require.False(t, imported)
+	})
+}
+
+func TestArtifactAddress_DependsOnCodeAndDependencies(t *testing.T) {
+	address := ArtifactAddress("hash", []byte("require foo v1.0.0"))
+
+	require.Equal(t, address, ArtifactAddress("hash", []byte("require foo v1.0.0")))
+	require.NotEqual(t, address, ArtifactAddress("other-hash", []byte("require foo v1.0.0")), "different code should have a different address")
+	require.NotEqual(t, address, ArtifactAddress("hash", []byte("require foo v1.0.1")), "different dependencies should have a different address")
+}
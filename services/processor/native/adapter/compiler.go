@@ -14,3 +14,8 @@ import (
 type Compiler interface {
 	Compile(ctx context.Context, code ...string) (*sdkContext.ContractInfo, error)
 }
+
+// SharedObjectCompiler builds contracts without loading them into the node, see LoadSharedObject
+type SharedObjectCompiler interface {
+	CompileSharedObject(ctx context.Context, code ...string) (string, error)
+}
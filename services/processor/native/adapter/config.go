@@ -23,5 +23,7 @@ const MAX_WARM_UP_COMPILATION_TIME = 45 * time.Second
 
 type Config interface {
 	ProcessorArtifactPath() string
+	ProcessorArtifactStorePath() string
+	ProcessorArtifactStoreSecret() string
 	ProcessorPerformWarmUpCompilation() bool
 }
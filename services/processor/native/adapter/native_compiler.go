@@ -18,7 +18,6 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
-	"plugin"
 	"runtime"
 	"strings"
 	"sync"
@@ -42,12 +41,15 @@ type nativeCompilerMetrics struct {
 	buildTime        *metric.Histogram
 	loadTime         *metric.Histogram
 	sourceSize       *metric.Histogram
+	artifactImports  *metric.Gauge
+	artifactExports  *metric.Gauge
 }
 
 type nativeCompiler struct {
-	config  Config
-	logger  log.Logger
-	metrics *nativeCompilerMetrics
+	config        Config
+	logger        log.Logger
+	metrics       *nativeCompilerMetrics
+	artifactStore ArtifactStore
 }
 
 func createNativeCompilerMetrics(factory metric.Factory) *nativeCompilerMetrics {
@@ -58,6 +60,8 @@ func createNativeCompilerMetrics(factory metric.Factory) *nativeCompilerMetrics
 		lastWarmUpTimeMs: factory.NewGauge("Processor.Native.Compiler.LastWarmUp.Time.Millis"),
 		writeToDiskTime:  factory.NewLatency("Processor.Native.Compiler.WriteToDisk.Time.Millis", 60*time.Minute),
 		sourceSize:       factory.NewHistogram("Processor.Native.Compiler.Source.Size.Bytes", 1024*1024), // megabyte
+		artifactImports:  factory.NewGauge("Processor.Native.Compiler.ArtifactStore.Imports.Count"),
+		artifactExports:  factory.NewGauge("Processor.Native.Compiler.ArtifactStore.Exports.Count"),
 	}
 }
 
@@ -69,6 +73,14 @@ func NewNativeCompiler(config Config, parent log.Logger, factory metric.Factory)
 		metrics: createNativeCompilerMetrics(factory),
 	}
 
+	if storePath := config.ProcessorArtifactStorePath(); storePath != "" {
+		if secret := config.ProcessorArtifactStoreSecret(); secret != "" {
+			c.artifactStore = NewDirectoryArtifactStore(storePath, []byte(secret))
+		} else {
+			logger.Info("artifact store secret is not set, artifact store is not used", log.String("artifact-store-path", storePath))
+		}
+	}
+
 	if config.ProcessorPerformWarmUpCompilation() {
 		c.warmUpCompilationCache() // so next compilations take 200 ms instead of 2 sec
 	} else {
@@ -97,6 +109,32 @@ func (c *nativeCompiler) Compile(ctx context.Context, code ...string) (*sdkConte
 	compilerLock.Lock()
 	defer compilerLock.Unlock()
 
+	soFilePath, err := c.compileSharedObject(ctx, code...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
+	logger.Info("loading shared object", log.String("so-path", soFilePath))
+	loadSoTime := time.Now()
+
+	so, err := LoadSharedObject(soFilePath)
+	c.metrics.loadTime.RecordSince(loadSoTime)
+
+	logger.Info("loaded shared object", log.String("so-path", soFilePath))
+
+	return so, err
+}
+
+// CompileSharedObject builds the contract and returns the path of the shared object without loading it
+func (c *nativeCompiler) CompileSharedObject(ctx context.Context, code ...string) (string, error) {
+	compilerLock.Lock()
+	defer compilerLock.Unlock()
+
+	return c.compileSharedObject(ctx, code...)
+}
+
+func (c *nativeCompiler) compileSharedObject(ctx context.Context, code ...string) (string, error) {
 	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
 	c.metrics.sourceSize.Record(int64(len(code)))
 	start := time.Now()
@@ -114,12 +152,17 @@ func (c *nativeCompiler) Compile(ctx context.Context, code ...string) (*sdkConte
 	versions := config.GetMainProjectDependencyVersions(projectGoModPath)
 	goModPath := filepath.Join(artifactsPath, "go.mod")
 	if err := WriteArtifactsGoModToDisk(goModPath, versions); err != nil {
-		return nil, err
+		return "", err
+	}
+
+	artifactAddress, importedSoFilePath, imported := c.importSharedObject(ctx, hashOfCode, goModPath, artifactsPath)
+	if imported {
+		return importedSoFilePath, nil
 	}
 
 	out, err := runGoCommand(context.Background(), artifactsPath, "mod", "download")
 	if err != nil {
-		return nil, errors.Wrapf(err, "could not download dependencies: %s", out)
+		return "", errors.Wrapf(err, "could not download dependencies: %s", out)
 	}
 
 	sourceCodeFilePaths, err := writeSourceCodeToDisk(hashOfCode, code, artifactsPath)
@@ -129,7 +172,7 @@ func (c *nativeCompiler) Compile(ctx context.Context, code ...string) (*sdkConte
 	}
 
 	if err != nil {
-		return nil, errors.Wrap(err, "could not write source code to disk")
+		return "", errors.Wrap(err, "could not write source code to disk")
 	}
 
 	logger.Info("building shared object", log.StringableSlice("source-path", sourceCodeFilePaths))
@@ -139,18 +182,57 @@ func (c *nativeCompiler) Compile(ctx context.Context, code ...string) (*sdkConte
 
 	c.metrics.buildTime.RecordSince(buildTime)
 	if err != nil {
-		return nil, errors.Wrap(err, "could not build a shared object")
+		return "", errors.Wrap(err, "could not build a shared object")
 	}
 
-	logger.Info("loading shared object", log.String("so-path", soFilePath))
-	loadSoTime := time.Now()
+	c.exportSharedObject(ctx, artifactAddress, soFilePath)
+	return soFilePath, nil
+}
 
-	so, err := loadSharedObject(soFilePath)
-	c.metrics.loadTime.RecordSince(loadSoTime)
+// failing to use the artifact store is not an error, the contract is compiled instead
+func (c *nativeCompiler) importSharedObject(ctx context.Context, hashOfCode string, goModPath string, artifactsPath string) (artifactAddress string, soFilePath string, imported bool) {
+	if c.artifactStore == nil {
+		return "", "", false
+	}
+	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
 
-	logger.Info("loaded shared object", log.String("so-path", soFilePath))
+	goMod, err := ioutil.ReadFile(goModPath)
+	if err != nil {
+		logger.Info("could not read go.mod, artifact store is not used", log.Error(err))
+		return "", "", false
+	}
+	artifactAddress = ArtifactAddress(hashOfCode, goMod)
 
-	return so, err
+	soFilePath = filepath.Join(artifactsPath, SHARED_OBJECT_PATH, hashOfCode) + ".so"
+	if err := os.MkdirAll(filepath.Dir(soFilePath), 0700); err != nil {
+		logger.Info("could not create shared object directory, artifact store is not used", log.Error(err))
+		return artifactAddress, "", false
+	}
+
+	imported, err = c.artifactStore.Import(artifactAddress, soFilePath)
+	if err != nil {
+		logger.Info("failed to import shared object from artifact store", log.String("artifact-address", artifactAddress), log.Error(err))
+		return artifactAddress, "", false
+	}
+	if imported {
+		c.metrics.artifactImports.Inc()
+		logger.Info("imported shared object from artifact store", log.String("artifact-address", artifactAddress), log.String("so-path", soFilePath))
+	}
+	return artifactAddress, soFilePath, imported
+}
+
+func (c *nativeCompiler) exportSharedObject(ctx context.Context, artifactAddress string, soFilePath string) {
+	if c.artifactStore == nil || artifactAddress == "" {
+		return
+	}
+	logger := c.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	if err := c.artifactStore.Export(artifactAddress, soFilePath); err != nil {
+		logger.Info("failed to export shared object to artifact store", log.String("artifact-address", artifactAddress), log.Error(err))
+		return
+	}
+	c.metrics.artifactExports.Inc()
+	logger.Info("exported shared object to artifact store", log.String("artifact-address", artifactAddress))
 }
 
 func getHashOfCode(code []string) string {
@@ -231,56 +313,6 @@ func runGoCommand(ctx context.Context, workDir string, cmdArgs ...string) ([]byt
 	return out, err
 }
 
-func loadSharedObject(soFilePath string) (*sdkContext.ContractInfo, error) {
-	loadedPlugin, err := plugin.Open(soFilePath)
-
-	if err != nil {
-		return nil, errors.Wrap(err, "could not open plugin")
-	}
-
-	publicMethods := []interface{}{}
-	var publicMethodsPtr *[]interface{}
-
-	publicMethodsSymbol, err := loadedPlugin.Lookup("PUBLIC")
-	if err != nil {
-		return nil, errors.Wrap(err, "could not look up a symbol inside a plugin")
-	}
-	publicMethodsPtr, ok := publicMethodsSymbol.(*[]interface{})
-	if !ok {
-		return nil, errors.New("PUBLIC methods export has incorrect type")
-	}
-	publicMethods = *publicMethodsPtr
-
-	systemMethods := []interface{}{}
-	var systemMethodsPtr *[]interface{}
-	systemMethodsSymbol, err := loadedPlugin.Lookup("SYSTEM")
-	if err == nil {
-		systemMethodsPtr, ok = systemMethodsSymbol.(*[]interface{})
-		if !ok {
-			return nil, errors.New("SYSTEM methods export has incorrect type")
-		}
-		systemMethods = *systemMethodsPtr
-	}
-
-	eventsMethods := []interface{}{}
-	var eventsMethodsPtr *[]interface{}
-	eventsMethodsSymbol, err := loadedPlugin.Lookup("EVENTS")
-	if err == nil {
-		eventsMethodsPtr, ok = eventsMethodsSymbol.(*[]interface{})
-		if !ok {
-			return nil, errors.New("EVENTS methods export has incorrect type")
-		}
-		eventsMethods = *eventsMethodsPtr
-	}
-
-	return &sdkContext.ContractInfo{
-		PublicMethods: publicMethods,
-		SystemMethods: systemMethods,
-		EventsMethods: eventsMethods,
-		Permission:    sdkContext.PERMISSION_SCOPE_SERVICE, // we don't support compiling system contracts on the fly
-	}, nil
-}
-
 func getGOPATH() string {
 	res := os.Getenv("GOPATH")
 	if res == "" {
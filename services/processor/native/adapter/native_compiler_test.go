@@ -163,7 +163,7 @@ func TestCompileCodeWithExistingArtifacts(t *testing.T) {
 
 	t.Log("Load artifact")
 
-	contractInfo, err := loadSharedObject(soFilePath)
+	contractInfo, err := LoadSharedObject(soFilePath)
 	require.NoError(t, err, "load should succeed")
 	require.NotNil(t, contractInfo, "loaded object should not be nil")
 	require.Equal(t, len(counter_mock.PUBLIC), len(contractInfo.PublicMethods), "loaded object should be valid")
@@ -178,7 +178,7 @@ func TestCompileCodeWithExistingArtifacts(t *testing.T) {
 	compilationTimeMs = (time.Now().UnixNano() - compilationStartTime) / 1000000
 	t.Logf("Compilation time: %d ms", compilationTimeMs)
 
-	contractInfo, err = loadSharedObject(soFilePath)
+	contractInfo, err = LoadSharedObject(soFilePath)
 	require.NoError(t, err, "load should succeed")
 	require.NotNil(t, contractInfo, "loaded object should not be nil")
 	require.Equal(t, len(counter_mock.PUBLIC), len(contractInfo.PublicMethods), "loaded object should be valid")
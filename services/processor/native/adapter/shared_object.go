@@ -0,0 +1,64 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package adapter
+
+import (
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/pkg/errors"
+	"plugin"
+)
+
+// LoadSharedObject opens a compiled contract, the sandbox child uses it to load contracts the node compiled
+func LoadSharedObject(soFilePath string) (*sdkContext.ContractInfo, error) {
+	loadedPlugin, err := plugin.Open(soFilePath)
+
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open plugin")
+	}
+
+	publicMethods := []interface{}{}
+	var publicMethodsPtr *[]interface{}
+
+	publicMethodsSymbol, err := loadedPlugin.Lookup("PUBLIC")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not look up a symbol inside a plugin")
+	}
+	publicMethodsPtr, ok := publicMethodsSymbol.(*[]interface{})
+	if !ok {
+		return nil, errors.New("PUBLIC methods export has incorrect type")
+	}
+	publicMethods = *publicMethodsPtr
+
+	systemMethods := []interface{}{}
+	var systemMethodsPtr *[]interface{}
+	systemMethodsSymbol, err := loadedPlugin.Lookup("SYSTEM")
+	if err == nil {
+		systemMethodsPtr, ok = systemMethodsSymbol.(*[]interface{})
+		if !ok {
+			return nil, errors.New("SYSTEM methods export has incorrect type")
+		}
+		systemMethods = *systemMethodsPtr
+	}
+
+	eventsMethods := []interface{}{}
+	var eventsMethodsPtr *[]interface{}
+	eventsMethodsSymbol, err := loadedPlugin.Lookup("EVENTS")
+	if err == nil {
+		eventsMethodsPtr, ok = eventsMethodsSymbol.(*[]interface{})
+		if !ok {
+			return nil, errors.New("EVENTS methods export has incorrect type")
+		}
+		eventsMethods = *eventsMethodsPtr
+	}
+
+	return &sdkContext.ContractInfo{
+		PublicMethods: publicMethods,
+		SystemMethods: systemMethods,
+		EventsMethods: eventsMethods,
+		Permission:    sdkContext.PERMISSION_SCOPE_SERVICE, // we don't support compiling system contracts on the fly
+	}, nil
+}
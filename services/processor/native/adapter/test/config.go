@@ -8,6 +8,8 @@ import (
 
 type Config interface {
 	ProcessorArtifactPath() string
+	ProcessorArtifactStorePath() string
+	ProcessorArtifactStoreSecret() string
 	ProcessorPerformWarmUpCompilation() bool
 }
 
@@ -21,7 +23,9 @@ func NewConfigWithTempDir(t *testing.T) (config Config, cleanupFunc func()) {
 }
 
 type tempDirConfig struct {
-	ArtifactPath string
+	ArtifactPath        string
+	ArtifactStorePath   string
+	ArtifactStoreSecret string
 }
 
 func (c *tempDirConfig) ProcessorPerformWarmUpCompilation() bool {
@@ -31,3 +35,11 @@ func (c *tempDirConfig) ProcessorPerformWarmUpCompilation() bool {
 func (c *tempDirConfig) ProcessorArtifactPath() string {
 	return c.ArtifactPath
 }
+
+func (c *tempDirConfig) ProcessorArtifactStorePath() string {
+	return c.ArtifactStorePath
+}
+
+func (c *tempDirConfig) ProcessorArtifactStoreSecret() string {
+	return c.ArtifactStoreSecret
+}
@@ -77,20 +77,11 @@ func (r *CompilingRepository) ContractInfo(ctx context.Context, executionContext
 func (r *CompilingRepository) retrieveDeployedContractInfoFromState(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (*sdkContext.ContractInfo, error) {
 	start := time.Now()
 
-	rawCodeFiles, err := r.getFullCodeOfDeploymentSystemContract(ctx, executionContextId, contractName)
+	code, err := r.retrieveSanitizedCodeFromState(ctx, executionContextId, contractName)
 	if err != nil {
 		return nil, err
 	}
 
-	var code []string
-	for _, rawCodeFile := range rawCodeFiles {
-		sanitizedCode, err := r.sanitizeDeployedSourceCode(rawCodeFile)
-		if err != nil {
-			return nil, errors.Wrapf(err, "source code for contract '%s' failed security sandbox audit", contractName)
-		}
-		code = append(code, sanitizedCode)
-	}
-
 	// TODO(v1): replace with given wrapped given context
 	ctx, cancel := context.WithTimeout(context.Background(), adapter.MAX_COMPILATION_TIME)
 	defer cancel()
@@ -112,6 +103,50 @@ func (r *CompilingRepository) retrieveDeployedContractInfoFromState(ctx context.
 	return newContractInfo, nil
 }
 
+// same as retrieveDeployedContractInfoFromState but leaves loading the contract to the sandbox child
+func (r *CompilingRepository) compileDeployedContractFromState(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string, compiler adapter.SharedObjectCompiler) (string, error) {
+	start := time.Now()
+
+	code, err := r.retrieveSanitizedCodeFromState(ctx, executionContextId, contractName)
+	if err != nil {
+		return "", err
+	}
+
+	// TODO(v1): replace with given wrapped given context
+	ctx, cancel := context.WithTimeout(context.Background(), adapter.MAX_COMPILATION_TIME)
+	defer cancel()
+
+	soFilePath, err := compiler.CompileSharedObject(ctx, code...)
+	if err != nil {
+		return "", errors.Wrapf(err, "compilation of deployable contract '%s' failed", contractName)
+	}
+
+	r.logger.Info("compiled deployable contract successfully", log.String("contract", contractName), log.String("so-path", soFilePath))
+
+	r.deployedContracts.Inc()
+	r.contractCompilationTime.RecordSince(start)
+
+	return soFilePath, nil
+}
+
+func (r *CompilingRepository) retrieveSanitizedCodeFromState(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) ([]string, error) {
+	rawCodeFiles, err := r.getFullCodeOfDeploymentSystemContract(ctx, executionContextId, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	var code []string
+	for _, rawCodeFile := range rawCodeFiles {
+		sanitizedCode, err := r.sanitizeDeployedSourceCode(rawCodeFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "source code for contract '%s' failed security sandbox audit", contractName)
+		}
+		code = append(code, sanitizedCode)
+	}
+
+	return code, nil
+}
+
 func (r *CompilingRepository) sanitizeDeployedSourceCode(code string) (string, error) {
 	if r.config.ProcessorSanitizeDeployedContracts() {
 		return r.sanitizer.Process(code)
@@ -139,48 +174,10 @@ func (r *CompilingRepository) getFullCodeOfDeploymentSystemContract(ctx context.
 }
 
 func (r *CompilingRepository) callGetCodeOfDeploymentSystemContract(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string, index uint32) (string, error) {
-	systemContractName := primitives.ContractName(deployments_systemcontract.CONTRACT_NAME)
-	systemMethodName := primitives.MethodName(deployments_systemcontract.METHOD_GET_CODE_PART)
-	inputArguments, err := protocol.ArgumentArrayFromNatives([]interface{}{contractName, index})
-	if err != nil {
-		panic(errors.Wrap(err, "input arguments"))
-	}
-
-	output, err := r.sdkHandler.HandleSdkCall(ctx, &handlers.HandleSdkCallInput{
-		ContextId:     primitives.ExecutionContextId(executionContextId),
-		OperationName: sdk.SDK_OPERATION_NAME_SERVICE,
-		MethodName:    "callMethod",
-		InputArguments: []*protocol.Argument{
-			(&protocol.ArgumentBuilder{
-				// serviceName
-				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
-				StringValue: string(systemContractName),
-			}).Build(),
-			(&protocol.ArgumentBuilder{
-				// methodName
-				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
-				StringValue: string(systemMethodName),
-			}).Build(),
-			(&protocol.ArgumentBuilder{
-				// inputArgs
-				Type:       protocol.ARGUMENT_TYPE_BYTES_VALUE,
-				BytesValue: inputArguments.Raw(),
-			}).Build(),
-		},
-		PermissionScope: protocol.PERMISSION_SCOPE_SYSTEM,
-	})
+	arg0, err := r.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_PART, contractName, index)
 	if err != nil {
 		return "", err
 	}
-	if len(output.OutputArguments) != 1 || !output.OutputArguments[0].IsTypeBytesValue() {
-		return "", errors.Errorf("callMethod Sdk.Service of _Deployments.getCode returned corrupt output value")
-	}
-	ArgumentArray := protocol.ArgumentArrayReader(output.OutputArguments[0].BytesValue())
-	argIterator := ArgumentArray.ArgumentsIterator()
-	if !argIterator.HasNext() {
-		return "", errors.Errorf("callMethod Sdk.Service of _Deployments.getCode returned corrupt output value")
-	}
-	arg0 := argIterator.NextArguments()
 	if !arg0.IsTypeBytesValue() {
 		return "", errors.Errorf("callMethod Sdk.Service of _Deployments.getCode returned corrupt output value")
 	}
@@ -188,27 +185,52 @@ func (r *CompilingRepository) callGetCodeOfDeploymentSystemContract(ctx context.
 }
 
 func (r *CompilingRepository) getCodeParts(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (uint32, error) {
-	systemContractName := deployments_systemcontract.CONTRACT_NAME
-	systemMethodName := deployments_systemcontract.METHOD_GET_CODE_PARTS
-	inputArguments, err := protocol.ArgumentArrayFromNatives([]interface{}{contractName})
+	arg0, err := r.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_PARTS, contractName)
+	if err != nil {
+		return 0, err
+	}
+	if !arg0.IsTypeUint32Value() {
+		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeParts returned corrupt output value")
+	}
+
+	return arg0.Uint32Value(), nil
+}
+
+// ContractVersion changes every time the owner upgrades the contract, it is read in the execution context so calls at
+// heights before the upgrade still see the previous version
+func (r *CompilingRepository) ContractVersion(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (uint32, error) {
+	arg0, err := r.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_VERSION, contractName)
+	if err != nil {
+		return 0, err
+	}
+	if !arg0.IsTypeUint32Value() {
+		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeVersion returned corrupt output value")
+	}
+
+	return arg0.Uint32Value(), nil
+}
+
+// returns the first output argument of the method
+func (r *CompilingRepository) callDeploymentSystemContract(ctx context.Context, executionContextId primitives.ExecutionContextId, methodName string, args ...interface{}) (*protocol.Argument, error) {
+	inputArguments, err := protocol.ArgumentArrayFromNatives(args)
 	if err != nil {
 		panic(errors.Wrap(err, "input arguments"))
 	}
 
 	output, err := r.sdkHandler.HandleSdkCall(ctx, &handlers.HandleSdkCallInput{
-		ContextId:     primitives.ExecutionContextId(executionContextId),
+		ContextId:     executionContextId,
 		OperationName: sdk.SDK_OPERATION_NAME_SERVICE,
 		MethodName:    "callMethod",
 		InputArguments: []*protocol.Argument{
 			(&protocol.ArgumentBuilder{
 				// serviceName
 				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
-				StringValue: systemContractName,
+				StringValue: deployments_systemcontract.CONTRACT_NAME,
 			}).Build(),
 			(&protocol.ArgumentBuilder{
 				// methodName
 				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
-				StringValue: systemMethodName,
+				StringValue: methodName,
 			}).Build(),
 			(&protocol.ArgumentBuilder{
 				// inputArgs
@@ -219,21 +241,14 @@ func (r *CompilingRepository) getCodeParts(ctx context.Context, executionContext
 		PermissionScope: protocol.PERMISSION_SCOPE_SYSTEM,
 	})
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-
 	if len(output.OutputArguments) != 1 || !output.OutputArguments[0].IsTypeBytesValue() {
-		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeParts returned corrupt output value")
+		return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.%s returned corrupt output value", methodName)
 	}
-	ArgumentArray := protocol.ArgumentArrayReader(output.OutputArguments[0].BytesValue())
-	argIterator := ArgumentArray.ArgumentsIterator()
+	argIterator := protocol.ArgumentArrayReader(output.OutputArguments[0].BytesValue()).ArgumentsIterator()
 	if !argIterator.HasNext() {
-		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeParts returned corrupt output value")
-	}
-	arg0 := argIterator.NextArguments()
-	if !arg0.IsTypeUint32Value() {
-		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeParts returned corrupt output value")
+		return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.%s returned corrupt output value", methodName)
 	}
-
-	return arg0.Uint32Value(), nil
+	return argIterator.NextArguments(), nil
 }
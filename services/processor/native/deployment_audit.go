@@ -0,0 +1,65 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+)
+
+// auditDeployment rejects native code which is not deterministic when it is deployed or upgraded, before _Deployments
+// writes it to state. Code already in state is never audited again, so existing contracts keep executing
+func (r *CompilingRepository) auditDeployment(ctx context.Context, input *services.ProcessCallInput) error {
+	if !r.config.ProcessorSanitizeDeployedContracts() || input.ContractName != deployments_systemcontract.CONTRACT_NAME {
+		return nil
+	}
+
+	var args []*protocol.Argument
+	for i := input.InputArgumentArray.ArgumentsIterator(); i.HasNext(); {
+		args = append(args, i.NextArguments())
+	}
+	// both methods take the service name, one more argument and then the code
+	if len(args) < 3 || !args[0].IsTypeStringValue() {
+		return nil
+	}
+	serviceName := args[0].StringValue()
+
+	switch input.MethodName {
+	case deployments_systemcontract.METHOD_DEPLOY_SERVICE:
+		if !args[1].IsTypeUint32Value() || args[1].Uint32Value() != uint32(protocol.PROCESSOR_TYPE_NATIVE) {
+			return nil
+		}
+	case deployments_systemcontract.METHOD_UPGRADE_SERVICE:
+		processorType, err := r.callDeploymentSystemContract(ctx, input.ContextId, deployments_systemcontract.METHOD_GET_INFO, serviceName)
+		if err != nil || !processorType.IsTypeUint32Value() || processorType.Uint32Value() != uint32(protocol.PROCESSOR_TYPE_NATIVE) {
+			return nil // upgrading a contract which is not deployed fails in _Deployments itself
+		}
+	default:
+		return nil
+	}
+
+	// pre-built contracts are deployed lazily with empty code, there is nothing to audit
+	for _, arg := range args[2:] {
+		if !arg.IsTypeBytesValue() || len(arg.BytesValue()) == 0 {
+			continue
+		}
+		if err := r.sanitizer.VerifyDeterminism(string(arg.BytesValue())); err != nil {
+			return errors.Wrapf(err, "source code for contract '%s' failed determinism audit", serviceName)
+		}
+	}
+	return nil
+}
+
+func auditFailedOutput(err error) *services.ProcessCallOutput {
+	return &services.ProcessCallOutput{
+		OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+		CallResult:          protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT,
+	}
+}
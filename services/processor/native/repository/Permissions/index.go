@@ -0,0 +1,27 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package permissions_systemcontract
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+)
+
+// helpers for avoiding reliance on strings throughout the system
+const CONTRACT_NAME = "_Permissions"
+const METHOD_ALLOW_CALLER = "allowCaller"
+const METHOD_DISALLOW_CALLER = "disallowCaller"
+const METHOD_SET_REENTRANCY_GUARD = "setReentrancyGuard"
+const METHOD_HAS_POLICY = "hasPolicy" // used by the virtual machine before checking calls to a contract
+const METHOD_CHECK_CALL = "checkCall" // used by the virtual machine on calls to contracts which have a policy
+
+const MAX_ALLOWED_CALLERS = 32
+
+var PUBLIC = sdk.Export(allowCaller, disallowCaller, getAllowedCallers, setReentrancyGuard, isReentrancyGuarded, hasPolicy, checkCall)
+var SYSTEM = sdk.Export(_init)
+
+func _init() {
+}
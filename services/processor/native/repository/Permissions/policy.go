@@ -0,0 +1,134 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package permissions_systemcontract
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+// allowCaller restricts calls to a method from other contracts to the allowed callers, a method without allowed callers
+// can be called by any contract. Calls from clients and from the contract itself are never restricted. The list is
+// bounded by MAX_ALLOWED_CALLERS since every restricted call scans it
+func allowCaller(contractName string, methodName string, callerName string) {
+	_validatePolicyContract(contractName)
+	if callerName == "" {
+		panic("caller name is empty")
+	}
+	if _isAllowedCaller(contractName, methodName, callerName) {
+		return
+	}
+
+	count := _readAllowedCallersCount(contractName, methodName)
+	if count >= MAX_ALLOWED_CALLERS {
+		panic(fmt.Sprintf("method %s.%s already has the maximum of %d allowed callers", contractName, methodName, MAX_ALLOWED_CALLERS))
+	}
+	state.WriteString(_allowedCallerKey(contractName, methodName, count), callerName)
+	state.WriteUint32(_allowedCallersCountKey(contractName, methodName), count+1)
+	if count == 0 {
+		state.WriteUint32(_restrictedMethodsCountKey(contractName), state.ReadUint32(_restrictedMethodsCountKey(contractName))+1)
+	}
+}
+
+// disallowCaller removes an allowed caller, once the last one is removed the method can be called by any contract again
+func disallowCaller(contractName string, methodName string, callerName string) {
+	_validatePolicyContract(contractName)
+
+	count := _readAllowedCallersCount(contractName, methodName)
+	for i := uint32(0); i < count; i++ {
+		if state.ReadString(_allowedCallerKey(contractName, methodName, i)) == callerName {
+			last := state.ReadString(_allowedCallerKey(contractName, methodName, count-1))
+			state.WriteString(_allowedCallerKey(contractName, methodName, i), last)
+			state.Clear(_allowedCallerKey(contractName, methodName, count-1))
+			state.WriteUint32(_allowedCallersCountKey(contractName, methodName), count-1)
+			if count == 1 {
+				state.WriteUint32(_restrictedMethodsCountKey(contractName), state.ReadUint32(_restrictedMethodsCountKey(contractName))-1)
+			}
+			return
+		}
+	}
+}
+
+func getAllowedCallers(contractName string, methodName string) []string {
+	count := _readAllowedCallersCount(contractName, methodName)
+	callers := make([]string, count)
+	for i := uint32(0); i < count; i++ {
+		callers[i] = state.ReadString(_allowedCallerKey(contractName, methodName, i))
+	}
+	return callers
+}
+
+// setReentrancyGuard rejects calls to the contract while it is already running lower in the call stack, like A calling B
+// which calls A again
+func setReentrancyGuard(contractName string, enabled uint32) {
+	_validatePolicyContract(contractName)
+	state.WriteUint32(_reentrancyGuardKey(contractName), enabled)
+}
+
+func isReentrancyGuarded(contractName string) uint32 {
+	return state.ReadUint32(_reentrancyGuardKey(contractName))
+}
+
+// hasPolicy tells whether calls to the contract need to be checked at all, which is not the case for most contracts
+func hasPolicy(contractName string) uint32 {
+	if isReentrancyGuarded(contractName) != 0 || state.ReadUint32(_restrictedMethodsCountKey(contractName)) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// checkCall panics when the call of contractName.methodName by callerName breaks the policy of contractName, reentrant
+// tells whether contractName is already running lower in the call stack
+func checkCall(contractName string, methodName string, callerName string, reentrant uint32) {
+	if reentrant != 0 && isReentrancyGuarded(contractName) != 0 {
+		panic(fmt.Sprintf("reentrant call to contract %s is not allowed", contractName))
+	}
+	if callerName == contractName || _readAllowedCallersCount(contractName, methodName) == 0 {
+		return
+	}
+	if !_isAllowedCaller(contractName, methodName, callerName) {
+		panic(fmt.Sprintf("contract %s is not allowed to call %s.%s", callerName, contractName, methodName))
+	}
+}
+
+func _validatePolicyContract(contractName string) {
+	if !bytes.Equal(address.GetCallerAddress(), address.GetContractAddress(contractName)) {
+		panic(fmt.Sprintf("only contract %s can change its own policy", contractName))
+	}
+}
+
+func _isAllowedCaller(contractName string, methodName string, callerName string) bool {
+	count := _readAllowedCallersCount(contractName, methodName)
+	for i := uint32(0); i < count; i++ {
+		if state.ReadString(_allowedCallerKey(contractName, methodName, i)) == callerName {
+			return true
+		}
+	}
+	return false
+}
+
+func _readAllowedCallersCount(contractName string, methodName string) uint32 {
+	return state.ReadUint32(_allowedCallersCountKey(contractName, methodName))
+}
+
+func _allowedCallersCountKey(contractName string, methodName string) []byte {
+	return []byte(fmt.Sprintf("%s.%s.AllowedCallers_Count", contractName, methodName))
+}
+
+func _allowedCallerKey(contractName string, methodName string, index uint32) []byte {
+	return []byte(fmt.Sprintf("%s.%s.AllowedCaller_%d", contractName, methodName, index))
+}
+
+func _restrictedMethodsCountKey(contractName string) []byte {
+	return []byte(fmt.Sprintf("%s.RestrictedMethods_Count", contractName))
+}
+
+func _reentrancyGuardKey(contractName string) []byte {
+	return []byte(fmt.Sprintf("%s.ReentrancyGuard", contractName))
+}
@@ -0,0 +1,145 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package permissions_systemcontract
+
+import (
+	"fmt"
+	. "github.com/orbs-network/orbs-contract-sdk/go/testing/unit"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const POLICY_CONTRACT = "MyToken"
+
+func TestPermissionsContract_checkCall_AnyCallerWithoutPolicy(t *testing.T) {
+	InServiceScope(nil, nil, func(m Mockery) {
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", "Other", 0)
+		})
+	})
+}
+
+func TestPermissionsContract_checkCall_OnlyAllowedCallers(t *testing.T) {
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(POLICY_CONTRACT, contractAddress)
+		allowCaller(POLICY_CONTRACT, "mint", "Minter")
+
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", "Minter", 0)
+		}, "allowed caller should be able to call")
+		require.Panics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", "Other", 0)
+		}, "other callers should be rejected")
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", POLICY_CONTRACT, 0)
+		}, "the contract should always be able to call itself")
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "transfer", "Other", 0)
+		}, "policy should only apply to its method")
+	})
+}
+
+func TestPermissionsContract_disallowCaller_LastCallerOpensMethod(t *testing.T) {
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(POLICY_CONTRACT, contractAddress)
+		allowCaller(POLICY_CONTRACT, "mint", "Minter1")
+		allowCaller(POLICY_CONTRACT, "mint", "Minter2")
+		allowCaller(POLICY_CONTRACT, "mint", "Minter1")
+		require.Equal(t, []string{"Minter1", "Minter2"}, getAllowedCallers(POLICY_CONTRACT, "mint"), "allowing a caller twice should list it once")
+
+		disallowCaller(POLICY_CONTRACT, "mint", "Minter1")
+		require.Equal(t, []string{"Minter2"}, getAllowedCallers(POLICY_CONTRACT, "mint"))
+		require.Panics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", "Minter1", 0)
+		})
+
+		disallowCaller(POLICY_CONTRACT, "mint", "Minter2")
+		require.Empty(t, getAllowedCallers(POLICY_CONTRACT, "mint"))
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "mint", "Minter1", 0)
+		}, "method without allowed callers should be open to any caller")
+	})
+}
+
+func TestPermissionsContract_allowCaller_FailsForAnotherContract(t *testing.T) {
+	InServiceScope(nil, AnAddress(), func(m Mockery) {
+		m.MockCallContractAddress(POLICY_CONTRACT, AnAddress())
+
+		require.Panics(t, func() {
+			allowCaller(POLICY_CONTRACT, "mint", "Attacker")
+		}, "should not allow changing the policy of another contract")
+		require.Panics(t, func() {
+			setReentrancyGuard(POLICY_CONTRACT, 0)
+		}, "should not allow changing the policy of another contract")
+	})
+}
+
+func TestPermissionsContract_checkCall_ReentrancyGuard(t *testing.T) {
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "withdraw", "Other", 1)
+		}, "reentrant calls should be allowed unless guarded")
+
+		m.MockCallContractAddress(POLICY_CONTRACT, contractAddress)
+		setReentrancyGuard(POLICY_CONTRACT, 1)
+
+		require.Panics(t, func() {
+			checkCall(POLICY_CONTRACT, "withdraw", "Other", 1)
+		}, "reentrant calls should be rejected once guarded")
+		require.NotPanics(t, func() {
+			checkCall(POLICY_CONTRACT, "withdraw", "Other", 0)
+		}, "calls which are not reentrant should not be affected by the guard")
+	})
+}
+
+func TestPermissionsContract_hasPolicy(t *testing.T) {
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		require.EqualValues(t, 0, hasPolicy(POLICY_CONTRACT), "contract without policy")
+
+		m.MockCallContractAddress(POLICY_CONTRACT, contractAddress)
+		allowCaller(POLICY_CONTRACT, "mint", "Minter1")
+		allowCaller(POLICY_CONTRACT, "mint", "Minter2")
+		allowCaller(POLICY_CONTRACT, "burn", "Minter1")
+		require.EqualValues(t, 1, hasPolicy(POLICY_CONTRACT), "contract with allowed callers")
+
+		disallowCaller(POLICY_CONTRACT, "mint", "Minter1")
+		disallowCaller(POLICY_CONTRACT, "burn", "Minter1")
+		require.EqualValues(t, 1, hasPolicy(POLICY_CONTRACT), "one method is still restricted")
+
+		disallowCaller(POLICY_CONTRACT, "mint", "Minter2")
+		require.EqualValues(t, 0, hasPolicy(POLICY_CONTRACT), "no method is restricted anymore")
+
+		setReentrancyGuard(POLICY_CONTRACT, 1)
+		require.EqualValues(t, 1, hasPolicy(POLICY_CONTRACT), "contract with reentrancy guard")
+	})
+}
+
+func TestPermissionsContract_allowCaller_BoundedNumberOfCallers(t *testing.T) {
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(POLICY_CONTRACT, contractAddress)
+		for i := 0; i < MAX_ALLOWED_CALLERS; i++ {
+			allowCaller(POLICY_CONTRACT, "mint", fmt.Sprintf("Minter%d", i))
+		}
+
+		require.Panics(t, func() {
+			allowCaller(POLICY_CONTRACT, "mint", "OneTooMany")
+		}, "should not allow more than the maximum number of callers")
+		require.NotPanics(t, func() {
+			allowCaller(POLICY_CONTRACT, "mint", "Minter0")
+		}, "allowing a listed caller again should not fail")
+	})
+}
@@ -0,0 +1,228 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package triggers_systemcontract
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/env"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/safemath/safeuint64"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/service"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+// MAX_SCHEDULED_CALLS_PER_BLOCK bounds the work a block spends on scheduled calls, the rest wait for the next blocks in id order
+// contracts may schedule calls from this protocol version, every node of the virtual chain must start running them with
+// the same block as they are part of the state and of the block
+const SCHEDULED_CALLS_PROTOCOL_VERSION = 3
+
+const MAX_SCHEDULED_CALLS_PER_BLOCK = 10
+
+// MAX_SCHEDULES_SCANNED_PER_BLOCK bounds the state reads of finding the due calls. Each block continues the scan where the
+// previous one stopped and wraps around to the first schedule, so a due call waits at most one pass over all schedules
+const MAX_SCHEDULES_SCANNED_PER_BLOCK = 100
+
+// calls injected by the block proposer are not signed, their signer address is the empty (zero) address
+var emptySignerAddress = make([]byte, 20)
+
+// implemented by the SDK of the node, the protocol version is not part of the contract SDK
+type protocolVersionHandler interface {
+	SdkEnvGetProtocolVersion(executionContextId context.ContextId, permissionScope context.PermissionScope) uint32
+}
+
+var getProtocolVersion = func() uint32 {
+	contextId, handler, permissionScope := context.GetContext()
+	if h, ok := handler.(protocolVersionHandler); ok {
+		return h.SdkEnvGetProtocolVersion(contextId, permissionScope)
+	}
+	return 0
+}
+
+// scheduleAtHeight registers a one-time call to a method of the calling contract in the first block of the given height
+func scheduleAtHeight(contractName string, methodName string, blockHeight uint64) uint64 {
+	_validateSchedulingContract(contractName)
+	if blockHeight <= env.GetBlockHeight() {
+		panic(fmt.Sprintf("block height %d is not in the future", blockHeight))
+	}
+	return _addSchedule(contractName, methodName, blockHeight, 0, 0)
+}
+
+// scheduleAtTime registers a one-time call to a method of the calling contract in the first block whose timestamp (in nano
+// seconds) reaches the given time
+func scheduleAtTime(contractName string, methodName string, timestamp uint64) uint64 {
+	_validateSchedulingContract(contractName)
+	if timestamp <= env.GetBlockTimestamp() {
+		panic(fmt.Sprintf("timestamp %d is not in the future", timestamp))
+	}
+	return _addSchedule(contractName, methodName, 0, timestamp, 0)
+}
+
+// scheduleEvery registers a call to a method of the calling contract which repeats every given number of blocks until cancelled
+func scheduleEvery(contractName string, methodName string, blocks uint64) uint64 {
+	_validateSchedulingContract(contractName)
+	if blocks == 0 {
+		panic("interval must be at least one block")
+	}
+	return _addSchedule(contractName, methodName, safeuint64.Add(env.GetBlockHeight(), blocks), 0, blocks)
+}
+
+// cancelSchedule only marks the call, it is removed by the trigger the next time it is due
+func cancelSchedule(id uint64) {
+	contractName := _readScheduleContract(id)
+	if contractName == "" {
+		panic(fmt.Sprintf("schedule %d does not exist", id))
+	}
+	_validateSchedulingContract(contractName)
+	state.WriteUint32(_scheduleKey(id, "Cancelled"), 1)
+}
+
+func getSchedule(id uint64) (contractName string, methodName string, blockHeight uint64, timestamp uint64, interval uint64, cancelled uint32) {
+	return _readScheduleContract(id), state.ReadString(_scheduleKey(id, "Method")), state.ReadUint64(_scheduleKey(id, "BlockHeight")),
+		state.ReadUint64(_scheduleKey(id, "Timestamp")), state.ReadUint64(_scheduleKey(id, "Interval")), state.ReadUint32(_scheduleKey(id, "Cancelled"))
+}
+
+// getDueCalls lists the calls the current block runs before its trigger transaction. Cancellations are ignored on purpose,
+// the list must stay the same throughout the block so the trigger advances exactly the calls that were run
+func getDueCalls() []uint64 {
+	if getProtocolVersion() < SCHEDULED_CALLS_PROTOCOL_VERSION {
+		return []uint64{}
+	}
+	dueCalls, _ := _scanDueCalls()
+	return dueCalls
+}
+
+// runScheduled is injected by the block proposer for every due call, a failing call only fails its own transaction
+func runScheduled(id uint64) {
+	_validateSchedulingActive()
+	if !bytes.Equal(address.GetSignerAddress(), emptySignerAddress) {
+		panic("scheduled calls can only be run by the block proposer")
+	}
+	if !_isDue(id, env.GetBlockHeight(), env.GetBlockTimestamp()) {
+		panic(fmt.Sprintf("schedule %d is not due", id))
+	}
+	if state.ReadUint32(_scheduleKey(id, "Cancelled")) != 0 {
+		return
+	}
+	service.CallMethod(_readScheduleContract(id), state.ReadString(_scheduleKey(id, "Method")))
+}
+
+// advances the calls run by this block whether they succeeded or not, so a failing call is never retried forever
+func _advanceDueCalls() {
+	blockHeight := env.GetBlockHeight()
+	dueCalls, next := _scanDueCalls()
+	for _, id := range dueCalls {
+		interval := state.ReadUint64(_scheduleKey(id, "Interval"))
+		if interval == 0 || state.ReadUint32(_scheduleKey(id, "Cancelled")) != 0 {
+			_clearSchedule(id)
+		} else {
+			state.WriteUint64(_scheduleKey(id, "BlockHeight"), safeuint64.Add(blockHeight, interval))
+		}
+	}
+
+	count := state.ReadUint64([]byte("Schedules_Count"))
+	if count == 0 {
+		return
+	}
+	first := _readFirstSchedule()
+	for first <= count && _readScheduleContract(first) == "" {
+		first++
+	}
+	state.WriteUint64([]byte("Schedules_First"), first)
+	state.WriteUint64([]byte("Schedules_Next"), next)
+}
+
+// returns the due calls and the id the scan of the next block starts from. Schedules added during the block are never
+// due in it, so they do not change the list even when the scan reaches them
+func _scanDueCalls() (dueCalls []uint64, next uint64) {
+	blockHeight := env.GetBlockHeight()
+	blockTimestamp := env.GetBlockTimestamp()
+
+	dueCalls = []uint64{}
+	count := state.ReadUint64([]byte("Schedules_Count"))
+	id := _readNextScannedSchedule(count)
+	for scanned := 0; id <= count && scanned < MAX_SCHEDULES_SCANNED_PER_BLOCK && len(dueCalls) < MAX_SCHEDULED_CALLS_PER_BLOCK; scanned++ {
+		if _isDue(id, blockHeight, blockTimestamp) {
+			dueCalls = append(dueCalls, id)
+		}
+		id++
+	}
+	return dueCalls, id
+}
+
+func _validateSchedulingActive() {
+	if getProtocolVersion() < SCHEDULED_CALLS_PROTOCOL_VERSION {
+		panic(fmt.Sprintf("scheduled calls are supported from protocol version %d", SCHEDULED_CALLS_PROTOCOL_VERSION))
+	}
+}
+
+func _validateSchedulingContract(contractName string) {
+	_validateSchedulingActive()
+	if !bytes.Equal(address.GetCallerAddress(), address.GetContractAddress(contractName)) {
+		panic(fmt.Sprintf("only contract %s can schedule its own calls", contractName))
+	}
+}
+
+func _isDue(id uint64, blockHeight uint64, blockTimestamp uint64) bool {
+	if _readScheduleContract(id) == "" {
+		return false
+	}
+	dueHeight := state.ReadUint64(_scheduleKey(id, "BlockHeight"))
+	if dueHeight != 0 && dueHeight <= blockHeight {
+		return true
+	}
+	dueTimestamp := state.ReadUint64(_scheduleKey(id, "Timestamp"))
+	return dueTimestamp != 0 && dueTimestamp <= blockTimestamp
+}
+
+func _addSchedule(contractName string, methodName string, blockHeight uint64, timestamp uint64, interval uint64) uint64 {
+	if methodName == "" {
+		panic("method name is empty")
+	}
+	id := safeuint64.Add(state.ReadUint64([]byte("Schedules_Count")), 1)
+	state.WriteUint64([]byte("Schedules_Count"), id)
+
+	state.WriteString(_scheduleKey(id, "Contract"), contractName)
+	state.WriteString(_scheduleKey(id, "Method"), methodName)
+	state.WriteUint64(_scheduleKey(id, "BlockHeight"), blockHeight)
+	state.WriteUint64(_scheduleKey(id, "Timestamp"), timestamp)
+	state.WriteUint64(_scheduleKey(id, "Interval"), interval)
+	return id
+}
+
+func _clearSchedule(id uint64) {
+	for _, field := range []string{"Contract", "Method", "BlockHeight", "Timestamp", "Interval", "Cancelled"} {
+		state.Clear(_scheduleKey(id, field))
+	}
+}
+
+func _readScheduleContract(id uint64) string {
+	return state.ReadString(_scheduleKey(id, "Contract"))
+}
+
+func _readFirstSchedule() uint64 {
+	first := state.ReadUint64([]byte("Schedules_First"))
+	if first == 0 {
+		return 1
+	}
+	return first
+}
+
+// the scan starts over from the first schedule once it passed the last one
+func _readNextScannedSchedule(count uint64) uint64 {
+	first := _readFirstSchedule()
+	next := state.ReadUint64([]byte("Schedules_Next"))
+	if next < first || next > count {
+		return first
+	}
+	return next
+}
+
+func _scheduleKey(id uint64, field string) []byte {
+	return []byte(fmt.Sprintf("Schedule_%d_%s", id, field))
+}
@@ -0,0 +1,282 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package triggers_systemcontract
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+	. "github.com/orbs-network/orbs-contract-sdk/go/testing/unit"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Committee"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const SCHEDULING_CONTRACT = "MyContract"
+
+func TestTriggersContract_scheduleAtHeight_DueFromHeight(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 12)
+		require.EqualValues(t, 1, id)
+
+		m.MockEnvBlockHeight(11)
+		require.Empty(t, getDueCalls(), "call should not be due before its height")
+
+		m.MockEnvBlockHeight(12)
+		require.Equal(t, []uint64{id}, getDueCalls(), "call should be due at its height")
+	})
+}
+
+func TestTriggersContract_scheduleAtTime_DueFromTimestamp(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		m.MockEnvBlockTimestamp(1000)
+
+		id := scheduleAtTime(SCHEDULING_CONTRACT, "tick", 2000)
+
+		m.MockEnvBlockTimestamp(1999)
+		require.Empty(t, getDueCalls(), "call should not be due before its time")
+
+		m.MockEnvBlockTimestamp(2500)
+		require.Equal(t, []uint64{id}, getDueCalls(), "call should be due once the block time reaches it")
+	})
+}
+
+func TestTriggersContract_schedule_FailsForAnotherContract(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	InServiceScope(nil, AnAddress(), func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, AnAddress())
+
+		require.Panics(t, func() {
+			scheduleEvery(SCHEDULING_CONTRACT, "tick", 5)
+		}, "should not allow scheduling calls of another contract")
+	})
+}
+
+func TestTriggersContract_schedule_FailsInThePast(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+
+		require.Panics(t, func() {
+			scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 10)
+		}, "should not allow scheduling a call at the current height")
+	})
+}
+
+func TestTriggersContract_getDueCalls_LimitedPerBlock(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(nil, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		for i := 0; i < MAX_SCHEDULED_CALLS_PER_BLOCK+2; i++ {
+			scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+		}
+
+		m.MockEnvBlockHeight(11)
+		dueCalls := getDueCalls()
+		require.Len(t, dueCalls, MAX_SCHEDULED_CALLS_PER_BLOCK)
+		require.EqualValues(t, 1, dueCalls[0], "due calls should be listed in id order")
+	})
+}
+
+func TestTriggersContract_getDueCalls_ScanIsBoundedAndContinuesInTheNextBlock(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(emptySignerAddress, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockServiceCallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES, nil)
+		m.MockEnvBlockHeight(10)
+		for i := 0; i < MAX_SCHEDULES_SCANNED_PER_BLOCK; i++ {
+			scheduleAtHeight(SCHEDULING_CONTRACT, "later", 1000)
+		}
+		due := scheduleAtHeight(SCHEDULING_CONTRACT, "soon", 11)
+
+		m.MockEnvBlockHeight(11)
+		require.Empty(t, getDueCalls(), "call beyond the scanned schedules should not be found")
+		trigger()
+
+		m.MockEnvBlockHeight(12)
+		require.Equal(t, []uint64{due}, getDueCalls(), "next block should continue the scan")
+		trigger()
+
+		m.MockEnvBlockHeight(13)
+		require.Empty(t, getDueCalls(), "scan should wrap around to the first schedule")
+	})
+}
+
+func TestTriggersContract_runScheduled_CallsContract(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(emptySignerAddress, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+
+		m.MockEnvBlockHeight(11)
+		m.MockServiceCallMethod(SCHEDULING_CONTRACT, "tick", nil)
+
+		runScheduled(id)
+	})
+}
+
+func TestTriggersContract_runScheduled_FailsWhenSigned(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(AnAddress(), contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+
+		m.MockEnvBlockHeight(11)
+		require.Panics(t, func() {
+			runScheduled(id)
+		}, "should not allow clients to run scheduled calls")
+	})
+}
+
+func TestTriggersContract_runScheduled_FailsWhenNotDue(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(emptySignerAddress, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 20)
+
+		require.Panics(t, func() {
+			runScheduled(id)
+		}, "should not run a call before it is due")
+	})
+}
+
+func TestTriggersContract_runScheduled_SkipsCancelled(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(emptySignerAddress, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockEnvBlockHeight(10)
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+		cancelSchedule(id)
+
+		m.MockEnvBlockHeight(11)
+		require.NotPanics(t, func() {
+			runScheduled(id) // no service call is mocked
+		})
+	})
+}
+
+func TestTriggersContract_trigger_AdvancesDueCalls(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(emptySignerAddress, contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockServiceCallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES, nil)
+		m.MockEnvBlockHeight(10)
+		once := scheduleAtHeight(SCHEDULING_CONTRACT, "once", 11)
+		every := scheduleEvery(SCHEDULING_CONTRACT, "every", 1)
+		cancelled := scheduleEvery(SCHEDULING_CONTRACT, "cancelled", 1)
+		cancelSchedule(cancelled)
+
+		m.MockEnvBlockHeight(11)
+		require.Equal(t, []uint64{once, every, cancelled}, getDueCalls())
+		trigger()
+
+		contractName, _, _, _, _, _ := getSchedule(once)
+		require.Empty(t, contractName, "one-time call should be removed once run")
+		contractName, _, _, _, _, _ = getSchedule(cancelled)
+		require.Empty(t, contractName, "cancelled call should be removed once due")
+		_, _, blockHeight, _, _, _ := getSchedule(every)
+		require.EqualValues(t, 12, blockHeight, "repeating call should be due again after its interval")
+
+		require.Empty(t, getDueCalls(), "no call should be due twice in the same block")
+		m.MockEnvBlockHeight(12)
+		require.Equal(t, []uint64{every}, getDueCalls())
+	})
+}
+
+func TestTriggersContract_trigger_DoesNotAdvanceWhenSigned(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+	contractAddress := AnAddress()
+
+	InServiceScope(AnAddress(), contractAddress, func(m Mockery) {
+		m.MockCallContractAddress(SCHEDULING_CONTRACT, contractAddress)
+		m.MockServiceCallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES, nil)
+		m.MockEnvBlockHeight(10)
+		id := scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+
+		m.MockEnvBlockHeight(11)
+		trigger()
+
+		require.Equal(t, []uint64{id}, getDueCalls(), "a client calling trigger should not skip scheduled calls")
+	})
+}
+
+func TestTriggersContract_trigger_DoesNotWriteSchedulePointersWithoutSchedules(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION)()
+
+	InServiceScope(emptySignerAddress, AnAddress(), func(m Mockery) {
+		m.MockServiceCallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES, nil)
+		m.MockEnvBlockHeight(10)
+
+		trigger()
+
+		require.Zero(t, state.ReadUint64([]byte("Schedules_First")), "first schedule should not be written")
+		require.Zero(t, state.ReadUint64([]byte("Schedules_Next")), "next scanned schedule should not be written")
+	})
+}
+
+func TestTriggersContract_BeforeTheProtocolVersionSchedulesNothing(t *testing.T) {
+	defer withProtocolVersion(SCHEDULED_CALLS_PROTOCOL_VERSION - 1)()
+
+	InServiceScope(emptySignerAddress, AnAddress(), func(m Mockery) {
+		m.MockServiceCallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES, nil)
+		m.MockEnvBlockHeight(10)
+
+		require.PanicsWithValue(t, "scheduled calls are supported from protocol version 3", func() {
+			scheduleAtHeight(SCHEDULING_CONTRACT, "tick", 11)
+		})
+		require.PanicsWithValue(t, "scheduled calls are supported from protocol version 3", func() {
+			runScheduled(1)
+		})
+		require.Empty(t, getDueCalls())
+
+		state.WriteUint64([]byte("Schedules_Count"), 1) // should not be read by the trigger
+		trigger()
+		require.Zero(t, state.ReadUint64([]byte("Schedules_First")), "first schedule should not be written")
+		require.Zero(t, state.ReadUint64([]byte("Schedules_Next")), "next scanned schedule should not be written")
+	})
+}
+
+// the unit test handler does not implement the protocol version, returns a function restoring the original lookup
+func withProtocolVersion(version uint32) func() {
+	original := getProtocolVersion
+	getProtocolVersion = func() uint32 {
+		return version
+	}
+	return func() {
+		getProtocolVersion = original
+	}
+}
@@ -1,7 +1,9 @@
 package triggers_systemcontract
 
 import (
+	"bytes"
 	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
 	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/service"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Committee"
 )
@@ -9,13 +11,20 @@ import (
 // helpers for avoiding reliance on strings throughout the system
 const CONTRACT_NAME = "_Triggers"
 const METHOD_TRIGGER = "trigger"
+const METHOD_GET_DUE_CALLS = "getDueCalls"
+const METHOD_RUN_SCHEDULED = "runScheduled"
 
-var PUBLIC = sdk.Export(trigger)
-var SYSTEM = sdk.Export(_init)
+var PUBLIC = sdk.Export(trigger, scheduleAtHeight, scheduleAtTime, scheduleEvery, cancelSchedule, getSchedule)
+var SYSTEM = sdk.Export(_init, getDueCalls, runScheduled)
 
 func _init() {
 }
 
 func trigger() {
 	service.CallMethod(committee_systemcontract.CONTRACT_NAME, committee_systemcontract.METHOD_UPDATE_MISSES) // committee always refers to the current block's validators - so if this block contains election the result will only affect next block update committee
+
+	// only the trigger injected by the block proposer runs after the scheduled calls of the block
+	if bytes.Equal(address.GetSignerAddress(), emptySignerAddress) && getProtocolVersion() >= SCHEDULED_CALLS_PROTOCOL_VERSION {
+		_advanceDueCalls()
+	}
 }
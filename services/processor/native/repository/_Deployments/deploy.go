@@ -7,9 +7,11 @@
 package deployments_systemcontract
 
 import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
 	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/service"
 	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Committee"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Elections"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Info"
@@ -66,6 +68,10 @@ func deployService(serviceName string, processorType uint32, code ...[]byte) {
 	}
 
 	_writeProcessor(serviceName, processorType)
+	if getProtocolVersion() >= UPGRADE_SERVICE_PROTOCOL_VERSION {
+		_writeOwner(serviceName, address.GetSignerAddress())
+		_writeCodeVersion(serviceName, 1)
+	}
 
 	if len(code) > 0 {
 		for i, c := range code {
@@ -86,6 +92,7 @@ func IsImplicitlyDeployed(serviceName string) bool {
 		info_systemcontract.CONTRACT_NAME,
 		triggers_systemcontract.CONTRACT_NAME,
 		committee_systemcontract.CONTRACT_NAME,
+		permissions_systemcontract.CONTRACT_NAME,
 		elections_systemcontract.CONTRACT_NAME:
 		return true
 	}
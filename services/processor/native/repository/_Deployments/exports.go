@@ -13,5 +13,12 @@ var PUBLIC = sdk.Export(getInfo,
 	getCodePart,
 	getCodeParts,
 	deployService,
+	upgradeService,
+	getCodeVersion,
+	getServices,
+	getPreviousCodeParts,
+	getPreviousCodePart,
+	getOwner,
+	transferOwnership,
 	lockNativeDeployment,
 	unlockNativeDeployment)
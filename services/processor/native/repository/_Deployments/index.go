@@ -13,3 +13,6 @@ const METHOD_GET_CODE = "getCode"
 const METHOD_GET_CODE_PART = "getCodePart"
 const METHOD_GET_CODE_PARTS = "getCodeParts"
 const METHOD_DEPLOY_SERVICE = "deployService"
+const METHOD_UPGRADE_SERVICE = "upgradeService"
+const METHOD_GET_CODE_VERSION = "getCodeVersion"
+const METHOD_GET_SERVICES = "getServices"
@@ -2,11 +2,29 @@ package deployments_systemcontract
 
 import (
 	"fmt"
+	"github.com/orbs-network/orbs-contract-sdk/go/context"
 	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
 	"regexp"
 	"strings"
 )
 
+// the names are kept as given from this protocol version, every node of the virtual chain must start writing them with
+// the same block as they are part of the state
+const KEEP_SERVICE_NAME_PROTOCOL_VERSION = 2
+
+// implemented by the SDK of the node, the protocol version is not part of the contract SDK
+type protocolVersionHandler interface {
+	SdkEnvGetProtocolVersion(executionContextId context.ContextId, permissionScope context.PermissionScope) uint32
+}
+
+var getProtocolVersion = func() uint32 {
+	contextId, handler, permissionScope := context.GetContext()
+	if h, ok := handler.(protocolVersionHandler); ok {
+		return h.SdkEnvGetProtocolVersion(contextId, permissionScope)
+	}
+	return 0
+}
+
 func _validateServiceName(serviceName string) {
 	if IsImplicitlyDeployed(serviceName) {
 		panic("a contract with this name exists")
@@ -30,9 +48,26 @@ func _isServiceNameUsed(serviceName string) bool {
 	return false
 }
 
+// getServices lists the deployed contracts in the order they were deployed, contracts deployed before the names were
+// kept as given are listed in lower case
+func getServices() []string {
+	numOfServices := _getNumberOfServices()
+	services := make([]string, numOfServices)
+	for i := 0; i < numOfServices; i++ {
+		services[i] = state.ReadString(_formatServiceNameIterator(i))
+		if services[i] == "" {
+			services[i] = _getServiceAtIndex(i)
+		}
+	}
+	return services
+}
+
 func _addServiceName(serviceName string) {
 	numOfServices := _getNumberOfServices()
 	_setServiceAtIndex(numOfServices, serviceName)
+	if getProtocolVersion() >= KEEP_SERVICE_NAME_PROTOCOL_VERSION {
+		state.WriteString(_formatServiceNameIterator(numOfServices), serviceName)
+	}
 	numOfServices++
 	_setNumberOfServices(numOfServices)
 }
@@ -53,6 +88,10 @@ func _formatServiceIterator(num int) []byte {
 	return []byte(fmt.Sprintf("Service_At_%d", num))
 }
 
+func _formatServiceNameIterator(num int) []byte {
+	return []byte(fmt.Sprintf("Service_Name_At_%d", num))
+}
+
 func _getServiceAtIndex(index int) string {
 	return state.ReadString(_formatServiceIterator(index))
 }
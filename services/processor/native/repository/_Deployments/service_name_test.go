@@ -1,6 +1,7 @@
 package deployments_systemcontract
 
 import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
 	. "github.com/orbs-network/orbs-contract-sdk/go/testing/unit"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -34,3 +35,37 @@ func TestOrbsDeployContract_isServiceNameValid(t *testing.T) {
 		}
 	})
 }
+
+func TestOrbsDeployContract_getServicesKeepsNamesAsDeployed(t *testing.T) {
+	defer withProtocolVersion(KEEP_SERVICE_NAME_PROTOCOL_VERSION)()
+
+	InServiceScope(nil, nil, func(m Mockery) {
+		_addServiceName("MyToken")
+		_setServiceAtIndex(1, "OldContract") // deployed before the names were kept as given
+		_setNumberOfServices(2)
+
+		require.Equal(t, []string{"MyToken", "oldcontract"}, getServices())
+	})
+}
+
+func TestOrbsDeployContract_getServicesListsNamesInLowerCaseBeforeTheProtocolVersionKeepsThem(t *testing.T) {
+	defer withProtocolVersion(KEEP_SERVICE_NAME_PROTOCOL_VERSION - 1)()
+
+	InServiceScope(nil, nil, func(m Mockery) {
+		_addServiceName("MyToken")
+
+		require.Empty(t, state.ReadString(_formatServiceNameIterator(0)), "service name should not be written")
+		require.Equal(t, []string{"mytoken"}, getServices())
+	})
+}
+
+// the unit test handler does not implement the protocol version, returns a function restoring the original lookup
+func withProtocolVersion(version uint32) func() {
+	original := getProtocolVersion
+	getProtocolVersion = func() uint32 {
+		return version
+	}
+	return func() {
+		getProtocolVersion = original
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package deployments_systemcontract
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/orbs-network/crypto-lib-go/crypto/encoding"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/service"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"strconv"
+)
+
+// the owner and code version are written and upgrades are allowed from this protocol version, every node of the virtual
+// chain must start writing them with the same block as they are part of the state
+const UPGRADE_SERVICE_PROTOCOL_VERSION = 3
+
+// upgradeService replaces the code of a deployed contract, only its owner (the signer who deployed it) may do so.
+// The replaced code is kept as a previous version and the migration method of the new code, if given, runs right after
+// the upgrade with system permissions so it can be a SYSTEM method nobody else is able to call
+func upgradeService(serviceName string, migrationMethod string, code ...[]byte) {
+	_validateUpgradesActive()
+	processorType := _readProcessor(serviceName)
+	if processorType == 0 {
+		panic("contract not deployed")
+	}
+	if processorType == uint32(protocol.PROCESSOR_TYPE_NATIVE) {
+		_validateNativeDeploymentLock()
+	}
+	_validateOwner(serviceName)
+
+	if len(code) == 0 {
+		panic("contract doesn't have any code")
+	}
+
+	version := getCodeVersion(serviceName)
+	_archiveCode(serviceName, version)
+	_clearCode(serviceName)
+	for i, c := range code {
+		_writeCode(serviceName, c, uint32(i))
+	}
+	_writeCodeVersion(serviceName, version+1)
+
+	if migrationMethod != "" {
+		service.CallMethod(serviceName, migrationMethod)
+	}
+}
+
+// getCodeVersion starts at 1 on deployment and grows with every upgrade, it is 0 for contracts which were not deployed
+func getCodeVersion(serviceName string) uint32 {
+	if _readProcessor(serviceName) == 0 {
+		return 0
+	}
+	version := state.ReadUint32(_codeVersionKey(serviceName))
+	if version == 0 { // backwards compatibility, deployed before upgrades were supported
+		return 1
+	}
+	return version
+}
+
+func getPreviousCodeParts(serviceName string, version uint32) uint32 {
+	if version == 0 || version >= getCodeVersion(serviceName) {
+		panic(fmt.Sprintf("contract has no previous version %d", version))
+	}
+	return state.ReadUint32(_archivedCodeCounterKey(serviceName, version))
+}
+
+func getPreviousCodePart(serviceName string, version uint32, index uint32) []byte {
+	code := state.ReadBytes(_archivedCodeKey(serviceName, version, index))
+	if len(code) == 0 {
+		panic("contract code not available")
+	}
+	return code
+}
+
+func getOwner(serviceName string) []byte {
+	return _readOwner(serviceName)
+}
+
+func transferOwnership(serviceName string, newOwner []byte) {
+	_validateUpgradesActive()
+	if _readProcessor(serviceName) == 0 {
+		panic("contract not deployed")
+	}
+	_validateOwner(serviceName)
+	if len(newOwner) != 20 {
+		panic("new owner must be a 20 byte address")
+	}
+	_writeOwner(serviceName, newOwner)
+}
+
+func _validateUpgradesActive() {
+	if getProtocolVersion() < UPGRADE_SERVICE_PROTOCOL_VERSION {
+		panic(fmt.Sprintf("contract upgrades are supported from protocol version %d", UPGRADE_SERVICE_PROTOCOL_VERSION))
+	}
+}
+
+func _validateOwner(serviceName string) {
+	owner := _readOwner(serviceName)
+	if len(owner) == 0 {
+		panic("contract was deployed without an owner and cannot be upgraded")
+	}
+	if !bytes.Equal(owner, address.GetSignerAddress()) {
+		panic(fmt.Sprintf("only the contract owner %s may change it", encoding.EncodeHex(owner)))
+	}
+}
+
+func _archiveCode(serviceName string, version uint32) {
+	parts := _codeCounter(serviceName) + 1
+	for i := uint32(0); i < parts; i++ {
+		state.WriteBytes(_archivedCodeKey(serviceName, version, i), _readCode(serviceName, i))
+	}
+	state.WriteUint32(_archivedCodeCounterKey(serviceName, version), parts)
+}
+
+func _clearCode(serviceName string) {
+	parts := _codeCounter(serviceName) + 1
+	for i := uint32(0); i < parts; i++ {
+		state.Clear(_codeKey(serviceName, i))
+	}
+	state.Clear(_codeCounterKey(serviceName))
+}
+
+func _readOwner(serviceName string) []byte {
+	return state.ReadBytes([]byte(serviceName + ".Owner"))
+}
+
+func _writeOwner(serviceName string, owner []byte) {
+	state.WriteBytes([]byte(serviceName+".Owner"), owner)
+}
+
+func _writeCodeVersion(serviceName string, version uint32) {
+	state.WriteUint32(_codeVersionKey(serviceName), version)
+}
+
+func _codeVersionKey(serviceName string) []byte {
+	return []byte(serviceName + ".CodeVersion")
+}
+
+func _archivedCodeKey(serviceName string, version uint32, index uint32) []byte {
+	return []byte(serviceName + ".V" + strconv.FormatInt(int64(version), 10) + ".Code." + strconv.FormatInt(int64(index), 10))
+}
+
+func _archivedCodeCounterKey(serviceName string, version uint32) []byte {
+	return []byte(serviceName + ".V" + strconv.FormatInt(int64(version), 10) + ".CodeParts")
+}
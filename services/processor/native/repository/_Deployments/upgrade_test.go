@@ -0,0 +1,122 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package deployments_systemcontract
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+	. "github.com/orbs-network/orbs-contract-sdk/go/testing/unit"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var owner = []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10, 0x11, 0x12, 0x13, 0x14}
+var stranger = []byte{0xa1, 0xa2, 0xa3, 0xa4, 0xa5, 0xa6, 0xa7, 0xa8, 0xa9, 0xaa, 0xab, 0xac, 0xad, 0xae, 0xaf, 0xb0, 0xb1, 0xb2, 0xb3, 0xb4}
+
+func TestUpgradeReplacesCodeAndKeepsPreviousVersion(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		m.MockServiceCallMethod("hello", "_init", nil)
+		m.MockServiceCallMethod("hello", "_migrate", nil)
+
+		deployService("hello", 2, []byte("contract"), []byte("more contract stuff"))
+		require.EqualValues(t, 1, getCodeVersion("hello"))
+		require.Equal(t, owner, getOwner("hello"))
+
+		upgradeService("hello", "_migrate", []byte("fixed contract"))
+		require.EqualValues(t, 2, getCodeVersion("hello"))
+		require.EqualValues(t, 1, getCodeParts("hello"))
+		require.EqualValues(t, []byte("fixed contract"), getCodePart("hello", 0))
+		require.Panics(t, func() { getCodePart("hello", 1) }, "parts of the replaced code should be removed")
+
+		require.EqualValues(t, 2, getPreviousCodeParts("hello", 1))
+		require.EqualValues(t, []byte("contract"), getPreviousCodePart("hello", 1, 0))
+		require.EqualValues(t, []byte("more contract stuff"), getPreviousCodePart("hello", 1, 1))
+		require.Panics(t, func() { getPreviousCodeParts("hello", 2) }, "the current version is not a previous version")
+	})
+}
+
+func TestUpgradeWithoutMigration(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		m.MockServiceCallMethod("hello", "_init", nil)
+
+		deployService("hello", 2, []byte("contract"))
+		upgradeService("hello", "", []byte("fixed contract"))
+		upgradeService("hello", "", []byte("fixed again"))
+
+		require.EqualValues(t, 3, getCodeVersion("hello"))
+		require.EqualValues(t, []byte("fixed again"), getCode("hello"))
+		require.EqualValues(t, []byte("fixed contract"), getPreviousCodePart("hello", 2, 0))
+	})
+}
+
+func TestOnlyOwnerCanUpgrade(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(stranger, nil, func(m Mockery) {
+		m.MockServiceCallMethod("hello", "_init", nil)
+		deployService("hello", 2, []byte("contract"))
+		_writeOwner("hello", owner) // deployed by someone else
+
+		require.Panics(t, func() { upgradeService("hello", "", []byte("malicious contract")) })
+		require.Panics(t, func() { transferOwnership("hello", stranger) })
+		require.EqualValues(t, 1, getCodeVersion("hello"))
+	})
+}
+
+func TestTransferOwnership(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		m.MockServiceCallMethod("hello", "_init", nil)
+		deployService("hello", 2, []byte("contract"))
+
+		require.Panics(t, func() { transferOwnership("hello", []byte{0x01}) }, "owner must be an address")
+		transferOwnership("hello", stranger)
+		require.Equal(t, stranger, getOwner("hello"))
+		require.Panics(t, func() { upgradeService("hello", "", []byte("fixed contract")) }, "previous owner should not be able to upgrade")
+	})
+}
+
+func TestUpgradeOfContractDeployedBeforeOwnership(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		_writeProcessor("hello", 2)
+		_writeCode("hello", []byte("contract"), 0)
+
+		require.EqualValues(t, 1, getCodeVersion("hello"))
+		require.PanicsWithValue(t, "contract was deployed without an owner and cannot be upgraded", func() {
+			upgradeService("hello", "", []byte("fixed contract"))
+		})
+	})
+}
+
+func TestCodeVersionOfContractNotDeployed(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		require.EqualValues(t, 0, getCodeVersion("hello"))
+		require.PanicsWithValue(t, "contract not deployed", func() {
+			upgradeService("hello", "", []byte("contract"))
+		})
+	})
+}
+
+func TestDeployBeforeUpgradesAreSupportedKeepsNoOwnerOrVersion(t *testing.T) {
+	defer withProtocolVersion(UPGRADE_SERVICE_PROTOCOL_VERSION - 1)()
+	InSystemScope(owner, nil, func(m Mockery) {
+		m.MockServiceCallMethod("hello", "_init", nil)
+		deployService("hello", 2, []byte("contract"))
+
+		require.Empty(t, getOwner("hello"), "owner should not be written")
+		require.Empty(t, state.ReadUint32(_codeVersionKey("hello")), "code version should not be written")
+		require.EqualValues(t, 1, getCodeVersion("hello"))
+		require.PanicsWithValue(t, "contract upgrades are supported from protocol version 3", func() {
+			upgradeService("hello", "", []byte("fixed contract"))
+		})
+		require.PanicsWithValue(t, "contract upgrades are supported from protocol version 3", func() {
+			transferOwnership("hello", stranger)
+		})
+	})
+}
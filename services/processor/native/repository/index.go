@@ -12,6 +12,7 @@ import (
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/BenchmarkContract"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/BenchmarkToken"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Committee"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Elections"
@@ -32,6 +33,7 @@ func NewPrebuilt() *prebuiltRepository {
 			},
 			triggers_systemcontract.CONTRACT_NAME: {
 				PublicMethods: triggers_systemcontract.PUBLIC,
+				SystemMethods: triggers_systemcontract.SYSTEM,
 				Permission:    sdkContext.PERMISSION_SCOPE_SYSTEM,
 			},
 			elections_systemcontract.CONTRACT_NAME: {
@@ -40,11 +42,17 @@ func NewPrebuilt() *prebuiltRepository {
 			},
 			committee_systemcontract.CONTRACT_NAME: {
 				PublicMethods: committee_systemcontract.PUBLIC,
+				EventsMethods: committee_systemcontract.EVENTS,
+				Permission:    sdkContext.PERMISSION_SCOPE_SYSTEM,
+			},
+			permissions_systemcontract.CONTRACT_NAME: {
+				PublicMethods: permissions_systemcontract.PUBLIC,
 				Permission:    sdkContext.PERMISSION_SCOPE_SYSTEM,
 			},
 			benchmarkcontract.CONTRACT_NAME: {
 				PublicMethods: benchmarkcontract.PUBLIC,
 				SystemMethods: benchmarkcontract.SYSTEM,
+				EventsMethods: benchmarkcontract.EVENTS,
 				Permission:    sdkContext.PERMISSION_SCOPE_SERVICE,
 			},
 			benchmarktoken.CONTRACT_NAME: {
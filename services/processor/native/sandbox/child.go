@@ -0,0 +1,91 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+)
+
+// the node binary runs as a sandbox child when started with this variable set
+const CHILD_PROCESS_ENV_VAR = "ORBS_NATIVE_SANDBOX_CHILD"
+
+func IsChildProcess() bool {
+	return os.Getenv(CHILD_PROCESS_ENV_VAR) != ""
+}
+
+// Executor loads and runs contracts inside the child, sdk calls made by contracts go through the given handler back to the parent
+type Executor interface {
+	Describe(sharedObjectPath string) (*ContractDescription, error)
+	Call(request *CallRequest, sdkHandler handlers.ContractSdkCallHandler) *CallResult
+}
+
+type child struct {
+	conn     *connection
+	executor Executor
+}
+
+// Serve runs the child side of the conversation until the parent closes the connection
+func Serve(in io.Reader, out io.Writer, executor Executor) error {
+	c := &child{
+		conn:     newConnection(in, out),
+		executor: executor,
+	}
+
+	for {
+		m, err := c.conn.receive()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := c.handle(m); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *child) handle(m *message) error {
+	switch {
+	case m.Describe != nil:
+		description, err := c.executor.Describe(m.Describe.SharedObjectPath)
+		if err != nil {
+			return c.conn.send(&message{CallResult: &CallResult{Error: err.Error()}})
+		}
+		return c.conn.send(&message{Description: description})
+
+	case m.Call != nil:
+		return c.conn.send(&message{CallResult: c.executor.Call(m.Call, c)})
+
+	default:
+		return errors.New("sandbox child received an unexpected message")
+	}
+}
+
+// HandleSdkCall forwards the call to the parent, nested calls the parent sends while serving it are executed before its result arrives
+func (c *child) HandleSdkCall(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+	if err := c.conn.send(&message{SdkCall: sdkCallFromInput(input)}); err != nil {
+		return nil, errors.Wrap(err, "lost connection to the node")
+	}
+
+	for {
+		m, err := c.conn.receive()
+		if err != nil {
+			return nil, errors.Wrap(err, "lost connection to the node")
+		}
+		if m.SdkResult != nil {
+			return m.SdkResult.toOutput()
+		}
+		if err := c.handle(m); err != nil {
+			return nil, err
+		}
+	}
+}
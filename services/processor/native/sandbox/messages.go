@@ -0,0 +1,143 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"encoding/gob"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/pkg/errors"
+	"io"
+)
+
+// the parent and child exchange gob encoded messages over the child stdin and stdout, exactly one field is set in every message.
+// the conversation is synchronous: while the child executes a call it may send sdk calls, and while the parent serves an sdk call
+// it may send a nested call (a contract calling another contract), so both sides keep serving messages until their response arrives
+type message struct {
+	Describe    *DescribeRequest
+	Description *ContractDescription
+	Call        *CallRequest
+	CallResult  *CallResult
+	SdkCall     *SdkCall
+	SdkResult   *SdkResult
+}
+
+type DescribeRequest struct {
+	SharedObjectPath string
+}
+
+type ContractDescription struct {
+	PublicMethods []string
+	SystemMethods []string
+	Permission    uint16
+	Abi           *processor.ContractAbi
+}
+
+type CallRequest struct {
+	SharedObjectPath       string
+	ContractName           string
+	MethodName             string
+	InputArguments         []byte // packed protocol.ArgumentArray
+	ContextId              []byte
+	CallingPermissionScope uint16
+	VirtualChainId         uint32
+}
+
+// Error is set when the call could not be made (bad input, unknown method), ContractError when the contract failed
+type CallResult struct {
+	OutputArguments []byte // packed protocol.ArgumentArray
+	ContractError   string
+	Error           string
+}
+
+type SdkCall struct {
+	ContextId       []byte
+	OperationName   string
+	MethodName      string
+	InputArguments  [][]byte // raw protocol.Argument
+	PermissionScope uint16
+}
+
+type SdkResult struct {
+	OutputArguments [][]byte // raw protocol.Argument
+	Error           string
+}
+
+type connection struct {
+	encoder *gob.Encoder
+	decoder *gob.Decoder
+}
+
+func newConnection(in io.Reader, out io.Writer) *connection {
+	return &connection{
+		encoder: gob.NewEncoder(out),
+		decoder: gob.NewDecoder(in),
+	}
+}
+
+func (c *connection) send(m *message) error {
+	return c.encoder.Encode(m)
+}
+
+func (c *connection) receive() (*message, error) {
+	m := &message{}
+	if err := c.decoder.Decode(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func sdkCallFromInput(input *handlers.HandleSdkCallInput) *SdkCall {
+	call := &SdkCall{
+		ContextId:       input.ContextId,
+		OperationName:   string(input.OperationName),
+		MethodName:      string(input.MethodName),
+		PermissionScope: uint16(input.PermissionScope),
+	}
+	for _, arg := range input.InputArguments {
+		call.InputArguments = append(call.InputArguments, arg.Raw())
+	}
+	return call
+}
+
+func (c *SdkCall) toInput() *handlers.HandleSdkCallInput {
+	return &handlers.HandleSdkCallInput{
+		ContextId:       c.ContextId,
+		OperationName:   primitives.ContractName(c.OperationName),
+		MethodName:      primitives.MethodName(c.MethodName),
+		InputArguments:  argumentsFromRaw(c.InputArguments),
+		PermissionScope: protocol.ExecutionPermissionScope(c.PermissionScope),
+	}
+}
+
+func sdkResultFromOutput(output *handlers.HandleSdkCallOutput, err error) *SdkResult {
+	if err != nil {
+		return &SdkResult{Error: err.Error()}
+	}
+	result := &SdkResult{}
+	for _, arg := range output.OutputArguments {
+		result.OutputArguments = append(result.OutputArguments, arg.Raw())
+	}
+	return result
+}
+
+func (r *SdkResult) toOutput() (*handlers.HandleSdkCallOutput, error) {
+	if r.Error != "" {
+		return nil, errors.New(r.Error)
+	}
+	return &handlers.HandleSdkCallOutput{OutputArguments: argumentsFromRaw(r.OutputArguments)}, nil
+}
+
+func argumentsFromRaw(raw [][]byte) []*protocol.Argument {
+	var args []*protocol.Argument
+	for _, r := range raw {
+		args = append(args, protocol.ArgumentReader(r))
+	}
+	return args
+}
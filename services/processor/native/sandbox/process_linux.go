@@ -0,0 +1,87 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// children must not outlive the node, even when it is killed without a chance to stop them
+func setParentDeathSignal(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Pdeathsig: syscall.SIGKILL}
+}
+
+// the kernel reports cpu time in clock ticks, the tick rate it uses is passed to every process in its auxiliary vector
+var clockTicksPerSecond = readClockTicksPerSecond()
+
+const auxvClockTicks = 17 // AT_CLKTCK, see getauxval(3)
+
+func readClockTicksPerSecond() uint64 {
+	auxv, err := ioutil.ReadFile("/proc/self/auxv")
+	if err != nil {
+		return 0
+	}
+	wordSize := int(unsafe.Sizeof(uintptr(0)))
+	for i := 0; i+2*wordSize <= len(auxv); i += 2 * wordSize {
+		key, value := readWord(auxv[i:], wordSize), readWord(auxv[i+wordSize:], wordSize)
+		if key == auxvClockTicks {
+			return value
+		}
+	}
+	return 0
+}
+
+// auxv holds native words, the node is only built for little endian platforms
+func readWord(b []byte, wordSize int) uint64 {
+	if wordSize == 4 {
+		return uint64(binary.LittleEndian.Uint32(b))
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+// reads /proc/<pid>/stat, see proc(5) for the field layout
+func processUsage(pid int) (usage, bool) {
+	stat, err := ioutil.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return usage{}, false
+	}
+
+	// the command name (field 2) may contain spaces, so the fields are counted from its closing parenthesis
+	end := strings.LastIndexByte(string(stat), ')')
+	if end < 0 {
+		return usage{}, false
+	}
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 22 {
+		return usage{}, false
+	}
+
+	// fields[0] is field 3 (state), utime and stime are fields 14 and 15, rss (in pages) is field 24
+	userTicks, err1 := strconv.ParseUint(fields[11], 10, 64)
+	systemTicks, err2 := strconv.ParseUint(fields[12], 10, 64)
+	residentPages, err3 := strconv.ParseUint(fields[21], 10, 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return usage{}, false
+	}
+
+	result := usage{
+		residentBytes: residentPages * uint64(os.Getpagesize()),
+	}
+	if clockTicksPerSecond > 0 {
+		result.cpu = time.Duration(userTicks+systemTicks) * time.Second / time.Duration(clockTicksPerSecond)
+	}
+	return result, true
+}
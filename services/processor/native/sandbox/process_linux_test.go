@@ -0,0 +1,20 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"github.com/stretchr/testify/require"
+	"os"
+	"testing"
+)
+
+func TestProcessUsage_ReadsTheClockTickRateFromTheKernel(t *testing.T) {
+	require.NotZero(t, clockTicksPerSecond, "clock tick rate should be read from the auxiliary vector")
+
+	_, ok := processUsage(os.Getpid())
+	require.True(t, ok)
+}
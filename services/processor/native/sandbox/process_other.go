@@ -0,0 +1,20 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+// +build !linux
+
+package sandbox
+
+import "os/exec"
+
+// children exit when the node closes their stdin, a node which is killed may leave a busy child running
+func setParentDeathSignal(cmd *exec.Cmd) {
+}
+
+// resource usage is only available on linux, elsewhere calls are limited by the call timeout alone
+func processUsage(pid int) (usage, bool) {
+	return usage{}, false
+}
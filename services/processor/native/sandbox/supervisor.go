@@ -0,0 +1,245 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+)
+
+var LogTag = log.Service("native-sandbox")
+
+const watchdogInterval = 10 * time.Millisecond
+
+type usage struct {
+	cpu           time.Duration
+	residentBytes uint64
+}
+
+type metrics struct {
+	runningProcesses *metric.Gauge
+	restarts         *metric.Gauge
+	terminatedCalls  *metric.Gauge
+	callTime         *metric.Histogram
+}
+
+func newMetrics(factory metric.Factory) *metrics {
+	return &metrics{
+		runningProcesses: factory.NewGauge("Processor.Native.Sandbox.RunningProcesses.Count"),
+		restarts:         factory.NewGauge("Processor.Native.Sandbox.Restarts.Count"),
+		terminatedCalls:  factory.NewGauge("Processor.Native.Sandbox.TerminatedCalls.Count"),
+		callTime:         factory.NewLatency("Processor.Native.Sandbox.CallTime.Millis", 60*time.Second),
+	}
+}
+
+// Supervisor runs contract calls in a pool of child processes, killing children which exceed the per call limits
+// and starting them again on the next call. Limits which are configured as zero are not enforced. The limits depend on
+// the host, so a terminated call is a failure of this node and never the result of a transaction
+type Supervisor struct {
+	config  config.NativeSandboxConfig
+	logger  log.Logger
+	command func() *exec.Cmd
+	metrics *metrics
+
+	pool chan *worker
+
+	sync.Mutex
+	workersByContext map[string]*worker
+}
+
+func NewSupervisor(cfg config.NativeSandboxConfig, command func() *exec.Cmd, parentLogger log.Logger, metricFactory metric.Factory) *Supervisor {
+	workers := int(cfg.ProcessorNativeSandboxWorkers())
+	if workers == 0 {
+		workers = 1
+	}
+
+	s := &Supervisor{
+		config:           cfg,
+		logger:           parentLogger.WithTags(LogTag),
+		command:          command,
+		metrics:          newMetrics(metricFactory),
+		pool:             make(chan *worker, workers),
+		workersByContext: make(map[string]*worker),
+	}
+	for i := 0; i < workers; i++ {
+		s.pool <- &worker{id: i}
+	}
+	return s
+}
+
+// ChildCommand runs the node binary again in sandbox mode, see IsChildProcess
+func ChildCommand() (func() *exec.Cmd, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not find the node executable")
+	}
+	return func() *exec.Cmd {
+		cmd := exec.Command(executable)
+		cmd.Env = append(os.Environ(), CHILD_PROCESS_ENV_VAR+"=1")
+		cmd.Stderr = os.Stderr
+		return cmd
+	}, nil
+}
+
+// Describe loads a contract in a child and lists its methods, a contract first called from within a running call is loaded in
+// the child running it since the pool may have no other child to spare
+func (s *Supervisor) Describe(ctx context.Context, contextId []byte, sharedObjectPath string) (description *ContractDescription, err error) {
+	s.Lock()
+	w, nested := s.workersByContext[string(contextId)]
+	s.Unlock()
+	if nested {
+		return w.describe(sharedObjectPath)
+	}
+
+	w, err = s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(w)
+
+	err = s.supervise(w, func() error {
+		description, err = w.describe(sharedObjectPath)
+		return err
+	})
+	return
+}
+
+// Call executes a contract method in a child, calls nested in a call which is already running (same execution context)
+// are sent to the child running it since the calling contract is waiting there for their result
+func (s *Supervisor) Call(ctx context.Context, request *CallRequest, sdkHandler handlers.ContractSdkCallHandler) (result *CallResult, err error) {
+	contextKey := string(request.ContextId)
+	s.Lock()
+	w, nested := s.workersByContext[contextKey]
+	s.Unlock()
+	if nested {
+		return w.call(ctx, request, sdkHandler) // the watchdog of the outer call covers the nested one
+	}
+
+	w, err = s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer s.release(w)
+
+	s.Lock()
+	s.workersByContext[contextKey] = w
+	s.Unlock()
+	defer func() {
+		s.Lock()
+		delete(s.workersByContext, contextKey)
+		s.Unlock()
+	}()
+
+	start := time.Now()
+	defer s.metrics.callTime.RecordSince(start)
+
+	err = s.supervise(w, func() error {
+		result, err = w.call(ctx, request, sdkHandler)
+		return err
+	})
+	return
+}
+
+// stops all children once they are done with their current call
+func (s *Supervisor) Shutdown() {
+	for i := 0; i < cap(s.pool); i++ {
+		w := <-s.pool
+		w.terminate("sandbox shut down")
+	}
+}
+
+func (s *Supervisor) acquire(ctx context.Context) (*worker, error) {
+	select {
+	case w := <-s.pool:
+		if w.isAlive() {
+			return w, nil
+		}
+		if w.processId() != 0 {
+			s.metrics.restarts.Inc()
+			s.logger.Info("restarting sandbox child process", log.Int("worker", w.id))
+		}
+		if err := s.start(w); err != nil {
+			s.pool <- w
+			return nil, err
+		}
+		return w, nil
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "no sandbox process available")
+	}
+}
+
+// plugins are never unloaded so children grow with every contract they load, those over the memory limit are replaced
+func (s *Supervisor) release(w *worker) {
+	if current, ok := processUsage(w.processId()); ok && s.exceedsMemoryLimit(current) {
+		w.terminate("recycled after exceeding the memory limit between calls")
+	}
+	s.pool <- w
+}
+
+func (s *Supervisor) supervise(w *worker, f func() error) error {
+	done := make(chan struct{})
+	govnr.Once(logfields.GovnrErrorer(s.logger), func() {
+		s.watch(w, done)
+	})
+
+	err := f()
+	close(done)
+
+	if terminated, ok := err.(*ContractTerminatedError); ok {
+		s.metrics.terminatedCalls.Inc()
+		s.logger.Info("sandbox child process terminated", log.Int("worker", w.id), log.String("reason", terminated.Reason))
+	}
+	return err
+}
+
+func (s *Supervisor) watch(w *worker, done chan struct{}) {
+	pid := w.processId()
+	start := time.Now()
+	initial, _ := processUsage(pid)
+
+	ticker := time.NewTicker(watchdogInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if timeout := s.config.ProcessorNativeSandboxCallTimeout(); timeout > 0 && time.Since(start) > timeout {
+				w.terminate(fmt.Sprintf("call exceeded the timeout of %s", timeout))
+				return
+			}
+			current, ok := processUsage(pid)
+			if !ok {
+				continue
+			}
+			if maxCpu := s.config.ProcessorNativeSandboxMaxCpuTimePerCall(); maxCpu > 0 && current.cpu-initial.cpu > maxCpu {
+				w.terminate(fmt.Sprintf("call exceeded the cpu time limit of %s", maxCpu))
+				return
+			}
+			if s.exceedsMemoryLimit(current) {
+				w.terminate(fmt.Sprintf("call exceeded the memory limit of %d MB", s.config.ProcessorNativeSandboxMaxMemoryMegabytes()))
+				return
+			}
+		}
+	}
+}
+
+func (s *Supervisor) exceedsMemoryLimit(current usage) bool {
+	maxMegabytes := s.config.ProcessorNativeSandboxMaxMemoryMegabytes()
+	return maxMegabytes > 0 && current.residentBytes > uint64(maxMegabytes)*1024*1024
+}
@@ -0,0 +1,226 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// the test binary doubles as the sandbox child
+func TestMain(m *testing.M) {
+	if IsChildProcess() {
+		if err := Serve(os.Stdin, os.Stdout, &testExecutor{}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func testChildCommand() *exec.Cmd {
+	cmd := exec.Command(os.Args[0], "-test.run=^$")
+	cmd.Env = append(os.Environ(), CHILD_PROCESS_ENV_VAR+"=1")
+	cmd.Stderr = os.Stderr
+	return cmd
+}
+
+func newTestSupervisor(t *testing.T, workers uint32, maxMemoryMegabytes uint32, maxCpuTimePerCall time.Duration, callTimeout time.Duration) *Supervisor {
+	cfg := config.ForNativeSandboxTests(workers, maxMemoryMegabytes, maxCpuTimePerCall, callTimeout)
+	return NewSupervisor(cfg, testChildCommand, log.DefaultTestingLogger(t), metric.NewRegistry())
+}
+
+func callRequest(contextId string, methodName string) *CallRequest {
+	return &CallRequest{
+		ContractName:   "TestContract",
+		MethodName:     methodName,
+		InputArguments: builders.ArgumentsArray("hello").Raw(),
+		ContextId:      []byte(contextId),
+	}
+}
+
+func outputOf(t *testing.T, result *CallResult) []interface{} {
+	require.Empty(t, result.Error)
+	require.Empty(t, result.ContractError)
+	out, err := protocol.ArgumentArrayReader(result.OutputArguments).ToNatives()
+	require.NoError(t, err)
+	return out
+}
+
+func TestSupervisor_CallsContractInChildProcess(t *testing.T) {
+	s := newTestSupervisor(t, 1, 0, 0, 10*time.Second)
+	defer s.Shutdown()
+
+	result, err := s.Call(context.Background(), callRequest("ctx", "echo"), nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"hello"}, outputOf(t, result))
+
+	result, err = s.Call(context.Background(), callRequest("ctx", "pid"), nil)
+	require.NoError(t, err)
+	require.NotEqual(t, uint32(os.Getpid()), outputOf(t, result)[0], "contract should not run in the node process")
+
+	description, err := s.Describe(context.Background(), []byte("ctx"), "/path/to/contract.so")
+	require.NoError(t, err)
+	require.Equal(t, []string{"/path/to/contract.so"}, description.PublicMethods)
+}
+
+func TestSupervisor_ForwardsSdkCallsToTheNode(t *testing.T) {
+	s := newTestSupervisor(t, 1, 0, 0, 10*time.Second)
+	defer s.Shutdown()
+
+	handler := sdkHandlerFunc(func(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+		require.EqualValues(t, sdk.SDK_OPERATION_NAME_STATE, input.OperationName)
+		require.Equal(t, []byte("key"), input.InputArguments[0].BytesValue())
+		return &handlers.HandleSdkCallOutput{OutputArguments: arguments([]byte("value"))}, nil
+	})
+	result, err := s.Call(context.Background(), callRequest("ctx", "readState"), handler)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{[]byte("value")}, outputOf(t, result))
+
+	failing := sdkHandlerFunc(func(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+		return nil, errors.New("state unavailable")
+	})
+	result, err = s.Call(context.Background(), callRequest("ctx", "readState"), failing)
+	require.NoError(t, err)
+	require.Equal(t, "state unavailable", result.ContractError)
+}
+
+func TestSupervisor_NestedCallsRunInTheCallingProcess(t *testing.T) {
+	s := newTestSupervisor(t, 2, 0, 0, 10*time.Second)
+	defer s.Shutdown()
+
+	var nestedPid interface{}
+	handler := sdkHandlerFunc(func(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+		// the virtual machine calls the processor again on the same goroutine with the same execution context
+		nested, err := s.Call(ctx, callRequest("ctx", "pid"), nil)
+		require.NoError(t, err)
+		nestedPid = outputOf(t, nested)[0]
+		return &handlers.HandleSdkCallOutput{}, nil
+	})
+	result, err := s.Call(context.Background(), callRequest("ctx", "callService"), handler)
+	require.NoError(t, err)
+	require.Equal(t, nestedPid, outputOf(t, result)[0])
+}
+
+func TestSupervisor_RestartsCrashedProcess(t *testing.T) {
+	s := newTestSupervisor(t, 1, 0, 0, 10*time.Second)
+	defer s.Shutdown()
+
+	_, err := s.Call(context.Background(), callRequest("ctx", "crash"), nil)
+	require.IsType(t, &ContractTerminatedError{}, err)
+
+	result, err := s.Call(context.Background(), callRequest("ctx", "echo"), nil)
+	require.NoError(t, err, "a new process should be started for the next call")
+	require.Equal(t, []interface{}{"hello"}, outputOf(t, result))
+}
+
+func TestSupervisor_TerminatesCallsExceedingTheTimeout(t *testing.T) {
+	s := newTestSupervisor(t, 1, 0, 0, 200*time.Millisecond)
+	defer s.Shutdown()
+
+	_, err := s.Call(context.Background(), callRequest("ctx", "hang"), nil)
+	require.IsType(t, &ContractTerminatedError{}, err)
+	require.Contains(t, err.Error(), "timeout")
+}
+
+func TestSupervisor_TerminatesCallsExceedingResourceLimits(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("resource usage is only tracked on linux")
+	}
+
+	s := newTestSupervisor(t, 1, 0, 200*time.Millisecond, 20*time.Second)
+	defer s.Shutdown()
+	_, err := s.Call(context.Background(), callRequest("ctx", "spin"), nil)
+	require.IsType(t, &ContractTerminatedError{}, err)
+	require.Contains(t, err.Error(), "cpu time")
+
+	s = newTestSupervisor(t, 1, 100, 0, 20*time.Second)
+	defer s.Shutdown()
+	_, err = s.Call(context.Background(), callRequest("ctx", "allocate"), nil)
+	require.IsType(t, &ContractTerminatedError{}, err)
+	require.Contains(t, err.Error(), "memory")
+}
+
+type sdkHandlerFunc func(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error)
+
+func (f sdkHandlerFunc) HandleSdkCall(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+	return f(ctx, input)
+}
+
+// runs in the child, each method misbehaves in a different way
+type testExecutor struct{}
+
+var memoryHog [][]byte
+
+func (e *testExecutor) Describe(sharedObjectPath string) (*ContractDescription, error) {
+	return &ContractDescription{PublicMethods: []string{sharedObjectPath}}, nil
+}
+
+func (e *testExecutor) Call(request *CallRequest, sdkHandler handlers.ContractSdkCallHandler) *CallResult {
+	var output []interface{}
+	switch request.MethodName {
+	case "echo":
+		return &CallResult{OutputArguments: request.InputArguments}
+	case "pid":
+		output = []interface{}{uint32(os.Getpid())}
+	case "readState":
+		result, err := sdkHandler.HandleSdkCall(context.Background(), &handlers.HandleSdkCallInput{
+			ContextId:      request.ContextId,
+			OperationName:  sdk.SDK_OPERATION_NAME_STATE,
+			MethodName:     "read",
+			InputArguments: arguments([]byte("key")),
+		})
+		if err != nil {
+			return &CallResult{ContractError: err.Error()}
+		}
+		output = []interface{}{result.OutputArguments[0].BytesValue()}
+	case "callService":
+		if _, err := sdkHandler.HandleSdkCall(context.Background(), &handlers.HandleSdkCallInput{ContextId: request.ContextId, OperationName: sdk.SDK_OPERATION_NAME_SERVICE}); err != nil {
+			return &CallResult{ContractError: err.Error()}
+		}
+		output = []interface{}{uint32(os.Getpid())}
+	case "crash":
+		go func() {
+			panic("a panic in a goroutine cannot be recovered by the processor")
+		}()
+		select {}
+	case "hang":
+		select {}
+	case "spin":
+		for {
+		}
+	case "allocate":
+		for {
+			chunk := make([]byte, 10*1024*1024)
+			for i := range chunk {
+				chunk[i] = 1
+			}
+			memoryHog = append(memoryHog, chunk)
+			time.Sleep(time.Millisecond)
+		}
+	}
+	packed, _ := protocol.ArgumentArrayFromNatives(output)
+	return &CallResult{OutputArguments: packed.Raw()}
+}
+
+func arguments(args ...interface{}) []*protocol.Argument {
+	res, _ := protocol.ArgumentsFromNatives(args)
+	return res
+}
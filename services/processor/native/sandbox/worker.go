@@ -0,0 +1,149 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sandbox
+
+import (
+	"context"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// ContractTerminatedError is returned when the child executing a call was killed for exceeding its limits or crashed
+type ContractTerminatedError struct {
+	Reason string
+}
+
+func (e *ContractTerminatedError) Error() string {
+	return "contract execution terminated: " + e.Reason
+}
+
+// worker is a single child process, it is owned by one top level call at a time (and the calls nested in it)
+type worker struct {
+	sync.Mutex
+	id                int
+	pid               int
+	kill              func() error
+	conn              *connection
+	alive             bool
+	terminationReason string
+}
+
+func (s *Supervisor) start(w *worker) error {
+	cmd := s.command()
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	setParentDeathSignal(cmd)
+	if err := cmd.Start(); err != nil {
+		return errors.Wrap(err, "failed to start sandbox child process")
+	}
+
+	w.Lock()
+	w.pid = cmd.Process.Pid
+	w.kill = cmd.Process.Kill
+	w.conn = newConnection(stdout, stdin)
+	w.alive = true
+	w.terminationReason = ""
+	w.Unlock()
+
+	govnr.Once(logfields.GovnrErrorer(s.logger), func() {
+		err := cmd.Wait()
+		w.Lock()
+		w.alive = false
+		w.Unlock()
+		s.metrics.runningProcesses.Dec()
+		s.logger.Info("sandbox child process exited", log.Int("worker", w.id), log.Int("pid", cmd.Process.Pid), log.Error(err))
+	})
+
+	s.metrics.runningProcesses.Inc()
+	return nil
+}
+
+func (w *worker) isAlive() bool {
+	w.Lock()
+	defer w.Unlock()
+	return w.alive
+}
+
+func (w *worker) processId() int {
+	w.Lock()
+	defer w.Unlock()
+	return w.pid
+}
+
+func (w *worker) terminate(reason string) {
+	w.Lock()
+	defer w.Unlock()
+	if !w.alive {
+		return
+	}
+	w.alive = false
+	w.terminationReason = reason
+	w.kill()
+}
+
+// once the connection fails the child is gone, either killed by the watchdog or crashed
+func (w *worker) failure(err error) error {
+	w.terminate("")
+	w.Lock()
+	defer w.Unlock()
+	if w.terminationReason == "" {
+		w.terminationReason = "contract process exited unexpectedly: " + err.Error()
+	}
+	return &ContractTerminatedError{Reason: w.terminationReason}
+}
+
+func (w *worker) describe(sharedObjectPath string) (*ContractDescription, error) {
+	if err := w.conn.send(&message{Describe: &DescribeRequest{SharedObjectPath: sharedObjectPath}}); err != nil {
+		return nil, w.failure(err)
+	}
+	m, err := w.conn.receive()
+	if err != nil {
+		return nil, w.failure(err)
+	}
+	switch {
+	case m.Description != nil:
+		return m.Description, nil
+	case m.CallResult != nil:
+		return nil, errors.New(m.CallResult.Error)
+	default:
+		return nil, w.failure(errors.New("unexpected message"))
+	}
+}
+
+func (w *worker) call(ctx context.Context, request *CallRequest, sdkHandler handlers.ContractSdkCallHandler) (*CallResult, error) {
+	if err := w.conn.send(&message{Call: request}); err != nil {
+		return nil, w.failure(err)
+	}
+
+	for {
+		m, err := w.conn.receive()
+		if err != nil {
+			return nil, w.failure(err)
+		}
+		switch {
+		case m.CallResult != nil:
+			return m.CallResult, nil
+		case m.SdkCall != nil:
+			output, err := sdkHandler.HandleSdkCall(ctx, m.SdkCall.toInput())
+			if err := w.conn.send(&message{SdkResult: sdkResultFromOutput(output, err)}); err != nil {
+				return nil, w.failure(err)
+			}
+		default:
+			return nil, w.failure(errors.New("unexpected message"))
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	"fmt"
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/adapter"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sandbox"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/types"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"os"
+	"sort"
+)
+
+// ServeSandbox runs the node binary as a sandbox child (see sandbox.IsChildProcess), the node talks to it over stdin and stdout
+func ServeSandbox() error {
+	out := os.Stdout
+	os.Stdout = os.Stderr // contracts printing to stdout would corrupt the conversation with the node
+	return sandbox.Serve(os.Stdin, out, newSandboxExecutor(adapter.LoadSharedObject))
+}
+
+type loadedContract struct {
+	info     *sdkContext.ContractInfo
+	instance *types.ContractInstance
+}
+
+// runs in the child, which serves one message at a time so no locking is needed
+type sandboxExecutor struct {
+	load      func(sharedObjectPath string) (*sdkContext.ContractInfo, error)
+	contracts map[string]*loadedContract
+}
+
+func newSandboxExecutor(load func(sharedObjectPath string) (*sdkContext.ContractInfo, error)) *sandboxExecutor {
+	return &sandboxExecutor{
+		load:      load,
+		contracts: make(map[string]*loadedContract),
+	}
+}
+
+func (e *sandboxExecutor) Describe(sharedObjectPath string) (*sandbox.ContractDescription, error) {
+	contract, err := e.loadContract(sharedObjectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	contractAbi, err := newContractAbi(contract.info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sandbox.ContractDescription{
+		PublicMethods: sortedMethodNames(contract.instance.PublicMethods),
+		SystemMethods: sortedMethodNames(contract.instance.SystemMethods),
+		Permission:    uint16(contract.info.Permission),
+		Abi:           contractAbi,
+	}, nil
+}
+
+func (e *sandboxExecutor) Call(request *sandbox.CallRequest, sdkHandler handlers.ContractSdkCallHandler) *sandbox.CallResult {
+	contract, err := e.loadContract(request.SharedObjectPath)
+	if err != nil {
+		return &sandbox.CallResult{Error: err.Error()}
+	}
+
+	methodInstance, err := retrieveMethodInstance(contract.instance, request.ContractName, request.MethodName, protocol.ExecutionPermissionScope(request.CallingPermissionScope))
+	if err != nil {
+		return &sandbox.CallResult{Error: err.Error()}
+	}
+
+	contextId := sdkContext.ContextId(request.ContextId)
+	sdkContext.PushContext(contextId, sdk.NewSDK(sdkHandler, sandboxSdkConfig(request.VirtualChainId)), contract.info.Permission)
+	defer sdkContext.PopContext(contextId)
+
+	functionNameForErrors := fmt.Sprintf("%s.%s", request.ContractName, request.MethodName)
+	outputArgs, contractErr, err := processMethodCall(primitives.ExecutionContextId(request.ContextId), contract.instance, methodInstance, protocol.ArgumentArrayReader(request.InputArguments), functionNameForErrors)
+	if err != nil {
+		return &sandbox.CallResult{Error: err.Error()}
+	}
+	if outputArgs == nil {
+		outputArgs = protocol.ArgumentsArrayEmpty()
+	}
+
+	result := &sandbox.CallResult{OutputArguments: outputArgs.Raw()}
+	if contractErr != nil {
+		result.ContractError = contractErr.Error()
+	}
+	return result
+}
+
+func (e *sandboxExecutor) loadContract(sharedObjectPath string) (*loadedContract, error) {
+	if contract, found := e.contracts[sharedObjectPath]; found {
+		return contract, nil
+	}
+
+	info, err := e.load(sharedObjectPath)
+	if err != nil {
+		return nil, err
+	}
+	instance, err := types.NewContractInstance(info)
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &loadedContract{info: info, instance: instance}
+	e.contracts[sharedObjectPath] = contract
+	return contract, nil
+}
+
+func sortedMethodNames(methods map[string]types.MethodInstance) []string {
+	var names []string
+	for name := range methods {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+type sandboxSdkConfig primitives.VirtualChainId
+
+func (c sandboxSdkConfig) VirtualChainId() primitives.VirtualChainId {
+	return primitives.VirtualChainId(c)
+}
@@ -0,0 +1,213 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/adapter"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sandbox"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+type deployedContract struct {
+	sharedObjectPath string
+	description      *sandbox.ContractDescription
+}
+
+// sandboxedService runs pre-built system contracts in the node process like service does, deployed contracts are compiled
+// by the node but only ever loaded and executed by the sandbox children
+type sandboxedService struct {
+	logger     log.Logger
+	config     config.NativeProcessorConfig
+	sdkHandler handlers.ContractSdkCallHandler
+
+	prebuilt            Repository
+	inProcess           *service
+	compiler            adapter.SharedObjectCompiler
+	compilingRepository *CompilingRepository
+	supervisor          *sandbox.Supervisor
+
+	sync.RWMutex
+	deployed map[contractKey]*deployedContract
+}
+
+func NewSandboxedNativeProcessor(compiler adapter.SharedObjectCompiler, supervisor *sandbox.Supervisor, config config.NativeProcessorConfig, parentLogger log.Logger, metricFactory metric.Factory) services.Processor {
+	logger := parentLogger.WithTags(LogTag)
+	prebuilt := repository.NewPrebuilt()
+
+	return &sandboxedService{
+		logger:   logger,
+		config:   config,
+		prebuilt: prebuilt,
+		inProcess: &service{
+			repository: prebuilt,
+			config:     config,
+			logger:     logger,
+			metrics:    getMetrics(metricFactory),
+			cache:      newContractCache(),
+		},
+		compiler:            compiler,
+		compilingRepository: NewCompilingRepository(nil, config, parentLogger, metricFactory), // only fetches and sanitizes code, compiler is used instead
+		supervisor:          supervisor,
+		deployed:            make(map[contractKey]*deployedContract),
+	}
+}
+
+// runs once on system initialization (called by the virtual machine constructor)
+func (s *sandboxedService) RegisterContractSdkCallHandler(handler handlers.ContractSdkCallHandler) {
+	s.sdkHandler = handler
+	s.inProcess.RegisterContractSdkCallHandler(handler)
+	s.compilingRepository.SetSdkHandler(handler)
+}
+
+func (s *sandboxedService) ProcessCall(ctx context.Context, input *services.ProcessCallInput) (*services.ProcessCallOutput, error) {
+	if s.isPrebuilt(ctx, input.ContextId, string(input.ContractName)) {
+		if err := s.compilingRepository.auditDeployment(ctx, input); err != nil {
+			return auditFailedOutput(err), err
+		}
+		return s.inProcess.ProcessCall(ctx, input)
+	}
+
+	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	// retrieve code
+	contract, err := s.retrieveDeployedContract(ctx, input.ContextId, string(input.ContractName))
+	if err != nil {
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_CONTRACT_NOT_DEPLOYED,
+		}, err
+	}
+
+	// execute
+	logger.Info("processor executing contract in sandbox", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName))
+
+	result, err := s.supervisor.Call(ctx, &sandbox.CallRequest{
+		SharedObjectPath:       contract.sharedObjectPath,
+		ContractName:           string(input.ContractName),
+		MethodName:             string(input.MethodName),
+		InputArguments:         input.InputArgumentArray.Raw(),
+		ContextId:              input.ContextId,
+		CallingPermissionScope: uint16(input.CallingPermissionScope),
+		VirtualChainId:         uint32(s.config.VirtualChainId()),
+	}, s.sdkHandler)
+	if err != nil {
+		logger.Info("contract execution in sandbox failed", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName), log.Error(err))
+
+		// the limits are measured in time and memory of this host, another node may complete the same call
+		if _, terminated := err.(*sandbox.ContractTerminatedError); terminated {
+			err = errors.Wrap(processor.ErrExecutionAborted, err.Error())
+		}
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_UNEXPECTED,
+		}, err
+	}
+	if result.Error != "" {
+		err = errors.New(result.Error)
+		logger.Info("contract execution failed", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName), log.Error(err))
+
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_INPUT,
+		}, err
+	}
+
+	// result
+	outputArgs := protocol.ArgumentArrayReader(result.OutputArguments)
+	if result.ContractError != "" {
+		contractErr := errors.New(result.ContractError)
+		logger.Info("contract returned error", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName), log.Error(contractErr))
+
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: outputArgs,
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT,
+		}, contractErr
+	}
+	return &services.ProcessCallOutput{
+		OutputArgumentArray: outputArgs,
+		CallResult:          protocol.EXECUTION_RESULT_SUCCESS,
+	}, nil
+}
+
+func (s *sandboxedService) GetContractInfo(ctx context.Context, input *services.GetContractInfoInput) (*services.GetContractInfoOutput, error) {
+	if s.isPrebuilt(ctx, input.ContextId, string(input.ContractName)) {
+		return s.inProcess.GetContractInfo(ctx, input)
+	}
+
+	contract, err := s.retrieveDeployedContract(ctx, input.ContextId, string(input.ContractName))
+	if err != nil {
+		return nil, err
+	}
+
+	return &services.GetContractInfoOutput{
+		PermissionScope: protocol.ExecutionPermissionScope(contract.description.Permission),
+	}, nil
+}
+
+func (s *sandboxedService) GetContractAbi(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	if s.isPrebuilt(ctx, executionContextId, string(contractName)) {
+		return s.inProcess.GetContractAbi(ctx, executionContextId, contractName)
+	}
+
+	contract, err := s.retrieveDeployedContract(ctx, executionContextId, string(contractName))
+	if err != nil {
+		return nil, err
+	}
+
+	// the description is cached and shared, the name is set on a copy
+	contractAbi := *contract.description.Abi
+	contractAbi.Name = string(contractName)
+	return &contractAbi, nil
+}
+
+func (s *sandboxedService) isPrebuilt(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) bool {
+	contractInfo, _ := s.prebuilt.ContractInfo(ctx, executionContextId, contractName)
+	return contractInfo != nil
+}
+
+func (s *sandboxedService) retrieveDeployedContract(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (*deployedContract, error) {
+	version, err := s.compilingRepository.ContractVersion(ctx, executionContextId, contractName)
+	if err != nil {
+		return nil, err
+	}
+	key := contractKey{name: contractName, version: version}
+
+	s.RLock()
+	contract, found := s.deployed[key]
+	s.RUnlock()
+	if found {
+		return contract, nil
+	}
+
+	sharedObjectPath, err := s.compilingRepository.compileDeployedContractFromState(ctx, executionContextId, contractName, s.compiler)
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := s.supervisor.Describe(ctx, executionContextId, sharedObjectPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading deployable contract '%s' in the sandbox failed", contractName)
+	}
+
+	contract = &deployedContract{sharedObjectPath: sharedObjectPath, description: description}
+	s.Lock()
+	s.deployed[key] = contract
+	s.Unlock()
+	return contract, nil
+}
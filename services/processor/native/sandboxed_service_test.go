@@ -0,0 +1,220 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package native
+
+import (
+	"context"
+	"encoding/binary"
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/BenchmarkContract"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sandbox"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+const deployedContractName = "DeployedBenchmark"
+
+// the test binary doubles as the sandbox child, "compiled" contracts are pre-built ones looked up by name since plugins cannot be built here
+func TestMain(m *testing.M) {
+	if sandbox.IsChildProcess() {
+		if err := sandbox.Serve(os.Stdin, os.Stdout, newSandboxExecutor(loadPrebuiltContract)); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+func loadPrebuiltContract(sharedObjectPath string) (*sdkContext.ContractInfo, error) {
+	contractInfo, _ := repository.NewPrebuilt().ContractInfo(context.Background(), nil, sharedObjectPath)
+	if contractInfo == nil {
+		return nil, errors.Errorf("could not open plugin %s", sharedObjectPath)
+	}
+	return contractInfo, nil
+}
+
+type prebuiltCompiler struct {
+	compilations int
+}
+
+func (c *prebuiltCompiler) CompileSharedObject(ctx context.Context, code ...string) (string, error) {
+	c.compilations++
+	return benchmarkcontract.CONTRACT_NAME, nil
+}
+
+type sandboxHarness struct {
+	service    services.Processor
+	supervisor *sandbox.Supervisor
+	compiler   *prebuiltCompiler
+	state      map[string][]byte
+	version    uint32
+}
+
+func newSandboxHarness(t *testing.T, callTimeout time.Duration) *sandboxHarness {
+	command := func() *exec.Cmd {
+		cmd := exec.Command(os.Args[0], "-test.run=^$")
+		cmd.Env = append(os.Environ(), sandbox.CHILD_PROCESS_ENV_VAR+"=1")
+		cmd.Stderr = os.Stderr
+		return cmd
+	}
+	logger := log.DefaultTestingLogger(t)
+	registry := metric.NewRegistry()
+
+	h := &sandboxHarness{
+		supervisor: sandbox.NewSupervisor(config.ForNativeSandboxTests(1, 0, 0, callTimeout), command, logger, registry),
+		compiler:   &prebuiltCompiler{},
+		state:      make(map[string][]byte),
+		version:    1,
+	}
+	h.service = NewSandboxedNativeProcessor(h.compiler, h.supervisor, config.ForNativeProcessorTests(42), logger, registry)
+	h.service.RegisterContractSdkCallHandler(h)
+	return h
+}
+
+// plays the virtual machine, serving deployed code and state
+func (h *sandboxHarness) HandleSdkCall(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+	switch input.OperationName {
+	case sdk.SDK_OPERATION_NAME_SERVICE:
+		var result *protocol.ArgumentArray
+		switch input.InputArguments[1].StringValue() {
+		case deployments_systemcontract.METHOD_GET_CODE_VERSION:
+			result = builders.ArgumentsArray(h.version)
+		case deployments_systemcontract.METHOD_GET_CODE_PARTS:
+			result = builders.ArgumentsArray(uint32(1))
+		case deployments_systemcontract.METHOD_GET_CODE_PART:
+			result = builders.ArgumentsArray([]byte("package main"))
+		default:
+			return nil, errors.New("unexpected service call")
+		}
+		outputArgs, _ := protocol.ArgumentsFromNatives(builders.VarsToSlice(result.Raw()))
+		return &handlers.HandleSdkCallOutput{OutputArguments: outputArgs}, nil
+	case sdk.SDK_OPERATION_NAME_STATE:
+		key := string(input.InputArguments[0].BytesValue())
+		if input.MethodName == "write" {
+			h.state[key] = input.InputArguments[1].BytesValue()
+			return &handlers.HandleSdkCallOutput{}, nil
+		}
+		outputArgs, _ := protocol.ArgumentsFromNatives(builders.VarsToSlice(h.state[key]))
+		return &handlers.HandleSdkCallOutput{OutputArguments: outputArgs}, nil
+	}
+	return nil, errors.Errorf("unexpected sdk call %s", input.OperationName)
+}
+
+func (h *sandboxHarness) processCall(contractName string, methodName string, permissionScope protocol.ExecutionPermissionScope, args ...interface{}) (*services.ProcessCallOutput, error) {
+	return h.service.ProcessCall(context.Background(), &services.ProcessCallInput{
+		ContextId:              primitives.ExecutionContextId("ctx"),
+		ContractName:           primitives.ContractName(contractName),
+		MethodName:             primitives.MethodName(methodName),
+		InputArgumentArray:     builders.ArgumentsArray(args...),
+		AccessScope:            protocol.ACCESS_SCOPE_READ_WRITE,
+		CallingPermissionScope: permissionScope,
+	})
+}
+
+func TestSandboxedProcessCall_RunsDeployedContractInChildProcess(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	output, err := h.processCall(deployedContractName, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(12), uint64(27))
+	require.NoError(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, output.CallResult)
+	require.Equal(t, builders.ArgumentsArray(uint64(39)).Raw(), output.OutputArgumentArray.Raw())
+
+	_, err = h.processCall(deployedContractName, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, 1, h.compiler.compilations, "contract should be compiled once")
+
+	info, err := h.service.GetContractInfo(context.Background(), &services.GetContractInfoInput{ContextId: primitives.ExecutionContextId("ctx"), ContractName: deployedContractName})
+	require.NoError(t, err)
+	require.Equal(t, protocol.PERMISSION_SCOPE_SERVICE, info.PermissionScope)
+}
+
+func TestSandboxedProcessCall_RecompilesDeployedContractOnUpgrade(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	_, err := h.processCall(deployedContractName, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, 1, h.compiler.compilations)
+
+	h.version = 2
+	_, err = h.processCall(deployedContractName, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, 2, h.compiler.compilations, "upgraded contract should be compiled again")
+
+	h.version = 1
+	_, err = h.processCall(deployedContractName, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, 2, h.compiler.compilations, "calls at heights before the upgrade should reuse the previous version")
+}
+
+func TestSandboxedProcessCall_ForwardsSdkCallsToTheVirtualMachine(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	_, err := h.processCall(deployedContractName, "set", protocol.PERMISSION_SCOPE_SERVICE, uint64(17))
+	require.NoError(t, err)
+	require.Equal(t, uint64(17), binary.LittleEndian.Uint64(h.state["example-key"]))
+
+	output, err := h.processCall(deployedContractName, "get", protocol.PERMISSION_SCOPE_SERVICE)
+	require.NoError(t, err)
+	require.Equal(t, builders.ArgumentsArray(uint64(17)).Raw(), output.OutputArgumentArray.Raw())
+}
+
+func TestSandboxedProcessCall_Errors(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	output, err := h.processCall(deployedContractName, "throw", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, output.CallResult)
+
+	output, err = h.processCall(deployedContractName, "unknownMethod", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	output, err = h.processCall(deployedContractName, "_init", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err, "system methods should only be callable by system contracts")
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+}
+
+func TestSandboxedGetContractAbi_DescribesDeployedContractInChildProcess(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	contractAbi, err := h.service.(processor.ContractAbiProvider).GetContractAbi(context.Background(), primitives.ExecutionContextId("ctx"), deployedContractName)
+	require.NoError(t, err)
+	require.Equal(t, deployedContractName, contractAbi.Name)
+	require.Len(t, contractAbi.Methods, 7)
+	require.Equal(t, []*processor.EventAbi{{Name: "BabyBorn", Arguments: []string{"string", "uint32"}}}, contractAbi.Events)
+}
+
+func TestSandboxedProcessCall_PrebuiltContractsRunInProcess(t *testing.T) {
+	h := newSandboxHarness(t, 10*time.Second)
+	defer h.supervisor.Shutdown()
+
+	output, err := h.processCall(benchmarkcontract.CONTRACT_NAME, "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1), uint64(2))
+	require.NoError(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, output.CallResult)
+	require.Zero(t, h.compiler.compilations)
+}
@@ -0,0 +1,487 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package sanitizer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// packages whose use makes validators disagree even when they are whitelisted (such as crypto/rand under crypto/*)
+var nonDeterministicImports = map[string]string{
+	"unsafe":      "unsafe memory access",
+	"reflect":     "reflection",
+	"math/rand":   "random numbers",
+	"crypto/rand": "random numbers",
+	"runtime":     "runtime introspection",
+}
+
+var floatingPointTypes = map[string]bool{
+	"float32":    true,
+	"float64":    true,
+	"complex64":  true,
+	"complex128": true,
+}
+
+var floatingPointFunctions = map[string][]string{
+	"strconv": {"ParseFloat", "FormatFloat", "AppendFloat"},
+	"big":     {"NewFloat", "ParseFloat"},
+}
+
+type violation struct {
+	pos     token.Pos
+	message string
+}
+
+// DeterminismError lists every construct which could make validators executing the contract reach different results,
+// each prefixed with its line and column in the deployed source
+type DeterminismError struct {
+	Violations []string
+}
+
+func (e *DeterminismError) Error() string {
+	return "non-deterministic code: " + strings.Join(e.Violations, "; ")
+}
+
+// the analysis is syntactic since deployed code cannot be type checked without its dependencies, so map types and
+// map typed variables are tracked by name across the file
+type determinismAnalyzer struct {
+	fset       *token.FileSet
+	violations []violation
+
+	mapTypes     map[string]bool // named types declared as maps
+	mapVariables map[string]bool // package level variables, struct fields and functions returning maps
+	functions    map[string]*ast.FuncDecl
+}
+
+func (s *Sanitizer) verifyDeterminism(fset *token.FileSet, astFile *ast.File) error {
+	a := &determinismAnalyzer{
+		fset:         fset,
+		mapTypes:     make(map[string]bool),
+		mapVariables: make(map[string]bool),
+		functions:    make(map[string]*ast.FuncDecl),
+	}
+
+	a.verifyImports(astFile)
+	a.collectDeclarations(astFile)
+	a.verifyFloatingPoint(astFile)
+	for _, function := range a.functions {
+		a.verifyMapIteration(function)
+	}
+	a.verifyRecursion()
+
+	if len(a.violations) == 0 {
+		return nil
+	}
+
+	sort.Slice(a.violations, func(i, j int) bool {
+		return a.violations[i].pos < a.violations[j].pos
+	})
+	err := &DeterminismError{}
+	for _, v := range a.violations {
+		position := a.fset.Position(v.pos)
+		err.Violations = append(err.Violations, fmt.Sprintf("%d:%d: %s", position.Line, position.Column, v.message))
+	}
+	return err
+}
+
+func (a *determinismAnalyzer) report(pos token.Pos, format string, args ...interface{}) {
+	a.violations = append(a.violations, violation{pos: pos, message: fmt.Sprintf(format, args...)})
+}
+
+func (a *determinismAnalyzer) verifyImports(astFile *ast.File) {
+	for _, importSpec := range astFile.Imports {
+		importPath, _ := strconv.Unquote(importSpec.Path.Value)
+		if reason, found := nonDeterministicImports[importPath]; found {
+			a.report(importSpec.Pos(), "import of %s not allowed (%s)", importPath, reason)
+		}
+	}
+}
+
+func (a *determinismAnalyzer) collectDeclarations(astFile *ast.File) {
+	for _, decl := range astFile.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.TYPE {
+			for _, spec := range genDecl.Specs {
+				if typeSpec := spec.(*ast.TypeSpec); isMapTypeExpr(typeSpec.Type, nil) {
+					a.mapTypes[typeSpec.Name.Name] = true
+				}
+			}
+		}
+	}
+
+	for _, decl := range astFile.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			ast.Inspect(decl, func(node ast.Node) bool {
+				switch node := node.(type) {
+				case *ast.ValueSpec:
+					a.collectValueSpec(node, a.mapVariables)
+				case *ast.Field: // struct fields
+					if isMapTypeExpr(node.Type, a.mapTypes) {
+						for _, name := range node.Names {
+							a.mapVariables[name.Name] = true
+						}
+					}
+				}
+				return true
+			})
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				a.functions[decl.Name.Name] = decl
+			} else {
+				a.functions[receiverTypeName(decl)+"."+decl.Name.Name] = decl
+			}
+			if decl.Type.Results != nil && len(decl.Type.Results.List) == 1 && isMapTypeExpr(decl.Type.Results.List[0].Type, a.mapTypes) {
+				a.mapVariables[decl.Name.Name] = true
+			}
+		}
+	}
+}
+
+func (a *determinismAnalyzer) collectValueSpec(spec *ast.ValueSpec, variables map[string]bool) {
+	for i, name := range spec.Names {
+		if spec.Type != nil {
+			variables[name.Name] = isMapTypeExpr(spec.Type, a.mapTypes)
+		} else if i < len(spec.Values) {
+			variables[name.Name] = a.isMapValue(spec.Values[i], variables)
+		}
+	}
+}
+
+func (a *determinismAnalyzer) verifyFloatingPoint(astFile *ast.File) {
+	ast.Inspect(astFile, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.ImportSpec:
+			return false
+		case *ast.BasicLit:
+			if node.Kind == token.FLOAT || node.Kind == token.IMAG {
+				a.report(node.Pos(), "floating point literal %s not allowed", node.Value)
+			}
+		case *ast.Ident:
+			if floatingPointTypes[node.Name] {
+				a.report(node.Pos(), "floating point type %s not allowed", node.Name)
+			}
+		case *ast.SelectorExpr:
+			if pkg, ok := node.X.(*ast.Ident); ok {
+				for _, f := range floatingPointFunctions[pkg.Name] {
+					if node.Sel.Name == f {
+						a.report(node.Pos(), "floating point function %s.%s not allowed", pkg.Name, f)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// ranging over a map visits keys in a random order, the loop is only accepted when its result cannot depend on that
+// order: it collects into slices sorted later in the function, accumulates with commutative operators, copies into
+// another map by the same key or deletes
+func (a *determinismAnalyzer) verifyMapIteration(function *ast.FuncDecl) {
+	if function.Body == nil {
+		return
+	}
+
+	locals := make(map[string]bool)
+	if function.Recv != nil {
+		a.collectFields(function.Recv, locals)
+	}
+	a.collectFields(function.Type.Params, locals)
+	a.collectFields(function.Type.Results, locals)
+
+	sorted := sortedSlices(function.Body)
+
+	ast.Inspect(function.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.DeclStmt:
+			if genDecl, ok := node.Decl.(*ast.GenDecl); ok {
+				for _, spec := range genDecl.Specs {
+					if valueSpec, ok := spec.(*ast.ValueSpec); ok {
+						a.collectValueSpec(valueSpec, locals)
+					}
+				}
+			}
+		case *ast.AssignStmt:
+			if node.Tok == token.DEFINE && len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					if ident, ok := lhs.(*ast.Ident); ok {
+						locals[ident.Name] = a.isMapValue(node.Rhs[i], locals)
+					}
+				}
+			}
+		case *ast.RangeStmt:
+			if a.isMapValue(node.X, locals) && !isOrderIndependent(node, sorted) {
+				a.report(node.Pos(), "iteration order over map %s is random and affects the result, iterate over sorted keys instead", exprString(node.X))
+			}
+		}
+		return true
+	})
+}
+
+func (a *determinismAnalyzer) collectFields(fields *ast.FieldList, variables map[string]bool) {
+	if fields == nil {
+		return
+	}
+	for _, field := range fields.List {
+		for _, name := range field.Names {
+			variables[name.Name] = isMapTypeExpr(field.Type, a.mapTypes)
+		}
+	}
+}
+
+func (a *determinismAnalyzer) isMapValue(expr ast.Expr, locals map[string]bool) bool {
+	switch expr := expr.(type) {
+	case *ast.ParenExpr:
+		return a.isMapValue(expr.X, locals)
+	case *ast.Ident:
+		if isMap, declared := locals[expr.Name]; declared {
+			return isMap
+		}
+		return a.mapVariables[expr.Name]
+	case *ast.SelectorExpr:
+		return a.mapVariables[expr.Sel.Name]
+	case *ast.CompositeLit:
+		return isMapTypeExpr(expr.Type, a.mapTypes)
+	case *ast.CallExpr:
+		if ident, ok := expr.Fun.(*ast.Ident); ok && ident.Name == "make" && len(expr.Args) > 0 {
+			return isMapTypeExpr(expr.Args[0], a.mapTypes)
+		}
+		if isMapTypeExpr(expr.Fun, a.mapTypes) { // conversion
+			return true
+		}
+		return a.isMapValue(expr.Fun, nil)
+	}
+	return false
+}
+
+func isMapTypeExpr(expr ast.Expr, mapTypes map[string]bool) bool {
+	switch expr := expr.(type) {
+	case *ast.MapType:
+		return true
+	case *ast.Ident:
+		return mapTypes[expr.Name]
+	case *ast.ParenExpr:
+		return isMapTypeExpr(expr.X, mapTypes)
+	}
+	return false
+}
+
+func isOrderIndependent(loop *ast.RangeStmt, sorted map[string]bool) bool {
+	key := ""
+	if ident, ok := loop.Key.(*ast.Ident); ok {
+		key = ident.Name
+	}
+
+	for _, stmt := range loop.Body.List {
+		switch stmt := stmt.(type) {
+		case *ast.IncDecStmt:
+			continue
+		case *ast.ExprStmt:
+			if call, ok := stmt.X.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "delete" {
+					continue
+				}
+			}
+			return false
+		case *ast.AssignStmt:
+			if !isOrderIndependentAssignment(stmt, key, sorted) {
+				return false
+			}
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func isOrderIndependentAssignment(stmt *ast.AssignStmt, key string, sorted map[string]bool) bool {
+	switch stmt.Tok {
+	case token.ADD_ASSIGN, token.MUL_ASSIGN, token.OR_ASSIGN, token.AND_ASSIGN, token.XOR_ASSIGN:
+		return true
+	case token.ASSIGN:
+		if len(stmt.Lhs) != 1 || len(stmt.Rhs) != 1 {
+			return false
+		}
+		if index, ok := stmt.Lhs[0].(*ast.IndexExpr); ok {
+			ident, ok := index.Index.(*ast.Ident)
+			return ok && key != "" && ident.Name == key
+		}
+		if call, ok := stmt.Rhs[0].(*ast.CallExpr); ok {
+			fun, ok := call.Fun.(*ast.Ident)
+			return ok && fun.Name == "append" && sorted[exprString(stmt.Lhs[0])] && exprString(call.Args[0]) == exprString(stmt.Lhs[0])
+		}
+	}
+	return false
+}
+
+// slices passed to the sort package anywhere in the function body
+func sortedSlices(body *ast.BlockStmt) map[string]bool {
+	sorted := make(map[string]bool)
+	ast.Inspect(body, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok && len(call.Args) > 0 {
+			if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+				if pkg, ok := selector.X.(*ast.Ident); ok && pkg.Name == "sort" {
+					arg := call.Args[0]
+					if conversion, ok := arg.(*ast.CallExpr); ok && len(conversion.Args) == 1 { // sort.Sort(sort.StringSlice(keys))
+						arg = conversion.Args[0]
+					}
+					sorted[exprString(arg)] = true
+				}
+			}
+		}
+		return true
+	})
+	return sorted
+}
+
+// a call which closes a cycle in the call graph must pass a parameter of the caller reduced by a constant (n-1, n/2)
+// and the caller must check that parameter, otherwise the recursion depth depends on state and may exhaust the stack
+func (a *determinismAnalyzer) verifyRecursion() {
+	var names []string
+	for name := range a.functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		function := a.functions[name]
+		if function.Body == nil {
+			continue
+		}
+		ast.Inspect(function.Body, func(node ast.Node) bool {
+			call, ok := node.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			callee := a.calleeOf(function, call)
+			if callee == "" || !a.reaches(callee, name) {
+				return true
+			}
+			if !isBoundedCall(function, call) {
+				a.report(call.Pos(), "recursion without a bound in %s, pass a decreasing depth parameter and check it", function.Name.Name)
+			}
+			return true
+		})
+	}
+}
+
+func (a *determinismAnalyzer) calleeOf(caller *ast.FuncDecl, call *ast.CallExpr) string {
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if _, found := a.functions[fun.Name]; found {
+			return fun.Name
+		}
+	case *ast.SelectorExpr:
+		// methods are resolved by name on the caller's receiver type only
+		if caller.Recv != nil {
+			name := receiverTypeName(caller) + "." + fun.Sel.Name
+			if _, found := a.functions[name]; found {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+func (a *determinismAnalyzer) reaches(from string, to string) bool {
+	visited := make(map[string]bool)
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		if name == to {
+			return true
+		}
+		if visited[name] {
+			return false
+		}
+		visited[name] = true
+		function := a.functions[name]
+		if function.Body == nil {
+			return false
+		}
+		found := false
+		ast.Inspect(function.Body, func(node ast.Node) bool {
+			if found {
+				return false
+			}
+			if call, ok := node.(*ast.CallExpr); ok {
+				if callee := a.calleeOf(function, call); callee != "" && visit(callee) {
+					found = true
+				}
+			}
+			return !found
+		})
+		return found
+	}
+	return visit(from)
+}
+
+func isBoundedCall(caller *ast.FuncDecl, call *ast.CallExpr) bool {
+	params := make(map[string]bool)
+	for _, field := range caller.Type.Params.List {
+		for _, name := range field.Names {
+			params[name.Name] = true
+		}
+	}
+
+	for _, arg := range call.Args {
+		binary, ok := arg.(*ast.BinaryExpr)
+		if !ok || (binary.Op != token.SUB && binary.Op != token.QUO && binary.Op != token.SHR) {
+			continue
+		}
+		param, ok := binary.X.(*ast.Ident)
+		if _, constant := binary.Y.(*ast.BasicLit); ok && constant && params[param.Name] && isChecked(caller.Body, param.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isChecked(body *ast.BlockStmt, param string) bool {
+	checked := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		if ifStmt, ok := node.(*ast.IfStmt); ok {
+			ast.Inspect(ifStmt.Cond, func(node ast.Node) bool {
+				if ident, ok := node.(*ast.Ident); ok && ident.Name == param {
+					checked = true
+				}
+				return !checked
+			})
+		}
+		return !checked
+	})
+	return checked
+}
+
+func receiverTypeName(function *ast.FuncDecl) string {
+	expr := function.Recv.List[0].Type
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	return exprString(expr)
+}
+
+func exprString(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.SelectorExpr:
+		return exprString(expr.X) + "." + expr.Sel.Name
+	case *ast.ParenExpr:
+		return exprString(expr.X)
+	case *ast.StarExpr:
+		return "*" + exprString(expr.X)
+	case *ast.CallExpr:
+		return exprString(expr.Fun) + "()"
+	case *ast.IndexExpr:
+		return exprString(expr.X) + "[]"
+	}
+	return fmt.Sprintf("%T", expr)
+}
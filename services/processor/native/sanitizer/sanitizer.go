@@ -47,6 +47,24 @@ func (s *Sanitizer) Process(code string) (string, error) {
 	return resBuffer.String(), nil
 }
 
+// VerifyDeterminism audits code submitted for deployment or upgrade. It is deliberately not part of Process, which runs
+// on every compilation, so contracts deployed before the audit existed keep compiling after an upgrade or a resync
+func (s *Sanitizer) VerifyDeterminism(code string) error {
+	fset := token.NewFileSet()
+
+	astFile, err := parser.ParseFile(fset, "", code, 0)
+	if err != nil {
+		return errors.Wrap(err, "native code verifier cannot parse source file")
+	}
+
+	err = s.verifyDeterminism(fset, astFile)
+	if err != nil {
+		return errors.Wrap(err, "native code verification error")
+	}
+
+	return nil
+}
+
 func (s *Sanitizer) verifyAll(astFile *ast.File) error {
 	allowedPrefixes := s.config.AllowedPrefixes()
 	err := s.verifyImports(astFile, allowedPrefixes)
@@ -34,3 +34,10 @@ func SanitizerConfigWithWildcardForTests() *sanitizer.SanitizerConfig {
 		},
 	}
 }
+
+func SanitizerConfigForDeterminismTests() *sanitizer.SanitizerConfig {
+	config := SanitizerConfigForTests()
+	config.ImportWhitelist[`"sort"`] = "test"
+	config.ImportWhitelist[`"crypto/*"`] = "test"
+	return config
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sanitizer"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sanitizer/test/usecases"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCodeWithMapIteration(t *testing.T) {
+	source := usecases.IterateOverMap
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.Error(t, err)
+	require.Equal(t, `native code verification error: non-deterministic code: 18:2: iteration order over map balances is random and affects the result, iterate over sorted keys instead`, err.Error())
+}
+
+func TestCodeWithOrderIndependentMapIteration(t *testing.T) {
+	source := usecases.IterateOverMapInOrderIndependentWay
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.NoError(t, err)
+}
+
+func TestCodeWithFloatingPoint(t *testing.T) {
+	source := usecases.FloatingPoint
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.Error(t, err)
+	require.Equal(t, `native code verification error: non-deterministic code: 15:10: floating point literal 1.05 not allowed; 16:46: floating point type float64 not allowed`, err.Error())
+}
+
+func TestCodeWithRandomNumbersFromWhitelistedPackage(t *testing.T) {
+	source := usecases.RandomNumbers
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.Error(t, err)
+	require.Equal(t, `native code verification error: non-deterministic code: 4:2: import of crypto/rand not allowed (random numbers)`, err.Error())
+}
+
+func TestCodeWithUnboundedRecursion(t *testing.T) {
+	source := usecases.UnboundedRecursion
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.Error(t, err)
+	require.Equal(t, `native code verification error: non-deterministic code: 17:3: recursion without a bound in walk, pass a decreasing depth parameter and check it; 22:2: recursion without a bound in walkNext, pass a decreasing depth parameter and check it`, err.Error())
+}
+
+func TestCodeWithBoundedRecursion(t *testing.T) {
+	source := usecases.BoundedRecursion
+	err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).VerifyDeterminism(source)
+	require.NoError(t, err)
+}
+
+func TestProcessDoesNotAuditDeterminismOfDeployedCode(t *testing.T) {
+	source := usecases.FloatingPoint
+	output, err := sanitizer.NewSanitizer(SanitizerConfigForDeterminismTests()).Process(source)
+	require.NoError(t, err, "contracts deployed before the determinism audit should keep compiling")
+	require.Equal(t, source, output, "valid file content should not be altered by sanitizer")
+}
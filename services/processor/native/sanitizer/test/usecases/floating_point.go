@@ -0,0 +1,26 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package usecases
+
+const FloatingPoint = `package main
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+var PUBLIC = sdk.Export(interest)
+var SYSTEM = sdk.Export(_init)
+
+func _init() {
+}
+
+func interest(amount uint64) {
+	rate := 1.05
+	state.WriteUint64([]byte("balance"), uint64(float64(amount)*rate))
+}
+`
@@ -0,0 +1,64 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package usecases
+
+const IterateOverMap = `package main
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+var PUBLIC = sdk.Export(pay)
+var SYSTEM = sdk.Export(_init)
+
+type Balances map[string]uint64
+
+func _init() {
+}
+
+func pay() {
+	balances := Balances{"alice": 1, "bob": 2}
+	for name, amount := range balances {
+		state.WriteUint64([]byte("last"), amount)
+		state.WriteString([]byte("last-name"), name)
+	}
+}
+`
+
+const IterateOverMapInOrderIndependentWay = `package main
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+	"sort"
+)
+
+var PUBLIC = sdk.Export(pay)
+var SYSTEM = sdk.Export(_init)
+
+var balances = map[string]uint64{"alice": 1, "bob": 2}
+
+func _init() {
+}
+
+func pay() {
+	var names []string
+	total := uint64(0)
+	copied := make(map[string]uint64)
+	for name, amount := range balances {
+		names = append(names, name)
+		total += amount
+		copied[name] = amount
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		state.WriteUint64([]byte(name), copied[name])
+	}
+	state.WriteUint64([]byte("total"), total)
+}
+`
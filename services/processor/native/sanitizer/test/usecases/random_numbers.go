@@ -0,0 +1,28 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package usecases
+
+const RandomNumbers = `package main
+
+import (
+	"crypto/rand"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+var PUBLIC = sdk.Export(lottery)
+var SYSTEM = sdk.Export(_init)
+
+func _init() {
+}
+
+func lottery() {
+	winner := make([]byte, 1)
+	rand.Read(winner)
+	state.WriteBytes([]byte("winner"), winner)
+}
+`
@@ -0,0 +1,55 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package usecases
+
+const UnboundedRecursion = `package main
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+var PUBLIC = sdk.Export(walk)
+var SYSTEM = sdk.Export(_init)
+
+func _init() {
+}
+
+func walk(key string) {
+	next := state.ReadString([]byte(key))
+	if next != "" {
+		walkNext(next)
+	}
+}
+
+func walkNext(key string) {
+	walk(key)
+}
+`
+
+const BoundedRecursion = `package main
+
+import (
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/state"
+)
+
+var PUBLIC = sdk.Export(fib)
+var SYSTEM = sdk.Export(_init)
+
+func _init() {
+}
+
+func fib(n uint32) uint64 {
+	if n < 2 {
+		return uint64(n)
+	}
+	result := fib(n-1) + fib(n-2)
+	state.WriteUint64([]byte("fib"), result)
+	return result
+}
+`
@@ -13,6 +13,7 @@ import (
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/adapter"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/types"
@@ -40,6 +41,7 @@ type service struct {
 
 	cache *contractCache
 
+	prebuilt            Repository
 	repository          Repository
 	compilingRepository *CompilingRepository //TODO remove when refactor is done
 
@@ -52,7 +54,7 @@ type metrics struct {
 
 func getMetrics(m metric.Factory) *metrics {
 	return &metrics{
-		processCallTime: m.NewLatency("Processor.Native.ProcessCallTime.Millis", 10*time.Second),
+		processCallTime: m.NewLatency("Processor.Native.ProcessCallTime.Millis", 10*time.Second), // not labeled by contract, anyone can deploy contracts so the label values are unbounded
 	}
 }
 
@@ -60,9 +62,11 @@ func NewNativeProcessor(compiler adapter.Compiler, config config.NativeProcessor
 	logger := parentLogger.WithTags(LogTag)
 
 	compilingRepository := NewCompilingRepository(compiler, config, parentLogger, metricFactory)
-	compositeRepository := &CompositeRepository{Nested: []Repository{repository.NewPrebuilt(), compilingRepository}}
+	prebuilt := repository.NewPrebuilt()
+	compositeRepository := &CompositeRepository{Nested: []Repository{prebuilt, compilingRepository}}
 
 	return &service{
+		prebuilt:            prebuilt,
 		repository:          compositeRepository,
 		compilingRepository: compilingRepository,
 		config:              config,
@@ -74,9 +78,11 @@ func NewNativeProcessor(compiler adapter.Compiler, config config.NativeProcessor
 
 func NewProcessorWithContractRepository(repo Repository, config config.NativeProcessorConfig, parentLogger log.Logger, metricFactory metric.Factory) services.Processor {
 	logger := parentLogger.WithTags(LogTag)
-	compositeRepository := &CompositeRepository{Nested: []Repository{repository.NewPrebuilt(), repo}}
+	prebuilt := repository.NewPrebuilt()
+	compositeRepository := &CompositeRepository{Nested: []Repository{prebuilt, repo}}
 
 	return &service{
+		prebuilt:   prebuilt,
 		repository: compositeRepository,
 		config:     config,
 		logger:     logger,
@@ -96,8 +102,14 @@ func (s *service) RegisterContractSdkCallHandler(handler handlers.ContractSdkCal
 func (s *service) ProcessCall(ctx context.Context, input *services.ProcessCallInput) (*services.ProcessCallOutput, error) {
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
+	if s.compilingRepository != nil {
+		if err := s.compilingRepository.auditDeployment(ctx, input); err != nil {
+			return auditFailedOutput(err), err
+		}
+	}
+
 	// retrieve code
-	contractInfo, err := s.retrieveContractInfo(ctx, input.ContextId, string(input.ContractName))
+	contractInfo, key, err := s.retrieveContractInfo(ctx, input.ContextId, string(input.ContractName))
 	if err != nil {
 		return &services.ProcessCallOutput{
 			// TODO(https://github.com/orbs-network/orbs-spec/issues/97): do we need to remove system errors from OutputArguments?
@@ -107,7 +119,7 @@ func (s *service) ProcessCall(ctx context.Context, input *services.ProcessCallIn
 	}
 
 	// get the method and check permissions
-	contractInstance, methodInstance, err := s.retrieveContractAndMethodInstances(contractInfo, string(input.ContractName), string(input.MethodName), input.CallingPermissionScope)
+	contractInstance, methodInstance, err := s.retrieveContractAndMethodInstances(contractInfo, key, string(input.MethodName), input.CallingPermissionScope)
 	if err != nil {
 		return &services.ProcessCallOutput{
 			// TODO(https://github.com/orbs-network/orbs-spec/issues/97): do we need to remove system errors from OutputArguments?
@@ -156,7 +168,7 @@ func (s *service) ProcessCall(ctx context.Context, input *services.ProcessCallIn
 
 func (s *service) GetContractInfo(ctx context.Context, input *services.GetContractInfoInput) (*services.GetContractInfoOutput, error) {
 	// retrieve code
-	contractInfo, err := s.retrieveContractInfo(ctx, input.ContextId, string(input.ContractName))
+	contractInfo, _, err := s.retrieveContractInfo(ctx, input.ContextId, string(input.ContractName))
 	if err != nil {
 		return nil, err
 	}
@@ -167,49 +179,91 @@ func (s *service) GetContractInfo(ctx context.Context, input *services.GetContra
 	}, nil
 }
 
-func (s *service) retrieveContractAndMethodInstances(contractInfo *sdkContext.ContractInfo, contractName string, methodName string, permissionScope protocol.ExecutionPermissionScope) (*types.ContractInstance, types.MethodInstance, error) {
-	contractInstance, err := s.getContractInstance(contractInfo, contractName)
+func (s *service) GetContractAbi(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	contractInfo, _, err := s.retrieveContractInfo(ctx, executionContextId, string(contractName))
+	if err != nil {
+		return nil, err
+	}
+
+	contractAbi, err := newContractAbi(contractInfo)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed describing contract %s", contractName)
+	}
+	contractAbi.Name = string(contractName)
+	return contractAbi, nil
+}
+
+func (s *service) retrieveContractAndMethodInstances(contractInfo *sdkContext.ContractInfo, key contractKey, methodName string, permissionScope protocol.ExecutionPermissionScope) (*types.ContractInstance, types.MethodInstance, error) {
+	contractInstance, err := s.getContractInstance(contractInfo, key)
+	if err != nil {
+		return nil, nil, errors.Errorf("error creating contract instance for contract %s", key.name)
+	}
+
+	methodInstance, err := retrieveMethodInstance(contractInstance, key.name, methodName, permissionScope)
 	if err != nil {
-		return nil, nil, errors.Errorf("error creating contract instance for contract %s", contractName)
+		return nil, nil, err
 	}
+	return contractInstance, methodInstance, nil
+}
 
+func retrieveMethodInstance(contractInstance *types.ContractInstance, contractName string, methodName string, permissionScope protocol.ExecutionPermissionScope) (types.MethodInstance, error) {
 	methodInstance, found := contractInstance.PublicMethods[methodName]
 	if found {
-		return contractInstance, methodInstance, nil
+		return methodInstance, nil
 	}
 
 	methodInstance, found = contractInstance.SystemMethods[methodName]
 	if found {
 		if permissionScope == protocol.PERMISSION_SCOPE_SYSTEM {
-			return contractInstance, methodInstance, nil
+			return methodInstance, nil
 		} else {
-			return nil, nil, errors.Errorf("only system contracts can run method '%s'", methodName)
+			return nil, errors.Errorf("only system contracts can run method '%s'", methodName)
 		}
 	}
 
-	return nil, nil, errors.Errorf("method '%s' not found on contract '%s'", methodName, contractName)
+	return nil, errors.Errorf("method '%s' not found on contract '%s'", methodName, contractName)
 }
 
-func (s *service) retrieveContractInfo(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (*sdkContext.ContractInfo, error) {
-	contractInfo := s.cache.infoByName(contractName)
+// every version of a deployed contract is cached on its own since calls at heights before an upgrade still run the previous code
+// the version is read from the state of the execution context, so an upgrade running in a proposal, query or simulation
+// which is never committed does not change the code other executions run
+func (s *service) retrieveContractInfo(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (*sdkContext.ContractInfo, contractKey, error) {
+	version, err := s.contractVersion(ctx, executionContextId, contractName)
+	if err != nil {
+		return nil, contractKey{}, err
+	}
+	key := contractKey{name: contractName, version: version}
+
+	contractInfo := s.cache.infoByKey(key)
 	if contractInfo != nil {
-		return contractInfo, nil
+		return contractInfo, key, nil
 	}
 
-	contractInfo, err := s.repository.ContractInfo(ctx, executionContextId, contractName)
+	contractInfo, err = s.repository.ContractInfo(ctx, executionContextId, contractName)
 	if err != nil {
-		return nil, err
+		return nil, key, err
 	}
 	if contractInfo == nil {
-		return nil, errors.Errorf("Contract %s was not found", contractName)
+		return nil, key, errors.Errorf("Contract %s was not found", contractName)
 	}
 
-	s.cache.addInfo(contractName, contractInfo)
-	return contractInfo, err
+	s.cache.addInfo(key, contractInfo)
+	return contractInfo, key, err
 }
 
-func (s *service) getContractInstance(contractInfo *sdkContext.ContractInfo, contractName string) (*types.ContractInstance, error) {
-	contractInstance := s.cache.instanceByNam(contractName)
+// pre-built contracts cannot be upgraded, other repositories than the compiling one do not support upgrades
+func (s *service) contractVersion(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (uint32, error) {
+	if s.compilingRepository == nil {
+		return 0, nil
+	}
+	if prebuiltInfo, _ := s.prebuilt.ContractInfo(ctx, executionContextId, contractName); prebuiltInfo != nil {
+		return 0, nil
+	}
+	return s.compilingRepository.ContractVersion(ctx, executionContextId, contractName)
+}
+
+func (s *service) getContractInstance(contractInfo *sdkContext.ContractInfo, key contractKey) (*types.ContractInstance, error) {
+	contractInstance := s.cache.instanceByKey(key)
 	if contractInstance != nil {
 		return contractInstance, nil
 	}
@@ -218,47 +272,52 @@ func (s *service) getContractInstance(contractInfo *sdkContext.ContractInfo, con
 	if err != nil {
 		return nil, err
 	}
-	s.cache.addInstance(contractName, contractInstance)
+	s.cache.addInstance(key, contractInstance)
 	return contractInstance, nil
 }
 
-func (c *contractCache) infoByName(contractName string) *sdkContext.ContractInfo {
+func (c *contractCache) infoByKey(key contractKey) *sdkContext.ContractInfo {
 	c.RLock()
 	defer c.RUnlock()
 
-	return c.contractInfo[contractName]
+	return c.contractInfo[key]
 }
 
-func (c *contractCache) addInfo(contractName string, contractInfo *sdkContext.ContractInfo) {
+func (c *contractCache) addInfo(key contractKey, contractInfo *sdkContext.ContractInfo) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.contractInfo[contractName] = contractInfo
+	c.contractInfo[key] = contractInfo
 }
 
-func (c *contractCache) instanceByNam(contractName string) *types.ContractInstance {
+func (c *contractCache) instanceByKey(key contractKey) *types.ContractInstance {
 	c.RLock()
 	defer c.RUnlock()
 
-	return c.contractInstances[contractName]
+	return c.contractInstances[key]
 }
 
-func (c *contractCache) addInstance(contractName string, contractInstance *types.ContractInstance) {
+func (c *contractCache) addInstance(key contractKey, contractInstance *types.ContractInstance) {
 	c.Lock()
 	defer c.Unlock()
 
-	c.contractInstances[contractName] = contractInstance
+	c.contractInstances[key] = contractInstance
+}
+
+type contractKey struct {
+	name    string
+	version uint32
 }
 
 type contractCache struct {
 	sync.RWMutex
-	contractInfo      map[string]*sdkContext.ContractInfo
-	contractInstances map[string]*types.ContractInstance
+	contractInfo      map[contractKey]*sdkContext.ContractInfo
+	contractInstances map[contractKey]*types.ContractInstance
 }
 
 func newContractCache() *contractCache {
 	return &contractCache{
-		contractInfo:      make(map[string]*sdkContext.ContractInfo),
-		contractInstances: make(map[string]*types.ContractInstance),
+		contractInfo:      make(map[contractKey]*sdkContext.ContractInfo),
+		contractInstances: make(map[contractKey]*types.ContractInstance),
 	}
 }
@@ -88,6 +88,12 @@ func (p *processCall) WithSystemMethod() *processCall {
 	return p.WithArgs()
 }
 
+func (p *processCall) WithEvent() *processCall {
+	p.input.ContractName = "BenchmarkContract"
+	p.input.MethodName = "BabyBorn"
+	return p.WithArgs("Alice", uint32(3))
+}
+
 func (p *processCall) WithSystemPermissions() *processCall {
 	p.input.CallingPermissionScope = protocol.PERMISSION_SCOPE_SYSTEM
 	return p
@@ -8,7 +8,9 @@ package test
 
 import (
 	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -68,3 +70,30 @@ func TestProcessCall_Permissions(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessCall_TriggersRunsScheduledCallsOnlyForTheSystem(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			for _, methodName := range []primitives.MethodName{triggers_systemcontract.METHOD_GET_DUE_CALLS, triggers_systemcontract.METHOD_RUN_SCHEDULED} {
+				_, err := h.service.ProcessCall(ctx, ProcessCallInput().WithMethod(triggers_systemcontract.CONTRACT_NAME, methodName).Build())
+				require.Error(t, err, "clients should not be able to call %s", methodName)
+				require.Contains(t, err.Error(), "only system contracts can run method")
+			}
+		})
+	})
+}
+
+// events only declare the signature of what a contract emits, they were registered as system methods by mistake
+func TestProcessCall_EventsCannotBeCalledEvenBySystem(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			_, err := h.service.ProcessCall(ctx, ProcessCallInput().WithEvent().WithSystemPermissions().Build())
+			require.Error(t, err, "events should not be callable")
+			require.Contains(t, err.Error(), "method 'BabyBorn' not found")
+		})
+	})
+}
@@ -0,0 +1,63 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/BenchmarkContract"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetContractAbi_DescribesMethodsAndEvents(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			contractAbi, err := h.service.(processor.ContractAbiProvider).GetContractAbi(ctx, []byte{0x01}, benchmarkcontract.CONTRACT_NAME)
+			require.NoError(t, err)
+
+			require.Equal(t, &processor.ContractAbi{
+				Name:       benchmarkcontract.CONTRACT_NAME,
+				Permission: "PERMISSION_SCOPE_SERVICE",
+				Methods: []*processor.MethodAbi{
+					{Name: "_init", Permission: processor.ABI_PERMISSION_SYSTEM, Arguments: []string{}, Returns: []string{}},
+					{Name: "add", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"uint64", "uint64"}, Returns: []string{"uint64"}},
+					{Name: "argTypes", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"uint32", "uint64", "string", "bytes"}, Returns: []string{"uint32", "uint64", "string", "bytes"}},
+					{Name: "get", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{}, Returns: []string{"uint64"}},
+					{Name: "giveBirth", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"string"}, Returns: []string{}},
+					{Name: "set", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{"uint64"}, Returns: []string{}},
+					{Name: "throw", Permission: processor.ABI_PERMISSION_PUBLIC, Arguments: []string{}, Returns: []string{}},
+				},
+				Events: []*processor.EventAbi{
+					{Name: "BabyBorn", Arguments: []string{"string", "uint32"}},
+				},
+			}, contractAbi)
+		})
+	})
+}
+
+func TestGetContractAbi_UnknownContract(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectSdkCallMadeWithCodeVersion("UnknownContract", 0)
+			h.expectSdkCallMadeWithServiceCallMethod(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_CODE_PARTS, builders.ArgumentsArray("UnknownContract"), builders.ArgumentsArray(), errors.New("contract not deployed"))
+
+			_, err := h.service.(processor.ContractAbiProvider).GetContractAbi(ctx, []byte{0x01}, primitives.ContractName("UnknownContract"))
+			require.Error(t, err, "GetContractAbi should fail")
+
+			h.verifySdkCallMade(t)
+		})
+	})
+}
@@ -22,6 +22,7 @@ func TestProcessCall_WithUnknownContractFails(t *testing.T) {
 		with.Logging(t, func(parent *with.LoggingHarness) {
 			h := newHarness(parent.Logger)
 			input := ProcessCallInput().WithUnknownContract().Build()
+			h.expectSdkCallMadeWithCodeVersion(string(input.ContractName), 0)
 			h.expectSdkCallMadeWithServiceCallMethod(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_CODE_PARTS, builders.ArgumentsArray(string(input.ContractName)), builders.ArgumentsArray(), errors.New("contract not deployed"))
 
 			_, err := h.service.ProcessCall(ctx, input)
@@ -37,6 +38,7 @@ func TestGetContractInfo_WithUnknownContractFails(t *testing.T) {
 		with.Logging(t, func(parent *with.LoggingHarness) {
 			h := newHarness(parent.Logger)
 			input := getContractInfoInput().WithUnknownContract().Build()
+			h.expectSdkCallMadeWithCodeVersion(string(input.ContractName), 0)
 			h.expectSdkCallMadeWithServiceCallMethod(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_CODE_PARTS, builders.ArgumentsArray(string(input.ContractName)), builders.ArgumentsArray(), errors.New("contract not deployed"))
 
 			_, err := h.service.GetContractInfo(ctx, input)
@@ -55,6 +57,7 @@ func TestProcessCall_WithDeployableContractThatCompiles(t *testing.T) {
 
 			input := ProcessCallInput().WithDeployableCounterContract(contracts.MOCK_COUNTER_CONTRACT_START_FROM).Build()
 			codeOutput := builders.ArgumentsArray([]byte(contracts.NativeSourceCodeForCounter(contracts.MOCK_COUNTER_CONTRACT_START_FROM)))
+			h.expectSdkCallMadeWithCodeVersion(string(input.ContractName), 1)
 			h.expectSdkCallMadeWithServiceCallMethod(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_CODE_PART, builders.ArgumentsArray(string(input.ContractName), uint32(0)), codeOutput, nil)
 			h.expectSdkCallMadeWithServiceCallMethod(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_CODE_PARTS, builders.ArgumentsArray(string(input.ContractName)), builders.ArgumentsArray(uint32(1)), nil)
 
@@ -0,0 +1,44 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/sanitizer/test/usecases"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDeployService_RejectsNonDeterministicNativeCode(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			call := ProcessCallInput().WithMethod("_Deployments", "deployService").WithArgs("FloatingPoint", uint32(protocol.PROCESSOR_TYPE_NATIVE), []byte(usecases.FloatingPoint)).Build()
+			output, err := h.service.ProcessCall(ctx, call)
+			require.Error(t, err, "deployment should fail")
+			require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, output.CallResult)
+			require.Contains(t, err.Error(), "source code for contract 'FloatingPoint' failed determinism audit")
+			h.verifySdkCallMade(t) // nothing was written to state
+		})
+	})
+}
+
+func TestDeployService_DoesNotAuditEmptyCodeOfPreBuiltContracts(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			call := ProcessCallInput().WithMethod("_Deployments", "deployService").WithArgs("BenchmarkToken", uint32(protocol.PROCESSOR_TYPE_NATIVE), []byte{}).Build()
+			_, err := h.service.ProcessCall(ctx, call)
+			require.NotContains(t, fmt.Sprint(err), "determinism audit", "lazy deployment of a pre-built contract should not be audited")
+		})
+	})
+}
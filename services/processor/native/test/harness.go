@@ -14,6 +14,7 @@ import (
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/services/processor/native"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/adapter/fake"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
 	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -29,6 +30,7 @@ type harness struct {
 	sdkCallHandler *handlers.MockContractSdkCallHandler
 	service        services.Processor
 	compiler       *fake.FakeCompiler
+	metricRegistry metric.Registry
 }
 
 func newHarness(logger log.Logger) *harness {
@@ -48,6 +50,7 @@ func newHarness(logger log.Logger) *harness {
 		sdkCallHandler: sdkCallHandler,
 		service:        service,
 		compiler:       compiler,
+		metricRegistry: registry,
 	}
 }
 
@@ -106,6 +109,27 @@ func (h *harness) expectSdkCallMadeWithServiceCallMethod(expectedContractName st
 	h.sdkCallHandler.When("HandleSdkCall", mock.Any, mock.AnyIf("Contract equals Sdk.Service, method equals callMethod and 3 args match", serviceCallMethodCallMatcher)).Return(returnOutput, returnError).Times(1)
 }
 
+// the version of a deployed contract is read on every call to detect upgrades
+func (h *harness) expectSdkCallMadeWithCodeVersion(expectedContractName string, returnVersion uint32) {
+	codeVersionCallMatcher := func(i interface{}) bool {
+		input, ok := i.(*handlers.HandleSdkCallInput)
+		return ok &&
+			input.OperationName == sdk.SDK_OPERATION_NAME_SERVICE &&
+			input.MethodName == "callMethod" &&
+			len(input.InputArguments) == 3 &&
+			input.InputArguments[0].StringValue() == deployments_systemcontract.CONTRACT_NAME &&
+			input.InputArguments[1].StringValue() == deployments_systemcontract.METHOD_GET_CODE_VERSION &&
+			bytes.Equal(input.InputArguments[2].BytesValue(), builders.ArgumentsArray(expectedContractName).Raw())
+	}
+
+	outputArgs, _ := protocol.ArgumentsFromNatives(builders.VarsToSlice(builders.ArgumentsArray(returnVersion).Raw())) // err ignored because we support argument with type []byte
+	returnOutput := &handlers.HandleSdkCallOutput{
+		OutputArguments: outputArgs,
+	}
+
+	h.sdkCallHandler.When("HandleSdkCall", mock.Any, mock.AnyIf("Contract equals Sdk.Service, method equals callMethod of _Deployments.getCodeVersion", codeVersionCallMatcher)).Return(returnOutput, nil).AtLeast(1)
+}
+
 func (h *harness) expectSdkCallMadeWithAddressGetCaller(returnAddress []byte) {
 	addressGetCallerCallMatcher := func(i interface{}) bool {
 		input, ok := i.(*handlers.HandleSdkCallInput)
@@ -0,0 +1,34 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestProcessCall_RecordsCallTimeOfAllContractsTogether(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			_, err := h.service.ProcessCall(ctx, ProcessCallInput().WithMethod("BenchmarkContract", "add").WithArgs(uint64(12), uint64(27)).Build())
+			require.NoError(t, err, "call should succeed")
+
+			var callTimeMetrics []string
+			for name := range h.metricRegistry.ExportAll() {
+				if strings.Contains(name, "ProcessCallTime") {
+					callTimeMetrics = append(callTimeMetrics, name)
+				}
+			}
+			require.Equal(t, []string{"Processor.Native.ProcessCallTime.Millis"}, callTimeMetrics, "call time should not be split by contract, contract names are unbounded")
+		})
+	})
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package testkit
+
+import (
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type CallResult struct {
+	ExecutionResult    protocol.ExecutionResult
+	OutputArguments    []interface{}
+	OutputEvents       []*Event
+	ContractStateDiffs []*protocol.ContractStateDiff
+}
+
+type Event struct {
+	ContractName string
+	EventName    string
+	Arguments    []interface{}
+}
+
+func newCallResult(executionResult protocol.ExecutionResult, outputArguments *protocol.ArgumentArray, outputEvents *protocol.EventsArray, stateDiffs []*protocol.ContractStateDiff) *CallResult {
+	res := &CallResult{
+		ExecutionResult:    executionResult,
+		OutputArguments:    argumentsToNatives(outputArguments),
+		OutputEvents:       []*Event{},
+		ContractStateDiffs: stateDiffs,
+	}
+	for i := outputEvents.EventsIterator(); i.HasNext(); {
+		event := i.NextEvents()
+		res.OutputEvents = append(res.OutputEvents, &Event{
+			ContractName: string(event.ContractName()),
+			EventName:    string(event.EventName()),
+			Arguments:    argumentsToNatives(protocol.ArgumentArrayReader(event.RawOutputArgumentArrayWithHeader())),
+		})
+	}
+	return res
+}
+
+func argumentsToNatives(argumentArray *protocol.ArgumentArray) []interface{} {
+	natives, err := argumentArray.ToNatives()
+	if err != nil {
+		panic(err.Error())
+	}
+	return natives
+}
+
+func (r *CallResult) RequireSuccess(t testing.TB) {
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, r.ExecutionResult, "call should succeed, output: %v", r.OutputArguments)
+}
+
+// RequirePanic checks the contract panicked with a message containing the given one
+func (r *CallResult) RequirePanic(t testing.TB, message string) {
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, r.ExecutionResult, "call should panic, output: %v", r.OutputArguments)
+	require.Len(t, r.OutputArguments, 1, "a panicking call should output only its error")
+	require.Contains(t, r.OutputArguments[0], message, "call panicked with an unexpected message")
+}
+
+func (r *CallResult) RequireOutput(t testing.TB, expected ...interface{}) {
+	r.RequireSuccess(t)
+	require.Equal(t, expected, r.OutputArguments, "call output should match")
+}
+
+// RequireEvent checks an event with the given name and arguments was emitted, in any order relative to other events
+func (r *CallResult) RequireEvent(t testing.TB, eventName string, expectedArguments ...interface{}) {
+	for _, event := range r.OutputEvents {
+		if event.EventName == eventName {
+			require.Equal(t, expectedArguments, event.Arguments, "arguments of event %s should match", eventName)
+			return
+		}
+	}
+	require.Fail(t, "event was not emitted", "expected event %s, emitted %d other events", eventName, len(r.OutputEvents))
+}
+
+func (r *CallResult) RequireNoEvents(t testing.TB) {
+	require.Empty(t, r.OutputEvents, "call should not emit events")
+}
@@ -0,0 +1,253 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package testkit
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/orbs-network/crypto-lib-go/crypto/digest"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor/native"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const CONTRACT_HARNESS_VIRTUAL_CHAIN_ID = primitives.VirtualChainId(42)
+
+// ContractHarness runs the exported functions of a native contract in process, through the real virtual machine and
+// native processor, against an in-memory state. Signer, block height, timestamp and committee are set by the test and
+// every successful call is committed so the next call sees its state changes
+type ContractHarness struct {
+	contractName string
+
+	repository   *ManualRepository
+	stateStorage *inMemoryStateStorage
+	management   *fixedCommitteeManagement
+	vm           services.VirtualMachine
+
+	signer         primitives.Ed25519PublicKey
+	blockHeight    primitives.BlockHeight
+	blockTimestamp primitives.TimestampNano
+	proposer       primitives.NodeAddress
+}
+
+type contractHarnessConfig struct{}
+
+func (c *contractHarnessConfig) ProcessorSanitizeDeployedContracts() bool {
+	return false
+}
+
+func (c *contractHarnessConfig) VirtualChainId() primitives.VirtualChainId {
+	return CONTRACT_HARNESS_VIRTUAL_CHAIN_ID
+}
+
+func (c *contractHarnessConfig) ManagementNetworkLivenessTimeout() time.Duration {
+	return 0
+}
+
+// NewContractHarness deploys the contract given by its exports (PUBLIC, SYSTEM and EVENTS of the contract package), the
+// system contracts are available as they are on a node
+func NewContractHarness(logger log.Logger, contractName string, publicMethods []interface{}, systemMethods []interface{}, events []interface{}) *ContractHarness {
+	cfg := &contractHarnessConfig{}
+	h := &ContractHarness{
+		contractName:   contractName,
+		repository:     NewRepository(),
+		stateStorage:   newInMemoryStateStorage(),
+		management:     &fixedCommitteeManagement{},
+		signer:         testKeys.Ed25519KeyPairForTests(0).PublicKey(),
+		blockHeight:    1,
+		blockTimestamp: primitives.TimestampNano(time.Now().UnixNano()),
+		proposer:       testKeys.EcdsaSecp256K1KeyPairForTests(0).NodeAddress(),
+	}
+
+	processor := native.NewProcessorWithContractRepository(h.repository, cfg, logger, metric.NewRegistry())
+	processors := map[protocol.ProcessorType]services.Processor{protocol.PROCESSOR_TYPE_NATIVE: processor}
+	h.vm = virtualmachine.NewVirtualMachine(h.stateStorage, processors, nil, h.management, cfg, logger, trace.NewNoopTracer())
+
+	h.RegisterContract(contractName, publicMethods, systemMethods, events)
+	h.updateLastCommittedBlockInfo()
+	return h
+}
+
+// RegisterContract deploys another contract so the contract under test can call it
+func (h *ContractHarness) RegisterContract(contractName string, publicMethods []interface{}, systemMethods []interface{}, events []interface{}) {
+	h.repository.Register(contractName, publicMethods, systemMethods, events)
+
+	processorType := make([]byte, 4)
+	binary.LittleEndian.PutUint32(processorType, uint32(protocol.PROCESSOR_TYPE_NATIVE))
+	h.SetContractState(deployments_systemcontract.CONTRACT_NAME, []byte(contractName+".Processor"), processorType)
+}
+
+// WithSigner sets the public key signing the next calls, see SignerAddress for the address the contract will see
+func (h *ContractHarness) WithSigner(publicKey primitives.Ed25519PublicKey) *ContractHarness {
+	h.signer = publicKey
+	return h
+}
+
+// WithBlockHeight sets the height of the block the next calls run in, queries run on the block before it
+func (h *ContractHarness) WithBlockHeight(blockHeight uint64) *ContractHarness {
+	h.blockHeight = primitives.BlockHeight(blockHeight)
+	h.updateLastCommittedBlockInfo()
+	return h
+}
+
+func (h *ContractHarness) WithBlockTimestamp(timestamp time.Time) *ContractHarness {
+	h.blockTimestamp = primitives.TimestampNano(timestamp.UnixNano())
+	h.updateLastCommittedBlockInfo()
+	return h
+}
+
+func (h *ContractHarness) WithBlockProposer(address primitives.NodeAddress) *ContractHarness {
+	h.proposer = address
+	h.updateLastCommittedBlockInfo()
+	return h
+}
+
+// WithCommittee sets the members returned by both the current and the next block committee
+func (h *ContractHarness) WithCommittee(members ...primitives.NodeAddress) *ContractHarness {
+	h.management.setCommittee(members)
+	return h
+}
+
+func (h *ContractHarness) SignerAddress() primitives.ClientAddress {
+	address, err := digest.CalcClientAddressOfEd25519PublicKey(h.signer)
+	if err != nil {
+		panic(err.Error())
+	}
+	return address
+}
+
+// SetState pre-seeds a key of the contract under test, values are raw bytes as written by the sdk state package
+func (h *ContractHarness) SetState(key []byte, value []byte) {
+	h.SetContractState(h.contractName, key, value)
+}
+
+func (h *ContractHarness) SetContractState(contractName string, key []byte, value []byte) {
+	h.stateStorage.write(primitives.ContractName(contractName), key, value)
+}
+
+func (h *ContractHarness) State(key []byte) []byte {
+	return h.ContractState(h.contractName, key)
+}
+
+func (h *ContractHarness) ContractState(contractName string, key []byte) []byte {
+	return h.stateStorage.read(primitives.ContractName(contractName), key)
+}
+
+func (h *ContractHarness) RequireState(t testing.TB, key []byte, expected []byte) {
+	require.Equal(t, expected, h.State(key), "state of key %s of contract %s", string(key), h.contractName)
+}
+
+// Call runs a method of the contract under test as a transaction and commits its state diffs, a failed call changes nothing
+func (h *ContractHarness) Call(ctx context.Context, methodName string, args ...interface{}) *CallResult {
+	argumentArray, err := protocol.ArgumentArrayFromNatives(args)
+	if err != nil {
+		panic(errors.Wrapf(err, "unsupported argument for %s.%s", h.contractName, methodName).Error())
+	}
+
+	signedTransaction := (&protocol.SignedTransactionBuilder{
+		Transaction: &protocol.TransactionBuilder{
+			ProtocolVersion:    config.MAXIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE,
+			VirtualChainId:     CONTRACT_HARNESS_VIRTUAL_CHAIN_ID,
+			Timestamp:          h.blockTimestamp,
+			Signer:             h.signerBuilder(),
+			ContractName:       primitives.ContractName(h.contractName),
+			MethodName:         primitives.MethodName(methodName),
+			InputArgumentArray: argumentArray.RawArgumentsArray(),
+		},
+	}).Build()
+
+	referenceTime := primitives.TimestampSeconds(time.Duration(h.blockTimestamp) / time.Second)
+	output, err := h.vm.ProcessTransactionSet(ctx, &services.ProcessTransactionSetInput{
+		CurrentBlockHeight:        h.blockHeight,
+		CurrentBlockTimestamp:     h.blockTimestamp,
+		BlockProposerAddress:      h.proposer,
+		CurrentBlockReferenceTime: referenceTime,
+		PrevBlockReferenceTime:    referenceTime,
+		SignedTransactions:        []*protocol.SignedTransaction{signedTransaction},
+	})
+	if err != nil {
+		panic(errors.Wrapf(err, "failed to process %s.%s", h.contractName, methodName).Error())
+	}
+
+	receipt := output.TransactionReceipts[0]
+	if receipt.ExecutionResult() == protocol.EXECUTION_RESULT_SUCCESS {
+		_, _ = h.stateStorage.CommitStateDiff(ctx, &services.CommitStateDiffInput{ContractStateDiffs: output.ContractStateDiffs})
+	}
+
+	return newCallResult(receipt.ExecutionResult(),
+		protocol.ArgumentArrayReader(receipt.RawOutputArgumentArrayWithHeader()),
+		protocol.EventsArrayReader(receipt.RawOutputEventsArrayWithHeader()),
+		output.ContractStateDiffs)
+}
+
+// Query runs a method of the contract under test read-only, on top of the block before the current block height
+func (h *ContractHarness) Query(ctx context.Context, methodName string, args ...interface{}) *CallResult {
+	argumentArray, err := protocol.ArgumentArrayFromNatives(args)
+	if err != nil {
+		panic(errors.Wrapf(err, "unsupported argument for %s.%s", h.contractName, methodName).Error())
+	}
+
+	signedQuery := (&protocol.SignedQueryBuilder{
+		Query: &protocol.QueryBuilder{
+			ProtocolVersion:    config.MAXIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE,
+			VirtualChainId:     CONTRACT_HARNESS_VIRTUAL_CHAIN_ID,
+			Timestamp:          h.blockTimestamp,
+			Signer:             h.signerBuilder(),
+			ContractName:       primitives.ContractName(h.contractName),
+			MethodName:         primitives.MethodName(methodName),
+			InputArgumentArray: argumentArray.RawArgumentsArray(),
+		},
+	}).Build()
+
+	output, _ := h.vm.ProcessQuery(ctx, &services.ProcessQueryInput{SignedQuery: signedQuery})
+
+	// packed the same way the public api returns it so the arrays can be read with their headers
+	queryResult := (&protocol.QueryResultBuilder{
+		OutputArgumentArray: output.OutputArgumentArray,
+		OutputEventsArray:   output.OutputEventsArray,
+	}).Build()
+
+	return newCallResult(output.CallResult,
+		protocol.ArgumentArrayReader(queryResult.RawOutputArgumentArrayWithHeader()),
+		protocol.EventsArrayReader(queryResult.RawOutputEventsArrayWithHeader()),
+		nil)
+}
+
+func (h *ContractHarness) signerBuilder() *protocol.SignerBuilder {
+	return &protocol.SignerBuilder{
+		Scheme: protocol.SIGNER_SCHEME_EDDSA,
+		Eddsa: &protocol.EdDSA01SignerBuilder{
+			NetworkType:     protocol.NETWORK_TYPE_TEST_NET,
+			SignerPublicKey: h.signer,
+		},
+	}
+}
+
+// the previous block is assumed to be proposed by the same proposer a second before the current one
+func (h *ContractHarness) updateLastCommittedBlockInfo() {
+	lastTimestamp := h.blockTimestamp - primitives.TimestampNano(time.Second)
+	lastReferenceTime := primitives.TimestampSeconds(time.Duration(lastTimestamp) / time.Second)
+	h.stateStorage.setLastCommittedBlockInfo(&services.GetLastCommittedBlockInfoOutput{
+		BlockHeight:          h.blockHeight - 1,
+		BlockTimestamp:       lastTimestamp,
+		BlockProposerAddress: h.proposer,
+		CurrentReferenceTime: lastReferenceTime,
+		PrevReferenceTime:    lastReferenceTime,
+	})
+}
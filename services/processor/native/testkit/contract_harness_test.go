@@ -0,0 +1,110 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package testkit
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/address"
+	"github.com/orbs-network/orbs-contract-sdk/go/sdk/v1/env"
+	benchmarkcontract "github.com/orbs-network/orbs-network-go/services/processor/native/repository/BenchmarkContract"
+	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func uint64Bytes(value uint64) []byte {
+	res := make([]byte, 8)
+	binary.LittleEndian.PutUint64(res, value)
+	return res
+}
+
+func withBenchmarkContractHarness(t *testing.T, f func(ctx context.Context, h *ContractHarness)) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			f(ctx, NewContractHarness(parent.Logger, benchmarkcontract.CONTRACT_NAME, benchmarkcontract.PUBLIC, benchmarkcontract.SYSTEM, benchmarkcontract.EVENTS))
+		})
+	})
+}
+
+func TestContractHarness_CallCommitsState(t *testing.T) {
+	withBenchmarkContractHarness(t, func(ctx context.Context, h *ContractHarness) {
+		h.Call(ctx, "set", uint64(17)).RequireSuccess(t)
+
+		h.RequireState(t, []byte("example-key"), uint64Bytes(17))
+		h.Query(ctx, "get").RequireOutput(t, uint64(17))
+	})
+}
+
+func TestContractHarness_ReadsPreSeededState(t *testing.T) {
+	withBenchmarkContractHarness(t, func(ctx context.Context, h *ContractHarness) {
+		h.SetState([]byte("example-key"), uint64Bytes(42))
+
+		h.Call(ctx, "get").RequireOutput(t, uint64(42))
+	})
+}
+
+func TestContractHarness_RecordsPanics(t *testing.T) {
+	withBenchmarkContractHarness(t, func(ctx context.Context, h *ContractHarness) {
+		result := h.Call(ctx, "throw")
+
+		result.RequirePanic(t, "example error returned by contract")
+		result.RequireNoEvents(t)
+	})
+}
+
+func TestContractHarness_RecordsEvents(t *testing.T) {
+	withBenchmarkContractHarness(t, func(ctx context.Context, h *ContractHarness) {
+		result := h.Call(ctx, "giveBirth", "Alice")
+
+		result.RequireSuccess(t)
+		result.RequireEvent(t, "BabyBorn", "Alice", uint32(3))
+	})
+}
+
+/////////////////////////////////////////////////////////////////
+// a contract reading the environment controlled by the harness
+
+const ENV_CONTRACT_NAME = "EnvContract"
+
+var ENV_PUBLIC = sdk.Export(blockInfo, committee, signer)
+
+func blockInfo() (uint64, uint64) {
+	return env.GetBlockHeight(), env.GetBlockTimestamp()
+}
+
+func committee() uint32 {
+	return uint32(len(env.GetBlockCommittee()))
+}
+
+func signer() []byte {
+	return address.GetSignerAddress()
+}
+
+func TestContractHarness_ControlsEnvironment(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := NewContractHarness(parent.Logger, ENV_CONTRACT_NAME, ENV_PUBLIC, nil, nil)
+			timestamp := time.Unix(1500000000, 0)
+
+			h.WithBlockHeight(100).WithBlockTimestamp(timestamp)
+			h.Call(ctx, "blockInfo").RequireOutput(t, uint64(100), uint64(timestamp.UnixNano()))
+
+			h.WithCommittee(primitives.NodeAddress{0x01}, primitives.NodeAddress{0x02}, primitives.NodeAddress{0x03})
+			h.Call(ctx, "committee").RequireOutput(t, uint32(3))
+
+			h.WithSigner(testKeys.Ed25519KeyPairForTests(3).PublicKey())
+			result := h.Call(ctx, "signer")
+			result.RequireSuccess(t)
+			require.EqualValues(t, h.SignerAddress(), result.OutputArguments[0], "contract should see the signer set on the harness")
+		})
+	})
+}
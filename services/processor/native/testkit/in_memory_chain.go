@@ -0,0 +1,124 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package testkit
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+// inMemoryStateStorage keeps only the latest value of every key, the harness commits the state diffs of every call
+// into it so the next call sees them regardless of the block height it runs at
+type inMemoryStateStorage struct {
+	sync.Mutex
+	contracts     map[primitives.ContractName]map[string][]byte
+	lastCommitted *services.GetLastCommittedBlockInfoOutput
+}
+
+func newInMemoryStateStorage() *inMemoryStateStorage {
+	return &inMemoryStateStorage{
+		contracts:     make(map[primitives.ContractName]map[string][]byte),
+		lastCommitted: &services.GetLastCommittedBlockInfoOutput{},
+	}
+}
+
+func (s *inMemoryStateStorage) write(contractName primitives.ContractName, key []byte, value []byte) {
+	s.Lock()
+	defer s.Unlock()
+	contract, found := s.contracts[contractName]
+	if !found {
+		contract = make(map[string][]byte)
+		s.contracts[contractName] = contract
+	}
+	contract[string(key)] = value
+}
+
+func (s *inMemoryStateStorage) read(contractName primitives.ContractName, key []byte) []byte {
+	s.Lock()
+	defer s.Unlock()
+	return s.contracts[contractName][string(key)]
+}
+
+func (s *inMemoryStateStorage) setLastCommittedBlockInfo(info *services.GetLastCommittedBlockInfoOutput) {
+	s.Lock()
+	defer s.Unlock()
+	s.lastCommitted = info
+}
+
+func (s *inMemoryStateStorage) CommitStateDiff(ctx context.Context, input *services.CommitStateDiffInput) (*services.CommitStateDiffOutput, error) {
+	for _, stateDiff := range input.ContractStateDiffs {
+		for i := stateDiff.StateDiffsIterator(); i.HasNext(); {
+			record := i.NextStateDiffs()
+			s.write(stateDiff.ContractName(), record.Key(), record.Value())
+		}
+	}
+	return &services.CommitStateDiffOutput{}, nil
+}
+
+func (s *inMemoryStateStorage) ReadKeys(ctx context.Context, input *services.ReadKeysInput) (*services.ReadKeysOutput, error) {
+	records := make([]*protocol.StateRecord, 0, len(input.Keys))
+	for _, key := range input.Keys {
+		records = append(records, (&protocol.StateRecordBuilder{
+			Key:   key,
+			Value: s.read(input.ContractName, key),
+		}).Build())
+	}
+	return &services.ReadKeysOutput{StateRecords: records}, nil
+}
+
+func (s *inMemoryStateStorage) GetLastCommittedBlockInfo(ctx context.Context, input *services.GetLastCommittedBlockInfoInput) (*services.GetLastCommittedBlockInfoOutput, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.lastCommitted, nil
+}
+
+func (s *inMemoryStateStorage) GetStateHash(ctx context.Context, input *services.GetStateHashInput) (*services.GetStateHashOutput, error) {
+	return nil, errors.New("state hash is not supported by the contract test harness")
+}
+
+// fixedCommitteeManagement answers every committee request with the committee set on the harness
+type fixedCommitteeManagement struct {
+	sync.Mutex
+	committee []primitives.NodeAddress
+}
+
+func (m *fixedCommitteeManagement) setCommittee(committee []primitives.NodeAddress) {
+	m.Lock()
+	defer m.Unlock()
+	m.committee = committee
+}
+
+func (m *fixedCommitteeManagement) GetCommittee(ctx context.Context, input *services.GetCommitteeInput) (*services.GetCommitteeOutput, error) {
+	m.Lock()
+	defer m.Unlock()
+	weights := make([]primitives.Weight, len(m.committee))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return &services.GetCommitteeOutput{Members: m.committee, Weights: weights}, nil
+}
+
+func (m *fixedCommitteeManagement) GetCurrentReference(ctx context.Context, input *services.GetCurrentReferenceInput) (*services.GetCurrentReferenceOutput, error) {
+	return &services.GetCurrentReferenceOutput{CurrentReference: primitives.TimestampSeconds(time.Now().Unix())}, nil
+}
+
+func (m *fixedCommitteeManagement) GetGenesisReference(ctx context.Context, input *services.GetGenesisReferenceInput) (*services.GetGenesisReferenceOutput, error) {
+	return &services.GetGenesisReferenceOutput{}, nil
+}
+
+func (m *fixedCommitteeManagement) GetProtocolVersion(ctx context.Context, input *services.GetProtocolVersionInput) (*services.GetProtocolVersionOutput, error) {
+	return &services.GetProtocolVersionOutput{}, nil
+}
+
+func (m *fixedCommitteeManagement) GetSubscriptionStatus(ctx context.Context, input *services.GetSubscriptionStatusInput) (*services.GetSubscriptionStatusOutput, error) {
+	return &services.GetSubscriptionStatusOutput{SubscriptionStatusIsActive: true}, nil
+}
@@ -47,7 +47,7 @@ func NewContractInstance(contractInfo *context.ContractInfo) (*ContractInstance,
 		if err != nil {
 			return nil, errors.Wrap(err, "invalid event method")
 		}
-		res.SystemMethods[name] = method
+		res.EventsMethods[name] = method
 	}
 	return res, nil
 }
@@ -3,8 +3,17 @@ package processor
 import (
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
 )
 
 type StatelessProcessor interface {
 	ProcessMethodCall(executionContextId primitives.ExecutionContextId, code string, methodName primitives.MethodName, args *protocol.ArgumentArray) (contractOutputArgs *protocol.ArgumentArray, contractOutputErr error, err error)
 }
+
+// ErrExecutionAborted is the cause of errors returned by processors which stopped a call for reasons local to the node,
+// such as its resource limits. Other nodes may complete the same call, so the call has no result which can enter a block
+var ErrExecutionAborted = errors.New("execution aborted by the node")
+
+func IsExecutionAborted(err error) bool {
+	return err != nil && errors.Cause(err) == ErrExecutionAborted
+}
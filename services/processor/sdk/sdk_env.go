@@ -104,3 +104,21 @@ func (s *service) SdkEnvGetNextBlockCommittee(executionContextId sdkContext.Cont
 	}
 	return output.OutputArguments[0].BytesArrayValueCopiedToNative()
 }
+
+// SdkEnvGetProtocolVersion is not part of the contract SDK, the system contracts reach it through the handler of their context
+func (s *service) SdkEnvGetProtocolVersion(executionContextId sdkContext.ContextId, permissionScope sdkContext.PermissionScope) uint32 {
+	output, err := s.sdkHandler.HandleSdkCall(context.TODO(), &handlers.HandleSdkCallInput{
+		ContextId:       primitives.ExecutionContextId(executionContextId),
+		OperationName:   SDK_OPERATION_NAME_ENV,
+		MethodName:      "getProtocolVersion",
+		InputArguments:  []*protocol.Argument{},
+		PermissionScope: protocol.ExecutionPermissionScope(permissionScope),
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(output.OutputArguments) != 1 || !output.OutputArguments[0].IsTypeUint32Value() {
+		panic("getProtocolVersion Sdk.Env returned corrupt output value")
+	}
+	return output.OutputArguments[0].Uint32Value()
+}
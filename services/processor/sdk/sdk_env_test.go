@@ -59,6 +59,13 @@ func TestSdkEnv_GetNextBlockCommittee(t *testing.T) {
 	require.EqualValues(t, [][]byte{{0x01, 0x02}, {0x04, 0x05}}, addrs, "next block committee should be returned")
 }
 
+func TestSdkEnv_GetProtocolVersion(t *testing.T) {
+	s := createEnvSdk()
+
+	version := s.SdkEnvGetProtocolVersion(EXAMPLE_CONTEXT, sdkContext.PERMISSION_SCOPE_SERVICE)
+	require.EqualValues(t, 2, version, "protocol version should be returned")
+}
+
 func createEnvSdk() *service {
 	return &service{sdkHandler: &contractSdkEnvCallHandlerStub{}}
 }
@@ -81,6 +88,8 @@ func (c *contractSdkEnvCallHandlerStub) HandleSdkCall(ctx context.Context, input
 		envValue = [][]byte{{0x01, 0x02}, {0x03, 0x05}}
 	case "getNextBlockCommittee":
 		envValue =[][]byte{{0x01, 0x02}, {0x04, 0x05}}
+	case "getProtocolVersion":
+		envValue = uint32(2)
 	default:
 		return nil, errors.New("unknown method")
 	}
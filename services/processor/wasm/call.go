@@ -0,0 +1,168 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	sdkContext "github.com/orbs-network/orbs-contract-sdk/go/context"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/services/processor/wasm/interpreter"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/pkg/errors"
+)
+
+// contracts receiving string, bytes or uint256 arguments must export alloc(size i32) -> i32 so the processor can place them in memory
+const ALLOC_FUNCTION_NAME = "alloc"
+
+// called once on deployment, contracts which have nothing to initialize may omit it
+const INIT_FUNCTION_NAME = "_init"
+
+// call holds the state of a single method execution, every call runs in a fresh instance so nothing
+// besides contract state carries over between calls
+type call struct {
+	sdk             sdkContext.SdkHandler
+	sdkHandler      handlers.ContractSdkCallHandler
+	contextId       sdkContext.ContextId
+	permissionScope sdkContext.PermissionScope
+
+	buffer           []byte
+	results          []interface{}
+	instructionsUsed uint64
+}
+
+func newCall(handler handlers.ContractSdkCallHandler, config sdk.SDKConfig, executionContextId primitives.ExecutionContextId) *call {
+	return &call{
+		sdk:             sdk.NewSDK(handler, config),
+		sdkHandler:      handler,
+		contextId:       sdkContext.ContextId(executionContextId),
+		permissionScope: sdkContext.PermissionScope(protocol.PERMISSION_SCOPE_SERVICE),
+	}
+}
+
+// an input argument as passed to the wasm function, either a value or data which is passed as pointer and length
+type wasmArgument struct {
+	value     uint64
+	data      []byte
+	isPointer bool
+}
+
+func (c *call) run(module *interpreter.Module, methodName string, functionType interpreter.FunctionType, args *protocol.ArgumentArray, limits interpreter.Limits) (contractOutputArgs *protocol.ArgumentArray, contractOutputErr error, err error) {
+	inArgs, err := verifyMethodInputArgs(methodName, functionType, args)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	instance, err := interpreter.Instantiate(module, c.imports(), limits)
+	if err != nil {
+		return nil, err, nil
+	}
+	defer func() {
+		c.instructionsUsed = instance.InstructionsUsed()
+	}()
+
+	values, err := writeMethodInputArgs(instance, inArgs)
+	if err != nil {
+		return nil, err, nil
+	}
+
+	outValues, err := instance.Invoke(methodName, values...)
+	if err != nil {
+		return nil, err, nil
+	}
+
+	// explicit results come first, followed by the return values of the function
+	for i, outValue := range outValues {
+		if functionType.Results[i] == interpreter.I32 {
+			c.results = append(c.results, uint32(outValue))
+		} else {
+			c.results = append(c.results, outValue)
+		}
+	}
+
+	contractOutputArgs, err = protocol.ArgumentArrayFromNatives(c.results)
+	if err != nil {
+		return nil, errors.Errorf("method '%s' output %s", methodName, err.Error()), nil
+	}
+	return contractOutputArgs, nil, nil
+}
+
+func verifyMethodInputArgs(methodName string, functionType interpreter.FunctionType, args *protocol.ArgumentArray) ([]wasmArgument, error) {
+	var res []wasmArgument
+	params := functionType.Params
+
+	index := 0
+	for i := args.ArgumentsIterator(); i.HasNext(); index++ {
+		arg := i.NextArguments()
+
+		var wasmArg wasmArgument
+		var expected []interpreter.ValueType
+		switch arg.Type() {
+		case protocol.ARGUMENT_TYPE_UINT_32_VALUE:
+			wasmArg.value, expected = uint64(arg.Uint32Value()), i32
+		case protocol.ARGUMENT_TYPE_BOOL_VALUE:
+			if arg.BoolValue() {
+				wasmArg.value = 1
+			}
+			expected = i32
+		case protocol.ARGUMENT_TYPE_UINT_64_VALUE:
+			wasmArg.value, expected = arg.Uint64Value(), i64
+		case protocol.ARGUMENT_TYPE_STRING_VALUE:
+			wasmArg.data, wasmArg.isPointer, expected = []byte(arg.StringValue()), true, ptr
+		case protocol.ARGUMENT_TYPE_BYTES_VALUE:
+			wasmArg.data, wasmArg.isPointer, expected = arg.BytesValue(), true, ptr
+		case protocol.ARGUMENT_TYPE_BYTES_20_VALUE:
+			value := arg.Bytes20Value()
+			wasmArg.data, wasmArg.isPointer, expected = value[:], true, ptr
+		case protocol.ARGUMENT_TYPE_BYTES_32_VALUE:
+			value := arg.Bytes32Value()
+			wasmArg.data, wasmArg.isPointer, expected = value[:], true, ptr
+		case protocol.ARGUMENT_TYPE_UINT_256_VALUE:
+			value := make([]byte, 32) // big endian
+			b := arg.Uint256Value().Bytes()
+			copy(value[32-len(b):], b)
+			wasmArg.data, wasmArg.isPointer, expected = value, true, ptr
+		default:
+			return nil, errors.Errorf("method '%s' arg %d has type %s which is not supported by wasm contracts", methodName, index, arg.StringType())
+		}
+
+		if len(params) < len(expected) || !(interpreter.FunctionType{Params: params[:len(expected)]}).Equals(interpreter.FunctionType{Params: expected}) {
+			return nil, errors.Errorf("method '%s' arg %d of type %s does not match the wasm function signature", methodName, index, arg.StringType())
+		}
+		params = params[len(expected):]
+		res = append(res, wasmArg)
+	}
+
+	if len(params) > 0 {
+		return nil, errors.Errorf("method '%s' takes more args than the %d received", methodName, index)
+	}
+
+	return res, nil
+}
+
+func writeMethodInputArgs(instance *interpreter.Instance, args []wasmArgument) ([]uint64, error) {
+	var values []uint64
+	for _, arg := range args {
+		if !arg.isPointer {
+			values = append(values, arg.value)
+			continue
+		}
+
+		allocated, err := instance.Invoke(ALLOC_FUNCTION_NAME, uint64(len(arg.data)))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to allocate memory for input arguments")
+		}
+		if len(allocated) != 1 {
+			return nil, errors.Errorf("%s must return a single pointer", ALLOC_FUNCTION_NAME)
+		}
+		if err := instance.WriteMemory(uint32(allocated[0]), arg.data); err != nil {
+			return nil, err
+		}
+		values = append(values, uint64(uint32(allocated[0])), uint64(len(arg.data)))
+	}
+	return values, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/pkg/errors"
+)
+
+func (s *service) getFullCodeOfDeploymentSystemContract(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) ([]byte, error) {
+	output, err := s.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_PARTS, contractName)
+	if err != nil {
+		return nil, err
+	}
+	if !output.IsTypeUint32Value() {
+		return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeParts returned corrupt output value")
+	}
+	codeParts := output.Uint32Value()
+
+	var parts [][]byte
+	for i := uint32(0); i < codeParts; i++ {
+		output, err := s.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_PART, contractName, i)
+		if err != nil {
+			return nil, err
+		}
+		if !output.IsTypeBytesValue() {
+			return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodePart returned corrupt output value")
+		}
+		parts = append(parts, output.BytesValue())
+	}
+
+	return bytes.Join(parts, nil), nil
+}
+
+func (s *service) getCodeVersionOfDeploymentSystemContract(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (uint32, error) {
+	output, err := s.callDeploymentSystemContract(ctx, executionContextId, deployments_systemcontract.METHOD_GET_CODE_VERSION, contractName)
+	if err != nil {
+		return 0, err
+	}
+	if !output.IsTypeUint32Value() {
+		return 0, errors.Errorf("callMethod Sdk.Service of _Deployments.getCodeVersion returned corrupt output value")
+	}
+	return output.Uint32Value(), nil
+}
+
+func (s *service) validateProtocolVersion(ctx context.Context, executionContextId primitives.ExecutionContextId) error {
+	output, err := s.sdkHandler.HandleSdkCall(ctx, &handlers.HandleSdkCallInput{
+		ContextId:       executionContextId,
+		OperationName:   sdk.SDK_OPERATION_NAME_ENV,
+		MethodName:      "getProtocolVersion",
+		InputArguments:  []*protocol.Argument{},
+		PermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+	})
+	if err != nil {
+		return err
+	}
+	if len(output.OutputArguments) != 1 || !output.OutputArguments[0].IsTypeUint32Value() {
+		return errors.New("getProtocolVersion Sdk.Env returned corrupt output value")
+	}
+	if protocolVersion := output.OutputArguments[0].Uint32Value(); protocolVersion < WASM_PROCESSOR_PROTOCOL_VERSION {
+		return errors.Errorf("wasm contracts are supported from protocol version %d, current protocol version is %d", WASM_PROCESSOR_PROTOCOL_VERSION, protocolVersion)
+	}
+	return nil
+}
+
+// returns the first output argument of the method
+func (s *service) callDeploymentSystemContract(ctx context.Context, executionContextId primitives.ExecutionContextId, methodName string, args ...interface{}) (*protocol.Argument, error) {
+	inputArguments, err := protocol.ArgumentArrayFromNatives(args)
+	if err != nil {
+		panic(errors.Wrap(err, "input arguments"))
+	}
+
+	output, err := s.sdkHandler.HandleSdkCall(ctx, &handlers.HandleSdkCallInput{
+		ContextId:     executionContextId,
+		OperationName: sdk.SDK_OPERATION_NAME_SERVICE,
+		MethodName:    "callMethod",
+		InputArguments: []*protocol.Argument{
+			(&protocol.ArgumentBuilder{
+				// serviceName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: deployments_systemcontract.CONTRACT_NAME,
+			}).Build(),
+			(&protocol.ArgumentBuilder{
+				// methodName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: methodName,
+			}).Build(),
+			(&protocol.ArgumentBuilder{
+				// inputArgs
+				Type:       protocol.ARGUMENT_TYPE_BYTES_VALUE,
+				BytesValue: inputArguments.Raw(),
+			}).Build(),
+		},
+		PermissionScope: protocol.PERMISSION_SCOPE_SYSTEM,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.OutputArguments) != 1 || !output.OutputArguments[0].IsTypeBytesValue() {
+		return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.%s returned corrupt output value", methodName)
+	}
+	argIterator := protocol.ArgumentArrayReader(output.OutputArguments[0].BytesValue()).ArgumentsIterator()
+	if !argIterator.HasNext() {
+		return nil, errors.Errorf("callMethod Sdk.Service of _Deployments.%s returned corrupt output value", methodName)
+	}
+	return argIterator.NextArguments(), nil
+}
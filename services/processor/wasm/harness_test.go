@@ -0,0 +1,224 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type harness struct {
+	sdkHandler *fakeSdkHandler
+	service    services.Processor
+}
+
+func newHarness(t *testing.T) *harness {
+	cfg := config.ForWasmProcessorTests(42, 100000, 2)
+	sdkHandler := &fakeSdkHandler{
+		protocolVersion: WASM_PROCESSOR_PROTOCOL_VERSION,
+		code:            make(map[string][]byte),
+		versions:        make(map[string]uint32),
+		state:           make(map[string][]byte),
+	}
+	service := NewWasmProcessor(cfg, log.DefaultTestingLogger(t), metric.NewRegistry())
+	service.RegisterContractSdkCallHandler(sdkHandler)
+	return &harness{
+		sdkHandler: sdkHandler,
+		service:    service,
+	}
+}
+
+// deploying over an existing contract upgrades it to the next version
+func (h *harness) deploy(contractName string, code []byte) {
+	h.sdkHandler.code[contractName] = code
+	h.sdkHandler.versions[contractName]++
+}
+
+func (h *harness) processCall(t *testing.T, contractName string, methodName string, scope protocol.ExecutionPermissionScope, args ...interface{}) (*services.ProcessCallOutput, error) {
+	output, err := h.service.ProcessCall(context.Background(), &services.ProcessCallInput{
+		ContextId:              []byte{0x01},
+		ContractName:           primitives.ContractName(contractName),
+		MethodName:             primitives.MethodName(methodName),
+		InputArgumentArray:     builders.ArgumentsArray(args...),
+		AccessScope:            protocol.ACCESS_SCOPE_READ_WRITE,
+		CallingPermissionScope: scope,
+	})
+	require.NotNil(t, output, "processor should always return an output")
+	return output, err
+}
+
+// stands in for the virtual machine, keeping state in memory and serving code from the _Deployments system contract
+type fakeSdkHandler struct {
+	protocolVersion uint32
+	code            map[string][]byte
+	versions        map[string]uint32
+	state           map[string][]byte
+	events          []string
+}
+
+func (f *fakeSdkHandler) HandleSdkCall(ctx context.Context, input *handlers.HandleSdkCallInput) (*handlers.HandleSdkCallOutput, error) {
+	switch input.OperationName {
+	case sdk.SDK_OPERATION_NAME_ENV:
+		if input.MethodName == "getProtocolVersion" {
+			return outputOf(f.protocolVersion), nil
+		}
+
+	case sdk.SDK_OPERATION_NAME_STATE:
+		key := string(input.InputArguments[0].BytesValue())
+		if input.MethodName == "read" {
+			return outputOf(f.state[key]), nil
+		}
+		f.state[key] = input.InputArguments[1].BytesValue()
+		return outputOf(), nil
+
+	case sdk.SDK_OPERATION_NAME_EVENTS:
+		f.events = append(f.events, input.InputArguments[0].StringValue())
+		return outputOf(), nil
+
+	case sdk.SDK_OPERATION_NAME_SERVICE:
+		serviceName := input.InputArguments[0].StringValue()
+		methodName := input.InputArguments[1].StringValue()
+		args, err := protocol.ArgumentArrayReader(input.InputArguments[2].BytesValue()).ToNatives()
+		if err != nil || serviceName != deployments_systemcontract.CONTRACT_NAME || input.PermissionScope != protocol.PERMISSION_SCOPE_SYSTEM {
+			return nil, errors.Errorf("unexpected service call %s.%s", serviceName, methodName)
+		}
+		code, deployed := f.code[args[0].(string)]
+		if !deployed {
+			return nil, errors.New("contract not deployed")
+		}
+		// code is served in two parts to make sure the processor joins them
+		half := len(code) / 2
+		switch methodName {
+		case deployments_systemcontract.METHOD_GET_CODE_VERSION:
+			return outputOf(builders.ArgumentsArray(f.versions[args[0].(string)]).Raw()), nil
+		case deployments_systemcontract.METHOD_GET_CODE_PARTS:
+			return outputOf(builders.ArgumentsArray(uint32(2)).Raw()), nil
+		case deployments_systemcontract.METHOD_GET_CODE_PART:
+			if args[1].(uint32) == 0 {
+				return outputOf(builders.ArgumentsArray(code[:half]).Raw()), nil
+			}
+			return outputOf(builders.ArgumentsArray(code[half:]).Raw()), nil
+		}
+	}
+	return nil, errors.Errorf("unexpected sdk call %s.%s", input.OperationName, input.MethodName)
+}
+
+func outputOf(args ...interface{}) *handlers.HandleSdkCallOutput {
+	outputArgs, _ := protocol.ArgumentsFromNatives(args)
+	return &handlers.HandleSdkCallOutput{OutputArguments: outputArgs}
+}
+
+// wasm opcodes used by the test contracts
+const (
+	opIf        = 0x04
+	opElse      = 0x05
+	opEnd       = 0x0b
+	opLoop      = 0x03
+	opBr        = 0x0c
+	opCall      = 0x10
+	opLocalGet  = 0x20
+	opGlobalGet = 0x23
+	opGlobalSet = 0x24
+	opI64Load   = 0x29
+	opI64Store  = 0x37
+	opI32Eq     = 0x46
+	opI32Add    = 0x6a
+	opI64Add    = 0x7c
+)
+
+var (
+	wasmNone = []byte{}
+	wasmI32  = []byte{builders.WASM_I32}
+	wasmI64  = []byte{builders.WASM_I64}
+	wasmPtr  = []byte{builders.WASM_I32, builders.WASM_I32}
+	wasmPtr2 = []byte{builders.WASM_I32, builders.WASM_I32, builders.WASM_I32, builders.WASM_I32}
+)
+
+func op(opcode byte, immediates ...uint32) []byte {
+	return builders.WasmInstr(opcode, immediates...)
+}
+
+func i32Const(v int32) []byte {
+	return builders.WasmI32Const(v)
+}
+
+// the next version of a contract, add no longer touches state
+func constantContract(value int64) []byte {
+	m := builders.WasmModule().Memory(1)
+
+	m.ExportFunction("add", m.Function(m.Type(wasmI64, wasmI64), wasmNone,
+		builders.WasmI64Const(value)))
+
+	return m.Build()
+}
+
+// a contract exercising the host functions, written by hand since there is no compiler available in tests
+func counterContract() []byte {
+	eventArgs := builders.ArgumentsArray("hello", uint32(17)).Raw()
+
+	m := builders.WasmModule().Memory(1).
+		Data(0, []byte("count")).
+		Data(32, []byte("boom")).
+		Data(64, []byte("Notified")).
+		Data(128, eventArgs)
+
+	stateRead := m.ImportFunction(HOST_MODULE_NAME, "state_read", m.Type(wasmPtr, wasmI32))
+	stateWrite := m.ImportFunction(HOST_MODULE_NAME, "state_write", m.Type(wasmPtr2, wasmNone))
+	bufferCopy := m.ImportFunction(HOST_MODULE_NAME, "buffer_copy", m.Type(wasmI32, wasmNone))
+	resultString := m.ImportFunction(HOST_MODULE_NAME, "result_string", m.Type(wasmPtr, wasmNone))
+	resultBool := m.ImportFunction(HOST_MODULE_NAME, "result_bool", m.Type(wasmI32, wasmNone))
+	eventsEmit := m.ImportFunction(HOST_MODULE_NAME, "events_emit", m.Type(wasmPtr2, wasmNone))
+	abort := m.ImportFunction(HOST_MODULE_NAME, "panic", m.Type(wasmPtr, wasmNone))
+
+	heap := m.Global(builders.WASM_I32, true, i32Const(1024))
+
+	// bump allocator, memory is never freed since every call runs in a fresh instance
+	m.ExportFunction(ALLOC_FUNCTION_NAME, m.Function(m.Type(wasmI32, wasmI32), wasmNone,
+		op(opGlobalGet, heap),
+		op(opGlobalGet, heap), op(opLocalGet, 0), op(opI32Add), op(opGlobalSet, heap)))
+
+	// adds to the counter kept in state under "count" and returns the new value
+	m.ExportFunction("add", m.Function(m.Type(wasmI64, wasmI64), wasmNone,
+		i32Const(0), i32Const(5), op(opCall, stateRead), i32Const(8), op(opI32Eq),
+		op(opIf), []byte{0x40},
+		i32Const(16), op(opCall, bufferCopy),
+		op(opElse),
+		i32Const(16), builders.WasmI64Const(0), op(opI64Store, 3, 0),
+		op(opEnd),
+		i32Const(16), i32Const(16), op(opI64Load, 3, 0), op(opLocalGet, 0), op(opI64Add), op(opI64Store, 3, 0),
+		i32Const(0), i32Const(5), i32Const(16), i32Const(8), op(opCall, stateWrite),
+		i32Const(16), op(opI64Load, 3, 0)))
+
+	m.ExportFunction("greet", m.Function(m.Type(wasmPtr, wasmNone), wasmNone,
+		op(opLocalGet, 0), op(opLocalGet, 1), op(opCall, resultString),
+		i32Const(1), op(opCall, resultBool)))
+
+	m.ExportFunction("notify", m.Function(m.Type(wasmNone, wasmNone), wasmNone,
+		i32Const(64), i32Const(8), i32Const(128), i32Const(int32(len(eventArgs))), op(opCall, eventsEmit)))
+
+	m.ExportFunction("fail", m.Function(m.Type(wasmNone, wasmNone), wasmNone,
+		i32Const(32), i32Const(4), op(opCall, abort)))
+
+	m.ExportFunction("spin", m.Function(m.Type(wasmNone, wasmNone), wasmNone,
+		op(opLoop), []byte{0x40}, op(opBr, 0), op(opEnd)))
+
+	m.ExportFunction("_cleanup", m.Function(m.Type(wasmNone, wasmNone), wasmNone))
+
+	return m.Build()
+}
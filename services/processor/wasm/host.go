@@ -0,0 +1,361 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/services/processor/wasm/interpreter"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/pkg/errors"
+	"unicode/utf16"
+)
+
+// contracts import the sdk from this module, pointers and lengths are i32 values into the contract memory.
+// host functions returning variable length data (state, addresses, service call outputs) place it in a buffer
+// and return its length, the contract then allocates memory and calls buffer_copy to retrieve it
+const HOST_MODULE_NAME = "orbs"
+
+// AssemblyScript reports failed assertions and thrown errors by calling env.abort
+const ASSEMBLYSCRIPT_MODULE_NAME = "env"
+
+var (
+	none = []interpreter.ValueType{}
+	i32  = []interpreter.ValueType{interpreter.I32}
+	i64  = []interpreter.ValueType{interpreter.I64}
+	ptr  = []interpreter.ValueType{interpreter.I32, interpreter.I32}
+	ptr2 = []interpreter.ValueType{interpreter.I32, interpreter.I32, interpreter.I32, interpreter.I32}
+	ptr3 = []interpreter.ValueType{interpreter.I32, interpreter.I32, interpreter.I32, interpreter.I32, interpreter.I32, interpreter.I32}
+)
+
+type contractPanic struct {
+	message string
+}
+
+func (p *contractPanic) Error() string {
+	return p.message
+}
+
+func hostFunction(params []interpreter.ValueType, results []interpreter.ValueType, call func(instance *interpreter.Instance, args []uint64) ([]uint64, error)) *interpreter.HostFunction {
+	return &interpreter.HostFunction{
+		Type: interpreter.FunctionType{Params: params, Results: results},
+		Call: call,
+	}
+}
+
+func (c *call) imports() interpreter.Imports {
+	return interpreter.Imports{
+		HOST_MODULE_NAME: {
+			// state
+			"state_read":  hostFunction(ptr, i32, c.stateRead),
+			"state_write": hostFunction(ptr2, none, c.stateWrite),
+			"state_clear": hostFunction(ptr, none, c.stateClear),
+
+			// events
+			"events_emit": hostFunction(ptr2, none, c.eventsEmit),
+
+			// service
+			"service_call": hostFunction(ptr3, i32, c.serviceCall),
+
+			// env
+			"env_block_height":           hostFunction(none, i64, c.envBlockHeight),
+			"env_block_timestamp":        hostFunction(none, i64, c.envBlockTimestamp),
+			"env_virtual_chain_id":       hostFunction(none, i32, c.envVirtualChainId),
+			"env_block_proposer_address": hostFunction(none, i32, c.envBlockProposerAddress),
+
+			// address
+			"address_signer":   hostFunction(none, i32, c.addressSigner),
+			"address_caller":   hostFunction(none, i32, c.addressCaller),
+			"address_own":      hostFunction(none, i32, c.addressOwn),
+			"address_contract": hostFunction(ptr, i32, c.addressContract),
+
+			// results
+			"result_uint32": hostFunction(i32, none, c.resultUint32),
+			"result_uint64": hostFunction(i64, none, c.resultUint64),
+			"result_bool":   hostFunction(i32, none, c.resultBool),
+			"result_string": hostFunction(ptr, none, c.resultString),
+			"result_bytes":  hostFunction(ptr, none, c.resultBytes),
+
+			// buffer
+			"buffer_copy": hostFunction(i32, none, c.bufferCopy),
+
+			// errors
+			"panic": hostFunction(ptr, none, c.panic),
+		},
+		ASSEMBLYSCRIPT_MODULE_NAME: {
+			"abort": hostFunction(ptr2, none, c.abort),
+		},
+	}
+}
+
+// the sdk reports failures by panicking, they abort the contract execution like a contract panic
+func protect(f func()) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.Errorf("%v", r)
+		}
+	}()
+	f()
+	return nil
+}
+
+func read(instance *interpreter.Instance, args []uint64) ([]byte, error) {
+	return instance.ReadMemory(uint32(args[0]), uint32(args[1]))
+}
+
+func (c *call) returnInBuffer(data []byte) []uint64 {
+	c.buffer = data
+	return []uint64{uint64(len(data))}
+}
+
+func (c *call) bufferCopy(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	return nil, instance.WriteMemory(uint32(args[0]), c.buffer)
+}
+
+func (c *call) stateRead(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	key, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	var value []byte
+	if err := protect(func() { value = c.sdk.SdkStateReadBytes(c.contextId, c.permissionScope, key) }); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(value), nil
+}
+
+func (c *call) stateWrite(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	key, err := read(instance, args[0:2])
+	if err != nil {
+		return nil, err
+	}
+	value, err := read(instance, args[2:4])
+	if err != nil {
+		return nil, err
+	}
+	return nil, protect(func() { c.sdk.SdkStateWriteBytes(c.contextId, c.permissionScope, key, value) })
+}
+
+func (c *call) stateClear(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	key, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	return nil, protect(func() { c.sdk.SdkStateWriteBytes(c.contextId, c.permissionScope, key, []byte{}) })
+}
+
+// the native sdk derives the event name and argument types from a go function signature, wasm contracts pass the packed arguments directly
+func (c *call) eventsEmit(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	eventName, err := read(instance, args[0:2])
+	if err != nil {
+		return nil, err
+	}
+	eventArguments, err := read(instance, args[2:4])
+	if err != nil {
+		return nil, err
+	}
+	if _, err := protocol.ArgumentArrayReader(eventArguments).ToNatives(); err != nil {
+		return nil, errors.Errorf("event '%s' input arguments: %s", eventName, err)
+	}
+
+	_, err = c.sdkHandler.HandleSdkCall(context.TODO(), &handlers.HandleSdkCallInput{
+		ContextId:     primitives.ExecutionContextId(c.contextId),
+		OperationName: sdk.SDK_OPERATION_NAME_EVENTS,
+		MethodName:    "emitEvent",
+		InputArguments: []*protocol.Argument{
+			(&protocol.ArgumentBuilder{
+				// eventName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: string(eventName),
+			}).Build(),
+			(&protocol.ArgumentBuilder{
+				// inputArgs
+				Type:       protocol.ARGUMENT_TYPE_BYTES_VALUE,
+				BytesValue: eventArguments,
+			}).Build(),
+		},
+		PermissionScope: protocol.ExecutionPermissionScope(c.permissionScope),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to emit event '%s'", eventName)
+	}
+	return nil, nil
+}
+
+// input and output arguments are packed argument arrays
+func (c *call) serviceCall(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	serviceName, err := read(instance, args[0:2])
+	if err != nil {
+		return nil, err
+	}
+	methodName, err := read(instance, args[2:4])
+	if err != nil {
+		return nil, err
+	}
+	packedInputArguments, err := read(instance, args[4:6])
+	if err != nil {
+		return nil, err
+	}
+	inputArguments, err := protocol.ArgumentArrayReader(packedInputArguments).ToNatives()
+	if err != nil {
+		return nil, errors.Wrap(err, "input arguments")
+	}
+
+	var outputArguments []interface{}
+	err = protect(func() {
+		outputArguments = c.sdk.SdkServiceCallMethod(c.contextId, c.permissionScope, string(serviceName), string(methodName), inputArguments...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	packedOutputArguments, err := protocol.ArgumentArrayFromNatives(outputArguments)
+	if err != nil {
+		return nil, errors.Wrap(err, "output arguments")
+	}
+	return c.returnInBuffer(packedOutputArguments.Raw()), nil
+}
+
+func (c *call) envBlockHeight(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var height uint64
+	err := protect(func() { height = c.sdk.SdkEnvGetBlockHeight(c.contextId, c.permissionScope) })
+	return []uint64{height}, err
+}
+
+func (c *call) envBlockTimestamp(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var timestamp uint64
+	err := protect(func() { timestamp = c.sdk.SdkEnvGetBlockTimestamp(c.contextId, c.permissionScope) })
+	return []uint64{timestamp}, err
+}
+
+func (c *call) envVirtualChainId(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var vcid uint32
+	err := protect(func() { vcid = c.sdk.SdkEnvGetVirtualChainId(c.contextId, c.permissionScope) })
+	return []uint64{uint64(vcid)}, err
+}
+
+func (c *call) envBlockProposerAddress(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var address []byte
+	if err := protect(func() { address = c.sdk.SdkEnvGetBlockProposerAddress(c.contextId, c.permissionScope) }); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(address), nil
+}
+
+func (c *call) addressSigner(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var address []byte
+	if err := protect(func() { address = c.sdk.SdkAddressGetSignerAddress(c.contextId, c.permissionScope) }); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(address), nil
+}
+
+func (c *call) addressCaller(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var address []byte
+	if err := protect(func() { address = c.sdk.SdkAddressGetCallerAddress(c.contextId, c.permissionScope) }); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(address), nil
+}
+
+func (c *call) addressOwn(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	var address []byte
+	if err := protect(func() { address = c.sdk.SdkAddressGetOwnAddress(c.contextId, c.permissionScope) }); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(address), nil
+}
+
+func (c *call) addressContract(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	contractName, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	var address []byte
+	if err := protect(func() {
+		address = c.sdk.SdkAddressGetContractAddress(c.contextId, c.permissionScope, string(contractName))
+	}); err != nil {
+		return nil, err
+	}
+	return c.returnInBuffer(address), nil
+}
+
+func (c *call) resultUint32(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	c.results = append(c.results, uint32(args[0]))
+	return nil, nil
+}
+
+func (c *call) resultUint64(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	c.results = append(c.results, args[0])
+	return nil, nil
+}
+
+func (c *call) resultBool(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	c.results = append(c.results, uint32(args[0]) != 0)
+	return nil, nil
+}
+
+func (c *call) resultString(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	value, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	c.results = append(c.results, string(value))
+	return nil, nil
+}
+
+func (c *call) resultBytes(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	value, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	c.results = append(c.results, value)
+	return nil, nil
+}
+
+func (c *call) panic(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	message, err := read(instance, args)
+	if err != nil {
+		return nil, err
+	}
+	return nil, &contractPanic{message: string(message)}
+}
+
+// AssemblyScript strings are utf-16 with their byte length stored right before them
+func (c *call) abort(instance *interpreter.Instance, args []uint64) ([]uint64, error) {
+	message, err := readAssemblyScriptString(instance, uint32(args[0]))
+	if err != nil {
+		return nil, err
+	}
+	file, err := readAssemblyScriptString(instance, uint32(args[1]))
+	if err != nil {
+		return nil, err
+	}
+	return nil, &contractPanic{message: fmt.Sprintf("%s (%s:%d:%d)", message, file, uint32(args[2]), uint32(args[3]))}
+}
+
+func readAssemblyScriptString(instance *interpreter.Instance, ptr uint32) (string, error) {
+	if ptr < 4 {
+		return "", nil
+	}
+	header, err := instance.ReadMemory(ptr-4, 4)
+	if err != nil {
+		return "", err
+	}
+	data, err := instance.ReadMemory(ptr, binary.LittleEndian.Uint32(header))
+	if err != nil {
+		return "", err
+	}
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(data[2*i:])
+	}
+	return string(utf16.Decode(units)), nil
+}
@@ -0,0 +1,255 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+const maxLocalsPerFunction = 50000
+
+// instruction is a decoded instruction with its immediates, blocks also hold the resolved positions of their else and end
+type instruction struct {
+	opcode  uint16
+	a       uint64
+	b       uint64
+	params  uint32
+	results uint32
+	els     uint32
+	end     uint32
+}
+
+const noElse = ^uint32(0)
+
+func decodeFunctionBody(r *reader, m *Module, f *function) {
+	groups := r.vectorLength()
+	total := uint64(0)
+	for i := uint32(0); i < groups; i++ {
+		count := r.u32()
+		valueType := r.valueType()
+		total += uint64(count)
+		if total > maxLocalsPerFunction {
+			fail("function declares too many locals")
+		}
+		for j := uint32(0); j < count; j++ {
+			f.locals = append(f.locals, valueType)
+		}
+	}
+
+	v := newCodeValidator(m, f)
+	var openBlocks []uint32
+	for {
+		opcode := r.byte()
+		ins := instruction{opcode: uint16(opcode)}
+		index := uint32(len(f.code))
+
+		switch {
+		case isFloatingPointOpcode(opcode):
+			fail("floating point instruction 0x%x is not deterministic and not supported", opcode)
+
+		case opcode == opBlock || opcode == opLoop || opcode == opIf:
+			signature := blockType(r, m)
+			ins.params, ins.results = uint32(len(signature.Params)), uint32(len(signature.Results))
+			ins.els = noElse
+			openBlocks = append(openBlocks, index)
+			v.enterBlock(ins.opcode, signature)
+
+		case opcode == opElse:
+			if len(openBlocks) == 0 || f.code[openBlocks[len(openBlocks)-1]].opcode != opIf {
+				fail("else without if")
+			}
+			f.code[openBlocks[len(openBlocks)-1]].els = index
+			v.elseBlock()
+
+		case opcode == opEnd:
+			v.endBlock()
+			if len(openBlocks) == 0 { // end of the function body
+				f.code = append(f.code, ins)
+				if !r.eof() {
+					fail("function body has trailing data")
+				}
+				return
+			}
+			start := openBlocks[len(openBlocks)-1]
+			f.code[start].end = index
+			if els := f.code[start].els; els != noElse {
+				f.code[els].end = index
+			}
+			openBlocks = openBlocks[:len(openBlocks)-1]
+
+		case opcode == opBr || opcode == opBrIf:
+			ins.a = uint64(r.u32())
+			if ins.a > uint64(len(openBlocks)) {
+				fail("branch depth %d out of range", ins.a)
+			}
+			if opcode == opBr {
+				v.branch(uint32(ins.a))
+			} else {
+				v.branchIf(uint32(ins.a))
+			}
+
+		case opcode == opBrTable:
+			n := r.vectorLength()
+			ins.a = uint64(len(f.brTables))
+			ins.b = uint64(n)
+			for i := uint32(0); i <= n; i++ {
+				depth := r.u32()
+				if depth > uint32(len(openBlocks)) {
+					fail("branch depth %d out of range", depth)
+				}
+				f.brTables = append(f.brTables, depth)
+			}
+			v.branchTable(f.brTables[ins.a:])
+
+		case opcode == opCall:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(m.numFunctions()) {
+				fail("call to undefined function %d", ins.a)
+			}
+			signature, _ := m.functionType(uint32(ins.a))
+			v.call(signature)
+
+		case opcode == opCallIndirect:
+			ins.a = uint64(r.u32())
+			ins.b = uint64(r.u32())
+			if ins.a >= uint64(len(m.Types)) || ins.b != 0 || m.table == nil {
+				fail("invalid indirect call")
+			}
+			v.pop(I32)
+			v.call(m.Types[ins.a])
+
+		case opcode == opSelectTyped:
+			n := r.vectorLength()
+			var selectTypes []ValueType
+			for i := uint32(0); i < n; i++ {
+				selectTypes = append(selectTypes, r.valueType())
+			}
+			if n == 0 {
+				fail("typed select without a type")
+			}
+			ins.opcode = opSelect
+			v.selectOperands(selectTypes)
+
+		case opcode == opLocalGet || opcode == opLocalSet || opcode == opLocalTee:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(len(m.Types[f.typeIndex].Params)+len(f.locals)) {
+				fail("local %d out of range", ins.a)
+			}
+			v.local(ins.opcode, uint32(ins.a))
+
+		case opcode == opGlobalGet || opcode == opGlobalSet:
+			ins.a = uint64(r.u32())
+			if ins.a >= uint64(len(m.globals)) {
+				fail("global %d out of range", ins.a)
+			}
+			if opcode == opGlobalSet && !m.globals[ins.a].mutable {
+				fail("global %d is immutable", ins.a)
+			}
+			v.global(ins.opcode, uint32(ins.a))
+
+		case opcode >= opI32Load && opcode <= opI64Store32:
+			if m.memory == nil {
+				fail("memory access without memory")
+			}
+			r.u32() // alignment is only a hint
+			ins.a = uint64(r.u32())
+			v.operator(ins.opcode)
+
+		case opcode == opMemorySize || opcode == opMemoryGrow:
+			if m.memory == nil || r.byte() != 0 {
+				fail("invalid memory instruction")
+			}
+			v.operator(ins.opcode)
+
+		case opcode == opI32Const:
+			ins.a = uint64(uint32(r.s32()))
+			v.operator(ins.opcode)
+
+		case opcode == opI64Const:
+			ins.a = uint64(r.s64())
+			v.operator(ins.opcode)
+
+		case opcode == opPrefixMisc:
+			decodeMiscInstruction(r, m, &ins)
+			v.operator(ins.opcode)
+
+		case opcode == opUnreachable:
+			v.setUnreachable()
+		case opcode == opReturn:
+			v.returnFromFunction()
+		case opcode == opDrop:
+			v.pop(unknownType)
+		case opcode == opSelect:
+			v.selectOperands(nil)
+		case opcode == opNop:
+		case opcode >= opI32Eqz && opcode <= opI64GeU,
+			opcode >= opI32Clz && opcode <= opI64Rotr,
+			opcode == opI32WrapI64 || opcode == opI64ExtendI32S || opcode == opI64ExtendI32U,
+			opcode >= opI32Extend8S && opcode <= opI64Extend32S:
+			v.operator(ins.opcode)
+
+		default:
+			fail("unsupported instruction 0x%x", opcode)
+		}
+
+		f.code = append(f.code, ins)
+	}
+}
+
+func decodeMiscInstruction(r *reader, m *Module, ins *instruction) {
+	sub := r.u32()
+	if sub > 0xff {
+		fail("unsupported instruction 0xfc %d", sub)
+	}
+	ins.opcode = uint16(opPrefixMisc<<miscOpcodesShift | sub)
+	if m.memory == nil {
+		fail("memory instruction 0xfc %d without memory", sub)
+	}
+
+	switch ins.opcode {
+	case opMemoryInit:
+		ins.a = uint64(r.u32())
+		validateDataIndex(m, ins.a)
+		r.byte()
+	case opDataDrop:
+		ins.a = uint64(r.u32())
+		validateDataIndex(m, ins.a)
+	case opMemoryCopy:
+		r.byte()
+		r.byte()
+	case opMemoryFill:
+		r.byte()
+	default:
+		if sub <= 7 {
+			fail("floating point instruction 0xfc %d is not deterministic and not supported", sub)
+		}
+		fail("unsupported instruction 0xfc %d", sub)
+	}
+}
+
+// the data section follows the code section, so data indices are checked against the data count section before it
+func validateDataIndex(m *Module, index uint64) {
+	if m.dataCount < 0 {
+		fail("data instructions require a data count section")
+	}
+	if index >= uint64(m.dataCount) {
+		fail("data segment %d out of range", index)
+	}
+}
+
+func blockType(r *reader, m *Module) FunctionType {
+	t := r.signed(33)
+	switch {
+	case t == -0x40: // empty
+		return FunctionType{}
+	case t == -0x01: // i32
+		return FunctionType{Results: []ValueType{I32}}
+	case t == -0x02: // i64
+		return FunctionType{Results: []ValueType{I64}}
+	case t < 0:
+		fail("unsupported block type %d", t)
+	case t >= int64(len(m.Types)):
+		fail("block type index %d out of range", t)
+	}
+	return m.Types[t]
+}
@@ -0,0 +1,476 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pkg/errors"
+	"unicode/utf8"
+)
+
+var wasmMagic = []byte{0x00, 0x61, 0x73, 0x6d}
+var wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+// the data count section is placed before the code section
+var sectionOrder = [...]int{
+	sectionType:      1,
+	sectionImport:    2,
+	sectionFunction:  3,
+	sectionTable:     4,
+	sectionMemory:    5,
+	sectionGlobal:    6,
+	sectionExport:    7,
+	sectionStart:     8,
+	sectionElement:   9,
+	sectionDataCount: 10,
+	sectionCode:      11,
+	sectionData:      12,
+}
+
+// protects the node from allocating huge amounts of memory for a malicious module
+const maxItemsInVector = 100000
+
+type decodeError struct {
+	err error
+}
+
+type reader struct {
+	data []byte
+	pos  int
+}
+
+func fail(format string, args ...interface{}) {
+	panic(decodeError{errors.Errorf(format, args...)})
+}
+
+func (r *reader) eof() bool {
+	return r.pos >= len(r.data)
+}
+
+func (r *reader) byte() byte {
+	if r.pos >= len(r.data) {
+		fail("unexpected end of data at offset %d", r.pos)
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b
+}
+
+func (r *reader) bytes(n uint32) []byte {
+	if uint64(r.pos)+uint64(n) > uint64(len(r.data)) {
+		fail("unexpected end of data at offset %d", r.pos)
+	}
+	b := r.data[r.pos : r.pos+int(n)]
+	r.pos += int(n)
+	return b
+}
+
+func (r *reader) u32() uint32 {
+	var result uint64
+	for shift := uint(0); shift < 35; shift += 7 {
+		b := r.byte()
+		result |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			if result > 0xffffffff {
+				fail("integer too large at offset %d", r.pos)
+			}
+			return uint32(result)
+		}
+	}
+	fail("integer representation too long at offset %d", r.pos)
+	return 0
+}
+
+func (r *reader) signed(size uint) int64 {
+	var result int64
+	var shift uint
+	for {
+		b := r.byte()
+		result |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				result |= -1 << shift
+			}
+			return result
+		}
+		if shift >= size+7 {
+			fail("integer representation too long at offset %d", r.pos)
+		}
+	}
+}
+
+func (r *reader) s32() int32 {
+	return int32(r.signed(32))
+}
+
+func (r *reader) s64() int64 {
+	return r.signed(64)
+}
+
+func (r *reader) vectorLength() uint32 {
+	n := r.u32()
+	if n > maxItemsInVector {
+		fail("vector of %d items is too long", n)
+	}
+	return n
+}
+
+func (r *reader) name() string {
+	b := r.bytes(r.u32())
+	if !utf8.Valid(b) {
+		fail("invalid utf8 name")
+	}
+	return string(b)
+}
+
+func (r *reader) valueType() ValueType {
+	t := ValueType(r.byte())
+	switch t {
+	case I32, I64:
+		return t
+	case F32, F64:
+		fail("floating point values are not deterministic and not supported")
+	}
+	fail("unsupported value type 0x%x", byte(t))
+	return 0
+}
+
+func (r *reader) limits() *limits {
+	flags := r.byte()
+	switch flags {
+	case 0x00:
+		return &limits{min: r.u32()}
+	case 0x01:
+		l := &limits{min: r.u32(), hasMax: true}
+		l.max = r.u32()
+		if l.max < l.min {
+			fail("limits maximum is smaller than minimum")
+		}
+		return l
+	}
+	fail("unsupported limits flags 0x%x", flags)
+	return nil
+}
+
+func (r *reader) constExpr() constExpr {
+	var expr constExpr
+	expr.opcode = r.byte()
+	switch expr.opcode {
+	case opI32Const:
+		expr.value = uint64(uint32(r.s32()))
+	case opI64Const:
+		expr.value = uint64(r.s64())
+	case opGlobalGet, opRefFunc:
+		expr.value = uint64(r.u32())
+	case opRefNull:
+		r.byte()
+	default:
+		fail("unsupported initializer expression opcode 0x%x", expr.opcode)
+	}
+	if r.byte() != opEnd {
+		fail("initializer expression must contain a single instruction")
+	}
+	return expr
+}
+
+// Decode parses and validates a binary WebAssembly module, modules using floating point or features outside the deterministic subset are rejected
+func Decode(code []byte) (m *Module, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(decodeError); ok {
+				m, err = nil, errors.Wrap(e.err, "invalid wasm module")
+				return
+			}
+			panic(r)
+		}
+	}()
+
+	r := &reader{data: code}
+	if !bytes.Equal(r.bytes(4), wasmMagic) {
+		fail("missing wasm magic header")
+	}
+	if !bytes.Equal(r.bytes(4), wasmVersion) {
+		fail("unsupported wasm version")
+	}
+
+	m = &Module{
+		exports:   make(map[string]export),
+		start:     -1,
+		dataCount: -1,
+	}
+	var functionTypeIndices []uint32
+	lastSection := 0
+	for !r.eof() {
+		id := r.byte()
+		section := &reader{data: r.bytes(r.u32())}
+		if id != sectionCustom {
+			if id > sectionDataCount || sectionOrder[id] <= lastSection {
+				fail("unexpected section %d", id)
+			}
+			lastSection = sectionOrder[id]
+		}
+
+		switch id {
+		case sectionCustom:
+			// names and debug info have no effect on execution
+		case sectionType:
+			m.Types = decodeTypes(section)
+		case sectionImport:
+			m.Imports = decodeImports(section, m)
+		case sectionFunction:
+			n := section.vectorLength()
+			for i := uint32(0); i < n; i++ {
+				typeIndex := section.u32()
+				if typeIndex >= uint32(len(m.Types)) {
+					fail("function type index %d out of range", typeIndex)
+				}
+				functionTypeIndices = append(functionTypeIndices, typeIndex)
+			}
+		case sectionTable:
+			n := section.vectorLength()
+			for i := uint32(0); i < n; i++ {
+				if i > 0 {
+					fail("multiple tables are not supported")
+				}
+				if refType := section.byte(); refType != 0x70 {
+					fail("unsupported table element type 0x%x", refType)
+				}
+				m.table = section.limits()
+			}
+		case sectionMemory:
+			n := section.vectorLength()
+			for i := uint32(0); i < n; i++ {
+				if i > 0 {
+					fail("multiple memories are not supported")
+				}
+				m.memory = section.limits()
+			}
+		case sectionGlobal:
+			n := section.vectorLength()
+			for i := uint32(0); i < n; i++ {
+				g := global{valueType: section.valueType(), mutable: section.byte() == 0x01}
+				g.init = section.constExpr()
+				validateConstExpr(m, g.init, g.valueType, fmt.Sprintf("global %d", i))
+				m.globals = append(m.globals, g)
+			}
+		case sectionExport:
+			n := section.vectorLength()
+			for i := uint32(0); i < n; i++ {
+				name := section.name()
+				if _, exists := m.exports[name]; exists {
+					fail("duplicate export %s", name)
+				}
+				m.exports[name] = export{kind: section.byte(), index: section.u32()}
+			}
+		case sectionStart:
+			m.start = int64(section.u32())
+		case sectionElement:
+			m.elements = decodeElements(section)
+		case sectionCode:
+			n := section.vectorLength()
+			if n != uint32(len(functionTypeIndices)) {
+				fail("code section has %d bodies for %d functions", n, len(functionTypeIndices))
+			}
+			m.functions = make([]*function, n)
+			for i := uint32(0); i < n; i++ {
+				m.functions[i] = &function{typeIndex: functionTypeIndices[i]}
+			}
+			for i := uint32(0); i < n; i++ {
+				body := &reader{data: section.bytes(section.u32())}
+				decodeFunctionBody(body, m, m.functions[i])
+			}
+		case sectionData:
+			m.data = decodeData(section)
+		case sectionDataCount:
+			m.dataCount = int64(section.u32())
+		}
+
+		if id != sectionCustom && !section.eof() {
+			fail("section %d has trailing data", id)
+		}
+	}
+
+	if len(functionTypeIndices) != len(m.functions) {
+		fail("missing code section")
+	}
+	validateIndices(m)
+	return m, nil
+}
+
+func decodeTypes(r *reader) []FunctionType {
+	n := r.vectorLength()
+	types := make([]FunctionType, n)
+	for i := uint32(0); i < n; i++ {
+		if form := r.byte(); form != 0x60 {
+			fail("unsupported type form 0x%x", form)
+		}
+		params := r.vectorLength()
+		for j := uint32(0); j < params; j++ {
+			types[i].Params = append(types[i].Params, r.valueType())
+		}
+		results := r.vectorLength()
+		for j := uint32(0); j < results; j++ {
+			types[i].Results = append(types[i].Results, r.valueType())
+		}
+	}
+	return types
+}
+
+func decodeImports(r *reader, m *Module) []Import {
+	n := r.vectorLength()
+	var imports []Import
+	for i := uint32(0); i < n; i++ {
+		imp := Import{Module: r.name(), Name: r.name()}
+		if kind := r.byte(); kind != externalKindFunction {
+			fail("import %s.%s: only function imports are supported", imp.Module, imp.Name)
+		}
+		imp.TypeIndex = r.u32()
+		if imp.TypeIndex >= uint32(len(m.Types)) {
+			fail("import %s.%s: type index %d out of range", imp.Module, imp.Name, imp.TypeIndex)
+		}
+		imports = append(imports, imp)
+	}
+	return imports
+}
+
+func decodeElements(r *reader) []elementSegment {
+	n := r.vectorLength()
+	segments := make([]elementSegment, n)
+	for i := uint32(0); i < n; i++ {
+		flags := r.u32()
+		if flags > 7 {
+			fail("unsupported element segment flags %d", flags)
+		}
+		segment := &segments[i]
+		segment.active = flags&0x01 == 0
+		if segment.active {
+			if flags&0x02 != 0 {
+				segment.table = r.u32()
+			}
+			segment.offset = r.constExpr()
+		}
+		usesExpressions := flags&0x04 != 0
+		if flags&0x03 != 0 {
+			r.byte() // element kind or reference type, funcref is the only supported one
+		}
+
+		count := r.vectorLength()
+		for j := uint32(0); j < count; j++ {
+			if !usesExpressions {
+				segment.functions = append(segment.functions, int64(r.u32()))
+				continue
+			}
+			expr := r.constExpr()
+			switch expr.opcode {
+			case opRefFunc:
+				segment.functions = append(segment.functions, int64(expr.value))
+			case opRefNull:
+				segment.functions = append(segment.functions, nullFunctionReference)
+			default:
+				fail("unsupported element expression opcode 0x%x", expr.opcode)
+			}
+		}
+	}
+	return segments
+}
+
+func decodeData(r *reader) []dataSegment {
+	n := r.vectorLength()
+	segments := make([]dataSegment, n)
+	for i := uint32(0); i < n; i++ {
+		flags := r.u32()
+		switch flags {
+		case 0:
+			segments[i].active = true
+			segments[i].offset = r.constExpr()
+		case 1:
+		case 2:
+			if memory := r.u32(); memory != 0 {
+				fail("data segment refers to memory %d", memory)
+			}
+			segments[i].active = true
+			segments[i].offset = r.constExpr()
+		default:
+			fail("unsupported data segment flags %d", flags)
+		}
+		segments[i].init = r.bytes(r.u32())
+	}
+	return segments
+}
+
+func validateIndices(m *Module) {
+	for name, e := range m.exports {
+		switch e.kind {
+		case externalKindFunction:
+			if e.index >= m.numFunctions() {
+				fail("export %s refers to undefined function %d", name, e.index)
+			}
+		case externalKindMemory:
+			if m.memory == nil || e.index != 0 {
+				fail("export %s refers to undefined memory %d", name, e.index)
+			}
+		case externalKindTable:
+			if m.table == nil || e.index != 0 {
+				fail("export %s refers to undefined table %d", name, e.index)
+			}
+		case externalKindGlobal:
+			if e.index >= uint32(len(m.globals)) {
+				fail("export %s refers to undefined global %d", name, e.index)
+			}
+		default:
+			fail("export %s has unsupported kind %d", name, e.kind)
+		}
+	}
+	if m.start >= 0 {
+		t, found := m.functionType(uint32(m.start))
+		if !found || len(t.Params) != 0 || len(t.Results) != 0 {
+			fail("invalid start function %d", m.start)
+		}
+	}
+	for i, segment := range m.elements {
+		if segment.active && (m.table == nil || segment.table != 0) {
+			fail("element segment %d refers to an undefined table", i)
+		}
+		if segment.active {
+			validateConstExpr(m, segment.offset, I32, fmt.Sprintf("element segment %d offset", i))
+		}
+		for _, f := range segment.functions {
+			if f != nullFunctionReference && uint32(f) >= m.numFunctions() {
+				fail("element segment %d refers to undefined function %d", i, f)
+			}
+		}
+	}
+	for i, segment := range m.data {
+		if segment.active && m.memory == nil {
+			fail("data segment %d refers to an undefined memory", i)
+		}
+		if segment.active {
+			validateConstExpr(m, segment.offset, I32, fmt.Sprintf("data segment %d offset", i))
+		}
+	}
+	if m.dataCount >= 0 && m.dataCount != int64(len(m.data)) {
+		fail("data count section declares %d segments but the data section has %d", m.dataCount, len(m.data))
+	}
+}
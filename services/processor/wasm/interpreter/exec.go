@@ -0,0 +1,544 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+import (
+	"encoding/binary"
+	"math"
+	"math/bits"
+)
+
+type label struct {
+	height       int
+	arity        int
+	results      int
+	continuation int
+	loop         bool
+}
+
+// leaving a block keeps only its results, so code the decoder did not fully validate cannot grow the stack without bound
+func (in *Instance) leaveBlock(labels []label) []label {
+	l := labels[len(labels)-1]
+	if len(in.stack) > l.height+l.results {
+		copy(in.stack[l.height:], in.stack[len(in.stack)-l.results:])
+		in.stack = in.stack[:l.height+l.results]
+	}
+	return labels[:len(labels)-1]
+}
+
+func (in *Instance) push(v uint64) {
+	in.stack = append(in.stack, v)
+}
+
+func (in *Instance) pop() uint64 {
+	v := in.stack[len(in.stack)-1]
+	in.stack = in.stack[:len(in.stack)-1]
+	return v
+}
+
+func (in *Instance) push32(v uint32) {
+	in.stack = append(in.stack, uint64(v))
+}
+
+func (in *Instance) pop32() uint32 {
+	return uint32(in.pop())
+}
+
+func (in *Instance) pushBool(b bool) {
+	if b {
+		in.push(1)
+	} else {
+		in.push(0)
+	}
+}
+
+func (in *Instance) call(index uint32, depth int) error {
+	if depth > in.limits.MaxCallDepth {
+		return trap("call stack exhausted")
+	}
+	if len(in.stack) > in.limits.MaxStackSize {
+		return trap("value stack exhausted")
+	}
+
+	functionType, _ := in.module.functionType(index)
+	numParams := len(functionType.Params)
+
+	if index < uint32(len(in.hostFunctions)) {
+		args := make([]uint64, numParams)
+		copy(args, in.stack[len(in.stack)-numParams:])
+		in.stack = in.stack[:len(in.stack)-numParams]
+		results, err := in.hostFunctions[index].Call(in, args)
+		if err != nil {
+			return err
+		}
+		if len(results) != len(functionType.Results) {
+			return trap("host function returned %d results instead of %d", len(results), len(functionType.Results))
+		}
+		in.stack = append(in.stack, results...)
+		return nil
+	}
+
+	f := in.module.functions[index-uint32(len(in.hostFunctions))]
+	locals := make([]uint64, numParams+len(f.locals))
+	copy(locals, in.stack[len(in.stack)-numParams:])
+	in.stack = in.stack[:len(in.stack)-numParams]
+
+	return in.execute(f, locals, len(functionType.Results), depth)
+}
+
+func (in *Instance) branch(labels []label, depth int) ([]label, int) {
+	target := labels[len(labels)-1-depth]
+	if target.arity > 0 {
+		copy(in.stack[target.height:], in.stack[len(in.stack)-target.arity:])
+	}
+	in.stack = in.stack[:target.height+target.arity]
+	if target.loop {
+		return labels[:len(labels)-depth], target.continuation
+	}
+	return labels[:len(labels)-1-depth], target.continuation
+}
+
+func (in *Instance) address(base uint32, offset uint64, size uint64) uint64 {
+	ea := uint64(base) + offset
+	if ea+size > uint64(len(in.memory)) {
+		panic(trap("out of bounds memory access at %d", ea))
+	}
+	return ea
+}
+
+func (in *Instance) useInstructions(n uint64) error {
+	in.instructionsUsed += n
+	if in.limits.MaxInstructions > 0 && in.instructionsUsed > in.limits.MaxInstructions {
+		return ErrInstructionLimitExceeded
+	}
+	return nil
+}
+
+func (in *Instance) execute(f *function, locals []uint64, numResults int, depth int) (err error) {
+	// memory access traps are raised as panics to keep the hot path short
+	defer func() {
+		if r := recover(); r != nil {
+			t, ok := r.(*Trap)
+			if !ok {
+				panic(r)
+			}
+			err = t
+		}
+	}()
+
+	code := f.code
+	labels := make([]label, 1, 16)
+	labels[0] = label{height: len(in.stack), arity: numResults, results: numResults, continuation: len(code)}
+	mem := binary.LittleEndian
+
+	for pc := 0; pc < len(code); {
+		ins := &code[pc]
+		pc++
+
+		in.instructionsUsed++
+		if in.limits.MaxInstructions > 0 && in.instructionsUsed > in.limits.MaxInstructions {
+			return ErrInstructionLimitExceeded
+		}
+
+		switch ins.opcode {
+		case opUnreachable:
+			return trap("unreachable executed")
+		case opNop:
+		case opBlock:
+			labels = append(labels, label{height: len(in.stack) - int(ins.params), arity: int(ins.results), results: int(ins.results), continuation: int(ins.end) + 1})
+		case opLoop:
+			labels = append(labels, label{height: len(in.stack) - int(ins.params), arity: int(ins.params), results: int(ins.results), continuation: pc, loop: true})
+		case opIf:
+			condition := in.pop32()
+			l := label{height: len(in.stack) - int(ins.params), arity: int(ins.results), results: int(ins.results), continuation: int(ins.end) + 1}
+			if condition != 0 {
+				labels = append(labels, l)
+			} else if ins.els != noElse {
+				labels = append(labels, l)
+				pc = int(ins.els) + 1
+			} else {
+				pc = int(ins.end) + 1
+			}
+		case opElse: // reached the end of the then branch
+			labels = in.leaveBlock(labels)
+			pc = int(ins.end) + 1
+		case opEnd:
+			labels = in.leaveBlock(labels)
+		case opBr:
+			labels, pc = in.branch(labels, int(ins.a))
+		case opBrIf:
+			if in.pop32() != 0 {
+				labels, pc = in.branch(labels, int(ins.a))
+			}
+		case opBrTable:
+			i := uint64(in.pop32())
+			if i > ins.b {
+				i = ins.b
+			}
+			labels, pc = in.branch(labels, int(f.brTables[ins.a+i]))
+		case opReturn:
+			labels, pc = in.branch(labels, len(labels)-1)
+		case opCall:
+			if err := in.call(uint32(ins.a), depth+1); err != nil {
+				return err
+			}
+		case opCallIndirect:
+			i := in.pop32()
+			if uint64(i) >= uint64(len(in.table)) {
+				return trap("indirect call to undefined table element %d", i)
+			}
+			target := in.table[i]
+			if target == nullFunctionReference {
+				return trap("indirect call to uninitialized table element %d", i)
+			}
+			actualType, _ := in.module.functionType(uint32(target))
+			if !actualType.Equals(in.module.Types[ins.a]) {
+				return trap("indirect call signature mismatch")
+			}
+			if err := in.call(uint32(target), depth+1); err != nil {
+				return err
+			}
+
+		case opDrop:
+			in.pop()
+		case opSelect:
+			condition := in.pop32()
+			b := in.pop()
+			a := in.pop()
+			if condition != 0 {
+				in.push(a)
+			} else {
+				in.push(b)
+			}
+
+		case opLocalGet:
+			in.push(locals[ins.a])
+		case opLocalSet:
+			locals[ins.a] = in.pop()
+		case opLocalTee:
+			locals[ins.a] = in.stack[len(in.stack)-1]
+		case opGlobalGet:
+			in.push(in.globals[ins.a])
+		case opGlobalSet:
+			in.globals[ins.a] = in.pop()
+
+		case opI32Load:
+			in.push32(mem.Uint32(in.memory[in.address(in.pop32(), ins.a, 4):]))
+		case opI64Load:
+			in.push(mem.Uint64(in.memory[in.address(in.pop32(), ins.a, 8):]))
+		case opI32Load8S:
+			in.push32(uint32(int32(int8(in.memory[in.address(in.pop32(), ins.a, 1)]))))
+		case opI32Load8U:
+			in.push32(uint32(in.memory[in.address(in.pop32(), ins.a, 1)]))
+		case opI32Load16S:
+			in.push32(uint32(int32(int16(mem.Uint16(in.memory[in.address(in.pop32(), ins.a, 2):])))))
+		case opI32Load16U:
+			in.push32(uint32(mem.Uint16(in.memory[in.address(in.pop32(), ins.a, 2):])))
+		case opI64Load8S:
+			in.push(uint64(int64(int8(in.memory[in.address(in.pop32(), ins.a, 1)]))))
+		case opI64Load8U:
+			in.push(uint64(in.memory[in.address(in.pop32(), ins.a, 1)]))
+		case opI64Load16S:
+			in.push(uint64(int64(int16(mem.Uint16(in.memory[in.address(in.pop32(), ins.a, 2):])))))
+		case opI64Load16U:
+			in.push(uint64(mem.Uint16(in.memory[in.address(in.pop32(), ins.a, 2):])))
+		case opI64Load32S:
+			in.push(uint64(int64(int32(mem.Uint32(in.memory[in.address(in.pop32(), ins.a, 4):])))))
+		case opI64Load32U:
+			in.push(uint64(mem.Uint32(in.memory[in.address(in.pop32(), ins.a, 4):])))
+		case opI32Store:
+			v := in.pop32()
+			mem.PutUint32(in.memory[in.address(in.pop32(), ins.a, 4):], v)
+		case opI64Store:
+			v := in.pop()
+			mem.PutUint64(in.memory[in.address(in.pop32(), ins.a, 8):], v)
+		case opI32Store8, opI64Store8:
+			v := in.pop()
+			in.memory[in.address(in.pop32(), ins.a, 1)] = byte(v)
+		case opI32Store16, opI64Store16:
+			v := in.pop()
+			mem.PutUint16(in.memory[in.address(in.pop32(), ins.a, 2):], uint16(v))
+		case opI64Store32:
+			v := in.pop()
+			mem.PutUint32(in.memory[in.address(in.pop32(), ins.a, 4):], uint32(v))
+		case opMemorySize:
+			in.push32(uint32(len(in.memory) / PageSize))
+		case opMemoryGrow:
+			delta := in.pop32()
+			pages := uint32(len(in.memory) / PageSize)
+			if uint64(pages)+uint64(delta) > uint64(in.maxPages) {
+				in.push32(math.MaxUint32)
+				break
+			}
+			if err := in.useInstructions(uint64(delta) * PageSize / 64); err != nil {
+				return err
+			}
+			in.memory = append(in.memory, make([]byte, int(delta)*PageSize)...)
+			in.push32(pages)
+		case opMemoryInit:
+			n, src, dst := in.pop32(), in.pop32(), in.pop32()
+			if ins.a >= uint64(len(in.data)) {
+				return trap("memory.init of undefined data segment %d", ins.a)
+			}
+			segment := in.data[ins.a]
+			if uint64(src)+uint64(n) > uint64(len(segment)) {
+				return trap("memory.init out of bounds of data segment %d", ins.a)
+			}
+			if err := in.useInstructions(uint64(n) / 64); err != nil {
+				return err
+			}
+			copy(in.memory[in.address(dst, 0, uint64(n)):], segment[src:src+n])
+		case opDataDrop:
+			if ins.a >= uint64(len(in.data)) {
+				return trap("data.drop of undefined data segment %d", ins.a)
+			}
+			in.data[ins.a] = nil
+		case opMemoryCopy:
+			n, src, dst := in.pop32(), in.pop32(), in.pop32()
+			if err := in.useInstructions(uint64(n) / 64); err != nil {
+				return err
+			}
+			s := in.address(src, 0, uint64(n))
+			d := in.address(dst, 0, uint64(n))
+			copy(in.memory[d:d+uint64(n)], in.memory[s:s+uint64(n)])
+		case opMemoryFill:
+			n, v, dst := in.pop32(), in.pop32(), in.pop32()
+			if err := in.useInstructions(uint64(n) / 64); err != nil {
+				return err
+			}
+			d := in.address(dst, 0, uint64(n))
+			region := in.memory[d : d+uint64(n)]
+			for i := range region {
+				region[i] = byte(v)
+			}
+
+		case opI32Const, opI64Const:
+			in.push(ins.a)
+
+		case opI32Eqz:
+			in.pushBool(in.pop32() == 0)
+		case opI32Eq, opI32Ne, opI32LtS, opI32LtU, opI32GtS, opI32GtU, opI32LeS, opI32LeU, opI32GeS, opI32GeU:
+			b := in.pop32()
+			a := in.pop32()
+			in.pushBool(compare32(ins.opcode, a, b))
+		case opI64Eqz:
+			in.pushBool(in.pop() == 0)
+		case opI64Eq, opI64Ne, opI64LtS, opI64LtU, opI64GtS, opI64GtU, opI64LeS, opI64LeU, opI64GeS, opI64GeU:
+			b := in.pop()
+			a := in.pop()
+			in.pushBool(compare64(ins.opcode, a, b))
+
+		case opI32Clz:
+			in.push32(uint32(bits.LeadingZeros32(in.pop32())))
+		case opI32Ctz:
+			in.push32(uint32(bits.TrailingZeros32(in.pop32())))
+		case opI32Popcnt:
+			in.push32(uint32(bits.OnesCount32(in.pop32())))
+		case opI32Add, opI32Sub, opI32Mul, opI32DivS, opI32DivU, opI32RemS, opI32RemU, opI32And, opI32Or, opI32Xor, opI32Shl, opI32ShrS, opI32ShrU, opI32Rotl, opI32Rotr:
+			b := in.pop32()
+			a := in.pop32()
+			result, err := arithmetic32(ins.opcode, a, b)
+			if err != nil {
+				return err
+			}
+			in.push32(result)
+
+		case opI64Clz:
+			in.push(uint64(bits.LeadingZeros64(in.pop())))
+		case opI64Ctz:
+			in.push(uint64(bits.TrailingZeros64(in.pop())))
+		case opI64Popcnt:
+			in.push(uint64(bits.OnesCount64(in.pop())))
+		case opI64Add, opI64Sub, opI64Mul, opI64DivS, opI64DivU, opI64RemS, opI64RemU, opI64And, opI64Or, opI64Xor, opI64Shl, opI64ShrS, opI64ShrU, opI64Rotl, opI64Rotr:
+			b := in.pop()
+			a := in.pop()
+			result, err := arithmetic64(ins.opcode, a, b)
+			if err != nil {
+				return err
+			}
+			in.push(result)
+
+		case opI32WrapI64:
+			in.push32(uint32(in.pop()))
+		case opI64ExtendI32S:
+			in.push(uint64(int64(int32(in.pop32()))))
+		case opI64ExtendI32U:
+			in.push(uint64(in.pop32()))
+		case opI32Extend8S:
+			in.push32(uint32(int32(int8(in.pop32()))))
+		case opI32Extend16S:
+			in.push32(uint32(int32(int16(in.pop32()))))
+		case opI64Extend8S:
+			in.push(uint64(int64(int8(in.pop()))))
+		case opI64Extend16S:
+			in.push(uint64(int64(int16(in.pop()))))
+		case opI64Extend32S:
+			in.push(uint64(int64(int32(in.pop()))))
+
+		default:
+			return trap("unsupported instruction 0x%x", ins.opcode)
+		}
+	}
+
+	return nil
+}
+
+func compare32(opcode uint16, a, b uint32) bool {
+	switch opcode {
+	case opI32Eq:
+		return a == b
+	case opI32Ne:
+		return a != b
+	case opI32LtS:
+		return int32(a) < int32(b)
+	case opI32LtU:
+		return a < b
+	case opI32GtS:
+		return int32(a) > int32(b)
+	case opI32GtU:
+		return a > b
+	case opI32LeS:
+		return int32(a) <= int32(b)
+	case opI32LeU:
+		return a <= b
+	case opI32GeS:
+		return int32(a) >= int32(b)
+	default: // opI32GeU
+		return a >= b
+	}
+}
+
+func compare64(opcode uint16, a, b uint64) bool {
+	switch opcode {
+	case opI64Eq:
+		return a == b
+	case opI64Ne:
+		return a != b
+	case opI64LtS:
+		return int64(a) < int64(b)
+	case opI64LtU:
+		return a < b
+	case opI64GtS:
+		return int64(a) > int64(b)
+	case opI64GtU:
+		return a > b
+	case opI64LeS:
+		return int64(a) <= int64(b)
+	case opI64LeU:
+		return a <= b
+	case opI64GeS:
+		return int64(a) >= int64(b)
+	default: // opI64GeU
+		return a >= b
+	}
+}
+
+func arithmetic32(opcode uint16, a, b uint32) (uint32, error) {
+	switch opcode {
+	case opI32Add:
+		return a + b, nil
+	case opI32Sub:
+		return a - b, nil
+	case opI32Mul:
+		return a * b, nil
+	case opI32DivS:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		if int32(a) == math.MinInt32 && int32(b) == -1 {
+			return 0, trap("integer overflow")
+		}
+		return uint32(int32(a) / int32(b)), nil
+	case opI32DivU:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		return a / b, nil
+	case opI32RemS:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		if int32(b) == -1 {
+			return 0, nil
+		}
+		return uint32(int32(a) % int32(b)), nil
+	case opI32RemU:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		return a % b, nil
+	case opI32And:
+		return a & b, nil
+	case opI32Or:
+		return a | b, nil
+	case opI32Xor:
+		return a ^ b, nil
+	case opI32Shl:
+		return a << (b & 31), nil
+	case opI32ShrS:
+		return uint32(int32(a) >> (b & 31)), nil
+	case opI32ShrU:
+		return a >> (b & 31), nil
+	case opI32Rotl:
+		return bits.RotateLeft32(a, int(b&31)), nil
+	default: // opI32Rotr
+		return bits.RotateLeft32(a, -int(b&31)), nil
+	}
+}
+
+func arithmetic64(opcode uint16, a, b uint64) (uint64, error) {
+	switch opcode {
+	case opI64Add:
+		return a + b, nil
+	case opI64Sub:
+		return a - b, nil
+	case opI64Mul:
+		return a * b, nil
+	case opI64DivS:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		if int64(a) == math.MinInt64 && int64(b) == -1 {
+			return 0, trap("integer overflow")
+		}
+		return uint64(int64(a) / int64(b)), nil
+	case opI64DivU:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		return a / b, nil
+	case opI64RemS:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		if int64(b) == -1 {
+			return 0, nil
+		}
+		return uint64(int64(a) % int64(b)), nil
+	case opI64RemU:
+		if b == 0 {
+			return 0, trap("integer divide by zero")
+		}
+		return a % b, nil
+	case opI64And:
+		return a & b, nil
+	case opI64Or:
+		return a | b, nil
+	case opI64Xor:
+		return a ^ b, nil
+	case opI64Shl:
+		return a << (b & 63), nil
+	case opI64ShrS:
+		return uint64(int64(a) >> (b & 63)), nil
+	case opI64ShrU:
+		return a >> (b & 63), nil
+	case opI64Rotl:
+		return bits.RotateLeft64(a, int(b&63)), nil
+	default: // opI64Rotr
+		return bits.RotateLeft64(a, -int(b&63)), nil
+	}
+}
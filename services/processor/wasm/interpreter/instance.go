@@ -0,0 +1,222 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+const PageSize = 65536
+
+var ErrInstructionLimitExceeded = errors.New("wasm execution exceeded the instruction limit")
+
+// Trap is a runtime failure of the executed code, like an out of bounds memory access or an unreachable instruction
+type Trap struct {
+	Message string
+}
+
+func (t *Trap) Error() string {
+	return "wasm trap: " + t.Message
+}
+
+func trap(format string, args ...interface{}) *Trap {
+	return &Trap{Message: fmt.Sprintf(format, args...)}
+}
+
+// HostFunction is a function implemented by the node and imported by the module, returning an error aborts the execution
+type HostFunction struct {
+	Type FunctionType
+	Call func(instance *Instance, args []uint64) ([]uint64, error)
+}
+
+// Imports are the host functions by module name and function name
+type Imports map[string]map[string]*HostFunction
+
+// Limits bound the resources used by an instance, zero MaxInstructions means unlimited and other zero values use defaults
+type Limits struct {
+	MaxInstructions uint64
+	MaxMemoryPages  uint32
+	MaxCallDepth    int
+	MaxStackSize    int
+}
+
+const (
+	defaultMaxMemoryPages = 65536
+	defaultMaxCallDepth   = 512
+	defaultMaxStackSize   = 1 << 20
+)
+
+func (l Limits) withDefaults() Limits {
+	if l.MaxMemoryPages == 0 || l.MaxMemoryPages > defaultMaxMemoryPages {
+		l.MaxMemoryPages = defaultMaxMemoryPages
+	}
+	if l.MaxCallDepth == 0 {
+		l.MaxCallDepth = defaultMaxCallDepth
+	}
+	if l.MaxStackSize == 0 {
+		l.MaxStackSize = defaultMaxStackSize
+	}
+	return l
+}
+
+// Instance is a module with its own memory, globals and table, it is not safe for concurrent use
+type Instance struct {
+	module *Module
+	limits Limits
+
+	hostFunctions []*HostFunction
+	memory        []byte
+	maxPages      uint32
+	globals       []uint64
+	table         []int64
+	data          [][]byte
+
+	stack            []uint64
+	instructionsUsed uint64
+}
+
+func Instantiate(module *Module, imports Imports, limits Limits) (*Instance, error) {
+	in := &Instance{
+		module:  module,
+		limits:  limits.withDefaults(),
+		globals: make([]uint64, len(module.globals)),
+	}
+
+	for _, imp := range module.Imports {
+		host, found := imports[imp.Module][imp.Name]
+		if !found {
+			return nil, errors.Errorf("unresolved import %s.%s", imp.Module, imp.Name)
+		}
+		if !host.Type.Equals(module.Types[imp.TypeIndex]) {
+			return nil, errors.Errorf("import %s.%s has a different signature than the host function", imp.Module, imp.Name)
+		}
+		in.hostFunctions = append(in.hostFunctions, host)
+	}
+
+	for i, g := range module.globals {
+		in.globals[i] = in.evaluate(g.init)
+	}
+
+	if module.memory != nil {
+		in.maxPages = in.limits.MaxMemoryPages
+		if module.memory.hasMax && module.memory.max < in.maxPages {
+			in.maxPages = module.memory.max
+		}
+		if module.memory.min > in.maxPages {
+			return nil, errors.Errorf("module requires %d memory pages but only %d are allowed", module.memory.min, in.maxPages)
+		}
+		in.memory = make([]byte, int(module.memory.min)*PageSize)
+	}
+
+	if module.table != nil {
+		if module.table.min > maxItemsInVector {
+			return nil, errors.Errorf("table of %d elements is too large", module.table.min)
+		}
+		in.table = make([]int64, module.table.min)
+		for i := range in.table {
+			in.table[i] = nullFunctionReference
+		}
+	}
+	for i, segment := range module.elements {
+		if !segment.active {
+			continue
+		}
+		offset := uint64(uint32(in.evaluate(segment.offset)))
+		if offset+uint64(len(segment.functions)) > uint64(len(in.table)) {
+			return nil, errors.Errorf("element segment %d does not fit in the table", i)
+		}
+		copy(in.table[offset:], segment.functions)
+	}
+
+	in.data = make([][]byte, len(module.data))
+	for i, segment := range module.data {
+		if !segment.active {
+			in.data[i] = segment.init
+			continue
+		}
+		offset := uint64(uint32(in.evaluate(segment.offset)))
+		if offset+uint64(len(segment.init)) > uint64(len(in.memory)) {
+			return nil, errors.Errorf("data segment %d does not fit in memory", i)
+		}
+		copy(in.memory[offset:], segment.init)
+	}
+
+	if module.start >= 0 {
+		if err := in.protectedCall(uint32(module.start)); err != nil {
+			return nil, errors.Wrap(err, "start function failed")
+		}
+	}
+
+	return in, nil
+}
+
+func (in *Instance) evaluate(expr constExpr) uint64 {
+	if expr.opcode == opGlobalGet {
+		return in.globals[expr.value]
+	}
+	return expr.value
+}
+
+// Invoke calls an exported function with its arguments encoded as i32 or i64 values
+func (in *Instance) Invoke(name string, args ...uint64) ([]uint64, error) {
+	e, found := in.module.exports[name]
+	if !found || e.kind != externalKindFunction {
+		return nil, errors.Errorf("function %s is not exported", name)
+	}
+	functionType, _ := in.module.functionType(e.index)
+	if len(args) != len(functionType.Params) {
+		return nil, errors.Errorf("function %s takes %d arguments but received %d", name, len(functionType.Params), len(args))
+	}
+
+	// host functions may invoke exported functions while the instance is executing, so the current stack is kept
+	base := len(in.stack)
+	in.stack = append(in.stack, args...)
+	if err := in.protectedCall(e.index); err != nil {
+		in.stack = in.stack[:base]
+		return nil, err
+	}
+
+	results := make([]uint64, len(functionType.Results))
+	copy(results, in.stack[base:])
+	in.stack = in.stack[:base]
+	return results, nil
+}
+
+// the decoder validates the operand types of all code, so a runtime panic can only come from a bug in the interpreter, it is reported as a trap
+func (in *Instance) protectedCall(index uint32) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = trap("invalid code: %v", r)
+		}
+	}()
+	return in.call(index, 0)
+}
+
+func (in *Instance) InstructionsUsed() uint64 {
+	return in.instructionsUsed
+}
+
+// ReadMemory returns a copy of a memory range
+func (in *Instance) ReadMemory(ptr uint32, length uint32) ([]byte, error) {
+	end := uint64(ptr) + uint64(length)
+	if end > uint64(len(in.memory)) {
+		return nil, trap("memory read of %d bytes at %d is out of bounds", length, ptr)
+	}
+	result := make([]byte, length)
+	copy(result, in.memory[ptr:end])
+	return result, nil
+}
+
+func (in *Instance) WriteMemory(ptr uint32, data []byte) error {
+	end := uint64(ptr) + uint64(len(data))
+	if end > uint64(len(in.memory)) {
+		return trap("memory write of %d bytes at %d is out of bounds", len(data), ptr)
+	}
+	copy(in.memory[ptr:end], data)
+	return nil
+}
@@ -0,0 +1,271 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+import (
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+var i32 = []byte{builders.WASM_I32}
+var i64 = []byte{builders.WASM_I64}
+var none []byte
+
+func instantiate(t *testing.T, code []byte, imports Imports, limits Limits) *Instance {
+	module, err := Decode(code)
+	require.NoError(t, err)
+	instance, err := Instantiate(module, imports, limits)
+	require.NoError(t, err)
+	return instance
+}
+
+func op(opcode byte, immediates ...uint32) []byte {
+	return builders.WasmInstr(opcode, immediates...)
+}
+
+func TestInterpreter_RecursiveFactorial(t *testing.T) {
+	m := builders.WasmModule()
+	fac := m.Function(m.Type(i64, i64), none,
+		op(opLocalGet, 0), builders.WasmI64Const(2), op(opI64LtU),
+		op(opIf), []byte{builders.WASM_I64},
+		builders.WasmI64Const(1),
+		op(opElse),
+		op(opLocalGet, 0), op(opLocalGet, 0), builders.WasmI64Const(1), op(opI64Sub), op(opCall, 0), op(opI64Mul),
+		op(opEnd))
+	m.ExportFunction("fac", fac)
+
+	results, err := instantiate(t, m.Build(), nil, Limits{}).Invoke("fac", 20)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2432902008176640000}, results)
+}
+
+func TestInterpreter_LoopWithLocals(t *testing.T) {
+	m := builders.WasmModule()
+	sum := m.Function(m.Type(i32, i32), i32,
+		op(opBlock), []byte{0x40},
+		op(opLoop), []byte{0x40},
+		op(opLocalGet, 0), op(opI32Eqz), op(opBrIf, 1),
+		op(opLocalGet, 1), op(opLocalGet, 0), op(opI32Add), op(opLocalSet, 1),
+		op(opLocalGet, 0), builders.WasmI32Const(1), op(opI32Sub), op(opLocalSet, 0),
+		op(opBr, 0),
+		op(opEnd),
+		op(opEnd),
+		op(opLocalGet, 1))
+	m.ExportFunction("sum", sum)
+
+	results, err := instantiate(t, m.Build(), nil, Limits{}).Invoke("sum", 100)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5050}, results)
+}
+
+func TestInterpreter_BranchTable(t *testing.T) {
+	m := builders.WasmModule()
+	// returns 10 for 0, 20 for 1 and 30 for anything else
+	choose := m.Function(m.Type(i32, i32), none,
+		op(opBlock), []byte{0x40},
+		op(opBlock), []byte{0x40},
+		op(opBlock), []byte{0x40},
+		op(opLocalGet, 0), op(opBrTable, 2, 0, 1, 2),
+		op(opEnd), builders.WasmI32Const(10), op(opReturn),
+		op(opEnd), builders.WasmI32Const(20), op(opReturn),
+		op(opEnd), builders.WasmI32Const(30))
+	m.ExportFunction("choose", choose)
+	instance := instantiate(t, m.Build(), nil, Limits{})
+
+	for input, expected := range map[uint64]uint64{0: 10, 1: 20, 2: 30, 1000: 30} {
+		results, err := instance.Invoke("choose", input)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{expected}, results, "choose(%d)", input)
+	}
+}
+
+func TestInterpreter_MemoryDataAndGrow(t *testing.T) {
+	m := builders.WasmModule().MemoryWithMax(1, 2).Data(16, []byte{0x01, 0x02, 0x03, 0x04})
+	load := m.Function(m.Type(none, i32), none,
+		builders.WasmI32Const(16), op(opI32Load, 2, 0))
+	store := m.Function(m.Type(i64, none), none,
+		builders.WasmI32Const(100), op(opLocalGet, 0), op(opI64Store, 3, 8))
+	grow := m.Function(m.Type(i32, i32), none,
+		op(opLocalGet, 0), op(opMemoryGrow, 0))
+	m.ExportFunction("load", load).ExportFunction("store", store).ExportFunction("grow", grow)
+	instance := instantiate(t, m.Build(), nil, Limits{})
+
+	results, err := instance.Invoke("load")
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0x04030201}, results, "data segment should be loaded little endian")
+
+	_, err = instance.Invoke("store", 0x1122334455667788)
+	require.NoError(t, err)
+	stored, err := instance.ReadMemory(108, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x88, 0x77}, stored, "store should apply the static offset")
+
+	results, err = instance.Invoke("grow", 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1}, results, "grow should return the previous size in pages")
+
+	results, err = instance.Invoke("grow", 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{0xffffffff}, results, "grow beyond the maximum should fail")
+}
+
+func TestInterpreter_Traps(t *testing.T) {
+	m := builders.WasmModule().Memory(1)
+	m.ExportFunction("unreachable", m.Function(m.Type(none, none), none, op(opUnreachable)))
+	m.ExportFunction("divide", m.Function(m.Type([]byte{builders.WASM_I32, builders.WASM_I32}, i32), none,
+		op(opLocalGet, 0), op(opLocalGet, 1), op(opI32DivS)))
+	m.ExportFunction("outOfBounds", m.Function(m.Type(none, i32), none,
+		builders.WasmI32Const(PageSize-2), op(opI32Load, 2, 0)))
+	m.ExportFunction("recurse", m.Function(m.Type(none, none), none, op(opCall, 3)))
+	instance := instantiate(t, m.Build(), nil, Limits{})
+
+	var trap *Trap
+
+	_, err := instance.Invoke("unreachable")
+	require.IsType(t, trap, err)
+
+	_, err = instance.Invoke("divide", 1, 0)
+	require.IsType(t, trap, err, "division by zero should trap")
+
+	_, err = instance.Invoke("divide", 0x80000000, 0xffffffff)
+	require.IsType(t, trap, err, "signed division overflow should trap")
+
+	_, err = instance.Invoke("outOfBounds")
+	require.IsType(t, trap, err)
+
+	_, err = instance.Invoke("recurse")
+	require.IsType(t, trap, err, "infinite recursion should exhaust the call stack")
+
+	results, err := instance.Invoke("divide", 7, 2)
+	require.NoError(t, err, "instance should be usable after a trap")
+	require.Equal(t, []uint64{3}, results)
+}
+
+func TestInterpreter_StopsAfterInstructionLimit(t *testing.T) {
+	m := builders.WasmModule()
+	m.ExportFunction("forever", m.Function(m.Type(none, none), none,
+		op(opLoop), []byte{0x40}, op(opBr, 0), op(opEnd)))
+	instance := instantiate(t, m.Build(), nil, Limits{MaxInstructions: 10000})
+
+	_, err := instance.Invoke("forever")
+	require.Equal(t, ErrInstructionLimitExceeded, err)
+	require.EqualValues(t, 10001, instance.InstructionsUsed())
+}
+
+func TestInterpreter_CallsHostFunctions(t *testing.T) {
+	m := builders.WasmModule()
+	addTen := m.ImportFunction("env", "addTen", m.Type(i64, i64))
+	m.ExportFunction("run", m.Function(m.Type(i64, i64), none,
+		op(opLocalGet, 0), op(opCall, addTen), op(opCall, addTen)))
+	code := m.Build()
+
+	imports := Imports{"env": {"addTen": &HostFunction{
+		Type: FunctionType{Params: []ValueType{I64}, Results: []ValueType{I64}},
+		Call: func(instance *Instance, args []uint64) ([]uint64, error) {
+			return []uint64{args[0] + 10}, nil
+		},
+	}}}
+
+	results, err := instantiate(t, code, imports, Limits{}).Invoke("run", 5)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{25}, results)
+
+	module, err := Decode(code)
+	require.NoError(t, err)
+	_, err = Instantiate(module, Imports{}, Limits{})
+	require.Error(t, err, "missing imports should fail instantiation")
+
+	imports["env"]["addTen"].Type = FunctionType{Params: []ValueType{I32}, Results: []ValueType{I64}}
+	_, err = Instantiate(module, imports, Limits{})
+	require.Error(t, err, "imports with a different signature should fail instantiation")
+}
+
+func TestInterpreter_IndirectCallsGlobalsAndStartFunction(t *testing.T) {
+	m := builders.WasmModule().Table(2)
+	counter := m.Global(builders.WASM_I32, true, builders.WasmI32Const(40))
+	binary := m.Type(none, i32)
+	one := m.Function(binary, none, builders.WasmI32Const(1))
+	two := m.Function(binary, none, builders.WasmI32Const(2))
+	start := m.Function(m.Type(none, none), none,
+		op(opGlobalGet, counter), builders.WasmI32Const(2), op(opI32Add), op(opGlobalSet, counter))
+	dispatch := m.Function(m.Type(i32, i32), none,
+		op(opLocalGet, 0), op(opCallIndirect, binary, 0), op(opGlobalGet, counter), op(opI32Add))
+	m.Elements(0, one, two).Start(start).ExportFunction("dispatch", dispatch)
+	instance := instantiate(t, m.Build(), nil, Limits{})
+
+	results, err := instance.Invoke("dispatch", 1)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{44}, results, "start function should run on instantiation")
+
+	_, err = instance.Invoke("dispatch", 2)
+	require.IsType(t, &Trap{}, err, "calling outside the table should trap")
+}
+
+func TestDecode_RejectsNonDeterministicAndInvalidModules(t *testing.T) {
+	floatSignature := builders.WasmModule()
+	floatSignature.Type([]byte{0x7c}, none)
+	_, err := Decode(floatSignature.Build())
+	require.Error(t, err, "f64 types should be rejected")
+
+	floatInstruction := builders.WasmModule()
+	floatInstruction.Function(floatInstruction.Type(none, none), none, []byte{0x43, 0, 0, 0, 0}, op(opDrop))
+	_, err = Decode(floatInstruction.Build())
+	require.Error(t, err, "f32.const should be rejected")
+
+	_, err = Decode([]byte{0x00, 0x61, 0x73, 0x6d, 0x02, 0x00, 0x00, 0x00})
+	require.Error(t, err, "unknown versions should be rejected")
+
+	valid := builders.WasmModule()
+	valid.ExportFunction("f", valid.Function(valid.Type(none, none), none))
+	code := valid.Build()
+	_, err = Decode(code[:len(code)-1])
+	require.Error(t, err, "truncated modules should be rejected")
+
+	m, err := Decode(code)
+	require.NoError(t, err)
+	require.Equal(t, []string{"f"}, m.ExportedFunctions())
+}
+
+func TestDecode_RejectsIllTypedCode(t *testing.T) {
+	for name, body := range map[string][][]byte{
+		"operand stack underflow":       {builders.WasmI32Const(1), op(opI32Add), op(opDrop)},
+		"operand of the wrong type":     {builders.WasmI64Const(1), builders.WasmI32Const(1), op(opI32Add), op(opDrop)},
+		"operands left on the stack":    {builders.WasmI32Const(1)},
+		"branch condition of i64":       {op(opBlock), []byte{0x40}, builders.WasmI64Const(1), op(opBrIf, 0), op(opEnd)},
+		"if without else with a result": {builders.WasmI32Const(1), op(opIf), []byte{builders.WASM_I32}, builders.WasmI32Const(1), op(opEnd), op(opDrop)},
+		"select of different types":     {builders.WasmI32Const(1), builders.WasmI64Const(1), builders.WasmI32Const(1), op(opSelect), op(opDrop)},
+	} {
+		m := builders.WasmModule()
+		m.Function(m.Type(none, none), none, body...)
+		_, err := Decode(m.Build())
+		require.Error(t, err, name)
+	}
+
+	wrongResult := builders.WasmModule()
+	wrongResult.Function(wrongResult.Type(none, i32), none, builders.WasmI64Const(1))
+	_, err := Decode(wrongResult.Build())
+	require.Error(t, err, "result of the wrong type should be rejected")
+
+	wrongGlobal := builders.WasmModule()
+	wrongGlobal.Global(builders.WASM_I32, false, builders.WasmI64Const(1))
+	_, err = Decode(wrongGlobal.Build())
+	require.Error(t, err, "global initializer of the wrong type should be rejected")
+
+	afterUnreachable := builders.WasmModule()
+	afterUnreachable.Function(afterUnreachable.Type(none, i32), none, op(opUnreachable), op(opI32Add))
+	_, err = Decode(afterUnreachable.Build())
+	require.NoError(t, err, "operands after unreachable may be of any type")
+}
+
+func TestInstantiate_EnforcesMemoryLimit(t *testing.T) {
+	module, err := Decode(builders.WasmModule().Memory(3).Build())
+	require.NoError(t, err)
+
+	_, err = Instantiate(module, nil, Limits{MaxMemoryPages: 2})
+	require.Error(t, err)
+}
@@ -0,0 +1,167 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+import (
+	"sort"
+)
+
+type ValueType byte
+
+const (
+	I32 ValueType = 0x7f
+	I64 ValueType = 0x7e
+	F32 ValueType = 0x7d
+	F64 ValueType = 0x7c
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case I32:
+		return "i32"
+	case I64:
+		return "i64"
+	case F32:
+		return "f32"
+	case F64:
+		return "f64"
+	}
+	return "unknown"
+}
+
+type FunctionType struct {
+	Params  []ValueType
+	Results []ValueType
+}
+
+func (t FunctionType) Equals(other FunctionType) bool {
+	if len(t.Params) != len(other.Params) || len(t.Results) != len(other.Results) {
+		return false
+	}
+	for i := range t.Params {
+		if t.Params[i] != other.Params[i] {
+			return false
+		}
+	}
+	for i := range t.Results {
+		if t.Results[i] != other.Results[i] {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	externalKindFunction = 0x00
+	externalKindTable    = 0x01
+	externalKindMemory   = 0x02
+	externalKindGlobal   = 0x03
+)
+
+type Import struct {
+	Module    string
+	Name      string
+	TypeIndex uint32
+}
+
+type export struct {
+	kind  byte
+	index uint32
+}
+
+type limits struct {
+	min    uint32
+	max    uint32
+	hasMax bool
+}
+
+type global struct {
+	valueType ValueType
+	mutable   bool
+	init      constExpr
+}
+
+// constExpr is an initializer expression, either a constant, a global.get or a (possibly null) function reference
+type constExpr struct {
+	opcode byte
+	value  uint64
+}
+
+const nullFunctionReference = -1
+
+type elementSegment struct {
+	active    bool
+	table     uint32
+	offset    constExpr
+	functions []int64
+}
+
+type dataSegment struct {
+	active bool
+	offset constExpr
+	init   []byte
+}
+
+type function struct {
+	typeIndex uint32
+	locals    []ValueType
+	code      []instruction
+	brTables  []uint32
+}
+
+// Module is a decoded and validated WebAssembly module, it holds no execution state and may be instantiated any number of times
+type Module struct {
+	Types    []FunctionType
+	Imports  []Import
+	exports  map[string]export
+	start    int64
+	memory   *limits
+	table    *limits
+	globals  []global
+	elements []elementSegment
+	data     []dataSegment
+	// -1 when the module has no data count section
+	dataCount int64
+
+	functions []*function
+}
+
+func (m *Module) functionType(index uint32) (FunctionType, bool) {
+	if index < uint32(len(m.Imports)) {
+		return m.Types[m.Imports[index].TypeIndex], true
+	}
+	index -= uint32(len(m.Imports))
+	if index >= uint32(len(m.functions)) {
+		return FunctionType{}, false
+	}
+	return m.Types[m.functions[index].typeIndex], true
+}
+
+func (m *Module) numFunctions() uint32 {
+	return uint32(len(m.Imports) + len(m.functions))
+}
+
+// ExportedFunction returns the type of an exported function by its export name
+func (m *Module) ExportedFunction(name string) (FunctionType, bool) {
+	e, found := m.exports[name]
+	if !found || e.kind != externalKindFunction {
+		return FunctionType{}, false
+	}
+	return m.functionType(e.index)
+}
+
+// ExportedFunctions returns the sorted names of all exported functions
+func (m *Module) ExportedFunctions() []string {
+	var names []string
+	for name, e := range m.exports {
+		if e.kind == externalKindFunction {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
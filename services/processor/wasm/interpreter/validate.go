@@ -0,0 +1,297 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package interpreter
+
+// the type of an operand pushed by unreachable code, it matches any expected type
+const unknownType ValueType = 0
+
+type controlFrame struct {
+	opcode      uint16
+	signature   FunctionType
+	height      int
+	unreachable bool
+}
+
+// codeValidator follows the operand and control stacks of a function body while it is decoded, the same way the
+// WebAssembly specification validates code. A module which passes it can not underflow the operand stack or use
+// an operand of the wrong type when executed, so the interpreter does not need to check for it
+type codeValidator struct {
+	m        *Module
+	locals   []ValueType
+	operands []ValueType
+	controls []controlFrame
+}
+
+func newCodeValidator(m *Module, f *function) *codeValidator {
+	signature := m.Types[f.typeIndex]
+	v := &codeValidator{
+		m:      m,
+		locals: append(append([]ValueType{}, signature.Params...), f.locals...),
+	}
+	v.pushControl(opBlock, FunctionType{Results: signature.Results})
+	return v
+}
+
+func (v *codeValidator) push(t ValueType) {
+	v.operands = append(v.operands, t)
+}
+
+func (v *codeValidator) pushAll(types []ValueType) {
+	for _, t := range types {
+		v.push(t)
+	}
+}
+
+// pops an operand of the expected type, or of any type when expected is unknownType
+func (v *codeValidator) pop(expected ValueType) ValueType {
+	frame := &v.controls[len(v.controls)-1]
+	if len(v.operands) == frame.height {
+		if frame.unreachable {
+			return expected
+		}
+		fail("operand stack underflow")
+	}
+	actual := v.operands[len(v.operands)-1]
+	v.operands = v.operands[:len(v.operands)-1]
+	if actual != unknownType && expected != unknownType && actual != expected {
+		fail("type mismatch, expected %s but got %s", expected, actual)
+	}
+	if actual == unknownType {
+		return expected
+	}
+	return actual
+}
+
+func (v *codeValidator) popAll(types []ValueType) {
+	for i := len(types) - 1; i >= 0; i-- {
+		v.pop(types[i])
+	}
+}
+
+func (v *codeValidator) pushControl(opcode uint16, signature FunctionType) {
+	v.controls = append(v.controls, controlFrame{opcode: opcode, signature: signature, height: len(v.operands)})
+	v.pushAll(signature.Params)
+}
+
+func (v *codeValidator) popControl() controlFrame {
+	frame := v.controls[len(v.controls)-1]
+	v.popAll(frame.signature.Results)
+	if len(v.operands) != frame.height {
+		fail("block leaves %d extra operands on the stack", len(v.operands)-frame.height)
+	}
+	v.controls = v.controls[:len(v.controls)-1]
+	return frame
+}
+
+// branching to a loop continues it, so its label expects the loop parameters
+func (v *codeValidator) labelTypes(depth uint32) []ValueType {
+	frame := v.controls[len(v.controls)-1-int(depth)]
+	if frame.opcode == opLoop {
+		return frame.signature.Params
+	}
+	return frame.signature.Results
+}
+
+func (v *codeValidator) setUnreachable() {
+	frame := &v.controls[len(v.controls)-1]
+	v.operands = v.operands[:frame.height]
+	frame.unreachable = true
+}
+
+func (v *codeValidator) enterBlock(opcode uint16, signature FunctionType) {
+	if opcode == opIf {
+		v.pop(I32)
+	}
+	v.popAll(signature.Params)
+	v.pushControl(opcode, signature)
+}
+
+func (v *codeValidator) elseBlock() {
+	frame := v.popControl()
+	v.pushControl(opElse, frame.signature)
+}
+
+func (v *codeValidator) endBlock() {
+	frame := v.popControl()
+	if frame.opcode == opIf && !equalTypes(frame.signature.Params, frame.signature.Results) {
+		fail("if without else must leave its parameters as results")
+	}
+	v.pushAll(frame.signature.Results)
+}
+
+func (v *codeValidator) branch(depth uint32) {
+	v.popAll(v.labelTypes(depth))
+	v.setUnreachable()
+}
+
+func (v *codeValidator) branchIf(depth uint32) {
+	v.pop(I32)
+	types := v.labelTypes(depth)
+	v.popAll(types)
+	v.pushAll(types)
+}
+
+// the last depth is the default target
+func (v *codeValidator) branchTable(depths []uint32) {
+	v.pop(I32)
+	defaultTypes := v.labelTypes(depths[len(depths)-1])
+	for _, depth := range depths[:len(depths)-1] {
+		types := v.labelTypes(depth)
+		if len(types) != len(defaultTypes) {
+			fail("br_table targets have different arities")
+		}
+		v.popAll(types)
+		v.pushAll(types)
+	}
+	v.popAll(defaultTypes)
+	v.setUnreachable()
+}
+
+func (v *codeValidator) returnFromFunction() {
+	v.popAll(v.controls[0].signature.Results)
+	v.setUnreachable()
+}
+
+func (v *codeValidator) call(signature FunctionType) {
+	v.popAll(signature.Params)
+	v.pushAll(signature.Results)
+}
+
+// selectTypes is empty for the untyped select, which takes the type of its operands
+func (v *codeValidator) selectOperands(selectTypes []ValueType) {
+	if len(selectTypes) > 1 {
+		fail("select with %d result types", len(selectTypes))
+	}
+	v.pop(I32)
+	if len(selectTypes) == 1 {
+		v.pop(selectTypes[0])
+		v.pop(selectTypes[0])
+		v.push(selectTypes[0])
+		return
+	}
+	first := v.pop(unknownType)
+	second := v.pop(first)
+	if first == unknownType {
+		first = second
+	}
+	v.push(first)
+}
+
+func (v *codeValidator) local(opcode uint16, index uint32) {
+	t := v.locals[index]
+	switch opcode {
+	case opLocalGet:
+		v.push(t)
+	case opLocalSet:
+		v.pop(t)
+	case opLocalTee:
+		v.pop(t)
+		v.push(t)
+	}
+}
+
+func (v *codeValidator) global(opcode uint16, index uint32) {
+	t := v.m.globals[index].valueType
+	if opcode == opGlobalGet {
+		v.push(t)
+	} else {
+		v.pop(t)
+	}
+}
+
+func equalTypes(a []ValueType, b []ValueType) bool {
+	return FunctionType{Params: a}.Equals(FunctionType{Params: b})
+}
+
+// operator validates the instructions whose operand types only depend on the opcode
+func (v *codeValidator) operator(opcode uint16) {
+	params, results := operatorSignature(opcode)
+	v.popAll(params)
+	v.pushAll(results)
+}
+
+var (
+	noValues  = []ValueType{}
+	oneI32    = []ValueType{I32}
+	twoI32    = []ValueType{I32, I32}
+	threeI32  = []ValueType{I32, I32, I32}
+	oneI64    = []ValueType{I64}
+	twoI64    = []ValueType{I64, I64}
+	i32AndI64 = []ValueType{I32, I64}
+)
+
+func operatorSignature(opcode uint16) (params []ValueType, results []ValueType) {
+	switch {
+	case opcode == opI32Load || opcode >= opI32Load8S && opcode <= opI32Load16U:
+		return oneI32, oneI32
+	case opcode == opI64Load || opcode >= opI64Load8S && opcode <= opI64Load32U:
+		return oneI32, oneI64
+	case opcode == opI32Store || opcode == opI32Store8 || opcode == opI32Store16:
+		return twoI32, noValues
+	case opcode == opI64Store || opcode >= opI64Store8 && opcode <= opI64Store32:
+		return i32AndI64, noValues
+	case opcode == opMemorySize:
+		return noValues, oneI32
+	case opcode == opMemoryGrow:
+		return oneI32, oneI32
+	case opcode == opI32Const:
+		return noValues, oneI32
+	case opcode == opI64Const:
+		return noValues, oneI64
+	case opcode == opI32Eqz:
+		return oneI32, oneI32
+	case opcode >= opI32Eq && opcode <= opI32GeU:
+		return twoI32, oneI32
+	case opcode == opI64Eqz:
+		return oneI64, oneI32
+	case opcode >= opI64Eq && opcode <= opI64GeU:
+		return twoI64, oneI32
+	case opcode >= opI32Clz && opcode <= opI32Popcnt:
+		return oneI32, oneI32
+	case opcode >= opI32Add && opcode <= opI32Rotr:
+		return twoI32, oneI32
+	case opcode >= opI64Clz && opcode <= opI64Popcnt:
+		return oneI64, oneI64
+	case opcode >= opI64Add && opcode <= opI64Rotr:
+		return twoI64, oneI64
+	case opcode == opI32WrapI64:
+		return oneI64, oneI32
+	case opcode == opI64ExtendI32S || opcode == opI64ExtendI32U:
+		return oneI32, oneI64
+	case opcode == opI32Extend8S || opcode == opI32Extend16S:
+		return oneI32, oneI32
+	case opcode >= opI64Extend8S && opcode <= opI64Extend32S:
+		return oneI64, oneI64
+	case opcode == opMemoryInit || opcode == opMemoryCopy || opcode == opMemoryFill:
+		return threeI32, noValues
+	case opcode == opNop || opcode == opDataDrop:
+		return noValues, noValues
+	}
+	fail("no operand types for instruction 0x%x", opcode)
+	return nil, nil
+}
+
+// initializer expressions are constants or reads of earlier globals, their type must match what they initialize
+func validateConstExpr(m *Module, expr constExpr, expected ValueType, what string) {
+	var actual ValueType
+	switch expr.opcode {
+	case opI32Const:
+		actual = I32
+	case opI64Const:
+		actual = I64
+	case opGlobalGet:
+		if expr.value >= uint64(len(m.globals)) {
+			fail("%s refers to undefined global %d", what, expr.value)
+		}
+		actual = m.globals[expr.value].valueType
+	default:
+		fail("%s has unsupported initializer expression opcode 0x%x", what, expr.opcode)
+	}
+	if actual != expected {
+		fail("%s initializer has type %s instead of %s", what, actual, expected)
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor/wasm/interpreter"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PROCESSOR_TYPE_WASM is not part of orbs-spec, which only defines the native (1) and javascript (2) processor types.
+// Until the type is added to the ProcessorType enum in orbs-spec (an external module) the value is only reserved by
+// this repository, so clients and other node implementations do not know it and may assign 3 to another processor
+const PROCESSOR_TYPE_WASM = protocol.ProcessorType(3)
+
+// the processor refuses every call before this protocol version, so wasm contracts can neither be deployed (deployment
+// runs _init through the processor) nor executed. The maximal supported protocol version must not reach it before
+// PROCESSOR_TYPE_WASM is part of orbs-spec
+const WASM_PROCESSOR_PROTOCOL_VERSION = 4
+
+var LogTag = log.Service("processor-wasm")
+
+type service struct {
+	logger     log.Logger
+	config     config.WasmProcessorConfig
+	sdkHandler handlers.ContractSdkCallHandler
+
+	cache *moduleCache
+
+	metrics *metrics
+}
+
+type metrics struct {
+	processCallTime     *metric.Histogram
+	instructionsPerCall *metric.Histogram
+}
+
+func getMetrics(m metric.Factory) *metrics {
+	return &metrics{
+		processCallTime:     m.NewLatency("Processor.Wasm.ProcessCallTime.Millis", 10*time.Second),
+		instructionsPerCall: m.NewHistogram("Processor.Wasm.InstructionsPerCall.Count", 1000000000),
+	}
+}
+
+func NewWasmProcessor(config config.WasmProcessorConfig, parentLogger log.Logger, metricFactory metric.Factory) services.Processor {
+	return &service{
+		config:  config,
+		logger:  parentLogger.WithTags(LogTag),
+		metrics: getMetrics(metricFactory),
+		cache:   newModuleCache(),
+	}
+}
+
+// runs once on system initialization (called by the virtual machine constructor)
+func (s *service) RegisterContractSdkCallHandler(handler handlers.ContractSdkCallHandler) {
+	s.sdkHandler = handler
+}
+
+func (s *service) ProcessCall(ctx context.Context, input *services.ProcessCallInput) (*services.ProcessCallOutput, error) {
+	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	if err := s.validateProtocolVersion(ctx, input.ContextId); err != nil {
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_INPUT,
+		}, err
+	}
+
+	// retrieve code
+	module, err := s.retrieveModule(ctx, input.ContextId, string(input.ContractName))
+	if err != nil {
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_CONTRACT_NOT_DEPLOYED,
+		}, err
+	}
+
+	// get the method and check permissions
+	methodName := string(input.MethodName)
+	functionType, err := verifyMethod(module, string(input.ContractName), methodName, input.CallingPermissionScope)
+	if err != nil {
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_INPUT,
+		}, err
+	}
+	if functionType == nil { // optional system method which the contract does not implement
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: protocol.ArgumentsArrayEmpty(),
+			CallResult:          protocol.EXECUTION_RESULT_SUCCESS,
+		}, nil
+	}
+
+	start := time.Now()
+	defer s.metrics.processCallTime.RecordSince(start)
+
+	// execute
+	logger.Info("processor executing contract", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName))
+
+	c := newCall(s.sdkHandler, s.config, input.ContextId)
+	outputArgs, contractErr, err := c.run(module, methodName, *functionType, input.InputArgumentArray, s.limits())
+	s.metrics.instructionsPerCall.Record(int64(c.instructionsUsed))
+	if err != nil {
+		logger.Info("contract execution failed", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName), log.Error(err))
+
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(err.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_INPUT,
+		}, err
+	}
+
+	// result
+	if contractErr != nil {
+		logger.Info("contract returned error", log.Stringable("contract", input.ContractName), log.Stringable("method", input.MethodName), log.Error(contractErr))
+
+		return &services.ProcessCallOutput{
+			OutputArgumentArray: createMethodOutputArgsWithString(contractErr.Error()),
+			CallResult:          protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT,
+		}, contractErr
+	}
+	return &services.ProcessCallOutput{
+		OutputArgumentArray: outputArgs,
+		CallResult:          protocol.EXECUTION_RESULT_SUCCESS,
+	}, nil
+}
+
+func (s *service) GetContractInfo(ctx context.Context, input *services.GetContractInfoInput) (*services.GetContractInfoOutput, error) {
+	if err := s.validateProtocolVersion(ctx, input.ContextId); err != nil {
+		return nil, err
+	}
+
+	// retrieve code
+	_, err := s.retrieveModule(ctx, input.ContextId, string(input.ContractName))
+	if err != nil {
+		return nil, err
+	}
+
+	// wasm contracts can only be deployed by users, so they never run with system permissions
+	return &services.GetContractInfoOutput{
+		PermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+	}, nil
+}
+
+func (s *service) limits() interpreter.Limits {
+	return interpreter.Limits{
+		MaxInstructions: uint64(s.config.ProcessorWasmMaxInstructionsPerCall()),
+		MaxMemoryPages:  s.config.ProcessorWasmMaxMemoryPages(),
+	}
+}
+
+// returns a nil type for system methods like _init which the contract is not required to export
+func verifyMethod(module *interpreter.Module, contractName string, methodName string, permissionScope protocol.ExecutionPermissionScope) (*interpreter.FunctionType, error) {
+	isSystemMethod := strings.HasPrefix(methodName, "_")
+	if isSystemMethod && permissionScope != protocol.PERMISSION_SCOPE_SYSTEM {
+		return nil, errors.Errorf("only system contracts can run method '%s'", methodName)
+	}
+
+	functionType, found := module.ExportedFunction(methodName)
+	if !found || methodName == ALLOC_FUNCTION_NAME {
+		if methodName == INIT_FUNCTION_NAME {
+			return nil, nil
+		}
+		return nil, errors.Errorf("method '%s' not found on contract '%s'", methodName, contractName)
+	}
+
+	return &functionType, nil
+}
+
+// every version of a contract is cached on its own since calls at heights before an upgrade still run the previous code
+func (s *service) retrieveModule(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName string) (*interpreter.Module, error) {
+	version, err := s.getCodeVersionOfDeploymentSystemContract(ctx, executionContextId, contractName)
+	if err != nil {
+		return nil, err
+	}
+	key := moduleKey{name: contractName, version: version}
+
+	module := s.cache.moduleByKey(key)
+	if module != nil {
+		return module, nil
+	}
+
+	code, err := s.getFullCodeOfDeploymentSystemContract(ctx, executionContextId, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	module, err = interpreter.Decode(code)
+	if err != nil {
+		return nil, errors.Wrapf(err, "contract %s is not a valid wasm module", contractName)
+	}
+
+	s.cache.addModule(key, module)
+	return module, nil
+}
+
+func createMethodOutputArgsWithString(str string) *protocol.ArgumentArray {
+	res, _ := protocol.ArgumentArrayFromNatives([]interface{}{str}) // err ignored because we support argument with type string
+	return res
+}
+
+// decoded modules are immutable and shared between calls, every call creates its own instance
+type moduleCache struct {
+	sync.RWMutex
+	modules map[moduleKey]*interpreter.Module
+}
+
+type moduleKey struct {
+	name    string
+	version uint32
+}
+
+func newModuleCache() *moduleCache {
+	return &moduleCache{
+		modules: make(map[moduleKey]*interpreter.Module),
+	}
+}
+
+func (c *moduleCache) moduleByKey(key moduleKey) *interpreter.Module {
+	c.RLock()
+	defer c.RUnlock()
+
+	return c.modules[key]
+}
+
+func (c *moduleCache) addModule(key moduleKey, module *interpreter.Module) {
+	c.Lock()
+	defer c.Unlock()
+
+	c.modules[key] = module
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestProcessCall_ReadsAndWritesState(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(5))
+	require.NoError(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, output.CallResult)
+	require.Equal(t, builders.ArgumentsArray(uint64(5)), output.OutputArgumentArray)
+
+	output, err = h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(7))
+	require.NoError(t, err)
+	require.Equal(t, builders.ArgumentsArray(uint64(12)), output.OutputArgumentArray, "counter should be kept in state between calls")
+
+	stored := h.sdkHandler.state["count"]
+	require.EqualValues(t, 12, binary.LittleEndian.Uint64(stored))
+}
+
+func TestProcessCall_PassesStringArgumentsThroughContractMemory(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "greet", protocol.PERMISSION_SCOPE_SERVICE, "hello orbs")
+	require.NoError(t, err)
+	require.Equal(t, builders.ArgumentsArray("hello orbs", true), output.OutputArgumentArray, "explicit results should be returned in order")
+}
+
+func TestProcessCall_EmitsEvents(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	_, err := h.processCall(t, "Counter", "notify", protocol.PERMISSION_SCOPE_SERVICE)
+	require.NoError(t, err)
+	require.Equal(t, []string{"Notified"}, h.sdkHandler.events)
+}
+
+func TestProcessCall_ContractFailures(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "fail", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, output.CallResult)
+	require.Equal(t, builders.ArgumentsArray("boom"), output.OutputArgumentArray, "contract panic message should be returned")
+
+	output, err = h.processCall(t, "Counter", "spin", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err, "infinite loop should be stopped by the instruction limit")
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, output.CallResult)
+}
+
+func TestProcessCall_InputErrors(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "unknownMethod", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	output, err = h.processCall(t, "Counter", ALLOC_FUNCTION_NAME, protocol.PERMISSION_SCOPE_SERVICE, uint32(10))
+	require.Error(t, err, "the allocator is not a contract method")
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	output, err = h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE, "not a number")
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	output, err = h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+}
+
+func TestProcessCall_SystemMethodsRequireSystemPermissions(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "_cleanup", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	output, err = h.processCall(t, "Counter", "_cleanup", protocol.PERMISSION_SCOPE_SYSTEM)
+	require.NoError(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, output.CallResult)
+
+	output, err = h.processCall(t, "Counter", INIT_FUNCTION_NAME, protocol.PERMISSION_SCOPE_SYSTEM)
+	require.NoError(t, err, "contracts without _init should deploy successfully")
+	require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, output.CallResult)
+}
+
+func TestProcessCall_UpgradedContractRunsNewCode(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(5))
+	require.NoError(t, err)
+	require.Equal(t, builders.ArgumentsArray(uint64(5)), output.OutputArgumentArray)
+
+	h.deploy("Counter", constantContract(42))
+
+	output, err = h.processCall(t, "Counter", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(5))
+	require.NoError(t, err)
+	require.Equal(t, builders.ArgumentsArray(uint64(42)), output.OutputArgumentArray, "cached module of the previous version should not be used")
+}
+
+func TestProcessCall_ContractNotDeployed(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Garbage", []byte("not a wasm module"))
+
+	output, err := h.processCall(t, "Missing", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1))
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_CONTRACT_NOT_DEPLOYED, output.CallResult)
+
+	output, err = h.processCall(t, "Garbage", "add", protocol.PERMISSION_SCOPE_SERVICE, uint64(1))
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_CONTRACT_NOT_DEPLOYED, output.CallResult)
+}
+
+func TestGetContractInfo_ReturnsServicePermissions(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+
+	output, err := h.service.GetContractInfo(context.Background(), &services.GetContractInfoInput{
+		ContextId:    []byte{0x01},
+		ContractName: primitives.ContractName("Counter"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, protocol.PERMISSION_SCOPE_SERVICE, output.PermissionScope)
+
+	_, err = h.service.GetContractInfo(context.Background(), &services.GetContractInfoInput{
+		ContextId:    []byte{0x01},
+		ContractName: primitives.ContractName("Missing"),
+	})
+	require.Error(t, err)
+}
+
+func TestProcessCall_RejectsCallsBeforeWasmProtocolVersion(t *testing.T) {
+	h := newHarness(t)
+	h.deploy("Counter", counterContract())
+	h.sdkHandler.protocolVersion = WASM_PROCESSOR_PROTOCOL_VERSION - 1
+
+	output, err := h.processCall(t, "Counter", INIT_FUNCTION_NAME, protocol.PERMISSION_SCOPE_SYSTEM)
+	require.Error(t, err, "deployment should fail since it runs _init")
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_INPUT, output.CallResult)
+
+	_, err = h.service.GetContractInfo(context.Background(), &services.GetContractInfoInput{
+		ContextId:    []byte{0x01},
+		ContractName: primitives.ContractName("Counter"),
+	})
+	require.Error(t, err)
+}
+
+func TestProcessCall_AssemblyScriptAbortIsReportedAsContractError(t *testing.T) {
+	h := newHarness(t)
+
+	// AssemblyScript strings are utf-16 preceded by their length in bytes
+	message := []byte{8, 0, 0, 0, 'o', 0, 'o', 0, 'p', 0, 's', 0}
+	m := builders.WasmModule().Memory(1).Data(100, message)
+	abort := m.ImportFunction(ASSEMBLYSCRIPT_MODULE_NAME, "abort", m.Type(wasmPtr2, wasmNone))
+	m.ExportFunction("assert", m.Function(m.Type(wasmNone, wasmNone), wasmNone,
+		i32Const(104), i32Const(0), i32Const(3), i32Const(7), op(opCall, abort)))
+	h.deploy("AssemblyScript", m.Build())
+
+	output, err := h.processCall(t, "AssemblyScript", "assert", protocol.PERMISSION_SCOPE_SERVICE)
+	require.Error(t, err)
+	require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, output.CallResult)
+	require.Equal(t, builders.ArgumentsArray("oops (:3:7)"), output.OutputArgumentArray)
+}
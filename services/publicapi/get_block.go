@@ -19,7 +19,8 @@ import (
 )
 
 func (s *service) GetBlock(parentCtx context.Context, input *services.GetBlockInput) (*services.GetBlockOutput, error) {
-	ctx := trace.NewContext(parentCtx, "PublicApi.GetBlock")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.GetBlock"), "PublicApi.GetBlock")
+	defer span.End()
 
 	if input.ClientRequest == nil {
 		err := errors.Errorf("client request is nil")
@@ -28,6 +29,7 @@ func (s *service) GetBlock(parentCtx context.Context, input *services.GetBlockIn
 	}
 
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx), logfields.BlockHeight(input.ClientRequest.BlockHeight()), log.String("flow", "checkpoint"))
+	span.SetAttribute("block-height", input.ClientRequest.BlockHeight().String())
 
 	if _, err := validateRequest(s.config, input.ClientRequest.ProtocolVersion(), input.ClientRequest.VirtualChainId()); err != nil {
 		logger.Info("get block received input failed", log.Error(err))
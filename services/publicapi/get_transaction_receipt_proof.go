@@ -19,7 +19,8 @@ import (
 )
 
 func (s *service) GetTransactionReceiptProof(parentCtx context.Context, input *services.GetTransactionReceiptProofInput) (*services.GetTransactionReceiptProofOutput, error) {
-	ctx := trace.NewContext(parentCtx, "PublicApi.GetTransactionReceiptProof")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.GetTransactionReceiptProof"), "PublicApi.GetTransactionReceiptProof")
+	defer span.End()
 
 	if input.ClientRequest == nil {
 		err := errors.Errorf("client request is nil")
@@ -30,6 +31,7 @@ func (s *service) GetTransactionReceiptProof(parentCtx context.Context, input *s
 	tx := input.ClientRequest.TransactionRef()
 	txHash := tx.Txhash()
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx), logfields.Transaction(txHash), log.String("flow", "checkpoint"))
+	span.SetAttribute("tx-hash", txHash.String())
 
 	if txStatus, err := validateRequest(s.config, tx.ProtocolVersion(), tx.VirtualChainId()); err != nil {
 		logger.Info("get transaction receipt proof received input failed", log.Error(err))
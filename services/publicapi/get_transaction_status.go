@@ -21,7 +21,8 @@ import (
 )
 
 func (s *service) GetTransactionStatus(parentCtx context.Context, input *services.GetTransactionStatusInput) (*services.GetTransactionStatusOutput, error) {
-	ctx := trace.NewContext(parentCtx, "PublicApi.GetTransactionStatus")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.GetTransactionStatus"), "PublicApi.GetTransactionStatus")
+	defer span.End()
 
 	if input.ClientRequest == nil {
 		err := errors.Errorf("client request is nil")
@@ -32,6 +33,7 @@ func (s *service) GetTransactionStatus(parentCtx context.Context, input *service
 	tx := input.ClientRequest.TransactionRef()
 	txHash := tx.Txhash()
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx), logfields.Transaction(txHash), log.String("flow", "checkpoint"))
+	span.SetAttribute("tx-hash", txHash.String())
 
 	if txStatus, err := validateRequest(s.config, tx.ProtocolVersion(), tx.VirtualChainId()); err != nil {
 		logger.Info("get transaction status received input failed", log.Error(err))
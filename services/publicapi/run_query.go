@@ -21,7 +21,8 @@ import (
 
 func (s *service) RunQuery(parentCtx context.Context, input *services.RunQueryInput) (*services.RunQueryOutput, error) {
 	s.metrics.queriesPerSecond.Measure(1)
-	ctx := trace.NewContext(parentCtx, "PublicApi.RunQuery")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.RunQuery"), "PublicApi.RunQuery")
+	defer span.End()
 
 	if input.ClientRequest == nil {
 		err := errors.Errorf("client request is nil")
@@ -32,6 +33,7 @@ func (s *service) RunQuery(parentCtx context.Context, input *services.RunQueryIn
 	query := input.ClientRequest.SignedQuery().Query()
 	queryHash := digest.CalcQueryHash(query)
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx), logfields.Query(queryHash), log.String("flow", "checkpoint"))
+	span.SetAttribute("query-hash", queryHash.String())
 
 	if _, err := validateRequest(s.config, query.ProtocolVersion(), query.VirtualChainId()); err != nil {
 		logger.Info("run query received input failed", log.Error(err))
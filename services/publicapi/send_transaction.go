@@ -20,9 +20,11 @@ import (
 )
 
 func (s *service) SendTransaction(parentCtx context.Context, input *services.SendTransactionInput) (*services.SendTransactionOutput, error) {
-	ctx := trace.NewContext(parentCtx, "PublicApi.SendTransaction")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.SendTransaction"), "PublicApi.SendTransaction")
+	defer span.End()
 	start := time.Now()
 	out, err := s.sendTransaction(ctx, input.ClientRequest, false)
+	span.SetError(err)
 	if out == nil {
 		return nil, err
 	}
@@ -33,8 +35,10 @@ func (s *service) SendTransaction(parentCtx context.Context, input *services.Sen
 }
 
 func (s *service) SendTransactionAsync(parentCtx context.Context, input *services.SendTransactionInput) (*services.SendTransactionOutput, error) {
-	ctx := trace.NewContext(parentCtx, "PublicApi.SendTransactionAsync")
+	ctx, span := s.tracer.StartSpan(trace.NewContext(parentCtx, "PublicApi.SendTransactionAsync"), "PublicApi.SendTransactionAsync")
+	defer span.End()
 	out, err := s.sendTransaction(ctx, input.ClientRequest, true)
+	span.SetError(err)
 	if out == nil {
 		return nil, err
 	}
@@ -54,6 +58,7 @@ func (s *service) sendTransaction(ctx context.Context, request *client.SendTrans
 	tx := request.SignedTransaction().Transaction()
 	txHash := digest.CalcTxHash(tx)
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx), logfields.Transaction(txHash), log.String("flow", "checkpoint"))
+	trace.SpanFromContext(ctx).SetAttribute("tx-hash", txHash.String())
 
 	if txStatus, err := validateRequest(s.config, tx.ProtocolVersion(), tx.VirtualChainId()); err != nil {
 		s.metrics.totalTransactionsErrInvalidRequest.Inc()
@@ -27,6 +27,7 @@ type service struct {
 	virtualMachine  services.VirtualMachine
 	blockStorage    services.BlockStorage
 	logger          log.Logger
+	tracer          *trace.Tracer
 
 	waiter *waiter
 
@@ -68,6 +69,7 @@ func NewPublicApi(
 	blockStorage services.BlockStorage,
 	logger log.Logger,
 	metricFactory metric.Factory,
+	tracer *trace.Tracer,
 ) services.PublicApi {
 	s := &service{
 		config:          config,
@@ -75,6 +77,7 @@ func NewPublicApi(
 		virtualMachine:  virtualMachine,
 		blockStorage:    blockStorage,
 		logger:          logger.WithTags(LogTag),
+		tracer:          tracer,
 
 		waiter:  newWaiter(),
 		metrics: newMetrics(metricFactory, config.PublicApiSendTransactionTimeout(), 2*time.Second, 1*time.Second),
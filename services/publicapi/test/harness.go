@@ -11,6 +11,7 @@ import (
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/publicapi"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
@@ -34,7 +35,7 @@ func newPublicApiHarness(logger log.Logger, txTimeout time.Duration, outOfSyncWa
 	txpMock := makeTxMock()
 	vmMock := &services.MockVirtualMachine{}
 	bksMock := &services.MockBlockStorage{}
-	papi := publicapi.NewPublicApi(cfg, txpMock, vmMock, bksMock, logger, metric.NewRegistry())
+	papi := publicapi.NewPublicApi(cfg, txpMock, vmMock, bksMock, logger, metric.NewRegistry(), trace.NewNoopTracer())
 	return &harness{
 		papi:    papi,
 		txpMock: txpMock,
@@ -0,0 +1,87 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// same wire protocol as the crypto-lib client, but the request is bound to the context and to a timeout
+type remoteClient struct {
+	address    string
+	httpClient *http.Client
+}
+
+func newRemoteClient(address string, timeout time.Duration) *remoteClient {
+	return &remoteClient{
+		address: address,
+		httpClient: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+func (c *remoteClient) Sign(ctx context.Context, input []byte) ([]byte, error) {
+	nodeSignInput := (&services.NodeSignInputBuilder{
+		Data: input,
+	}).Build()
+
+	request, err := http.NewRequest("POST", c.address+"/sign", bytes.NewReader(nodeSignInput.Raw()))
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating request to signer server")
+	}
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "binary/octet-stream")
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "error sending request to signer server")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("bad response code from signer server: %d", response.StatusCode)
+	}
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not read signer server response")
+	}
+
+	signature := services.NodeSignOutputReader(data).Signature()
+	if len(signature) == 0 {
+		return nil, errors.New("signer server returned an empty signature")
+	}
+
+	return signature, nil
+}
+
+// any answer from the signer server means it is reachable, only server errors mean it is not
+func (c *remoteClient) probe(ctx context.Context) error {
+	request, err := http.NewRequest("GET", c.address+"/", nil)
+	if err != nil {
+		return errors.Wrap(err, "error creating request to signer server")
+	}
+	request = request.WithContext(ctx)
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "error sending request to signer server")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return errors.Errorf("bad response code from signer server: %d", response.StatusCode)
+	}
+	return nil
+}
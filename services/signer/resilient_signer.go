@@ -0,0 +1,313 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package signer
+
+import (
+	"context"
+	ethereumDigest "github.com/orbs-network/crypto-lib-go/crypto/ethereum/digest"
+	cryptoSigner "github.com/orbs-network/crypto-lib-go/crypto/signer"
+	"github.com/orbs-network/govnr"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/synchronization"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+	"sync"
+	"time"
+)
+
+var LogTag = log.Service("signer")
+
+const STATUS_HEALTHY = "healthy"
+const STATUS_UNHEALTHY = "unhealthy"
+const STATUS_CIRCUIT_OPEN = "circuit-open"
+
+const ACTIVE_ENDPOINT_PRIMARY = "primary"
+const ACTIVE_ENDPOINT_SWITCHED = "switched"
+
+var ErrCircuitOpen = errors.New("signer circuit breaker is open, not sending sign requests until cooldown passes")
+
+// signers which can be checked without signing anything, a local key has nothing to check
+type prober interface {
+	probe(ctx context.Context) error
+}
+
+type metrics struct {
+	status           *metric.Text
+	endpoint         *metric.Text
+	activeEndpoint   *metric.Text
+	signTime         *metric.Histogram
+	errors           *metric.Gauge
+	retries          *metric.Gauge
+	rejected         *metric.Gauge
+	lastSuccessNano  *metric.Gauge
+	consecutiveFails *metric.Gauge
+}
+
+// Service wraps the configured signer (local key or remote sidecar) with timeouts, retries with exponential backoff,
+// a circuit breaker, periodic health checks and a switch to another signer endpoint once a committed block reaches the
+// switch reference time. This is not key rotation: the node address is the node's identity in the committee, so both
+// endpoints must hold the same node key. A new key means a new node address, registered through management
+type Service struct {
+	govnr.TreeSupervisor
+	config  config.ResilientSignerConfig
+	logger  log.Logger
+	metrics *metrics
+	clock   func() time.Time
+
+	primary  cryptoSigner.Signer
+	switched cryptoSigner.Signer
+
+	mu struct {
+		sync.Mutex
+		consecutiveFailures uint32
+		openUntil           time.Time
+		halfOpenTrial       bool
+		referenceTime       primitives.TimestampSeconds
+		switchVerified      bool
+	}
+}
+
+func newMetrics(factory metric.Factory, timeout time.Duration) *metrics {
+	return &metrics{
+		status:           factory.NewText("Signer.Status", STATUS_HEALTHY),
+		endpoint:         factory.NewText("Signer.Endpoint.Address", ""),
+		activeEndpoint:   factory.NewText("Signer.ActiveEndpoint", ACTIVE_ENDPOINT_PRIMARY),
+		signTime:         factory.NewLatency("Signer.Sign.Time.Millis", timeout),
+		errors:           factory.NewGauge("Signer.Sign.Errors.Count"),
+		retries:          factory.NewGauge("Signer.Sign.Retries.Count"),
+		rejected:         factory.NewGauge("Signer.Sign.RejectedByCircuitBreaker.Count"),
+		lastSuccessNano:  factory.NewGauge("Signer.LastSuccessfulSign.TimeNano"),
+		consecutiveFails: factory.NewGauge("Signer.ConsecutiveFailures.Count"),
+	}
+}
+
+func NewResilientSigner(ctx context.Context, cfg config.ResilientSignerConfig, parentLogger log.Logger, metricFactory metric.Factory) (*Service, error) {
+	s := &Service{
+		config:  cfg,
+		logger:  parentLogger.WithTags(LogTag),
+		metrics: newMetrics(metricFactory, maxSignDuration(cfg)),
+		clock:   time.Now,
+	}
+
+	if len(cfg.NodePrivateKey()) != 0 {
+		s.primary = cryptoSigner.NewLocalSigner(cfg.NodePrivateKey())
+		s.metrics.endpoint.Update("local")
+	} else if cfg.SignerEndpoint() != "" {
+		s.primary = newRemoteClient(cfg.SignerEndpoint(), cfg.SignerRequestTimeout())
+		s.metrics.endpoint.Update(cfg.SignerEndpoint())
+	} else {
+		return nil, errors.New("bad private key configuration: both private key and signer endpoint were not set")
+	}
+
+	// the switch configuration is validated with the rest of the node configuration
+	if cfg.SignerSwitchEndpoint() != "" {
+		s.switched = newRemoteClient(cfg.SignerSwitchEndpoint(), cfg.SignerRequestTimeout())
+	}
+
+	if cfg.SignerHealthCheckInterval() > 0 {
+		s.Supervise(synchronization.NewPeriodicalTrigger(ctx, "Signer health check", synchronization.NewTimeTicker(cfg.SignerHealthCheckInterval()), s.logger, func() {
+			s.checkHealth(ctx)
+		}, nil))
+	}
+
+	return s, nil
+}
+
+func maxSignDuration(cfg config.ResilientSignerConfig) time.Duration {
+	if cfg.SignerRequestTimeout() == 0 {
+		return 30 * time.Second
+	}
+	return cfg.SignerRequestTimeout() * time.Duration(cfg.SignerRetryAttempts()+1)
+}
+
+func (s *Service) Sign(ctx context.Context, input []byte) ([]byte, error) {
+	if !s.allowRequest() {
+		s.metrics.rejected.Inc()
+		return nil, ErrCircuitOpen
+	}
+
+	active := s.activeSigner()
+	attempts := s.config.SignerRetryAttempts()
+	if attempts == 0 || s.isHalfOpen() {
+		attempts = 1
+	}
+	backoff := s.config.SignerRetryBackoff()
+
+	for attempt := uint32(1); ; attempt++ {
+		start := time.Now()
+		sig, err := active.Sign(ctx, input)
+		s.metrics.signTime.RecordSince(start)
+		if err == nil && active == s.switched {
+			err = s.verifySwitchedSignature(input, sig)
+		}
+		if err == nil {
+			s.onSuccess()
+			return sig, nil
+		}
+
+		s.metrics.errors.Inc()
+		if attempt >= attempts {
+			s.onFailure(err)
+			return nil, errors.Wrapf(err, "signer failed after %d attempts", attempt)
+		}
+
+		s.metrics.retries.Inc()
+		s.logger.Info("sign request failed, retrying", log.Error(err), log.Uint32("attempt", attempt))
+		select {
+		case <-ctx.Done():
+			s.onFailure(err)
+			return nil, errors.Wrap(ctx.Err(), "context ended while retrying sign request")
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// UpdateReferenceTime is called with the reference time of every committed block, all nodes switch to the other
+// signer endpoint at the same block regardless of their clocks
+func (s *Service) UpdateReferenceTime(referenceTime primitives.TimestampSeconds) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switchTime := primitives.TimestampSeconds(s.config.SignerSwitchReferenceTime())
+	switching := s.switched != nil && s.mu.referenceTime < switchTime && referenceTime >= switchTime
+	s.mu.referenceTime = referenceTime
+
+	if switching {
+		s.logger.Info("signer switch reference time reached, signing with switched signer endpoint", log.String("endpoint", s.config.SignerSwitchEndpoint()), log.Uint32("switch-reference-time", s.config.SignerSwitchReferenceTime()))
+		s.metrics.activeEndpoint.Update(ACTIVE_ENDPOINT_SWITCHED)
+		s.metrics.endpoint.Update(s.config.SignerSwitchEndpoint())
+	}
+}
+
+func (s *Service) activeSigner() cryptoSigner.Signer {
+	if s.switched == nil {
+		return s.primary
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.referenceTime < primitives.TimestampSeconds(s.config.SignerSwitchReferenceTime()) {
+		return s.primary
+	}
+	return s.switched
+}
+
+// other nodes verify our signatures against our node address, a switched signer holding another key would get them all rejected
+func (s *Service) verifySwitchedSignature(input []byte, sig []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.switchVerified {
+		return nil
+	}
+
+	if err := ethereumDigest.VerifyNodeSignature(s.config.NodeAddress(), input, sig); err != nil {
+		return errors.Wrapf(err, "switched signer does not sign for node address %s", s.config.NodeAddress())
+	}
+	s.mu.switchVerified = true
+	return nil
+}
+
+// a request is allowed while the circuit is closed. Once the cooldown passed the circuit is half open and a single
+// trial request is let through, the circuit closes if it succeeds and opens for another cooldown if it fails
+func (s *Service) allowRequest() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.openUntil.IsZero() {
+		return true
+	}
+	if s.clock().Before(s.mu.openUntil) || s.mu.halfOpenTrial {
+		return false
+	}
+	s.mu.halfOpenTrial = true
+	return true
+}
+
+func (s *Service) isHalfOpen() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.mu.halfOpenTrial
+}
+
+func (s *Service) onSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.consecutiveFailures = 0
+	s.mu.openUntil = time.Time{}
+	s.mu.halfOpenTrial = false
+	s.metrics.consecutiveFails.Update(0)
+	s.metrics.lastSuccessNano.Update(s.clock().UnixNano())
+	s.metrics.status.Update(STATUS_HEALTHY)
+}
+
+func (s *Service) onFailure(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.consecutiveFailures++
+	s.metrics.consecutiveFails.Update(int64(s.mu.consecutiveFailures))
+
+	threshold := s.config.SignerCircuitBreakerThreshold()
+	if s.mu.halfOpenTrial || (threshold > 0 && s.mu.consecutiveFailures >= threshold) {
+		s.mu.openUntil = s.clock().Add(s.config.SignerCircuitBreakerCooldown())
+		s.mu.halfOpenTrial = false
+		s.metrics.status.Update(STATUS_CIRCUIT_OPEN)
+		s.logger.Error("signer circuit breaker opened", log.Error(err), log.Uint32("consecutive-failures", s.mu.consecutiveFailures), log.Stringable("cooldown", s.config.SignerCircuitBreakerCooldown()))
+	} else {
+		s.metrics.status.Update(STATUS_UNHEALTHY)
+	}
+}
+
+// a reachable signer ends the cooldown of an open circuit, so the next sign request tries it without waiting for
+// the cooldown to pass. Only a successful sign request closes the circuit
+func (s *Service) onProbeSuccess() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.mu.openUntil.IsZero() && s.clock().Before(s.mu.openUntil) {
+		s.mu.openUntil = s.clock()
+	}
+}
+
+// health checks only probe that the active signer is reachable, they never ask it to sign
+func (s *Service) checkHealth(ctx context.Context) {
+	p, ok := s.activeSigner().(prober)
+	if !ok {
+		return
+	}
+
+	if s.config.SignerRequestTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.SignerRequestTimeout())
+		defer cancel()
+	}
+
+	if err := p.probe(ctx); err != nil {
+		s.logger.Info("signer health check failed", log.Error(err))
+		s.onFailure(err)
+		return
+	}
+	s.onProbeSuccess()
+}
+
+func (s *Service) Status() string {
+	return s.metrics.status.Value()
+}
+
+// an unreachable signer is a problem of the signer, restarting the node would not fix it, so it only affects readiness
+func (s *Service) RegisterHealthChecks(registry health.Registry) {
+	registry.Register("signer", health.SEVERITY_WARNING, func(ctx context.Context) health.Result {
+		switch status := s.Status(); status {
+		case STATUS_CIRCUIT_OPEN:
+			return health.Failing("signer is unreachable, circuit breaker is open")
+		case STATUS_UNHEALTHY:
+			return health.Failing("signer requests are failing")
+		default:
+			return health.Ok("signing with %s key", s.metrics.activeEndpoint.Value())
+		}
+	})
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package signer
+
+import (
+	"context"
+	cryptoSigner "github.com/orbs-network/crypto-lib-go/crypto/signer"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/test/crypto/keys"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type signerConfigForTests struct {
+	nodeAddress         primitives.NodeAddress
+	endpoint            string
+	switchEndpoint      string
+	switchReferenceTime uint32
+	retryAttempts       uint32
+	breakerThreshold    uint32
+}
+
+func (c *signerConfigForTests) NodePrivateKey() primitives.EcdsaSecp256K1PrivateKey {
+	return nil
+}
+
+func (c *signerConfigForTests) NodeAddress() primitives.NodeAddress {
+	return c.nodeAddress
+}
+
+func (c *signerConfigForTests) SignerEndpoint() string {
+	return c.endpoint
+}
+
+func (c *signerConfigForTests) SignerRequestTimeout() time.Duration {
+	return 500 * time.Millisecond
+}
+
+func (c *signerConfigForTests) SignerRetryAttempts() uint32 {
+	return c.retryAttempts
+}
+
+func (c *signerConfigForTests) SignerRetryBackoff() time.Duration {
+	return time.Millisecond
+}
+
+func (c *signerConfigForTests) SignerHealthCheckInterval() time.Duration {
+	return 0
+}
+
+func (c *signerConfigForTests) SignerCircuitBreakerThreshold() uint32 {
+	return c.breakerThreshold
+}
+
+func (c *signerConfigForTests) SignerCircuitBreakerCooldown() time.Duration {
+	return time.Hour
+}
+
+func (c *signerConfigForTests) SignerSwitchEndpoint() string {
+	return c.switchEndpoint
+}
+
+func (c *signerConfigForTests) SignerSwitchReferenceTime() uint32 {
+	return c.switchReferenceTime
+}
+
+type fakeSignerSidecar struct {
+	*httptest.Server
+	failuresLeft int32
+	requests     int32
+	probes       int32
+	signature    []byte
+	key          primitives.EcdsaSecp256K1PrivateKey
+}
+
+func newFakeSignerSidecar(failures int32, signature []byte) *fakeSignerSidecar {
+	sidecar := &fakeSignerSidecar{failuresLeft: failures, signature: signature}
+	sidecar.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/sign" {
+			atomic.AddInt32(&sidecar.probes, 1)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		atomic.AddInt32(&sidecar.requests, 1)
+		if atomic.AddInt32(&sidecar.failuresLeft, -1) >= 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		signature := sidecar.signature
+		if sidecar.key != nil {
+			body, _ := ioutil.ReadAll(r.Body)
+			signature, _ = cryptoSigner.NewLocalSigner(sidecar.key).Sign(r.Context(), services.NodeSignInputReader(body).Data())
+		}
+		output := (&services.NodeSignOutputBuilder{Signature: signature}).Build()
+		_, _ = w.Write(output.Raw())
+	}))
+	return sidecar
+}
+
+// signs with the key of the node address instead of returning a fixed signature
+func newFakeSignerSidecarWithKey(key primitives.EcdsaSecp256K1PrivateKey) *fakeSignerSidecar {
+	sidecar := newFakeSignerSidecar(0, nil)
+	sidecar.key = key
+	return sidecar
+}
+
+func TestResilientSigner_RetriesUntilSignerRecovers(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			sidecar := newFakeSignerSidecar(2, []byte{0x01, 0x02})
+			defer sidecar.Close()
+
+			s, err := NewResilientSigner(ctx, &signerConfigForTests{endpoint: sidecar.URL, retryAttempts: 3}, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			sig, err := s.Sign(ctx, []byte("data"))
+			require.NoError(t, err)
+			require.EqualValues(t, []byte{0x01, 0x02}, sig)
+			require.EqualValues(t, 3, atomic.LoadInt32(&sidecar.requests), "expected two failed attempts and one successful attempt")
+			require.Equal(t, STATUS_HEALTHY, s.Status())
+		})
+	})
+}
+
+func TestResilientSigner_OpensCircuitAfterConsecutiveFailures(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			harness.AllowErrorsMatching("signer circuit breaker opened")
+			sidecar := newFakeSignerSidecar(100, nil)
+			defer sidecar.Close()
+
+			s, err := NewResilientSigner(ctx, &signerConfigForTests{endpoint: sidecar.URL, retryAttempts: 1, breakerThreshold: 2}, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err)
+			require.Equal(t, STATUS_UNHEALTHY, s.Status())
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err)
+			require.Equal(t, STATUS_CIRCUIT_OPEN, s.Status())
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Equal(t, ErrCircuitOpen, err, "circuit should reject requests during cooldown")
+			require.EqualValues(t, 2, atomic.LoadInt32(&sidecar.requests), "no request should reach the signer while the circuit is open")
+		})
+	})
+}
+
+func TestResilientSigner_LetsASingleTrialRequestThroughAfterCooldown(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			harness.AllowErrorsMatching("signer circuit breaker opened")
+			sidecar := newFakeSignerSidecar(2, []byte{0x01})
+			defer sidecar.Close()
+
+			s, err := NewResilientSigner(ctx, &signerConfigForTests{endpoint: sidecar.URL, retryAttempts: 1, breakerThreshold: 1}, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err)
+			require.Equal(t, STATUS_CIRCUIT_OPEN, s.Status())
+
+			now := time.Now().Add(2 * time.Hour)
+			s.clock = func() time.Time { return now }
+			require.True(t, s.allowRequest(), "a trial request should be allowed once the cooldown passed")
+			require.False(t, s.allowRequest(), "only a single trial request should be allowed while the circuit is half open")
+			s.mu.halfOpenTrial = false // the trial allowed above was never sent
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err)
+			require.Equal(t, STATUS_CIRCUIT_OPEN, s.Status(), "a failed trial request should open the circuit again")
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Equal(t, ErrCircuitOpen, err, "circuit should reject requests during another cooldown")
+
+			now = now.Add(2 * time.Hour)
+			sig, err := s.Sign(ctx, []byte("data"))
+			require.NoError(t, err)
+			require.EqualValues(t, []byte{0x01}, sig)
+			require.Equal(t, STATUS_HEALTHY, s.Status(), "a successful trial request should close the circuit")
+			require.EqualValues(t, 3, atomic.LoadInt32(&sidecar.requests))
+		})
+	})
+}
+
+func TestResilientSigner_HealthCheckProbesWithoutSigning(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			harness.AllowErrorsMatching("signer circuit breaker opened")
+			sidecar := newFakeSignerSidecar(1, []byte{0x01})
+			defer sidecar.Close()
+
+			s, err := NewResilientSigner(ctx, &signerConfigForTests{endpoint: sidecar.URL, retryAttempts: 1, breakerThreshold: 1}, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err)
+			require.Equal(t, STATUS_CIRCUIT_OPEN, s.Status())
+
+			s.checkHealth(ctx)
+			require.EqualValues(t, 1, atomic.LoadInt32(&sidecar.probes))
+			require.EqualValues(t, 1, atomic.LoadInt32(&sidecar.requests), "health check should not ask the signer to sign")
+
+			sig, err := s.Sign(ctx, []byte("data"))
+			require.NoError(t, err, "a reachable signer should be tried again without waiting for the cooldown")
+			require.EqualValues(t, []byte{0x01}, sig)
+			require.Equal(t, STATUS_HEALTHY, s.Status())
+
+			sidecar.Close()
+			s.checkHealth(ctx)
+			require.Equal(t, STATUS_CIRCUIT_OPEN, s.Status(), "an unreachable signer should fail the health check")
+		})
+	})
+}
+
+func TestResilientSigner_SwitchesEndpointAtReferenceTime(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			key := keys.EcdsaSecp256K1KeyPairForTests(0)
+			primary := newFakeSignerSidecar(0, []byte{0x01})
+			defer primary.Close()
+			switched := newFakeSignerSidecarWithKey(key.PrivateKey())
+			defer switched.Close()
+
+			switchTime := primitives.TimestampSeconds(1600000000)
+			cfg := &signerConfigForTests{nodeAddress: key.NodeAddress(), endpoint: primary.URL, switchEndpoint: switched.URL, switchReferenceTime: uint32(switchTime), retryAttempts: 1}
+			s, err := NewResilientSigner(ctx, cfg, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			s.UpdateReferenceTime(switchTime - 1)
+			s.clock = func() time.Time { return time.Unix(int64(switchTime)+3600, 0) }
+			sig, err := s.Sign(ctx, []byte("data"))
+			require.NoError(t, err)
+			require.EqualValues(t, []byte{0x01}, sig, "should sign with primary signer before a committed block reached the switch reference time, whatever the clock says")
+
+			s.UpdateReferenceTime(switchTime)
+			sig, err = s.Sign(ctx, []byte("data"))
+			require.NoError(t, err)
+			expected, _ := cryptoSigner.NewLocalSigner(key.PrivateKey()).Sign(ctx, []byte("data"))
+			require.EqualValues(t, expected, sig, "should sign with switched signer from switch reference time")
+		})
+	})
+}
+
+func TestResilientSigner_RejectsSwitchedSignerOfAnotherNodeAddress(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			primary := newFakeSignerSidecar(0, []byte{0x01})
+			defer primary.Close()
+			switched := newFakeSignerSidecarWithKey(keys.EcdsaSecp256K1KeyPairForTests(1).PrivateKey())
+			defer switched.Close()
+
+			cfg := &signerConfigForTests{nodeAddress: keys.EcdsaSecp256K1KeyPairForTests(0).NodeAddress(), endpoint: primary.URL, switchEndpoint: switched.URL, switchReferenceTime: 1600000000, retryAttempts: 1}
+			s, err := NewResilientSigner(ctx, cfg, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+
+			s.UpdateReferenceTime(1600000000)
+			_, err = s.Sign(ctx, []byte("data"))
+			require.Error(t, err, "signatures of another node address should not be used")
+			require.Contains(t, err.Error(), "switched signer does not sign for node address")
+		})
+	})
+}
+
+func TestResilientSigner_ReportsHealth(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(harness *with.LoggingHarness) {
+			harness.AllowErrorsMatching("signer circuit breaker opened")
+			sidecar := newFakeSignerSidecar(100, nil)
+			defer sidecar.Close()
+
+			s, err := NewResilientSigner(ctx, &signerConfigForTests{endpoint: sidecar.URL, retryAttempts: 1, breakerThreshold: 2}, harness.Logger, metric.NewRegistry())
+			require.NoError(t, err)
+			registry := health.NewRegistry()
+			s.RegisterHealthChecks(registry)
+
+			require.True(t, registry.Report(ctx).Ready, "signer should be healthy before any request failed")
+
+			_, _ = s.Sign(ctx, []byte("data"))
+			_, _ = s.Sign(ctx, []byte("data"))
+			report := registry.Report(ctx)
+			require.True(t, report.Live, "an unreachable signer should not restart the node")
+			require.False(t, report.Ready, "an unreachable signer should fail the node readiness")
+			require.Equal(t, "signer: signer is unreachable, circuit breaker is open", report.Summary())
+		})
+	})
+}
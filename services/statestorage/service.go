@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/orbs-network/crypto-lib-go/crypto/merkle"
 	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
@@ -164,6 +165,30 @@ func (s *service) GetLastCommittedBlockInfo(ctx context.Context, input *services
 	return result, nil
 }
 
+// RegisterHealthChecks compares the state height to the height of block storage, which state storage is synced from
+func (s *service) RegisterHealthChecks(registry health.Registry, blockStorage services.BlockStorage) {
+	registry.Register("state-storage-height", health.SEVERITY_CRITICAL, func(ctx context.Context) health.Result {
+		s.mutex.RLock()
+		stateHeight := s.revisions.getCurrentHeight()
+		s.mutex.RUnlock()
+
+		out, err := blockStorage.GetLastCommittedBlockHeight(ctx, &services.GetLastCommittedBlockHeightInput{})
+		if err != nil {
+			return health.Failing("could not read block storage height: %s", err)
+		}
+		blockHeight := out.LastCommittedBlockHeight
+
+		switch {
+		case stateHeight > blockHeight:
+			return health.Failing("state storage at height %d is ahead of block storage at height %d", stateHeight, blockHeight)
+		case stateHeight < blockHeight:
+			return health.Syncing("state storage is %d blocks behind block storage, at height %d of %d", blockHeight-stateHeight, stateHeight, blockHeight)
+		default:
+			return health.Ok("state storage is at block storage height %d", blockHeight)
+		}
+	})
+}
+
 func (s *service) GetStateHash(ctx context.Context, input *services.GetStateHashInput) (*services.GetStateHashOutput, error) {
 	timeoutCtx, cancel := context.WithTimeout(ctx, s.config.BlockTrackerGraceTimeout())
 	defer cancel()
@@ -0,0 +1,49 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/instrumentation/health"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type healthReporter interface {
+	RegisterHealthChecks(registry health.Registry, blockStorage services.BlockStorage)
+}
+
+func blockStorageAtHeight(height primitives.BlockHeight) *services.MockBlockStorage {
+	blockStorage := &services.MockBlockStorage{}
+	blockStorage.When("GetLastCommittedBlockHeight", mock.Any, mock.Any).Return(&services.GetLastCommittedBlockHeightOutput{LastCommittedBlockHeight: height}, nil)
+	return blockStorage
+}
+
+func TestHealth_ComparesStateHeightToBlockStorage(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		d := NewStateStorageDriver(1)
+		d.service.CommitStateDiff(ctx, CommitStateDiff().WithBlockHeight(1).WithDiff(builders.ContractStateDiff().Build()).Build())
+
+		for _, test := range []struct {
+			blockHeight    primitives.BlockHeight
+			expectedStatus health.Status
+		}{
+			{1, health.STATUS_OK},
+			{5, health.STATUS_SYNCING},
+			{0, health.STATUS_FAILING},
+		} {
+			registry := health.NewRegistry()
+			d.service.(healthReporter).RegisterHealthChecks(registry, blockStorageAtHeight(test.blockHeight))
+			require.Equal(t, test.expectedStatus, registry.Report(ctx).Checks[0].Status, "unexpected status when block storage is at height %d", test.blockHeight)
+		}
+	})
+}
@@ -24,7 +24,11 @@ func (s *service) AddNewTransaction(ctx context.Context, input *services.AddNewT
 	s.addNewTransactionConcurrencyLimiter.RequestSlot()
 	defer s.addNewTransactionConcurrencyLimiter.ReleaseSlot()
 
+	ctx, span := s.tracer.StartSpan(ctx, "TransactionPool.AddNewTransaction")
+	defer span.End()
+
 	txHash := digest.CalcTxHash(input.SignedTransaction.Transaction())
+	span.SetAttribute("tx-hash", txHash.String())
 
 	logger := s.logger.WithTags(logfields.Transaction(txHash), trace.LogFieldFrom(ctx), log.Stringable("transaction", input.SignedTransaction))
 
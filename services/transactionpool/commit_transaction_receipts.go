@@ -18,6 +18,9 @@ import (
 )
 
 func (s *service) CommitTransactionReceipts(ctx context.Context, input *services.CommitTransactionReceiptsInput) (*services.CommitTransactionReceiptsOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "TransactionPool.CommitTransactionReceipts")
+	defer span.End()
+	span.SetAttribute("block-height", input.LastCommittedBlockHeight.String())
 
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
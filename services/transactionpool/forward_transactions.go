@@ -23,6 +23,7 @@ import (
 	"github.com/orbs-network/scribe/log"
 	"github.com/pkg/errors"
 	"hash/adler32"
+	"strings"
 	"sync"
 	"time"
 )
@@ -40,9 +41,12 @@ func (s *service) RegisterTransactionResultsHandler(handler handlers.Transaction
 }
 
 func (s *service) HandleForwardedTransactions(ctx context.Context, input *gossiptopics.ForwardedTransactionsInput) (*gossiptopics.EmptyOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "TransactionPool.HandleForwardedTransactions")
+	defer span.End()
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	sender := input.Message.Sender
+	span.SetAttribute("sender", sender.SenderNodeAddress().String())
 	oneBigHash, _, err := HashTransactions(input.Message.SignedTransactions...)
 	if err != nil {
 		return nil, errors.Wrapf(err, "could not create one hash, invalid signature in relay message from sender %s", sender.SenderNodeAddress())
@@ -65,6 +69,7 @@ func (s *service) HandleForwardedTransactions(ctx context.Context, input *gossip
 type transactionForwarder struct {
 	govnr.TreeSupervisor
 	logger log.Logger
+	tracer *trace.Tracer
 	config TransactionForwarderConfig
 	gossip gossiptopics.TransactionRelay
 	signer signer.Signer
@@ -74,9 +79,10 @@ type transactionForwarder struct {
 	transactionAdded  chan uint16
 }
 
-func NewTransactionForwarder(ctx context.Context, logger log.Logger, signer signer.Signer, config TransactionForwarderConfig, gossip gossiptopics.TransactionRelay) *transactionForwarder {
+func NewTransactionForwarder(ctx context.Context, logger log.Logger, tracer *trace.Tracer, signer signer.Signer, config TransactionForwarderConfig, gossip gossiptopics.TransactionRelay) *transactionForwarder {
 	f := &transactionForwarder{
 		logger:            logger.WithTags(log.String("component", "transaction-forwarder")),
+		tracer:            tracer,
 		config:            config,
 		gossip:            gossip,
 		signer:            signer,
@@ -123,12 +129,16 @@ func (f *transactionForwarder) start(parent context.Context) {
 }
 
 func (f *transactionForwarder) drainQueueAndForward(ctx context.Context) {
-	logger := f.logger.WithTags(trace.LogFieldFrom(ctx))
 	txs := f.drainQueue()
 	if len(txs) == 0 {
 		return
 	}
 
+	// the forwarded batch is the parent of the handling spans on the other nodes, tx hashes allow finding it from a transaction
+	ctx, span := f.tracer.StartSpan(ctx, "TransactionPool.ForwardTransactions")
+	defer span.End()
+	logger := f.logger.WithTags(trace.LogFieldFrom(ctx))
+
 	oneBigHash, hashes, err := HashTransactions(txs...)
 	if err != nil {
 		logger.Error("error creating one big hash while signing transactions", log.Error(err), log.StringableSlice("transactions", txs))
@@ -142,6 +152,7 @@ func (f *transactionForwarder) drainQueueAndForward(ctx context.Context) {
 		f.submit(txs...)
 		return
 	}
+	span.SetAttribute("tx-hashes", joinHashes(hashes))
 
 	_, err = f.gossip.BroadcastForwardedTransactions(ctx, &gossiptopics.ForwardedTransactionsInput{
 		Message: &gossipmessages.ForwardedTransactionsMessage{
@@ -153,6 +164,7 @@ func (f *transactionForwarder) drainQueueAndForward(ctx context.Context) {
 		},
 	})
 
+	span.SetError(err)
 	for _, hash := range hashes {
 		if err != nil {
 			logger.Info("failed forwarding transaction via gossip", log.Error(err), log.String("flow", "checkpoint"), logfields.Transaction(hash))
@@ -170,6 +182,14 @@ func (f *transactionForwarder) drainQueue() []*protocol.SignedTransaction {
 	return txs
 }
 
+func joinHashes(hashes []primitives.Sha256) string {
+	var joined []string
+	for _, hash := range hashes {
+		joined = append(joined, hash.String())
+	}
+	return strings.Join(joined, ",")
+}
+
 func HashTransactions(txs ...*protocol.SignedTransaction) (oneBigHash []byte, hashes []primitives.Sha256, err error) {
 	checksum := adler32.New() // TODO(https://github.com/orbs-network/orbs-spec/issues/134): this needs to update to a bigger checksum/hash
 	for _, tx := range txs {
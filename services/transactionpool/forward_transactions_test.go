@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"github.com/orbs-network/crypto-lib-go/crypto/signer"
 	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/test"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
@@ -74,7 +75,7 @@ func TestForwardsTransactionAfterTimeout(t *testing.T) {
 		signer, err := signer.New(&signerConfig{keyPair})
 		require.NoError(t, err)
 
-		txForwarder := NewTransactionForwarder(ctx, harness.Logger, signer, cfg, gossip)
+		txForwarder := NewTransactionForwarder(ctx, harness.Logger, trace.NewNoopTracer(), signer, cfg, gossip)
 		harness.Supervise(txForwarder)
 
 		tx := builders.TransferTransaction().Build()
@@ -100,7 +101,7 @@ func TestForwardsTransactionAfterLimitWasReached(t *testing.T) {
 		signer, err := signer.New(&signerConfig{keyPair})
 		require.NoError(t, err)
 
-		txForwarder := NewTransactionForwarder(ctx, harness.Logger, signer, cfg, gossip)
+		txForwarder := NewTransactionForwarder(ctx, harness.Logger, trace.NewNoopTracer(), signer, cfg, gossip)
 		harness.Supervise(txForwarder)
 
 		tx := builders.TransferTransaction().Build()
@@ -128,7 +129,7 @@ func TestForwardsTransactionWithFaultySigner(t *testing.T) {
 		signer := &FaultySigner{}
 		signer.When("Sign", mock.Any, mock.Any).Return([]byte{}, fmt.Errorf("signer unavailable"))
 
-		txForwarder := NewTransactionForwarder(ctx, harness.Logger, signer, cfg, gossip)
+		txForwarder := NewTransactionForwarder(ctx, harness.Logger, trace.NewNoopTracer(), signer, cfg, gossip)
 		harness.Supervise(txForwarder)
 
 		tx := builders.TransferTransaction().Build()
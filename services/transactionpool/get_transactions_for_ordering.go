@@ -81,6 +81,10 @@ func newTransactionBatch(logger log.Logger, transactions Transactions) *transact
 }
 
 func (s *service) GetTransactionsForOrdering(ctx context.Context, input *services.GetTransactionsForOrderingInput) (*services.GetTransactionsForOrderingOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "TransactionPool.GetTransactionsForOrdering")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 	//TODO(v1) fail if requested block height is in the past
 	logger.Info("GetTransactionsForOrdering start", trace.LogFieldFrom(ctx), logfields.BlockHeight(input.CurrentBlockHeight), log.Stringable("transaction-pool-time-between-empty-blocks", s.config.TransactionPoolTimeBetweenEmptyBlocks()))
@@ -11,6 +11,7 @@ import (
 	"github.com/orbs-network/crypto-lib-go/crypto/signer"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/transactionpool/adapter"
 	"github.com/orbs-network/orbs-network-go/synchronization"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -29,7 +30,8 @@ func NewTransactionPool(ctx context.Context,
 	blockHeightReporter BlockHeightReporter,
 	config config.TransactionPoolConfig,
 	parent log.Logger,
-	metricFactory metric.Factory) *service {
+	metricFactory metric.Factory,
+	tracer *trace.Tracer) *service {
 
 	if blockHeightReporter == nil {
 		blockHeightReporter = synchronization.NopHeightReporter{}
@@ -41,7 +43,7 @@ func NewTransactionPool(ctx context.Context,
 
 	logger := parent.WithTags(LogTag)
 
-	txForwarder := NewTransactionForwarder(ctx, logger, signer, config, gossip)
+	txForwarder := NewTransactionForwarder(ctx, logger, tracer, signer, config, gossip)
 
 	s := &service{
 		clock:           createClockIfNeeded(maybeClock),
@@ -49,6 +51,7 @@ func NewTransactionPool(ctx context.Context,
 		virtualMachine:  virtualMachine,
 		config:          config,
 		logger:          logger,
+		tracer:          tracer,
 
 		pendingPool:                         pendingPool,
 		committedPool:                       committedPool,
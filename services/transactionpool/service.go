@@ -10,6 +10,7 @@ import (
 	"github.com/orbs-network/govnr"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/transactionpool/adapter"
 	"github.com/orbs-network/orbs-network-go/synchronization"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
@@ -22,6 +23,12 @@ import (
 
 var LogTag = log.Service("transaction-pool")
 
+// the forwarder reads these on every batch, so a config reload takes effect without a restart
+var HotReloadableConfigKeys = []string{
+	config.TRANSACTION_POOL_PROPAGATION_BATCH_SIZE,
+	config.TRANSACTION_POOL_PROPAGATION_BATCHING_TIMEOUT,
+}
+
 type BlockHeightReporter interface {
 	IncrementTo(height primitives.BlockHeight)
 }
@@ -34,6 +41,7 @@ type service struct {
 	virtualMachine      services.VirtualMachine
 	blockHeightReporter BlockHeightReporter // used to allow test to wait for a block height to reach the transaction pool
 	logger              log.Logger
+	tracer              *trace.Tracer
 	config              config.TransactionPoolConfig
 
 	transactionResultsHandlers struct {
@@ -16,6 +16,10 @@ import (
 )
 
 func (s *service) ValidateTransactionsForOrdering(ctx context.Context, input *services.ValidateTransactionsForOrderingInput) (*services.ValidateTransactionsForOrderingOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "TransactionPool.ValidateTransactionsForOrdering")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+
 	timeoutCtx, cancel := context.WithTimeout(ctx, s.config.BlockTrackerGraceTimeout())
 	defer cancel()
 
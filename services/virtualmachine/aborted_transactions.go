@@ -0,0 +1,51 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package virtualmachine
+
+import (
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"sync"
+)
+
+const MAX_ABORTED_TRANSACTIONS = 1000
+
+// the transactions this node could not execute within its limits, pre order rejects them so the proposals of this node
+// do not include them again and the transaction pool drops them. Only the most recent ones are kept
+type abortedTransactions struct {
+	sync.Mutex
+	hashes map[string]bool
+	order  []string
+}
+
+func newAbortedTransactions() *abortedTransactions {
+	return &abortedTransactions{
+		hashes: make(map[string]bool),
+	}
+}
+
+func (a *abortedTransactions) add(txHash primitives.Sha256) {
+	a.Lock()
+	defer a.Unlock()
+
+	key := string(txHash)
+	if a.hashes[key] {
+		return
+	}
+	if len(a.order) == MAX_ABORTED_TRANSACTIONS {
+		delete(a.hashes, a.order[0])
+		a.order = a.order[1:]
+	}
+	a.hashes[key] = true
+	a.order = append(a.order, key)
+}
+
+func (a *abortedTransactions) contains(txHash primitives.Sha256) bool {
+	a.Lock()
+	defer a.Unlock()
+
+	return a.hashes[string(txHash)]
+}
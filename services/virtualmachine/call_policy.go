@@ -0,0 +1,163 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package virtualmachine
+
+import (
+	"context"
+	"fmt"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/pkg/errors"
+)
+
+// the call policies read during one execution, most contracts have no policy so they are only read once per contract.
+// A contract can only change its own policy by calling _Permissions, which drops the cache
+type callPolicyCache struct {
+	hasPolicy map[primitives.ContractName]bool
+	checks    map[string]error
+}
+
+func newCallPolicyCache() *callPolicyCache {
+	c := &callPolicyCache{}
+	c.reset()
+	return c
+}
+
+func (c *callPolicyCache) reset() {
+	c.hasPolicy = make(map[primitives.ContractName]bool)
+	c.checks = make(map[string]error)
+}
+
+// enforces the policy the called contract set in the _Permissions system contract, must be called after the called contract
+// was pushed to the service stack so the caller is right below it
+func (s *service) enforceCallPolicy(ctx context.Context, executionContext *executionContext, methodName primitives.MethodName) error {
+	serviceName := executionContext.serviceStackPeekCurrent()
+	callerName := executionContext.serviceStackPeekCaller()
+	reentrant := uint32(0)
+	if executionContext.serviceStackPeekCurrentIsReentrant() {
+		reentrant = 1
+	}
+
+	// _Permissions itself has no policy
+	if serviceName == permissions_systemcontract.CONTRACT_NAME {
+		return nil
+	}
+
+	cache := executionContext.callPolicies
+	hasPolicy, found := cache.hasPolicy[serviceName]
+	if !found {
+		var err error
+		hasPolicy, err = s.callHasPolicyOfPermissionsSystemContract(ctx, executionContext, serviceName)
+		if err != nil {
+			return errors.Wrapf(err, "could not read the call policy of %s", serviceName)
+		}
+		cache.hasPolicy[serviceName] = hasPolicy
+	}
+	if !hasPolicy {
+		return nil
+	}
+
+	checkKey := fmt.Sprintf("%s.%s.%s.%d", serviceName, methodName, callerName, reentrant)
+	if err, found := cache.checks[checkKey]; found {
+		return err
+	}
+	err := s.callCheckCallOfPermissionsSystemContract(ctx, executionContext, serviceName, methodName, callerName, reentrant)
+	cache.checks[checkKey] = err
+	return err
+}
+
+func (s *service) callHasPolicyOfPermissionsSystemContract(ctx context.Context, executionContext *executionContext, serviceName primitives.ContractName) (bool, error) {
+	systemContractName := primitives.ContractName(permissions_systemcontract.CONTRACT_NAME)
+	systemMethodName := primitives.MethodName(permissions_systemcontract.METHOD_HAS_POLICY)
+
+	// modify execution context
+	executionContext.serviceStackPush(systemContractName)
+	defer executionContext.serviceStackPop()
+
+	// execute the call
+	inputArgs := (&protocol.ArgumentArrayBuilder{
+		Arguments: []*protocol.ArgumentBuilder{
+			{
+				// contractName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: string(serviceName),
+			},
+		},
+	}).Build()
+	output, err := s.processors[protocol.PROCESSOR_TYPE_NATIVE].ProcessCall(ctx, &services.ProcessCallInput{
+		ContextId:              executionContext.contextId,
+		ContractName:           systemContractName,
+		MethodName:             systemMethodName,
+		InputArgumentArray:     inputArgs,
+		AccessScope:            protocol.ACCESS_SCOPE_READ_ONLY,
+		CallingPermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+	})
+	if err != nil {
+		return false, err
+	}
+	outputArgsIterator := output.OutputArgumentArray.ArgumentsIterator()
+	if !outputArgsIterator.HasNext() {
+		return false, errors.Errorf("_Permissions.hasPolicy contract returned corrupt output value")
+	}
+	outputArg0 := outputArgsIterator.NextArguments()
+	if !outputArg0.IsTypeUint32Value() {
+		return false, errors.Errorf("_Permissions.hasPolicy contract returned corrupt output value")
+	}
+	return outputArg0.Uint32Value() != 0, nil
+}
+
+func (s *service) callCheckCallOfPermissionsSystemContract(ctx context.Context, executionContext *executionContext, serviceName primitives.ContractName, methodName primitives.MethodName, callerName primitives.ContractName, reentrant uint32) error {
+	systemContractName := primitives.ContractName(permissions_systemcontract.CONTRACT_NAME)
+	systemMethodName := primitives.MethodName(permissions_systemcontract.METHOD_CHECK_CALL)
+
+	// modify execution context
+	executionContext.serviceStackPush(systemContractName)
+	defer executionContext.serviceStackPop()
+
+	// execute the call
+	inputArgs := (&protocol.ArgumentArrayBuilder{
+		Arguments: []*protocol.ArgumentBuilder{
+			{
+				// contractName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: string(serviceName),
+			},
+			{
+				// methodName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: string(methodName),
+			},
+			{
+				// callerName
+				Type:        protocol.ARGUMENT_TYPE_STRING_VALUE,
+				StringValue: string(callerName),
+			},
+			{
+				// reentrant
+				Type:        protocol.ARGUMENT_TYPE_UINT_32_VALUE,
+				Uint32Value: reentrant,
+			},
+		},
+	}).Build()
+	output, err := s.processors[protocol.PROCESSOR_TYPE_NATIVE].ProcessCall(ctx, &services.ProcessCallInput{
+		ContextId:              executionContext.contextId,
+		ContractName:           systemContractName,
+		MethodName:             systemMethodName,
+		InputArgumentArray:     inputArgs,
+		AccessScope:            protocol.ACCESS_SCOPE_READ_ONLY,
+		CallingPermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "call from %s to %s.%s was rejected", callerName, serviceName, methodName)
+	}
+	if output.CallResult != protocol.EXECUTION_RESULT_SUCCESS {
+		return errors.Errorf("call from %s to %s.%s was rejected: %s", callerName, serviceName, methodName, output.CallResult)
+	}
+	return nil
+}
@@ -41,7 +41,7 @@ func (s *service) callSystemContract(
 		MethodName:             systemMethodName,
 		InputArgumentArray:     inputArgs,
 		AccessScope:            protocol.ACCESS_SCOPE_READ_ONLY,
-		CallingPermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+		CallingPermissionScope: protocol.PERMISSION_SCOPE_SYSTEM,
 	})
 
 	return output.CallResult, output.OutputArgumentArray, err
@@ -34,6 +34,8 @@ type executionContext struct {
 	batchTransientState         *transientState
 	transactionOrQuery          TransactionOrQuery
 	eventList                   []*protocol.EventBuilder
+	simulation                  bool
+	callPolicies                *callPolicyCache
 }
 
 func (c *executionContext) serviceStackTop() primitives.ContractName {
@@ -74,6 +76,17 @@ func (c *executionContext) serviceStackPeekCaller() primitives.ContractName {
 	return c.serviceStack[len(c.serviceStack)-2]
 }
 
+// the current service is already running lower in the stack, like A calling B which calls A again
+func (c *executionContext) serviceStackPeekCurrentIsReentrant() bool {
+	current := c.serviceStackPeekCurrent()
+	for i := 0; i < len(c.serviceStack)-1; i++ {
+		if c.serviceStack[i] == current {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *executionContext) eventListAdd(eventName primitives.EventName, opaqueArgumentArray []byte) {
 	event := &protocol.EventBuilder{
 		ContractName:        c.serviceStackPeekCurrent(),
@@ -126,6 +139,7 @@ func (cp *executionContextProvider) allocateExecutionContext(
 		transientState:              newTransientState(),
 		accessScope:                 accessScope,
 		transactionOrQuery:          transactionOrQuery,
+		callPolicies:                newCallPolicyCache(),
 	}
 
 	cp.lastContextIdCounter.Add(cp.lastContextIdCounter, BIG_INT_ONE)
@@ -69,6 +69,24 @@ func TestContext_ServiceStack(t *testing.T) {
 	require.Zero(t, c.serviceStackPeekCaller(), "calling service should be empty")
 }
 
+func TestContext_ServiceStackReentrancy(t *testing.T) {
+	cp := newExecutionContextProvider()
+	executionContextId, c := cp.allocateExecutionContext(0, 1, 0x222, []byte{0x01}, 8, 7, protocol.ACCESS_SCOPE_READ_ONLY, nil)
+	defer cp.destroyExecutionContext(executionContextId)
+
+	c.serviceStackPush("Service1")
+	require.False(t, c.serviceStackPeekCurrentIsReentrant(), "first call should not be reentrant")
+
+	c.serviceStackPush("Service2")
+	require.False(t, c.serviceStackPeekCurrentIsReentrant(), "call to another service should not be reentrant")
+
+	c.serviceStackPush("Service1")
+	require.True(t, c.serviceStackPeekCurrentIsReentrant(), "call back to a running service should be reentrant")
+
+	c.serviceStackPop()
+	require.False(t, c.serviceStackPeekCurrentIsReentrant(), "reentrancy should end with the reentrant call")
+}
+
 func TestContext_EventList(t *testing.T) {
 	cp := newExecutionContextProvider()
 	executionContextId, c := cp.allocateExecutionContext(0, 1, 0x222, []byte{0x01}, 9, 8, protocol.ACCESS_SCOPE_READ_ONLY, nil)
@@ -0,0 +1,48 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package virtualmachine
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+)
+
+// GetContractAbi describes a deployed contract as of the last committed block, like a query it never deploys contracts
+func (s *service) GetContractAbi(ctx context.Context, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	committedBlockHeight, committedBlockTimestamp, committeeReferenceTime, committedPrevReferenceTime, committedBlockProposerAddress, err := s.getRecentCommittedBlockInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	executionContextId, executionContext := s.contexts.allocateExecutionContext(committedBlockHeight, committedBlockHeight, committedBlockTimestamp, committedBlockProposerAddress, committeeReferenceTime, committedPrevReferenceTime, protocol.ACCESS_SCOPE_READ_ONLY, nil)
+	defer s.contexts.destroyExecutionContext(executionContextId)
+
+	logger.Info("describing contract", log.Stringable("contract", contractName), logfields.BlockHeight(committedBlockHeight))
+	contractProcessor, err := s.getServiceDeployment(ctx, executionContext, contractName)
+	if err != nil {
+		return nil, err
+	}
+
+	abiProvider, ok := contractProcessor.(processor.ContractAbiProvider)
+	if !ok {
+		return nil, errors.Errorf("the processor of contract %s does not support describing contracts", contractName)
+	}
+
+	// modify execution context
+	executionContext.serviceStackPush(contractName)
+	defer executionContext.serviceStackPop()
+
+	return abiProvider.GetContractAbi(ctx, executionContextId, contractName)
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/orbs-network/crypto-lib-go/crypto/digest"
 	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
 	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
 )
 
 type TransactionOrQuery interface {
@@ -35,13 +38,16 @@ func (s *service) runMethod(
 	lastBlockReferenceTime primitives.TimestampSeconds,
 	transactionOrQuery TransactionOrQuery,
 	accessScope protocol.ExecutionAccessScope,
+	permissionScope protocol.ExecutionPermissionScope,
 	batchTransientState *transientState,
+	simulation bool,
 ) (protocol.ExecutionResult, *protocol.ArgumentArray, *protocol.EventsArray, error) {
 
 	// create execution context
 	executionContextId, executionContext := s.contexts.allocateExecutionContext(lastCommittedBlockHeight, currentBlockHeight, currentBlockTimestamp, currentBlockProposerAddress, currentBlockReferenceTime, lastBlockReferenceTime, accessScope, transactionOrQuery)
 	defer s.contexts.destroyExecutionContext(executionContextId)
 	executionContext.batchTransientState = batchTransientState
+	executionContext.simulation = simulation
 
 	// get deployment info
 	processor, err := s.getServiceDeployment(ctx, executionContext, transactionOrQuery.ContractName())
@@ -62,7 +68,7 @@ func (s *service) runMethod(
 		MethodName:             transactionOrQuery.MethodName(),
 		InputArgumentArray:     inputArgs,
 		AccessScope:            accessScope,
-		CallingPermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+		CallingPermissionScope: permissionScope,
 	})
 	if err != nil {
 		s.logger.Info("transaction execution failed", log.Stringable("result", output.CallResult), log.Error(err), log.Stringable("transaction-or-query", transactionOrQuery))
@@ -87,7 +93,8 @@ func (s *service) processTransactionSet(
 	currentBlockReferenceTime primitives.TimestampSeconds,
 	lastBlockReferenceTime primitives.TimestampSeconds,
 	signedTransactions []*protocol.SignedTransaction,
-) ([]*protocol.TransactionReceipt, []*protocol.ContractStateDiff) {
+	simulation bool,
+) ([]*protocol.TransactionReceipt, []*protocol.ContractStateDiff, error) {
 
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 	lastCommittedBlockHeight := currentBlockHeight - 1
@@ -100,7 +107,13 @@ func (s *service) processTransactionSet(
 
 	for _, signedTransaction := range signedTransactions {
 		logger.Info("processing transaction", log.Stringable("contract", signedTransaction.Transaction().ContractName()), log.Stringable("method", signedTransaction.Transaction().MethodName()), logfields.BlockHeight(currentBlockHeight))
-		callResult, outputArgs, outputEvents, _ := s.runMethod(ctx, lastCommittedBlockHeight, currentBlockHeight, currentBlockTimestamp, currentBlockProposerAddress, currentBlockReferenceTime, lastBlockReferenceTime, signedTransaction.Transaction(), protocol.ACCESS_SCOPE_READ_WRITE, batchTransientState)
+		callResult, outputArgs, outputEvents, err := s.runMethod(ctx, lastCommittedBlockHeight, currentBlockHeight, currentBlockTimestamp, currentBlockProposerAddress, currentBlockReferenceTime, lastBlockReferenceTime, signedTransaction.Transaction(), protocol.ACCESS_SCOPE_READ_WRITE, transactionPermissionScope(signedTransaction, simulation), batchTransientState, simulation)
+		if processor.IsExecutionAborted(err) {
+			if !simulation {
+				s.abortedTransactions.add(digest.CalcTxHash(signedTransaction.Transaction()))
+			}
+			return nil, nil, errors.Wrapf(err, "transaction %s.%s was not executed", signedTransaction.Transaction().ContractName(), signedTransaction.Transaction().MethodName())
+		}
 		if outputArgs == nil {
 			outputArgs = protocol.ArgumentsArrayEmpty()
 		}
@@ -113,7 +126,18 @@ func (s *service) processTransactionSet(
 	}
 
 	stateDiffs := encodeBatchTransientStateToStateDiffs(batchTransientState)
-	return receipts, stateDiffs
+	return receipts, stateDiffs, nil
+}
+
+// only the block proposer adds unsigned transactions to a block (the trigger and the scheduled calls before it), those of
+// clients fail the signature check of pre order, so unsigned transactions in a block may run the system methods of contracts
+// from the protocol version of the scheduled calls (the proposer gives them the protocol version of the block)
+func transactionPermissionScope(signedTransaction *protocol.SignedTransaction, simulation bool) protocol.ExecutionPermissionScope {
+	transaction := signedTransaction.Transaction()
+	if !simulation && len(transaction.Signer().Raw()) == 0 && transaction.ProtocolVersion() >= triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION {
+		return protocol.PERMISSION_SCOPE_SYSTEM
+	}
+	return protocol.PERMISSION_SCOPE_SERVICE
 }
 
 func (s *service) getRecentCommittedBlockInfo(ctx context.Context) (primitives.BlockHeight, primitives.TimestampNano, primitives.TimestampSeconds, primitives.TimestampSeconds,  primitives.NodeAddress, error) {
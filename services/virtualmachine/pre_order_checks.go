@@ -33,6 +33,14 @@ func (s *service) verifyTransactionSignatures(signedTransactions []*protocol.Sig
 	}
 }
 
+func (s *service) rejectAbortedTransactions(signedTransactions []*protocol.SignedTransaction, resultStatuses []protocol.TransactionStatus) {
+	for i, signedTransaction := range signedTransactions {
+		if resultStatuses[i] == protocol.TRANSACTION_STATUS_PRE_ORDER_VALID && s.abortedTransactions.contains(digest.CalcTxHash(signedTransaction.Transaction())) {
+			resultStatuses[i] = protocol.TRANSACTION_STATUS_REJECTED_SMART_CONTRACT_PRE_ORDER
+		}
+	}
+}
+
 func verifyEd25519Signer(signedTransaction *protocol.SignedTransaction) bool {
 	signerPublicKey := signedTransaction.Transaction().Signer().Eddsa().SignerPublicKey()
 	txHash := digest.CalcTxHash(signedTransaction.Transaction())
@@ -0,0 +1,99 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package virtualmachine
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+)
+
+// PreWarmDeployedContracts loads every contract deployed as of the last committed block so the first transactions
+// calling them do not wait for compilation, a contract which fails to load is logged and skipped
+func (s *service) PreWarmDeployedContracts(ctx context.Context) error {
+	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	committedBlockHeight, committedBlockTimestamp, committeeReferenceTime, committedPrevReferenceTime, committedBlockProposerAddress, err := s.getRecentCommittedBlockInfo(ctx)
+	if err != nil {
+		return err
+	}
+
+	executionContextId, executionContext := s.contexts.allocateExecutionContext(committedBlockHeight, committedBlockHeight, committedBlockTimestamp, committedBlockProposerAddress, committeeReferenceTime, committedPrevReferenceTime, protocol.ACCESS_SCOPE_READ_ONLY, nil)
+	defer s.contexts.destroyExecutionContext(executionContextId)
+
+	deployedServices, err := s.callGetServicesOfDeploymentSystemContract(ctx, executionContext)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("pre-warming deployed contracts", log.Int("contracts", len(deployedServices)), logfields.BlockHeight(committedBlockHeight))
+	for _, serviceName := range deployedServices {
+		if err := s.preWarmContract(ctx, executionContext, serviceName); err != nil {
+			logger.Info("failed to pre-warm contract", log.Stringable("contract", serviceName), log.Error(err))
+		}
+	}
+	return nil
+}
+
+func (s *service) preWarmContract(ctx context.Context, executionContext *executionContext, serviceName primitives.ContractName) error {
+	contractProcessor, err := s.getServiceDeployment(ctx, executionContext, serviceName)
+	if err != nil {
+		return err
+	}
+
+	// modify execution context
+	executionContext.serviceStackPush(serviceName)
+	defer executionContext.serviceStackPop()
+
+	_, err = contractProcessor.GetContractInfo(ctx, &services.GetContractInfoInput{
+		ContextId:    executionContext.contextId,
+		ContractName: serviceName,
+	})
+	return err
+}
+
+func (s *service) callGetServicesOfDeploymentSystemContract(ctx context.Context, executionContext *executionContext) ([]primitives.ContractName, error) {
+	systemContractName := primitives.ContractName(deployments_systemcontract.CONTRACT_NAME)
+	systemMethodName := primitives.MethodName(deployments_systemcontract.METHOD_GET_SERVICES)
+
+	// modify execution context
+	executionContext.serviceStackPush(systemContractName)
+	defer executionContext.serviceStackPop()
+
+	// execute the call
+	output, err := s.processors[protocol.PROCESSOR_TYPE_NATIVE].ProcessCall(ctx, &services.ProcessCallInput{
+		ContextId:              executionContext.contextId,
+		ContractName:           systemContractName,
+		MethodName:             systemMethodName,
+		InputArgumentArray:     protocol.ArgumentsArrayEmpty(),
+		AccessScope:            executionContext.accessScope,
+		CallingPermissionScope: protocol.PERMISSION_SCOPE_SERVICE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	outputArgsIterator := output.OutputArgumentArray.ArgumentsIterator()
+	if !outputArgsIterator.HasNext() {
+		return nil, errors.Errorf("_Deployments.getServices contract returned corrupt output value")
+	}
+	outputArg0 := outputArgsIterator.NextArguments()
+	if !outputArg0.IsTypeStringArrayValue() {
+		return nil, errors.Errorf("_Deployments.getServices contract returned corrupt output value")
+	}
+
+	var deployedServices []primitives.ContractName
+	for itr := outputArg0.StringArrayValueIterator(); itr.HasNext(); {
+		deployedServices = append(deployedServices, primitives.ContractName(itr.NextString()))
+	}
+	return deployedServices, nil
+}
@@ -73,6 +73,17 @@ func (s *service) handleSdkEnvCall(ctx context.Context, executionContext *execut
 			BytesArrayValue: value,
 		}).Build()}, nil
 
+	case "getProtocolVersion":
+		value, err := s.handleSdkEnvGetProtocolVersion(ctx, executionContext, args)
+		if err != nil {
+			return nil, err
+		}
+		return []*protocol.Argument{(&protocol.ArgumentBuilder{
+			// value
+			Type:        protocol.ARGUMENT_TYPE_UINT_32_VALUE,
+			Uint32Value: value,
+		}).Build()}, nil
+
 	default:
 		return nil, errors.Errorf("unknown SDK env call method: %s", methodName)
 	}
@@ -147,3 +158,17 @@ func (s *service) handleSdkEnvGetNextBlockCommittee(ctx context.Context, executi
 	}
 	return committee, err
 }
+
+// outputArg0: value (uint32)
+func (s *service) handleSdkEnvGetProtocolVersion(ctx context.Context, executionContext *executionContext, args []*protocol.Argument) (uint32, error) {
+	if len(args) != 0 {
+		return 0, errors.Errorf("invalid SDK env getProtocolVersion args: %v", args)
+	}
+
+	res, err := s.management.GetProtocolVersion(ctx, &services.GetProtocolVersionInput{Reference: executionContext.currentBlockReferenceTime})
+	if err != nil {
+		s.logger.Error("management.GetProtocolVersion failed", log.Error(err))
+		return 0, err
+	}
+	return uint32(res.ProtocolVersion), nil
+}
@@ -8,6 +8,8 @@ package virtualmachine
 
 import (
 	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
 	"github.com/orbs-network/orbs-spec/types/go/services"
@@ -43,6 +45,10 @@ func (s *service) handleSdkServiceCallMethod(ctx context.Context, executionConte
 	methodName := args[1].StringValue()
 	inputArgumentArray := protocol.ArgumentArrayReader(args[2].BytesValue())
 
+	if executionContext.simulation && primitives.ContractName(serviceName) == deployments_systemcontract.CONTRACT_NAME {
+		return nil, ErrDeploymentNotSimulated
+	}
+
 	// get deployment info
 	processor, err := s.getServiceDeployment(ctx, executionContext, primitives.ContractName(serviceName))
 	if err != nil {
@@ -54,6 +60,13 @@ func (s *service) handleSdkServiceCallMethod(ctx context.Context, executionConte
 	executionContext.serviceStackPush(primitives.ContractName(serviceName))
 	defer executionContext.serviceStackPop()
 
+	// enforce the access policy of the called contract
+	err = s.enforceCallPolicy(ctx, executionContext, primitives.MethodName(methodName))
+	if err != nil {
+		s.logger.Info("Sdk.Service.CallMethod rejected by contract policy", log.Error(err), log.Stringable("callee", primitives.ContractName(serviceName)))
+		return nil, err
+	}
+
 	// execute the call
 	output, err := processor.ProcessCall(ctx, &services.ProcessCallInput{
 		ContextId:              executionContext.contextId,
@@ -68,5 +81,10 @@ func (s *service) handleSdkServiceCallMethod(ctx context.Context, executionConte
 		return nil, err
 	}
 
+	// the caller may have changed its own call policy
+	if serviceName == permissions_systemcontract.CONTRACT_NAME {
+		executionContext.callPolicies.reset()
+	}
+
 	return output.OutputArgumentArray.Raw(), nil
 }
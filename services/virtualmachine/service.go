@@ -16,6 +16,7 @@ import (
 	"github.com/orbs-network/orbs-spec/types/go/services/handlers"
 	"github.com/orbs-network/scribe/log"
 	"github.com/pkg/errors"
+	"strconv"
 	"time"
 )
 
@@ -32,11 +33,13 @@ type service struct {
 	management           services.Management
 	cfg                  ManagementConfig
 	logger               log.Logger
+	tracer               *trace.Tracer
 
-	contexts *executionContextProvider
+	contexts            *executionContextProvider
+	abortedTransactions *abortedTransactions
 }
 
-func NewVirtualMachine(stateStorage services.StateStorage, processors map[protocol.ProcessorType]services.Processor, crosschainConnectors map[protocol.CrosschainConnectorType]services.CrosschainConnector, management services.Management, cfg ManagementConfig, logger log.Logger, ) services.VirtualMachine {
+func NewVirtualMachine(stateStorage services.StateStorage, processors map[protocol.ProcessorType]services.Processor, crosschainConnectors map[protocol.CrosschainConnectorType]services.CrosschainConnector, management services.Management, cfg ManagementConfig, logger log.Logger, tracer *trace.Tracer) services.VirtualMachine {
 	s := &service{
 		processors:           processors,
 		crosschainConnectors: crosschainConnectors,
@@ -44,8 +47,10 @@ func NewVirtualMachine(stateStorage services.StateStorage, processors map[protoc
 		management:           management,
 		cfg:                  cfg,
 		logger:               logger.WithTags(LogTag),
+		tracer:               tracer,
 
-		contexts: newExecutionContextProvider(),
+		contexts:            newExecutionContextProvider(),
+		abortedTransactions: newAbortedTransactions(),
 	}
 
 	for _, processor := range processors {
@@ -56,6 +61,11 @@ func NewVirtualMachine(stateStorage services.StateStorage, processors map[protoc
 }
 
 func (s *service) ProcessQuery(ctx context.Context, input *services.ProcessQueryInput) (*services.ProcessQueryOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "VirtualMachine.ProcessQuery")
+	defer span.End()
+	span.SetAttribute("contract", input.SignedQuery.Query().ContractName().String())
+	span.SetAttribute("method", input.SignedQuery.Query().MethodName().String())
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	committedBlockHeight, committedBlockTimestamp, committeeReferenceTime, committedPrevReferenceTime, committedBlockProposerAddress, err := s.getRecentCommittedBlockInfo(ctx)
@@ -79,7 +89,7 @@ func (s *service) ProcessQuery(ctx context.Context, input *services.ProcessQuery
 	}
 
 	logger.Info("running local method", log.Stringable("contract", input.SignedQuery.Query().ContractName()), log.Stringable("method", input.SignedQuery.Query().MethodName()), logfields.BlockHeight(committedBlockHeight))
-	callResult, outputArgs, outputEvents, err := s.runMethod(ctx, committedBlockHeight, committedBlockHeight, committedBlockTimestamp, committedBlockProposerAddress, committeeReferenceTime, committedPrevReferenceTime, input.SignedQuery.Query(), protocol.ACCESS_SCOPE_READ_ONLY, nil)
+	callResult, outputArgs, outputEvents, err := s.runMethod(ctx, committedBlockHeight, committedBlockHeight, committedBlockTimestamp, committedBlockProposerAddress, committeeReferenceTime, committedPrevReferenceTime, input.SignedQuery.Query(), protocol.ACCESS_SCOPE_READ_ONLY, protocol.PERMISSION_SCOPE_SERVICE, nil, false)
 	if outputArgs == nil {
 		outputArgs = protocol.ArgumentsArrayEmpty()
 	}
@@ -97,10 +107,19 @@ func (s *service) ProcessQuery(ctx context.Context, input *services.ProcessQuery
 }
 
 func (s *service) ProcessTransactionSet(ctx context.Context, input *services.ProcessTransactionSetInput) (*services.ProcessTransactionSetOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "VirtualMachine.ProcessTransactionSet")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+	span.SetAttribute("num-transactions", strconv.Itoa(len(input.SignedTransactions)))
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	logger.Info("processing transaction set", log.Int("num-transactions", len(input.SignedTransactions)), logfields.BlockHeight(input.CurrentBlockHeight))
-	receipts, stateDiffs := s.processTransactionSet(ctx, input.CurrentBlockHeight, input.CurrentBlockTimestamp, input.BlockProposerAddress, input.CurrentBlockReferenceTime, input.PrevBlockReferenceTime, input.SignedTransactions)
+	receipts, stateDiffs, err := s.processTransactionSet(ctx, input.CurrentBlockHeight, input.CurrentBlockTimestamp, input.BlockProposerAddress, input.CurrentBlockReferenceTime, input.PrevBlockReferenceTime, input.SignedTransactions, false)
+	if err != nil {
+		logger.Error("failed to process transaction set", log.Error(err), logfields.BlockHeight(input.CurrentBlockHeight))
+		return nil, err
+	}
 
 	return &services.ProcessTransactionSetOutput{
 		TransactionReceipts: receipts,
@@ -109,6 +128,11 @@ func (s *service) ProcessTransactionSet(ctx context.Context, input *services.Pro
 }
 
 func (s *service) TransactionSetPreOrder(ctx context.Context, input *services.TransactionSetPreOrderInput) (*services.TransactionSetPreOrderOutput, error) {
+	ctx, span := s.tracer.StartSpan(ctx, "VirtualMachine.TransactionSetPreOrder")
+	defer span.End()
+	span.SetAttribute("block-height", input.CurrentBlockHeight.String())
+	span.SetAttribute("num-transactions", strconv.Itoa(len(input.SignedTransactions)))
+
 	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
 
 	// all statuses start as protocol.TRANSACTION_STATUS_RESERVED (zero)
@@ -124,6 +148,7 @@ func (s *service) TransactionSetPreOrder(ctx context.Context, input *services.Tr
 	} else {
 		// check signatures
 		s.verifyTransactionSignatures(input.SignedTransactions, statuses)
+		s.rejectAbortedTransactions(input.SignedTransactions, statuses)
 	}
 
 	if !isSubscriptionActive {
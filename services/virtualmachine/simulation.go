@@ -0,0 +1,66 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package virtualmachine
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/instrumentation/logfields"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/scribe/log"
+	"github.com/pkg/errors"
+)
+
+// TransactionSimulation is what a transaction would do if it were included in the next block, nothing of it is committed
+type TransactionSimulation struct {
+	BlockHeight        primitives.BlockHeight
+	BlockTimestamp     primitives.TimestampNano
+	TransactionReceipt *protocol.TransactionReceipt
+	ContractStateDiffs []*protocol.ContractStateDiff
+}
+
+// ErrDeploymentNotSimulated is returned for transactions which call _Deployments, directly or from another contract,
+// deploying compiles and loads code on the node so it is never done for a transaction which is then discarded
+var ErrDeploymentNotSimulated = errors.New("calls to the deployments contract cannot be simulated")
+
+// SimulateTransaction runs the transaction like the next block would, on top of the last committed block. The signature
+// is not verified so clients can preview a transaction before signing it, the state diffs are returned and then discarded
+func (s *service) SimulateTransaction(ctx context.Context, signedTransaction *protocol.SignedTransaction) (*TransactionSimulation, error) {
+	logger := s.logger.WithTags(trace.LogFieldFrom(ctx))
+
+	if signedTransaction == nil || signedTransaction.Transaction() == nil {
+		return nil, errors.New("transaction is missing")
+	}
+	if signedTransaction.Transaction().ContractName() == deployments_systemcontract.CONTRACT_NAME {
+		return nil, ErrDeploymentNotSimulated
+	}
+
+	committedBlockHeight, committedBlockTimestamp, committeeReferenceTime, _, committedBlockProposerAddress, err := s.getRecentCommittedBlockInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the next block is assumed to follow the last committed one right away, by the same proposer under the same reference
+	// time, so a simulation depends only on committed data and not on the clock of the node answering it
+	blockHeight := committedBlockHeight + 1
+	blockTimestamp := committedBlockTimestamp + 1
+
+	logger.Info("simulating transaction", log.Stringable("contract", signedTransaction.Transaction().ContractName()), log.Stringable("method", signedTransaction.Transaction().MethodName()), logfields.BlockHeight(blockHeight))
+	receipts, stateDiffs, err := s.processTransactionSet(ctx, blockHeight, blockTimestamp, committedBlockProposerAddress, committeeReferenceTime, committeeReferenceTime, []*protocol.SignedTransaction{signedTransaction}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionSimulation{
+		BlockHeight:        blockHeight,
+		BlockTimestamp:     blockTimestamp,
+		TransactionReceipt: receipts[0],
+		ContractStateDiffs: stateDiffs,
+	}, nil
+}
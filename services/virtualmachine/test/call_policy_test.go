@@ -0,0 +1,152 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCallPolicy_RejectedCallDoesNotRunCallee(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(errors.New("contract Contract1 is not allowed to call Contract2.method1"))
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", "Contract2", "method1", builders.ArgumentsArray().Raw())
+				require.Error(t, err, "handleSdkCall should fail when the policy rejects the call")
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodNotCalled("Contract2", "method1")
+
+			h.processTransactionSet(ctx, []*contractAndMethod{
+				{"Contract1", "method1"},
+			})
+
+			h.verifySystemContractCalled(t)
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
+
+func TestCallPolicy_CheckedWithCallerAndReentrancy(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPolicyReadFor("Contract2", true, 1)
+			h.expectCallPolicyReadFor("Contract1", true, 1)
+			h.expectCallPolicyCheckedFor("Contract2", "method1", "Contract1", 0, 1)
+			h.expectCallPolicyCheckedFor("Contract1", "method2", "Contract2", 1, 1)
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", "Contract2", "method1", builders.ArgumentsArray().Raw())
+				require.NoError(t, err, "handleSdkCall should succeed")
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodCalled("Contract2", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				t.Log("CallMethod back on the first contract")
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", "Contract1", "method2", builders.ArgumentsArray().Raw())
+				require.NoError(t, err, "handleSdkCall should succeed")
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodCalled("Contract1", "method2", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+
+			h.processTransactionSet(ctx, []*contractAndMethod{
+				{"Contract1", "method1"},
+			})
+
+			h.verifySystemContractCalled(t)
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
+
+func TestCallPolicy_NotCheckedForContractWithoutPolicy(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPolicyReadFor("Contract2", false, 1)
+			h.expectCallPolicyNotChecked()
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				for i := 0; i < 2; i++ {
+					_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", "Contract2", "method1", builders.ArgumentsArray().Raw())
+					require.NoError(t, err, "handleSdkCall should succeed")
+				}
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodCalledTimes("Contract2", "method1", 2, func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+
+			h.processTransactionSet(ctx, []*contractAndMethod{
+				{"Contract1", "method1"},
+			})
+
+			h.verifySystemContractCalled(t)
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
+
+func TestCallPolicy_CheckedOncePerExecutionUntilPolicyChanges(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPolicyReadFor("Contract2", true, 2)
+			h.expectCallPolicyCheckedFor("Contract2", "method1", "Contract1", 0, 2)
+
+			callContract2 := func(executionContextId primitives.ExecutionContextId) {
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", "Contract2", "method1", builders.ArgumentsArray().Raw())
+				require.NoError(t, err, "handleSdkCall should succeed")
+			}
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				callContract2(executionContextId)
+				callContract2(executionContextId)
+
+				t.Log("a contract changing its policy drops the cached checks")
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", permissions_systemcontract.CONTRACT_NAME, permissions_systemcontract.METHOD_ALLOW_CALLER, builders.ArgumentsArray("Contract1", "method3", "Contract2").Raw())
+				require.NoError(t, err, "handleSdkCall should succeed")
+				callContract2(executionContextId)
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodCalledTimes("Contract2", "method1", 3, func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+			h.expectNativeContractMethodCalled(permissions_systemcontract.CONTRACT_NAME, permissions_systemcontract.METHOD_ALLOW_CALLER, func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+
+			h.processTransactionSet(ctx, []*contractAndMethod{
+				{"Contract1", "method1"},
+			})
+
+			h.verifySystemContractCalled(t)
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
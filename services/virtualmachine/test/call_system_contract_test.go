@@ -24,7 +24,7 @@ func TestCallSystemContract_Success(t *testing.T) {
 
 			currentBlockHeight := primitives.BlockHeight(12)
 
-			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+			h.expectNativeContractMethodCalledWithPermissionScope("Contract1", "method1", protocol.PERMISSION_SCOPE_SYSTEM, func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("Input arguments are propagated correctly")
 				require.EqualValues(t, builders.ArgumentsArray(uint32(17), "hello", []byte{0x01, 0x02}), inputArgs, "call system contract should propagate matching input args")
 
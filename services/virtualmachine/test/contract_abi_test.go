@@ -0,0 +1,59 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (h *harness) getContractAbi(ctx context.Context, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	return h.service.(interface {
+		GetContractAbi(ctx context.Context, contractName primitives.ContractName) (*processor.ContractAbi, error)
+	}).GetContractAbi(ctx, contractName)
+}
+
+func TestGetContractAbi_Success(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE))
+			expectedAbi := &processor.ContractAbi{Name: "Contract1"}
+			h.expectNativeContractAbiRequested("Contract1", expectedAbi, nil)
+
+			contractAbi, err := h.getContractAbi(ctx, "Contract1")
+			require.NoError(t, err)
+			require.Equal(t, expectedAbi, contractAbi)
+
+			h.verifySystemContractCalled(t)
+			h.verifyStateStorageBlockHeightRequested(t)
+		})
+	})
+}
+
+func TestGetContractAbi_ContractNotDeployed(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, errors.New("not deployed"))
+
+			_, err := h.getContractAbi(ctx, "Contract1")
+			require.Error(t, err, "contracts are never deployed while describing them")
+
+			h.verifyStateStorageBlockHeightRequested(t)
+		})
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"fmt"
 	"github.com/orbs-network/crypto-lib-go/crypto/hash"
 	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Permissions"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
@@ -30,21 +32,33 @@ func (h *harness) verifyHandlerRegistrations(t *testing.T) {
 }
 
 func (h *harness) expectNativeContractMethodCalled(expectedContractName primitives.ContractName, expectedMethodName primitives.MethodName, contractFunction func(primitives.ExecutionContextId, *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error)) {
+	h.expectNativeContractMethodCalledWithPermissionScope(expectedContractName, expectedMethodName, protocol.PERMISSION_SCOPE_SERVICE, contractFunction)
+}
+
+func (h *harness) expectNativeContractMethodCalledTimes(expectedContractName primitives.ContractName, expectedMethodName primitives.MethodName, times int, contractFunction func(primitives.ExecutionContextId, *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error)) {
+	h.expectNativeContractMethodCalledWithPermissionScopeTimes(expectedContractName, expectedMethodName, protocol.PERMISSION_SCOPE_SERVICE, times, contractFunction)
+}
+
+func (h *harness) expectNativeContractMethodCalledWithPermissionScope(expectedContractName primitives.ContractName, expectedMethodName primitives.MethodName, expectedPermissionScope protocol.ExecutionPermissionScope, contractFunction func(primitives.ExecutionContextId, *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error)) {
+	h.expectNativeContractMethodCalledWithPermissionScopeTimes(expectedContractName, expectedMethodName, expectedPermissionScope, 1, contractFunction)
+}
+
+func (h *harness) expectNativeContractMethodCalledWithPermissionScopeTimes(expectedContractName primitives.ContractName, expectedMethodName primitives.MethodName, expectedPermissionScope protocol.ExecutionPermissionScope, times int, contractFunction func(primitives.ExecutionContextId, *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error)) {
 	contractMethodMatcher := func(i interface{}) bool {
 		input, ok := i.(*services.ProcessCallInput)
 		return ok &&
 			input.ContractName == expectedContractName &&
 			input.MethodName == expectedMethodName &&
-			input.CallingPermissionScope == protocol.PERMISSION_SCOPE_SERVICE
+			input.CallingPermissionScope == expectedPermissionScope
 	}
 
-	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf(fmt.Sprintf("Contract equals %s and Method %s and permissions are service", expectedContractName, expectedMethodName), contractMethodMatcher)).Call(func(ctx context.Context, input *services.ProcessCallInput) (*services.ProcessCallOutput, error) {
+	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf(fmt.Sprintf("Contract equals %s and Method %s and permissions are %s", expectedContractName, expectedMethodName, expectedPermissionScope), contractMethodMatcher)).Call(func(ctx context.Context, input *services.ProcessCallInput) (*services.ProcessCallOutput, error) {
 		callResult, outputArgsArray, err := contractFunction(input.ContextId, input.InputArgumentArray)
 		return &services.ProcessCallOutput{
 			OutputArgumentArray: outputArgsArray,
 			CallResult:          callResult,
 		}, err
-	}).Times(1)
+	}).Times(times)
 }
 
 func (h *harness) expectNativeContractMethodCalledWithSystemPermissions(expectedContractName primitives.ContractName, expectedMethodName primitives.MethodName, contractFunction func(primitives.ExecutionContextId) (protocol.ExecutionResult, *protocol.ArgumentArray, error)) {
@@ -101,6 +115,62 @@ func (h *harness) expectSystemContractCalled(expectedContractName string, expect
 	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf(fmt.Sprintf("Contract equals %s and Method %s", expectedContractName, expectedMethodName), contractMethodMatcher)).Return(outputToReturn, returnError).AtLeast(1)
 }
 
+func (h *harness) expectCallPoliciesChecked(returnError error) {
+	h.expectSystemContractCalled(permissions_systemcontract.CONTRACT_NAME, permissions_systemcontract.METHOD_HAS_POLICY, nil, uint32(1))
+	h.expectSystemContractCalled(permissions_systemcontract.CONTRACT_NAME, permissions_systemcontract.METHOD_CHECK_CALL, returnError)
+}
+
+func (h *harness) expectCallPolicyReadFor(expectedContractName string, hasPolicy bool, times int) {
+	expectedArgs := builders.ArgumentsArray(expectedContractName)
+	hasPolicyMatcher := func(i interface{}) bool {
+		input, ok := i.(*services.ProcessCallInput)
+		return ok &&
+			input.ContractName == permissions_systemcontract.CONTRACT_NAME &&
+			input.MethodName == permissions_systemcontract.METHOD_HAS_POLICY &&
+			bytes.Equal(input.InputArgumentArray.Raw(), expectedArgs.Raw())
+	}
+
+	result := uint32(0)
+	if hasPolicy {
+		result = 1
+	}
+	outputToReturn := &services.ProcessCallOutput{
+		OutputArgumentArray: builders.ArgumentsArray(result),
+		CallResult:          protocol.EXECUTION_RESULT_SUCCESS,
+	}
+
+	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf(fmt.Sprintf("Policy of %s read", expectedContractName), hasPolicyMatcher)).Return(outputToReturn, nil).Times(times)
+}
+
+func (h *harness) expectCallPolicyNotChecked() {
+	checkCallMatcher := func(i interface{}) bool {
+		input, ok := i.(*services.ProcessCallInput)
+		return ok &&
+			input.ContractName == permissions_systemcontract.CONTRACT_NAME &&
+			input.MethodName == permissions_systemcontract.METHOD_CHECK_CALL
+	}
+
+	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf("Policy checked", checkCallMatcher)).Return(nil, nil).Times(0)
+}
+
+func (h *harness) expectCallPolicyCheckedFor(expectedContractName string, expectedMethodName string, expectedCallerName string, expectedReentrant uint32, times int) {
+	expectedArgs := builders.ArgumentsArray(expectedContractName, expectedMethodName, expectedCallerName, expectedReentrant)
+	checkCallMatcher := func(i interface{}) bool {
+		input, ok := i.(*services.ProcessCallInput)
+		return ok &&
+			input.ContractName == permissions_systemcontract.CONTRACT_NAME &&
+			input.MethodName == permissions_systemcontract.METHOD_CHECK_CALL &&
+			bytes.Equal(input.InputArgumentArray.Raw(), expectedArgs.Raw())
+	}
+
+	outputToReturn := &services.ProcessCallOutput{
+		OutputArgumentArray: builders.ArgumentsArray(),
+		CallResult:          protocol.EXECUTION_RESULT_SUCCESS,
+	}
+
+	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("ProcessCall", mock.Any, mock.AnyIf(fmt.Sprintf("Policy check of %s.%s by %s", expectedContractName, expectedMethodName, expectedCallerName), checkCallMatcher)).Return(outputToReturn, nil).Times(times)
+}
+
 func (h *harness) verifySystemContractCalled(t *testing.T) {
 	ok, err := h.processors[protocol.PROCESSOR_TYPE_NATIVE].Verify()
 	require.True(t, ok, "did not call processor for system contract: %v", err)
@@ -120,6 +190,10 @@ func (h *harness) expectNativeContractInfoRequested(expectedContractName primiti
 	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("GetContractInfo", mock.Any, mock.AnyIf(fmt.Sprintf("Contract equals %s", expectedContractName), contractMatcher)).Return(outputToReturn, returnError).AtLeast(1)
 }
 
+func (h *harness) expectNativeContractAbiRequested(expectedContractName primitives.ContractName, returnAbi *processor.ContractAbi, returnError error) {
+	h.processors[protocol.PROCESSOR_TYPE_NATIVE].When("GetContractAbi", mock.Any, mock.Any, expectedContractName).Return(returnAbi, returnError).Times(1)
+}
+
 func (h *harness) verifyNativeContractInfoRequested(t *testing.T) {
 	ok, err := h.processors[protocol.PROCESSOR_TYPE_NATIVE].Verify()
 	require.True(t, ok, "did not request info for native contract: %v", err)
@@ -11,6 +11,9 @@ import (
 	"fmt"
 	"github.com/orbs-network/crypto-lib-go/crypto/hash"
 	"github.com/orbs-network/go-mock"
+	"github.com/orbs-network/orbs-network-go/config"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
+	"github.com/orbs-network/orbs-network-go/services/processor"
 	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
 	"github.com/orbs-network/orbs-network-go/test/builders"
 	testKeys "github.com/orbs-network/orbs-network-go/test/crypto/keys"
@@ -46,7 +49,7 @@ func newHarness(logger log.Logger) *harness {
 
 	processorsForService := make(map[protocol.ProcessorType]services.Processor)
 	for key, value := range processors {
-		processorsForService[key] = value
+		processorsForService[key] = &mockProcessorWithAbi{value}
 	}
 
 	crosschainConnectorsForService := make(map[protocol.CrosschainConnectorType]services.CrosschainConnector)
@@ -59,8 +62,9 @@ func newHarness(logger log.Logger) *harness {
 	management.When("GetCommittee", mock.Any, &services.GetCommitteeInput{Reference: 800}).Return(&services.GetCommitteeOutput{Members: testKeys.NodeAddressesForTests()[1:5]}, nil)
 	management.When("GetCommittee", mock.Any, mock.Any).Return(&services.GetCommitteeOutput{Members: testKeys.NodeAddressesForTests()[:4]}, nil)
 	management.When("GetSubscriptionStatus", mock.Any, mock.Any).Return(&services.GetSubscriptionStatusOutput{SubscriptionStatusIsActive: true}, nil)
+	management.When("GetProtocolVersion", mock.Any, mock.Any).Return(&services.GetProtocolVersionOutput{ProtocolVersion: 1}, nil)
 
-	service := virtualmachine.NewVirtualMachine(stateStorage, processorsForService, crosschainConnectorsForService, management, cfg, logger)
+	service := virtualmachine.NewVirtualMachine(stateStorage, processorsForService, crosschainConnectorsForService, management, cfg, logger, trace.NewNoopTracer())
 
 	return &harness{
 		blockStorage:         blockStorage,
@@ -74,6 +78,16 @@ func newHarness(logger log.Logger) *harness {
 	}
 }
 
+// the spec mocks do not know about contract ABIs
+type mockProcessorWithAbi struct {
+	*services.MockProcessor
+}
+
+func (p *mockProcessorWithAbi) GetContractAbi(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName primitives.ContractName) (*processor.ContractAbi, error) {
+	ret := p.Called(ctx, executionContextId, contractName)
+	return ret.GetType(0, &processor.ContractAbi{}).(*processor.ContractAbi), ret.Error(1)
+}
+
 func (h *harness) handleSdkCall(ctx context.Context, executionContextId primitives.ExecutionContextId, contractName primitives.ContractName, methodName primitives.MethodName, args ...interface{}) ([]*protocol.Argument, error) {
 	inputArgs, err := protocol.ArgumentsFromNatives(args)
 	if err != nil {
@@ -155,7 +169,11 @@ func (h *harness) processTransactionSet(ctx context.Context, contractAndMethods
 }
 
 func (h *harness) processTriggerTransaction(ctx context.Context, currentBlockHeight primitives.BlockHeight, currentBlockTimestamp primitives.TimestampNano, currentBlockProposer primitives.NodeAddress) {
-	transactions := []*protocol.SignedTransaction{builders.TriggerTransaction().Build()}
+	h.processTriggerTransactionWithProtocolVersion(ctx, currentBlockHeight, currentBlockTimestamp, currentBlockProposer, config.MAXIMAL_PROTOCOL_VERSION_SUPPORTED_VALUE)
+}
+
+func (h *harness) processTriggerTransactionWithProtocolVersion(ctx context.Context, currentBlockHeight primitives.BlockHeight, currentBlockTimestamp primitives.TimestampNano, currentBlockProposer primitives.NodeAddress, protocolVersion primitives.ProtocolVersion) {
+	transactions := []*protocol.SignedTransaction{builders.TriggerTransaction().WithProtocolVersion(protocolVersion).Build()}
 
 	h.service.ProcessTransactionSet(ctx, &services.ProcessTransactionSetInput{
 		SignedTransactions:    transactions,
@@ -0,0 +1,73 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func (h *harness) preWarmDeployedContracts(ctx context.Context) error {
+	return h.service.(interface {
+		PreWarmDeployedContracts(ctx context.Context) error
+	}).PreWarmDeployedContracts(ctx)
+}
+
+func TestPreWarmDeployedContracts_LoadsEveryDeployedContract(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_SERVICES, nil, []string{"Contract1", "Contract2"})
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE))
+			h.expectNativeContractInfoRequested("Contract1", nil)
+			h.expectNativeContractInfoRequested("Contract2", nil)
+
+			err := h.preWarmDeployedContracts(ctx)
+			require.NoError(t, err)
+
+			h.verifyNativeContractInfoRequested(t)
+			h.verifyStateStorageBlockHeightRequested(t)
+		})
+	})
+}
+
+func TestPreWarmDeployedContracts_ContinuesAfterFailingContract(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_SERVICES, nil, []string{"Contract1", "Contract2"})
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE))
+			h.expectNativeContractInfoRequested("Contract1", errors.New("compilation failed"))
+			h.expectNativeContractInfoRequested("Contract2", nil)
+
+			err := h.preWarmDeployedContracts(ctx)
+			require.NoError(t, err, "a contract which fails to load should not fail the others")
+
+			h.verifyNativeContractInfoRequested(t)
+		})
+	})
+}
+
+func TestPreWarmDeployedContracts_FailsWhenDeploymentsCannotBeListed(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_SERVICES, errors.New("state unavailable"))
+
+			err := h.preWarmDeployedContracts(ctx)
+			require.Error(t, err)
+		})
+	})
+}
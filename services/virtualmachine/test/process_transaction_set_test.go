@@ -8,11 +8,16 @@ package test
 
 import (
 	"context"
+	"github.com/orbs-network/crypto-lib-go/crypto/hash"
+	"github.com/orbs-network/orbs-network-go/services/processor"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/Triggers"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
 	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/crypto/keys"
 	"github.com/orbs-network/orbs-network-go/test/with"
 	"github.com/orbs-network/orbs-spec/types/go/primitives"
 	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/orbs-network/orbs-spec/types/go/services"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
 	"testing"
@@ -52,6 +57,24 @@ func TestProcessTransactionSet_Success(t *testing.T) {
 	})
 }
 
+func TestProcessTransactionSet_UnsignedTransactionRunsAsSystemFromTheScheduledCallsProtocolVersion(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+
+			h.expectNativeContractMethodCalledWithPermissionScope("_Triggers", "trigger", protocol.PERMISSION_SCOPE_SYSTEM, func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
+			})
+
+			h.processTriggerTransactionWithProtocolVersion(ctx, 12, 0x777, hash.Make32BytesWithFirstByte(5), triggers_systemcontract.SCHEDULED_CALLS_PROTOCOL_VERSION)
+
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
+
 func TestProcessTransactionSet_WithErrors(t *testing.T) {
 	with.Context(func(ctx context.Context) {
 		with.Logging(t, func(parent *with.LoggingHarness) {
@@ -86,3 +109,34 @@ func TestProcessTransactionSet_WithErrors(t *testing.T) {
 		})
 	})
 }
+
+func TestProcessTransactionSet_FailsWhenExecutionIsAbortedByTheNodeAndRejectsTheTransactionInPreOrder(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			parent.AllowErrorsMatching("failed to process transaction set")
+
+			h := newHarness(parent.Logger)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				t.Log("Transaction 1: killed by the sandbox")
+				return protocol.EXECUTION_RESULT_ERROR_UNEXPECTED, builders.ArgumentsArray(), errors.Wrap(processor.ErrExecutionAborted, "call exceeded the timeout")
+			})
+
+			abortedTx := builders.Transaction().WithMethod("Contract1", "method1").WithEd25519Signer(keys.Ed25519KeyPairForTests(1)).Build()
+			otherTx := builders.Transaction().WithMethod("Contract1", "method2").WithEd25519Signer(keys.Ed25519KeyPairForTests(1)).Build()
+			_, err := h.service.ProcessTransactionSet(ctx, &services.ProcessTransactionSetInput{
+				SignedTransactions: []*protocol.SignedTransaction{abortedTx},
+				CurrentBlockHeight: 12,
+			})
+			require.Error(t, err, "a transaction aborted by the node must not get a receipt")
+			require.True(t, processor.IsExecutionAborted(err))
+
+			results, err := h.transactionSetPreOrder(ctx, []*protocol.SignedTransaction{abortedTx, otherTx})
+			require.NoError(t, err)
+			require.Equal(t, []protocol.TransactionStatus{protocol.TRANSACTION_STATUS_REJECTED_SMART_CONTRACT_PRE_ORDER, protocol.TRANSACTION_STATUS_PRE_ORDER_VALID}, results, "the aborted transaction should be rejected so it is dropped from the next proposal and the pool")
+
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
@@ -65,6 +65,7 @@ func TestSdkAddress_GetSignerAddressDoesNotChangeWithContractCalls(t *testing.T)
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			var signerAddressRes []byte
 
@@ -143,6 +144,7 @@ func TestSdkAddress_GetCallerAddressChangesWithContractCalls(t *testing.T) {
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			var initialCallerAddress []byte
 			var firstCallerAddress []byte
@@ -225,6 +227,7 @@ func TestSdkAddress_GetOwnAddress(t *testing.T) {
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			expectedAddress1, _ := digest.CalcClientAddressOfContract("Contract1")
 			expectedAddress2, _ := digest.CalcClientAddressOfContract("Contract2")
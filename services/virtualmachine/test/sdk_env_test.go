@@ -57,6 +57,11 @@ func TestSdkEnv_GetBlockDetails_InTransaction(t *testing.T) {
 				res, err = h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_ENV, "getNextBlockCommittee")
 				require.Error(t, err, "handleSdkCall should fail next committee is not accessible in signed txs")
 
+				t.Log("getProtocolVersion")
+				res, err = h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_ENV, "getProtocolVersion")
+				require.NoError(t, err, "handleSdkCall should not fail")
+				require.EqualValues(t, 1, res[0].Uint32Value(), "handleSdkCall result should be equal")
+
 				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray(), nil
 			})
 
@@ -25,6 +25,7 @@ func TestSdkService_CallMethodFailingCall(t *testing.T) {
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("CallMethod on failing contract")
@@ -52,6 +53,7 @@ func TestSdkService_CallMethodMaintainsAddressSpaceUnderSameContract(t *testing.
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("Write to key in first contract")
@@ -90,6 +92,7 @@ func TestSdkService_CallMethodChangesAddressSpaceBetweenContracts(t *testing.T)
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("Write to key in first contract")
@@ -133,6 +136,7 @@ func TestSdkService_CallMethodWithSystemPermissions(t *testing.T) {
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("CallMethod on a different contract with system permissions")
@@ -161,6 +165,7 @@ func TestSdkService_CallMethodWithMultipleArguments(t *testing.T) {
 
 			h := newHarness(parent.Logger)
 			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+			h.expectCallPoliciesChecked(nil)
 
 			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
 				t.Log("CallMethod with multiple arguments")
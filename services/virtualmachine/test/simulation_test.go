@@ -0,0 +1,101 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package test
+
+import (
+	"context"
+	"github.com/orbs-network/orbs-network-go/services/processor/native/repository/_Deployments"
+	"github.com/orbs-network/orbs-network-go/services/processor/sdk"
+	"github.com/orbs-network/orbs-network-go/services/virtualmachine"
+	"github.com/orbs-network/orbs-network-go/test/builders"
+	"github.com/orbs-network/orbs-network-go/test/with"
+	"github.com/orbs-network/orbs-spec/types/go/primitives"
+	"github.com/orbs-network/orbs-spec/types/go/protocol"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+type transactionSimulator interface {
+	SimulateTransaction(ctx context.Context, signedTransaction *protocol.SignedTransaction) (*virtualmachine.TransactionSimulation, error)
+}
+
+func (h *harness) simulateTransaction(ctx context.Context, contractName primitives.ContractName, methodName primitives.MethodName) (*virtualmachine.TransactionSimulation, error) {
+	return h.service.(transactionSimulator).SimulateTransaction(ctx, builders.Transaction().WithMethod(contractName, methodName).Build())
+}
+
+func TestSimulateTransaction_ReturnsReceiptAndStateDiffsOfNextBlock(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_STATE, "write", []byte{0x01}, []byte{0x02})
+				require.NoError(t, err, "handleSdkCall should succeed")
+				return protocol.EXECUTION_RESULT_SUCCESS, builders.ArgumentsArray("done"), nil
+			})
+
+			simulation, err := h.simulateTransaction(ctx, "Contract1", "method1")
+			require.NoError(t, err)
+
+			require.EqualValues(t, 13, simulation.BlockHeight, "transaction should be simulated in the next block")
+			require.EqualValues(t, 1235, simulation.BlockTimestamp, "block timestamp should follow the last committed block")
+			require.Equal(t, protocol.EXECUTION_RESULT_SUCCESS, simulation.TransactionReceipt.ExecutionResult())
+			require.Equal(t, builders.ArgumentsArray("done").RawArgumentsArray(), simulation.TransactionReceipt.RawOutputArgumentArray())
+			require.Len(t, simulation.ContractStateDiffs, 1)
+			require.EqualValues(t, "Contract1", simulation.ContractStateDiffs[0].ContractName())
+
+			h.verifyNativeContractMethodCalled(t)
+			h.verifyStateStorageBlockHeightRequested(t)
+		})
+	})
+}
+
+func TestSimulateTransaction_FailsWithoutTransaction(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			_, err := h.service.(transactionSimulator).SimulateTransaction(ctx, nil)
+			require.Error(t, err)
+		})
+	})
+}
+
+func TestSimulateTransaction_RejectsDeployments(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+
+			_, err := h.simulateTransaction(ctx, deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_DEPLOY_SERVICE)
+			require.Equal(t, virtualmachine.ErrDeploymentNotSimulated, err)
+		})
+	})
+}
+
+func TestSimulateTransaction_RejectsDeploymentsCalledFromContract(t *testing.T) {
+	with.Context(func(ctx context.Context) {
+		with.Logging(t, func(parent *with.LoggingHarness) {
+			h := newHarness(parent.Logger)
+			h.expectStateStorageLastCommittedBlockInfoBlockHeightRequested(12)
+			h.expectSystemContractCalled(deployments_systemcontract.CONTRACT_NAME, deployments_systemcontract.METHOD_GET_INFO, nil, uint32(protocol.PROCESSOR_TYPE_NATIVE)) // assume all contracts are deployed
+
+			h.expectNativeContractMethodCalled("Contract1", "method1", func(executionContextId primitives.ExecutionContextId, inputArgs *protocol.ArgumentArray) (protocol.ExecutionResult, *protocol.ArgumentArray, error) {
+				_, err := h.handleSdkCall(ctx, executionContextId, sdk.SDK_OPERATION_NAME_SERVICE, "callMethod", string(deployments_systemcontract.CONTRACT_NAME), string(deployments_systemcontract.METHOD_DEPLOY_SERVICE), builders.ArgumentsArray().Raw())
+				require.Equal(t, virtualmachine.ErrDeploymentNotSimulated, err, "nested deployment should be rejected")
+				return protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, builders.ArgumentsArray(), err
+			})
+
+			simulation, err := h.simulateTransaction(ctx, "Contract1", "method1")
+			require.NoError(t, err)
+			require.Equal(t, protocol.EXECUTION_RESULT_ERROR_SMART_CONTRACT, simulation.TransactionReceipt.ExecutionResult())
+
+			h.verifyNativeContractMethodCalled(t)
+		})
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright 2019 the orbs-network-go authors
+// This file is part of the orbs-network-go library in the Orbs project.
+//
+// This source code is licensed under the MIT license found in the LICENSE file in the root directory of this source tree.
+// The above notice should be included in all copies or substantial portions of the software.
+
+package builders
+
+import (
+	"bytes"
+)
+
+const (
+	WASM_I32 = 0x7f
+	WASM_I64 = 0x7e
+)
+
+// assembles binary WebAssembly modules for tests, function bodies are given as raw instructions (see WasmInstr and WasmI32Const)
+type wasmModule struct {
+	types     [][]byte
+	imports   [][]byte
+	functions []uint32
+	bodies    [][]byte
+	exports   [][]byte
+	memory    []byte
+	table     []byte
+	globals   [][]byte
+	elements  [][]byte
+	data      [][]byte
+	start     []byte
+}
+
+func WasmModule() *wasmModule {
+	return &wasmModule{}
+}
+
+func wasmUleb(v uint64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v != 0 {
+			out = append(out, b|0x80)
+		} else {
+			return append(out, b)
+		}
+	}
+}
+
+func wasmSleb(v int64) []byte {
+	var out []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if (v == 0 && b&0x40 == 0) || (v == -1 && b&0x40 != 0) {
+			return append(out, b)
+		}
+		out = append(out, b|0x80)
+	}
+}
+
+func wasmVector(items [][]byte) []byte {
+	return append(wasmUleb(uint64(len(items))), bytes.Join(items, nil)...)
+}
+
+func wasmName(name string) []byte {
+	return append(wasmUleb(uint64(len(name))), name...)
+}
+
+// WasmInstr encodes an instruction with its unsigned immediates (indices, alignments, offsets)
+func WasmInstr(opcode byte, immediates ...uint32) []byte {
+	out := []byte{opcode}
+	for _, imm := range immediates {
+		out = append(out, wasmUleb(uint64(imm))...)
+	}
+	return out
+}
+
+func WasmI32Const(v int32) []byte {
+	return append([]byte{0x41}, wasmSleb(int64(v))...)
+}
+
+func WasmI64Const(v int64) []byte {
+	return append([]byte{0x42}, wasmSleb(v)...)
+}
+
+func (m *wasmModule) Type(params []byte, results []byte) uint32 {
+	t := append([]byte{0x60}, wasmUleb(uint64(len(params)))...)
+	t = append(t, params...)
+	t = append(t, wasmUleb(uint64(len(results)))...)
+	t = append(t, results...)
+	m.types = append(m.types, t)
+	return uint32(len(m.types) - 1)
+}
+
+// imports must be added before functions since they come first in the function index space
+func (m *wasmModule) ImportFunction(module string, name string, typeIndex uint32) uint32 {
+	if len(m.functions) > 0 {
+		panic("imports must be added before functions")
+	}
+	imp := append(wasmName(module), wasmName(name)...)
+	imp = append(imp, 0x00)
+	imp = append(imp, wasmUleb(uint64(typeIndex))...)
+	m.imports = append(m.imports, imp)
+	return uint32(len(m.imports) - 1)
+}
+
+func (m *wasmModule) Function(typeIndex uint32, locals []byte, code ...[]byte) uint32 {
+	var localGroups [][]byte
+	for _, l := range locals {
+		localGroups = append(localGroups, []byte{0x01, l})
+	}
+	body := append(wasmVector(localGroups), bytes.Join(code, nil)...)
+	body = append(body, 0x0b)
+	m.functions = append(m.functions, typeIndex)
+	m.bodies = append(m.bodies, append(wasmUleb(uint64(len(body))), body...))
+	return uint32(len(m.imports) + len(m.functions) - 1)
+}
+
+func (m *wasmModule) ExportFunction(name string, functionIndex uint32) *wasmModule {
+	e := append(wasmName(name), 0x00)
+	m.exports = append(m.exports, append(e, wasmUleb(uint64(functionIndex))...))
+	return m
+}
+
+func (m *wasmModule) Memory(minPages uint32) *wasmModule {
+	m.memory = append([]byte{0x00}, wasmUleb(uint64(minPages))...)
+	e := append(wasmName("memory"), 0x02, 0x00)
+	m.exports = append(m.exports, e)
+	return m
+}
+
+func (m *wasmModule) MemoryWithMax(minPages uint32, maxPages uint32) *wasmModule {
+	m.Memory(minPages)
+	m.memory = append([]byte{0x01}, wasmUleb(uint64(minPages))...)
+	m.memory = append(m.memory, wasmUleb(uint64(maxPages))...)
+	return m
+}
+
+func (m *wasmModule) Table(size uint32) *wasmModule {
+	m.table = append([]byte{0x70, 0x00}, wasmUleb(uint64(size))...)
+	return m
+}
+
+func (m *wasmModule) Global(valueType byte, mutable bool, init []byte) uint32 {
+	g := []byte{valueType, 0x00}
+	if mutable {
+		g[1] = 0x01
+	}
+	g = append(g, init...)
+	m.globals = append(m.globals, append(g, 0x0b))
+	return uint32(len(m.globals) - 1)
+}
+
+func (m *wasmModule) Elements(offset int32, functionIndices ...uint32) *wasmModule {
+	e := append([]byte{0x00}, WasmI32Const(offset)...)
+	e = append(e, 0x0b)
+	var indices [][]byte
+	for _, f := range functionIndices {
+		indices = append(indices, wasmUleb(uint64(f)))
+	}
+	m.elements = append(m.elements, append(e, wasmVector(indices)...))
+	return m
+}
+
+func (m *wasmModule) Data(offset int32, data []byte) *wasmModule {
+	d := append([]byte{0x00}, WasmI32Const(offset)...)
+	d = append(d, 0x0b)
+	d = append(d, wasmUleb(uint64(len(data)))...)
+	m.data = append(m.data, append(d, data...))
+	return m
+}
+
+func (m *wasmModule) Start(functionIndex uint32) *wasmModule {
+	m.start = wasmUleb(uint64(functionIndex))
+	return m
+}
+
+func wasmSection(id byte, content []byte) []byte {
+	return append(append([]byte{id}, wasmUleb(uint64(len(content)))...), content...)
+}
+
+func (m *wasmModule) Build() []byte {
+	out := []byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}
+	if len(m.types) > 0 {
+		out = append(out, wasmSection(1, wasmVector(m.types))...)
+	}
+	if len(m.imports) > 0 {
+		out = append(out, wasmSection(2, wasmVector(m.imports))...)
+	}
+	if len(m.functions) > 0 {
+		var indices [][]byte
+		for _, f := range m.functions {
+			indices = append(indices, wasmUleb(uint64(f)))
+		}
+		out = append(out, wasmSection(3, wasmVector(indices))...)
+	}
+	if m.table != nil {
+		out = append(out, wasmSection(4, wasmVector([][]byte{m.table}))...)
+	}
+	if m.memory != nil {
+		out = append(out, wasmSection(5, wasmVector([][]byte{m.memory}))...)
+	}
+	if len(m.globals) > 0 {
+		out = append(out, wasmSection(6, wasmVector(m.globals))...)
+	}
+	if len(m.exports) > 0 {
+		out = append(out, wasmSection(7, wasmVector(m.exports))...)
+	}
+	if m.start != nil {
+		out = append(out, wasmSection(8, m.start)...)
+	}
+	if len(m.elements) > 0 {
+		out = append(out, wasmSection(9, wasmVector(m.elements))...)
+	}
+	if len(m.bodies) > 0 {
+		out = append(out, wasmSection(10, wasmVector(m.bodies))...)
+	}
+	if len(m.data) > 0 {
+		out = append(out, wasmSection(11, wasmVector(m.data))...)
+	}
+	return out
+}
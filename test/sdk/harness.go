@@ -14,6 +14,7 @@ import (
 	"github.com/orbs-network/go-mock"
 	"github.com/orbs-network/orbs-network-go/config"
 	"github.com/orbs-network/orbs-network-go/instrumentation/metric"
+	"github.com/orbs-network/orbs-network-go/instrumentation/trace"
 	"github.com/orbs-network/orbs-network-go/services/processor/native"
 	"github.com/orbs-network/orbs-network-go/services/processor/native/testkit"
 	"github.com/orbs-network/orbs-network-go/services/statestorage"
@@ -50,6 +51,7 @@ func newVmHarness(logger log.Logger) *harness {
 
 	management := &services.MockManagement{}
 	management.When("GetCommittee", mock.Any, mock.Any).Return(&services.GetCommitteeOutput{Members: testKeys.NodeAddressesForTests()[:5]}, nil)
+	management.When("GetProtocolVersion", mock.Any, mock.Any).Return(&services.GetProtocolVersionOutput{ProtocolVersion: 1}, nil)
 
 	sdkCallHandler := &handlers.MockContractSdkCallHandler{}
 	psCfg := config.ForNativeProcessorTests(42)
@@ -61,7 +63,7 @@ func newVmHarness(logger log.Logger) *harness {
 	processorMap := map[protocol.ProcessorType]services.Processor{protocol.PROCESSOR_TYPE_NATIVE: processorService}
 	crosschainConnectors := make(map[protocol.CrosschainConnectorType]services.CrosschainConnector)
 	crosschainConnectors[protocol.CROSSCHAIN_CONNECTOR_TYPE_ETHEREUM] = &services.MockCrosschainConnector{}
-	vm := virtualmachine.NewVirtualMachine(stateStorage, processorMap, crosschainConnectors, management, &vmCfg{}, logger)
+	vm := virtualmachine.NewVirtualMachine(stateStorage, processorMap, crosschainConnectors, management, &vmCfg{}, logger, trace.NewNoopTracer())
 
 	return &harness{
 		vm:         vm,